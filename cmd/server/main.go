@@ -11,9 +11,16 @@ import (
 	"shop-aggregator/internal/config"
 	"shop-aggregator/internal/db/postgresql"
 	"shop-aggregator/internal/handler"
+	"shop-aggregator/internal/mailer"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/ocr"
+	"shop-aggregator/internal/oidc"
+	"shop-aggregator/internal/receipt/retailer"
 	"shop-aggregator/internal/router"
 	"shop-aggregator/internal/usecase"
+	"shop-aggregator/internal/utils"
 	"shop-aggregator/tools/migrations"
+	"time"
 )
 
 func main() {
@@ -24,6 +31,9 @@ func main() {
 	if err != nil {
 		log.Fatal().Caller().Err(err).Msg("Loading config failed")
 	}
+	if err = cfg.Auth.CheckSecrets(); err != nil {
+		log.Fatal().Caller().Err(err).Msg("Auth secrets error")
+	}
 
 	db, err := postgresql.NewDB(context.Background(), &cfg.Database)
 	if err != nil {
@@ -34,44 +44,117 @@ func main() {
 	if err = migrations.Run(context.Background(), db, "../../migrations/deploy"); err != nil {
 		log.Fatal().Caller().Err(err).Msg("Migrations error")
 	}
+
+	mail, err := mailer.New(&cfg.Mailer)
+	if err != nil {
+		log.Fatal().Caller().Err(err).Msg("Mailer error")
+	}
+
 	e := gin.Default()
+	if err = e.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal().Caller().Err(err).Msg("Trusted proxies error")
+	}
 
 	// Middleware
 	e.Use(gin.Logger())
 	e.Use(gin.Recovery())
 	e.Use(cors.Default())
 
-	sqlAuth := postgresql.NewAuth(db)
+	passwordHasher, err := utils.NewPasswordHasher(&cfg.Auth.PasswordHash)
+	if err != nil {
+		log.Fatal().Caller().Err(err).Msg("Password hasher error")
+	}
+
+	tokenHasher := utils.NewTokenHasher(cfg.Auth.TokenSecret)
+	sqlAuth := postgresql.NewAuth(db, tokenHasher)
+	sqlUserToken := postgresql.NewUserToken(db, tokenHasher)
 	sqlUser := postgresql.NewUsers(db)
 	sqlBrand := postgresql.NewBrand(db)
 	sqlCompany := postgresql.NewCompany(db)
 	sqlBill := postgresql.NewBill(db)
+	sqlBillEvent := postgresql.NewBillEvent(db)
 	sqlStore := postgresql.NewStore(db)
 	sqlProduct := postgresql.NewProduct(db)
 	sqlUserProduct := postgresql.NewUserProduct(db)
+	sqlPrice := postgresql.NewPrice(db)
+	sqlPriceHistory := postgresql.NewPriceHistory(db)
+	sqlAdmin := postgresql.NewAdmin(db)
+	sqlLoginAttempt := postgresql.NewLoginAttempt(db)
+	sqlOIDC := postgresql.NewOIDC(db, tokenHasher)
+	sqlAPIKey := postgresql.NewAPIKey(db, tokenHasher)
+	sqlTwoFactor := postgresql.NewTwoFactor(db, tokenHasher, utils.NewSecretCipher(cfg.Auth.TOTPEncryptionKey))
+	sqlHousehold := postgresql.NewHousehold(db)
+	sqlBillSplit := postgresql.NewBillSplit(db)
+	sqlAccount := postgresql.NewAccount(db)
+
+	for _, login := range cfg.Auth.AdminLogins {
+		if err = sqlUser.SetRoleByLogin(context.Background(), login, model.RoleAdmin); err != nil {
+			log.Warn().Caller().Err(err).Msgf("Admin login %s not promoted", login)
+		}
+	}
+
+	authorizer := usecase.NewAuthorizer(sqlBill, sqlUserProduct, sqlHousehold)
 
-	useCaseAuth := usecase.NewAuth(sqlAuth, sqlUser)
-	useCaseUser := usecase.NewUsers(sqlUser)
+	useCaseAuth := usecase.NewAuth(sqlAuth, sqlUser, sqlLoginAttempt, sqlTwoFactor, passwordHasher, &cfg.Auth)
+	useCaseOIDC := usecase.NewOIDC(oidc.NewProvider(&cfg.OIDC, nil), sqlOIDC, sqlUser, useCaseAuth, &cfg.OIDC)
+	useCaseAPIKey := usecase.NewAPIKey(sqlAPIKey)
+	useCaseTwoFactor := usecase.NewTwoFactor(sqlTwoFactor, sqlUser, passwordHasher, &cfg.Auth)
+	useCaseAccount := usecase.NewAccount(sqlAccount, sqlUser, sqlAuth, sqlUserProduct, &cfg.Auth)
+	useCaseUser := usecase.NewUsers(sqlUser, sqlUserToken, mail, passwordHasher, &cfg.Auth)
 	useCaseBrand := usecase.NewBrand(sqlBrand)
 	useCaseCompany := usecase.NewCompany(sqlCompany)
-	useCaseBill := usecase.NewBill(sqlBill, sqlStore, sqlCompany, sqlUserProduct)
+	useCaseHousehold := usecase.NewHousehold(sqlHousehold, sqlUser, authorizer)
+	useCaseBillSplit := usecase.NewBillSplit(sqlBillSplit, sqlUserProduct, sqlHousehold, sqlUser, authorizer)
+	useCaseBill := usecase.NewBill(sqlBill, sqlStore, sqlCompany, sqlUserProduct, sqlPriceHistory, sqlBillEvent, authorizer)
 	useCaseStore := usecase.NewStore(sqlStore, sqlCompany)
 	useCaseProduct := usecase.NewProduct(sqlProduct, sqlBrand)
-	useCaseUserProduct := usecase.NewUserProduct(sqlUserProduct)
+	useCaseUserProduct := usecase.NewUserProduct(sqlUserProduct, authorizer)
+	useCasePrice := usecase.NewPrice(sqlPrice, sqlUserProduct, sqlProduct)
+	useCasePriceHistory := usecase.NewPriceHistory(sqlPriceHistory)
+	useCaseShoppingList := usecase.NewShoppingList(sqlPrice, sqlProduct)
+	useCaseReceipt := usecase.NewReceipt(ocr.NewTesseract(&cfg.OCR), authorizer, sqlStore, sqlUserProduct)
+	useCaseAdmin := usecase.NewAdmin(sqlUser, sqlBrand, sqlCompany, sqlStore, sqlProduct, sqlAdmin, sqlPriceHistory, sqlLoginAttempt)
+	useCaseReceiptImport := usecase.NewReceiptImport(retailer.NewRegistry(), sqlStore, sqlCompany, sqlUserProduct, useCaseBill, useCaseUserProduct)
 
 	handlerAuth := handler.NewAuth(useCaseAuth)
 	handlerUser := handler.NewUser(useCaseUser)
+	handlerAccount := handler.NewAccount(useCaseAccount)
+	handlerOIDC := handler.NewOIDC(useCaseOIDC)
+	handlerAPIKey := handler.NewAPIKey(useCaseAPIKey)
+	handlerTwoFactor := handler.NewTwoFactor(useCaseTwoFactor)
 	handlerBrand := handler.NewBrand(useCaseBrand)
 	handlerCompany := handler.NewCompany(useCaseCompany)
+	handlerHousehold := handler.NewHousehold(useCaseHousehold)
 	handlerBill := handler.NewBill(useCaseBill)
+	handlerBillSplit := handler.NewBillSplit(useCaseBillSplit)
 	handlerStore := handler.NewStore(useCaseStore)
 	handlerProduct := handler.NewProduct(useCaseProduct)
 	handlerUserProduct := handler.NewUserProduct(useCaseUserProduct)
 	handlerInitialisation := handler.NewInitialisation()
+	handlerPrice := handler.NewPrice(useCasePrice)
+	handlerPriceHistory := handler.NewPriceHistory(useCasePriceHistory)
+	handlerShoppingList := handler.NewShoppingList(useCaseShoppingList)
+	handlerReceipt := handler.NewReceipt(useCaseReceipt, useCaseReceiptImport)
+	handlerAdmin := handler.NewAdmin(useCaseAdmin)
 
-	r := router.NewRouter(e, sqlAuth, handlerAuth, handlerUser, handlerBrand, handlerCompany, handlerBill, handlerStore, handlerProduct, handlerUserProduct, handlerInitialisation)
+	go purgeAccounts(useCaseAccount)
+
+	r := router.NewRouter(e, sqlAuth, sqlAPIKey, handlerAuth, handlerUser, handlerBrand, handlerCompany, handlerBill, handlerStore, handlerProduct, handlerUserProduct, handlerInitialisation, handlerPrice, handlerPriceHistory, handlerShoppingList, handlerReceipt, handlerAdmin, handlerOIDC, handlerAPIKey, handlerTwoFactor, handlerHousehold, handlerBillSplit, handlerAccount)
 	log.Info().Caller().Msgf("Starting server on port %d", cfg.Server.Port)
 	if err = r.Run(fmt.Sprintf(":%d", cfg.Server.Port)); err != nil {
 		log.Fatal().Caller().Err(err).Msg("Loading router failed")
 	}
 }
+
+// purgeAccounts deletes every hour the accounts whose grace period is over.
+func purgeAccounts(account *usecase.Account) {
+	for range time.Tick(time.Hour) {
+		deleted, err := account.PurgeAccounts(context.Background())
+		if err != nil {
+			continue
+		}
+		if deleted > 0 {
+			log.Info().Caller().Msgf("%d accounts deleted", deleted)
+		}
+	}
+}
@@ -3,34 +3,120 @@ package auth
 import (
 	"context"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"net/http"
+	"shop-aggregator/internal/model"
+	"time"
 )
 
 type Storer interface {
-	EnsureValidToken(context.Context, string) (uuid.UUID, error)
+	EnsureValidToken(context.Context, string) (*model.Session, error)
 }
 
-func Middleware(a Storer) gin.HandlerFunc {
+type APIKeyStorer interface {
+	EnsureValidAPIKey(context.Context, string) (*model.APIKey, error)
+}
+
+// Middleware accepts the session tokens and the API keys, the refused requests are answered by the problem
+// middleware. An API key also adds its ID and its scopes to the
+// context, the routes check them with RequireScopes or refuse the keys with RequireSession.
+func Middleware(a Storer, ak APIKeyStorer) gin.HandlerFunc {
 
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
 
 		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization token not provided"})
+			c.Error(model.ErrTokenMissingError)
 			c.Abort()
 			return
 		}
 
-		id, err := a.EnsureValidToken(c, token)
+		if model.IsAPIKey(token) {
+			apiKey, err := ak.EnsureValidAPIKey(c, token)
+			if err != nil {
+				c.Error(model.ErrInvalidTokenError)
+				c.Abort()
+				return
+			}
+
+			if apiKey.IsExpired(time.Now().UTC()) {
+				c.Error(model.ErrAPIKeyExpiredError)
+				c.Abort()
+				return
+			}
+
+			c.Set("userID", apiKey.UserID.String())
+			c.Set("apiKeyID", apiKey.APIKeyID.String())
+			c.Set("role", apiKey.Role)
+			c.Set("scopes", apiKey.Scopes)
+			c.Next()
+			return
+		}
+
+		session, err := a.EnsureValidToken(c, token)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization token"})
+			c.Error(model.ErrInvalidTokenError)
+			c.Abort()
+			return
+		}
+
+		// an expired token is refreshed by the client, an invalid one needs a new login
+		if session.IsExpired(time.Now().UTC()) {
+			c.Error(model.ErrTokenExpiredError)
 			c.Abort()
 			return
 		}
 
-		// Token is valid, add user ID to the context
-		c.Set("userID", id.String())
+		// Token is valid, add user ID, session ID and role to the context
+		c.Set("userID", session.UserID.String())
+		c.Set("sessionID", session.SessionID.String())
+		c.Set("role", session.Role)
+		c.Next()
+	}
+}
+
+// RequireRole lets through the users who have the rights of role, it runs after Middleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !model.RoleAllows(c.GetString("role"), role) {
+			c.Error(model.ErrForbiddenError)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScopes lets through the API keys granted read for the GET and HEAD requests and write for the
+// others. The sessions are not limited by scopes.
+func RequireScopes(read, write string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, ok := c.Get("scopes")
+		if !ok {
+			c.Next()
+			return
+		}
+
+		required := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			required = read
+		}
+		if !model.ScopesAllow(scopes.([]string), required) {
+			c.Error(model.ErrForbiddenError)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession refuses the API keys, the account and the admin routes need a login.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("scopes"); ok {
+			c.Error(model.ErrForbiddenError)
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
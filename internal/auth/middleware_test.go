@@ -0,0 +1,162 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"shop-aggregator/internal/auth"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/problem"
+	"testing"
+	"time"
+)
+
+type storer map[string]*model.Session
+
+func (s storer) EnsureValidToken(_ context.Context, token string) (*model.Session, error) {
+	session, ok := s[token]
+	if !ok {
+		return nil, errors.New("no rows in result set")
+	}
+	return session, nil
+}
+
+type apiKeyStorer map[string]*model.APIKey
+
+func (s apiKeyStorer) EnsureValidAPIKey(_ context.Context, key string) (*model.APIKey, error) {
+	apiKey, ok := s[key]
+	if !ok {
+		return nil, errors.New("no rows in result set")
+	}
+	return apiKey, nil
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	valid := &model.Session{SessionID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Minute)}
+	expired := &model.Session{SessionID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(-time.Minute)}
+
+	router := gin.New()
+	router.Use(problem.Middleware())
+	validKey := &model.APIKey{APIKeyID: uuid.New(), UserID: uuid.New(), Scopes: []string{model.ScopeBillsRead}}
+	past := time.Now().UTC().Add(-time.Minute)
+	expiredKey := &model.APIKey{APIKeyID: uuid.New(), UserID: uuid.New(), ExpiresAt: &past}
+
+	router.Use(auth.Middleware(storer{"valid": valid, "expired": expired}, apiKeyStorer{"sak_valid": validKey, "sak_expired": expiredKey}))
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, "%s %s%s", c.GetString("userID"), c.GetString("sessionID"), c.GetString("apiKeyID"))
+	})
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+		body   string
+	}{
+		{"no token", "", http.StatusUnauthorized, `"code":"token_missing"`},
+		{"invalid token", "invalid", http.StatusUnauthorized, `"code":"invalid_token"`},
+		{"expired token", "expired", http.StatusUnauthorized, `"code":"token_expired"`},
+		{"valid token", "valid", http.StatusOK, valid.UserID.String() + " " + valid.SessionID.String()},
+		{"invalid api key", "sak_invalid", http.StatusUnauthorized, `"code":"invalid_token"`},
+		{"expired api key", "sak_expired", http.StatusUnauthorized, `"code":"api_key_expired"`},
+		{"valid api key", "sak_valid", http.StatusOK, validKey.UserID.String() + " " + validKey.APIKeyID.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	expiresAt := time.Now().UTC().Add(time.Minute)
+	sessions := storer{
+		"user":      {SessionID: uuid.New(), UserID: uuid.New(), Role: model.RoleUser, ExpiresAt: expiresAt},
+		"moderator": {SessionID: uuid.New(), UserID: uuid.New(), Role: model.RoleModerator, ExpiresAt: expiresAt},
+		"admin":     {SessionID: uuid.New(), UserID: uuid.New(), Role: model.RoleAdmin, ExpiresAt: expiresAt},
+	}
+
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.Use(auth.Middleware(sessions, apiKeyStorer{}), auth.RequireRole(model.RoleModerator))
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("role"))
+	})
+
+	tests := []struct {
+		token  string
+		status int
+		body   string
+	}{
+		{"user", http.StatusForbidden, `"code":"forbidden"`},
+		{"moderator", http.StatusOK, model.RoleModerator},
+		{"admin", http.StatusOK, model.RoleAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", tt.token)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestRequireScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sessions := storer{"session": {SessionID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Minute)}}
+	apiKeys := apiKeyStorer{
+		"sak_read":  {APIKeyID: uuid.New(), UserID: uuid.New(), Scopes: []string{model.ScopeBillsRead}},
+		"sak_write": {APIKeyID: uuid.New(), UserID: uuid.New(), Scopes: []string{model.ScopeBillsWrite}},
+		"sak_other": {APIKeyID: uuid.New(), UserID: uuid.New(), Scopes: []string{model.ScopeCatalogWrite}},
+	}
+
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.Use(auth.Middleware(sessions, apiKeys))
+	bills := router.Group("/bills", auth.RequireScopes(model.ScopeBillsRead, model.ScopeBillsWrite))
+	bills.GET("", func(c *gin.Context) { c.Status(http.StatusOK) })
+	bills.POST("", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/user", auth.RequireSession(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name   string
+		token  string
+		method string
+		path   string
+		status int
+	}{
+		{"session reads", "session", http.MethodGet, "/bills", http.StatusOK},
+		{"session writes", "session", http.MethodPost, "/bills", http.StatusOK},
+		{"read key reads", "sak_read", http.MethodGet, "/bills", http.StatusOK},
+		{"read key writes", "sak_read", http.MethodPost, "/bills", http.StatusForbidden},
+		{"write key reads", "sak_write", http.MethodGet, "/bills", http.StatusOK},
+		{"write key writes", "sak_write", http.MethodPost, "/bills", http.StatusOK},
+		{"key of an other scope", "sak_other", http.MethodGet, "/bills", http.StatusForbidden},
+		{"session on a session route", "session", http.MethodGet, "/user", http.StatusOK},
+		{"key on a session route", "sak_write", http.MethodGet, "/user", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", tt.token)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
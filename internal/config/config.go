@@ -1,17 +1,36 @@
 package config
 
 import (
+	"fmt"
 	"gopkg.in/yaml.v2"
 	"os"
 )
 
+const (
+	// TokenSecretEnv overrides auth.token_secret, the shipped config leaves it empty.
+	TokenSecretEnv = "AUTH_TOKEN_SECRET"
+	// TOTPEncryptionKeyEnv overrides auth.totp_encryption_key, the shipped config leaves it empty.
+	TOTPEncryptionKeyEnv = "AUTH_TOTP_ENCRYPTION_KEY"
+	// MinSecretLength is the length in bytes of the shortest secret accepted at startup.
+	MinSecretLength = 32
+	// placeholderSecret is the value an earlier config shipped, it is public.
+	placeholderSecret = "change-me"
+)
+
 type Config struct {
 	Server   ServerConfig   `yaml:"server"`
 	Database DatabaseConfig `yaml:"database"`
+	OCR      OCRConfig      `yaml:"ocr"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Mailer   MailerConfig   `yaml:"mailer"`
+	OIDC     OIDCConfig     `yaml:"oidc"`
 }
 
 type ServerConfig struct {
 	Port int `yaml:"port"`
+	// TrustedProxies are the addresses or CIDRs of the proxies whose X-Forwarded-For is read for the client
+	// ip, none by default so a client can not choose the ip its login failures are counted against.
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
@@ -22,6 +41,68 @@ type DatabaseConfig struct {
 	DBName   string `yaml:"dbname"`
 }
 
+type OCRConfig struct {
+	TesseractPath  string `yaml:"tesseract_path"`
+	Language       string `yaml:"language"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+}
+
+type AuthConfig struct {
+	AccessTokenTTLMinutes int `yaml:"access_token_ttl_minutes"`
+	RefreshTokenTTLHours  int `yaml:"refresh_token_ttl_hours"`
+	// TokenSecret keys the hashes of the tokens, at least MinSecretLength bytes
+	TokenSecret               string `yaml:"token_secret"`
+	ResetTokenTTLMinutes      int    `yaml:"reset_token_ttl_minutes"`
+	VerificationTokenTTLHours int    `yaml:"verification_token_ttl_hours"`
+	LinkBaseURL               string `yaml:"link_base_url"`
+	// a login or an ip is locked after the free failures, for twice as long after each new failure
+	MaxLoginFailures    int `yaml:"max_login_failures"`
+	MaxIPLoginFailures  int `yaml:"max_ip_login_failures"`
+	LoginBackoffSeconds int `yaml:"login_backoff_seconds"`
+	LockoutMinutes      int `yaml:"lockout_minutes"`
+	// TOTPIssuer names the account in the authenticator apps
+	TOTPIssuer string `yaml:"totp_issuer"`
+	// TOTPEncryptionKey encrypts the TOTP secrets, apart from TokenSecret so rotating one keeps the other
+	TOTPEncryptionKey string `yaml:"totp_encryption_key"`
+	// AdminLogins are promoted to admin at startup, the first admin can not be granted otherwise.
+	AdminLogins []string `yaml:"admin_logins"`
+	// an account asked for deletion is kept AccountDeletionGraceDays, the user can still cancel
+	AccountDeletionGraceDays int `yaml:"account_deletion_grace_days"`
+	// PasswordHash hashes the new passwords, the hashes of an other algorithm or cost are upgraded at login
+	PasswordHash PasswordHashConfig `yaml:"password_hash"`
+}
+
+type PasswordHashConfig struct {
+	// Algorithm is argon2id or bcrypt
+	Algorithm   string `yaml:"algorithm"`
+	MemoryKiB   int    `yaml:"memory_kib"`
+	Iterations  int    `yaml:"iterations"`
+	Parallelism int    `yaml:"parallelism"`
+	BcryptCost  int    `yaml:"bcrypt_cost"`
+}
+
+// MailerConfig selects the mailer: "smtp" sends the mails, "log" writes them to LogPath or to stderr.
+type MailerConfig struct {
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	LogPath  string `yaml:"log_path"`
+}
+
+// OIDCConfig is the OpenID Connect provider of the single sign-on, it is disabled when Issuer is empty.
+// AutoCreate creates an account on the first login of an identity that matches no user.
+type OIDCConfig struct {
+	Issuer       string   `yaml:"issuer"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+	AutoCreate   bool     `yaml:"auto_create"`
+}
+
 func LoadConfig(path string) (*Config, error) {
 	configFile, err := os.ReadFile(path)
 	if err != nil {
@@ -32,6 +113,39 @@ func LoadConfig(path string) (*Config, error) {
 	if err := yaml.Unmarshal(configFile, &config); err != nil {
 		return nil, err
 	}
+	if secret, ok := os.LookupEnv(TokenSecretEnv); ok {
+		config.Auth.TokenSecret = secret
+	}
+	if key, ok := os.LookupEnv(TOTPEncryptionKeyEnv); ok {
+		config.Auth.TOTPEncryptionKey = key
+	}
 
 	return &config, nil
 }
+
+// CheckSecrets refuses to start with a missing, shipped or short secret, anyone reading the repository
+// could forge the token hashes or read the TOTP secrets with it. The two secrets must differ.
+func (a *AuthConfig) CheckSecrets() error {
+	if err := checkSecret("auth.token_secret", TokenSecretEnv, a.TokenSecret); err != nil {
+		return err
+	}
+	if err := checkSecret("auth.totp_encryption_key", TOTPEncryptionKeyEnv, a.TOTPEncryptionKey); err != nil {
+		return err
+	}
+	if a.TOTPEncryptionKey == a.TokenSecret {
+		return fmt.Errorf("auth.totp_encryption_key is the same as auth.token_secret")
+	}
+	return nil
+}
+
+func checkSecret(name, env, secret string) error {
+	switch {
+	case secret == "":
+		return fmt.Errorf("%s is not set, set it or %s", name, env)
+	case secret == placeholderSecret:
+		return fmt.Errorf("%s is the placeholder of the repository", name)
+	case len(secret) < MinSecretLength:
+		return fmt.Errorf("%s is shorter than %d bytes", name, MinSecretLength)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestAuthConfig_CheckSecrets(t *testing.T) {
+	key := strings.Repeat("k", MinSecretLength)
+	tests := []struct {
+		name    string
+		secret  string
+		key     string
+		wantErr bool
+	}{
+		{name: "empty", secret: "", key: key, wantErr: true},
+		{name: "placeholder", secret: placeholderSecret, key: key, wantErr: true},
+		{name: "short", secret: strings.Repeat("s", MinSecretLength-1), key: key, wantErr: true},
+		{name: "no totp key", secret: strings.Repeat("s", MinSecretLength), wantErr: true},
+		{name: "short totp key", secret: strings.Repeat("s", MinSecretLength), key: key[1:], wantErr: true},
+		{name: "same secret", secret: key, key: key, wantErr: true},
+		{name: "long enough", secret: strings.Repeat("s", MinSecretLength), key: key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthConfig{TokenSecret: tt.secret, TOTPEncryptionKey: tt.key}
+			if tt.wantErr {
+				assert.Error(t, a.CheckSecrets())
+			} else {
+				assert.NoError(t, a.CheckSecrets())
+			}
+		})
+	}
+}
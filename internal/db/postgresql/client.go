@@ -3,8 +3,11 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"shop-aggregator/internal/config"
+	"strings"
+	"time"
 )
 
 type Client struct {
@@ -27,3 +30,33 @@ func NewDB(ctx context.Context, cfg *config.DatabaseConfig) (*Client, error) {
 
 	return &Client{Pool: pool}, nil
 }
+
+// nullUUID turns uuid.Nil into a SQL NULL so optional filters can be written as `$1 IS NULL OR ...`.
+func nullUUID(id uuid.UUID) interface{} {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
+
+// nullTime turns the zero time into a SQL NULL so optional filters can be written as `$1 IS NULL OR ...`.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// likeEscaper escapes the wildcards of LIKE, a prefix typed by a user matches itself only.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,183 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type Account struct {
+	db *Client
+}
+
+func NewAccount(db *Client) *Account {
+	return &Account{
+		db: db,
+	}
+}
+
+const (
+	// SelectAccountBillsQuery returns the bills started by the user, the bills of the households started by
+	// the other members are theirs.
+	SelectAccountBillsQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE user_id = $1
+		ORDER BY created_at, bill_id`
+	ScheduleAccountDeletionQuery   = `UPDATE users SET deletion_scheduled_at = $2 WHERE user_id = $1 AND deletion_scheduled_at IS NULL`
+	CancelAccountDeletionQuery     = `UPDATE users SET deletion_scheduled_at = NULL WHERE user_id = $1 AND deletion_scheduled_at IS NOT NULL`
+	SelectDueAccountDeletionsQuery = `
+		SELECT user_id FROM users
+		WHERE deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at`
+	// DeleteAccountQuery deletes the user once its deletion is due. The personal bills still open or canceled
+	// go with their lines and events, the other bills, lines, events, splits and settlements stay for the
+	// price history and the other users under the pseudonym $2. The credentials, sessions, tokens, keys,
+	// memberships and login attempts are deleted.
+	DeleteAccountQuery = `
+		WITH target AS (
+		    SELECT user_id, login FROM users WHERE user_id = $1 AND deletion_scheduled_at <= NOW()
+		), deleted_user AS (
+		    DELETE FROM users WHERE user_id = (SELECT user_id FROM target)
+		    RETURNING user_id
+		), deleted_bills AS (
+		    DELETE FROM bill
+		    WHERE user_id = (SELECT user_id FROM target) AND household_id IS NULL AND bill_state IN ($3, $4)
+		    RETURNING bill_id
+		), deleted_lines AS (
+		    DELETE FROM user_product WHERE bill_id IN (SELECT bill_id FROM deleted_bills)
+		), lines AS (
+		    UPDATE user_product SET user_id = $2
+		    WHERE user_id = (SELECT user_id FROM target) AND bill_id NOT IN (SELECT bill_id FROM deleted_bills)
+		), deleted_events AS (
+		    DELETE FROM bill_event WHERE bill_id IN (SELECT bill_id FROM deleted_bills)
+		), events AS (
+		    UPDATE bill_event SET actor_id = $2
+		    WHERE actor_id = (SELECT user_id FROM target) AND bill_id NOT IN (SELECT bill_id FROM deleted_bills)
+		), bills AS (
+		    UPDATE bill SET user_id = $2
+		    WHERE user_id = (SELECT user_id FROM target) AND bill_id NOT IN (SELECT bill_id FROM deleted_bills)
+		), splits AS (
+		    UPDATE bill_split SET
+		        payer_id = CASE WHEN payer_id = (SELECT user_id FROM target) THEN $2 ELSE payer_id END,
+		        created_by = CASE WHEN created_by = (SELECT user_id FROM target) THEN $2 ELSE created_by END
+		    WHERE payer_id = (SELECT user_id FROM target) OR created_by = (SELECT user_id FROM target)
+		), split_lines AS (
+		    UPDATE bill_split_line SET participant_id = $2
+		    WHERE participant_type = $5 AND participant_id = (SELECT user_id FROM target)
+		), split_shares AS (
+		    UPDATE bill_split_share SET user_id = $2 WHERE user_id = (SELECT user_id FROM target)
+		), settlements AS (
+		    UPDATE settlement SET
+		        from_user_id = CASE WHEN from_user_id = (SELECT user_id FROM target) THEN $2 ELSE from_user_id END,
+		        to_user_id = CASE WHEN to_user_id = (SELECT user_id FROM target) THEN $2 ELSE to_user_id END
+		    WHERE from_user_id = (SELECT user_id FROM target) OR to_user_id = (SELECT user_id FROM target)
+		), households AS (
+		    UPDATE household SET created_by = $2 WHERE created_by = (SELECT user_id FROM target)
+		), members AS (
+		    DELETE FROM household_member WHERE user_id = (SELECT user_id FROM target)
+		), invitations AS (
+		    DELETE FROM household_invitation
+		    WHERE user_id = (SELECT user_id FROM target) OR invited_by = (SELECT user_id FROM target)
+		), credentials AS (
+		    DELETE FROM auth WHERE user_id = (SELECT user_id FROM target)
+		), refresh_tokens AS (
+		    DELETE FROM refresh_token
+		    WHERE session_id IN (SELECT session_id FROM session WHERE user_id = (SELECT user_id FROM target))
+		), sessions AS (
+		    DELETE FROM session WHERE user_id = (SELECT user_id FROM target)
+		), user_tokens AS (
+		    DELETE FROM user_token WHERE user_id = (SELECT user_id FROM target)
+		), api_keys AS (
+		    DELETE FROM api_key WHERE user_id = (SELECT user_id FROM target)
+		), identities AS (
+		    DELETE FROM user_identity WHERE user_id = (SELECT user_id FROM target)
+		), totp AS (
+		    DELETE FROM user_totp WHERE user_id = (SELECT user_id FROM target)
+		), recovery_codes AS (
+		    DELETE FROM recovery_code WHERE user_id = (SELECT user_id FROM target)
+		), challenges AS (
+		    DELETE FROM login_challenge WHERE user_id = (SELECT user_id FROM target)
+		), attempts AS (
+		    DELETE FROM login_attempt
+		    WHERE user_id = (SELECT user_id FROM target) OR login = (SELECT login FROM target)
+		), throttles AS (
+		    DELETE FROM login_throttle WHERE scope = 'login' AND key = (SELECT login FROM target)
+		)
+		SELECT user_id FROM deleted_user
+	`
+)
+
+// SelectBills returns the bills started by the user, the oldest first.
+func (a *Account) SelectBills(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	rows, err := a.db.Query(ctx, SelectAccountBillsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bills := make([]*model.Bill, 0)
+	for rows.Next() {
+		bill, err := scanBill(rows)
+		if err != nil {
+			return nil, err
+		}
+		bills = append(bills, bill)
+	}
+
+	return bills, rows.Err()
+}
+
+// ScheduleDeletion deletes the account at the time, it returns pgx.ErrNoRows when a deletion is already
+// scheduled.
+func (a *Account) ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	tag, err := a.db.Exec(ctx, ScheduleAccountDeletionQuery, userID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CancelDeletion keeps the account, it returns pgx.ErrNoRows when no deletion is scheduled.
+func (a *Account) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	tag, err := a.db.Exec(ctx, CancelAccountDeletionQuery, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SelectDueDeletions returns the users whose deletion is due at now.
+func (a *Account) SelectDueDeletions(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	rows, err := a.db.Query(ctx, SelectDueAccountDeletionsQuery, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var userID uuid.UUID
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// DeleteAccount deletes the user whose deletion is due, what stays of the account is given pseudonymID. It
+// returns pgx.ErrNoRows when the user is unknown or its deletion not due.
+func (a *Account) DeleteAccount(ctx context.Context, userID, pseudonymID uuid.UUID) error {
+	row := a.db.QueryRow(ctx, DeleteAccountQuery, userID, pseudonymID, model.BillStateCreate, model.BillStateCanceled, model.SplitParticipantUser)
+	return row.Scan(&userID)
+}
@@ -0,0 +1,102 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+type SqlAccountTestSuite struct {
+	DBTestSuite
+	Account     *Account
+	Bill        *Bill
+	UserProduct *UserProduct
+	User        *User
+	user        model.User
+}
+
+func (s *SqlAccountTestSuite) SetupTest() {
+	s.Account = NewAccount(s.DB)
+	s.Bill = NewBill(s.DB)
+	s.UserProduct = NewUserProduct(s.DB)
+	s.User = NewUsers(s.DB)
+	s.user = model.User{Login: "alice", Email: "alice@test.com", HashPassword: "hash"}
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.user))
+}
+
+func (s *SqlAccountTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, bill, bill_event, user_product, login_attempt")
+	s.Require().NoError(err)
+}
+
+func (s *SqlAccountTestSuite) newBill(state string) *model.Bill {
+	bill := &model.Bill{UserID: s.user.ID, StoreID: uuid.New(), Amount: money("3"), ComputedAmount: money("3"), State: state}
+	s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+	s.Require().NoError(s.Bill.Update(s.ctx, bill))
+	line := &model.UserProduct{ProductID: uuid.New(), BillID: bill.BillID, Price: money("3"), Quantity: 1}
+	s.Require().NoError(s.UserProduct.Insert(s.ctx, line, s.user.ID))
+	return bill
+}
+
+func (s *SqlAccountTestSuite) countRows(query string, args ...interface{}) int {
+	var count int
+	s.Require().NoError(s.DB.QueryRow(s.ctx, query, args...).Scan(&count))
+	return count
+}
+
+func (s *SqlAccountTestSuite) TestDeletion() {
+	s.Run("schedule and cancel", func() {
+		at := time.Now().UTC().Add(time.Hour)
+		s.Require().NoError(s.Account.ScheduleDeletion(s.ctx, s.user.ID, at))
+		s.ErrorIs(s.Account.ScheduleDeletion(s.ctx, s.user.ID, at), pgx.ErrNoRows)
+
+		// the deletion is not due yet
+		due, err := s.Account.SelectDueDeletions(s.ctx, time.Now().UTC())
+		s.Require().NoError(err)
+		s.Empty(due)
+		s.ErrorIs(s.Account.DeleteAccount(s.ctx, s.user.ID, uuid.New()), pgx.ErrNoRows)
+
+		s.Require().NoError(s.Account.CancelDeletion(s.ctx, s.user.ID))
+		s.ErrorIs(s.Account.CancelDeletion(s.ctx, s.user.ID), pgx.ErrNoRows)
+	})
+
+	s.Run("delete and pseudonymize", func() {
+		closed := s.newBill(model.BillStateCompleted)
+		open := s.newBill(model.BillStateCreate)
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO login_attempt (login, user_id, success) VALUES ($1, $2, true)", s.user.Login, s.user.ID)
+		s.Require().NoError(err)
+
+		bills, err := s.Account.SelectBills(s.ctx, s.user.ID)
+		s.Require().NoError(err)
+		s.Len(bills, 2)
+
+		s.Require().NoError(s.Account.ScheduleDeletion(s.ctx, s.user.ID, time.Now().UTC().Add(-time.Minute)))
+		due, err := s.Account.SelectDueDeletions(s.ctx, time.Now().UTC())
+		s.Require().NoError(err)
+		s.Equal([]uuid.UUID{s.user.ID}, due)
+
+		pseudonym := uuid.New()
+		s.Require().NoError(s.Account.DeleteAccount(s.ctx, s.user.ID, pseudonym))
+
+		user, err := s.User.GetUserByID(s.ctx, s.user.ID)
+		s.Require().NoError(err)
+		s.Nil(user)
+		s.Equal(0, s.countRows("SELECT COUNT(*) FROM login_attempt WHERE login = $1", s.user.Login))
+
+		// the closed bill and its line stay for the price history, the open one goes
+		s.Equal(1, s.countRows("SELECT COUNT(*) FROM bill WHERE bill_id = $1 AND user_id = $2", closed.BillID, pseudonym))
+		s.Equal(1, s.countRows("SELECT COUNT(*) FROM user_product WHERE bill_id = $1 AND user_id = $2", closed.BillID, pseudonym))
+		s.Equal(0, s.countRows("SELECT COUNT(*) FROM bill WHERE bill_id = $1", open.BillID))
+		s.Equal(0, s.countRows("SELECT COUNT(*) FROM user_product WHERE bill_id = $1", open.BillID))
+		s.Equal(0, s.countRows("SELECT COUNT(*) FROM bill_event WHERE actor_id = $1", s.user.ID))
+
+		s.ErrorIs(s.Account.DeleteAccount(s.ctx, s.user.ID, uuid.New()), pgx.ErrNoRows)
+	})
+}
+
+func TestAccountTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlAccountTestSuite))
+}
@@ -0,0 +1,45 @@
+package postgresql
+
+import (
+	"context"
+	"shop-aggregator/internal/model"
+)
+
+type Admin struct {
+	db *Client
+}
+
+func NewAdmin(db *Client) *Admin {
+	return &Admin{
+		db: db,
+	}
+}
+
+const (
+	SelectStatsQuery = `
+		SELECT
+		    (SELECT COUNT(*) FROM users),
+		    (SELECT COUNT(*) FROM users WHERE disabled_at IS NOT NULL),
+		    (SELECT COUNT(*) FROM session WHERE revoked_at IS NULL AND expires_at > NOW()),
+		    (SELECT COUNT(*) FROM bill),
+		    (SELECT COUNT(*) FROM bill WHERE bill_state = 'create'),
+		    (SELECT COUNT(*) FROM user_product),
+		    (SELECT COUNT(*) FROM product),
+		    (SELECT COUNT(*) FROM brand),
+		    (SELECT COUNT(*) FROM company),
+		    (SELECT COUNT(*) FROM store)
+	`
+)
+
+func (a *Admin) SelectStats(ctx context.Context) (*model.Stats, error) {
+	row := a.db.QueryRow(ctx, SelectStatsQuery)
+
+	stats := &model.Stats{}
+	err := row.Scan(&stats.Users, &stats.DisabledUsers, &stats.ActiveSessions, &stats.Bills, &stats.OpenBills,
+		&stats.UserProducts, &stats.Products, &stats.Brands, &stats.Companies, &stats.Stores)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,55 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type SqlAdminTestSuite struct {
+	DBTestSuite
+	Admin *Admin
+}
+
+func (s *SqlAdminTestSuite) SetupTest() {
+	s.Admin = NewAdmin(s.DB)
+}
+
+func (s *SqlAdminTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, bill")
+	s.Require().NoError(err)
+}
+
+func (s *SqlAdminTestSuite) TestSelectStats() {
+	s.Run("no error", func() {
+		before, err := s.Admin.SelectStats(s.ctx)
+		s.Require().NoError(err)
+
+		_, err = s.DB.Exec(s.ctx, "INSERT INTO users (login, email, password, disabled_at) VALUES ('stats', 'stats@test.com', '', NOW())")
+		s.Require().NoError(err)
+		_, err = s.DB.Exec(s.ctx, "INSERT INTO bill (user_id, store_id, amount) VALUES ($1, $2, 0)", uuid.New(), uuid.New())
+		s.Require().NoError(err)
+
+		stats, err := s.Admin.SelectStats(s.ctx)
+		s.Require().NoError(err)
+		s.Equal(before.Users+1, stats.Users)
+		s.Equal(before.DisabledUsers+1, stats.DisabledUsers)
+		s.Equal(before.Bills+1, stats.Bills)
+		s.Equal(before.OpenBills+1, stats.OpenBills)
+		// the bulk products of the migrations
+		s.GreaterOrEqual(stats.Products, int64(3))
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		stats, err := s.Admin.SelectStats(ctx)
+		s.Nil(stats)
+		s.EqualError(err, `context canceled`)
+	})
+}
+
+func TestAdminTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlAdminTestSuite))
+}
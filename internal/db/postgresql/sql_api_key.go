@@ -0,0 +1,100 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+)
+
+// APIKey stores the keyed hashes of the API keys.
+type APIKey struct {
+	db     *Client
+	hasher *utils.TokenHasher
+}
+
+func NewAPIKey(db *Client, hasher *utils.TokenHasher) *APIKey {
+	return &APIKey{
+		db:     db,
+		hasher: hasher,
+	}
+}
+
+const (
+	InsertAPIKeyQuery = `
+		INSERT INTO api_key (user_id, name, prefix, key, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING api_key_id, created_at
+	`
+	SelectAPIKeysByUserIDQuery = `
+		SELECT api_key_id, user_id, name, prefix, scopes, expires_at, last_used_at, created_at
+		FROM api_key
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	RevokeAPIKeyQuery = `UPDATE api_key SET revoked_at = NOW() WHERE api_key_id = $2 AND user_id = $1 AND revoked_at IS NULL`
+	// EnsureValidAPIKeyQuery also returns the expired keys, only the valid ones are recorded as used. The keys
+	// of a disabled user are not valid.
+	EnsureValidAPIKeyQuery = `
+		UPDATE api_key k
+		SET last_used_at = CASE WHEN k.expires_at IS NULL OR k.expires_at > NOW() THEN NOW() ELSE k.last_used_at END
+		FROM users u
+		WHERE u.user_id = k.user_id
+		AND k.key = $1
+		AND k.revoked_at IS NULL
+		AND u.disabled_at IS NULL
+		RETURNING k.api_key_id, k.user_id, u.role, k.name, k.prefix, k.scopes, k.expires_at, k.last_used_at, k.created_at
+	`
+)
+
+func (ak *APIKey) Insert(ctx context.Context, apiKey *model.APIKey) error {
+	row := ak.db.QueryRow(ctx, InsertAPIKeyQuery, apiKey.UserID, apiKey.Name, apiKey.Prefix, ak.hasher.Hash(apiKey.Key), apiKey.Scopes, apiKey.ExpiresAt)
+	return row.Scan(&apiKey.APIKeyID, &apiKey.CreatedAt)
+}
+
+// SelectAPIKeysByUserID returns the keys that are not revoked, the most recent first.
+func (ak *APIKey) SelectAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*model.APIKey, error) {
+	rows, err := ak.db.Query(ctx, SelectAPIKeysByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := make([]*model.APIKey, 0)
+	for rows.Next() {
+		apiKey := &model.APIKey{}
+		err = rows.Scan(&apiKey.APIKeyID, &apiKey.UserID, &apiKey.Name, &apiKey.Prefix, &apiKey.Scopes, &apiKey.ExpiresAt, &apiKey.LastUsedAt, &apiKey.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, rows.Err()
+}
+
+// Revoke returns pgx.ErrNoRows when the user has no such key.
+func (ak *APIKey) Revoke(ctx context.Context, userID, apiKeyID uuid.UUID) error {
+	tag, err := ak.db.Exec(ctx, RevokeAPIKeyQuery, userID, apiKeyID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// EnsureValidAPIKey returns the key, pgx.ErrNoRows when it is unknown or revoked. The caller checks the expiry.
+func (ak *APIKey) EnsureValidAPIKey(ctx context.Context, key string) (*model.APIKey, error) {
+	row := ak.db.QueryRow(ctx, EnsureValidAPIKeyQuery, ak.hasher.Hash(key))
+
+	apiKey := &model.APIKey{}
+	err := row.Scan(&apiKey.APIKeyID, &apiKey.UserID, &apiKey.Role, &apiKey.Name, &apiKey.Prefix, &apiKey.Scopes, &apiKey.ExpiresAt, &apiKey.LastUsedAt, &apiKey.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
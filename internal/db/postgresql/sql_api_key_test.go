@@ -0,0 +1,103 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+	"testing"
+	"time"
+)
+
+type SqlAPIKeyTestSuite struct {
+	DBTestSuite
+	APIKey *APIKey
+	User   *User
+	user   model.User
+}
+
+func (s *SqlAPIKeyTestSuite) SetupTest() {
+	s.APIKey = NewAPIKey(s.DB, utils.NewTokenHasher("secret"))
+	s.User = NewUsers(s.DB)
+	s.user = model.User{Login: "scripts", Email: "scripts@test.com", HashPassword: "hash"}
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.user))
+}
+
+func (s *SqlAPIKeyTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, api_key")
+	s.Require().NoError(err)
+}
+
+func (s *SqlAPIKeyTestSuite) newAPIKey(key string, expiresAt *time.Time) *model.APIKey {
+	apiKey := &model.APIKey{
+		UserID:    s.user.ID,
+		Name:      "backup",
+		Key:       key,
+		Prefix:    key[:8],
+		Scopes:    []string{model.ScopeBillsRead, model.ScopeCatalogWrite},
+		ExpiresAt: expiresAt,
+	}
+	s.Require().NoError(s.APIKey.Insert(s.ctx, apiKey))
+	s.NotEqual(uuid.Nil, apiKey.APIKeyID)
+	return apiKey
+}
+
+func (s *SqlAPIKeyTestSuite) TestAPIKey() {
+	s.Run("no error", func() {
+		inserted := s.newAPIKey("sak_valid-key", nil)
+
+		// only the hash of the key is stored
+		var stored string
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT key FROM api_key WHERE api_key_id = $1", inserted.APIKeyID).Scan(&stored))
+		s.Equal(utils.NewTokenHasher("secret").Hash("sak_valid-key"), stored)
+
+		apiKey, err := s.APIKey.EnsureValidAPIKey(s.ctx, "sak_valid-key")
+		s.Require().NoError(err)
+		s.Equal(inserted.APIKeyID, apiKey.APIKeyID)
+		s.Equal(s.user.ID, apiKey.UserID)
+		s.Equal(model.RoleUser, apiKey.Role)
+		s.Equal([]string{model.ScopeBillsRead, model.ScopeCatalogWrite}, apiKey.Scopes)
+		s.Nil(apiKey.ExpiresAt)
+		s.NotNil(apiKey.LastUsedAt)
+
+		apiKeys, err := s.APIKey.SelectAPIKeysByUserID(s.ctx, s.user.ID)
+		s.Require().NoError(err)
+		s.Require().Len(apiKeys, 1)
+		s.Equal("sak_vali", apiKeys[0].Prefix)
+		s.NotNil(apiKeys[0].LastUsedAt)
+		s.Empty(apiKeys[0].Key)
+
+		s.Require().NoError(s.APIKey.Revoke(s.ctx, s.user.ID, inserted.APIKeyID))
+		_, err = s.APIKey.EnsureValidAPIKey(s.ctx, "sak_valid-key")
+		s.ErrorIs(err, pgx.ErrNoRows)
+		s.ErrorIs(s.APIKey.Revoke(s.ctx, s.user.ID, inserted.APIKeyID), pgx.ErrNoRows)
+	})
+
+	s.Run("expired key is returned but not used", func() {
+		expiresAt := time.Now().UTC().Add(-time.Minute)
+		s.newAPIKey("sak_expired-key", &expiresAt)
+
+		apiKey, err := s.APIKey.EnsureValidAPIKey(s.ctx, "sak_expired-key")
+		s.Require().NoError(err)
+		s.True(apiKey.IsExpired(time.Now().UTC()))
+		s.Nil(apiKey.LastUsedAt)
+	})
+
+	s.Run("key of an other user is not revoked", func() {
+		inserted := s.newAPIKey("sak_other-key", nil)
+		s.ErrorIs(s.APIKey.Revoke(s.ctx, uuid.New(), inserted.APIKeyID), pgx.ErrNoRows)
+	})
+
+	s.Run("key of a disabled user", func() {
+		s.newAPIKey("sak_disabled-key", nil)
+		s.Require().NoError(s.User.Disable(s.ctx, s.user.ID))
+
+		_, err := s.APIKey.EnsureValidAPIKey(s.ctx, "sak_disabled-key")
+		s.ErrorIs(err, pgx.ErrNoRows)
+	})
+}
+
+func TestAPIKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlAPIKeyTestSuite))
+}
@@ -2,56 +2,164 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
 )
 
+// Auth stores the keyed hashes of the access and refresh tokens only, the plaintext tokens are known by
+// the clients.
 type Auth struct {
-	db *Client
+	db     *Client
+	hasher *utils.TokenHasher
 }
 
-func NewAuth(db *Client) *Auth {
+func NewAuth(db *Client, hasher *utils.TokenHasher) *Auth {
 	return &Auth{
-		db: db,
+		db:     db,
+		hasher: hasher,
 	}
 }
 
 const (
-	LoginQuery = `
-		INSERT INTO auth (user_id,token,is_active)
-		VALUES($1,$2,true)
-		ON CONFLICT(user_id)
-		DO UPDATE SET 
-		    token = EXCLUDED.token,
-		    is_active = true,
-		    updated_at = NOW()
+	// InsertSessionQuery opens the session with its first refresh token.
+	InsertSessionQuery = `
+		WITH inserted AS (
+		    INSERT INTO session (user_id, token, expires_at, device, ip, user_agent)
+		    VALUES ($1, $2, $3, $4, $5, $6)
+		    RETURNING session_id, created_at, last_seen_at
+		), refresh AS (
+		    INSERT INTO refresh_token (session_id, token, expires_at)
+		    SELECT session_id, $7, $8 FROM inserted
+		)
+		SELECT session_id, created_at, last_seen_at FROM inserted
 	`
+	// EnsureValidTokenQuery also returns the expired sessions, only the valid ones are recorded as seen. The
+	// sessions of a disabled user are not valid.
 	EnsureValidTokenQuery = `
-		SELECT u.user_id
+		UPDATE session s
+		SET last_seen_at = CASE WHEN s.expires_at > NOW() THEN NOW() ELSE s.last_seen_at END
 		FROM users u
-		INNER JOIN auth a ON a.user_id = u.user_id
-		WHERE a.token = $1
-		AND a.is_active
+		WHERE u.user_id = s.user_id
+		AND s.token = $1
+		AND s.revoked_at IS NULL
+		AND u.disabled_at IS NULL
+		RETURNING s.session_id, s.user_id, u.role, s.expires_at, s.device, s.ip, s.user_agent, s.created_at, s.last_seen_at
 	`
-	LogoutQuery = `UPDATE auth SET is_active = false, updated_at = NOW() WHERE user_id = $1`
+	SelectRefreshTokenQuery = `
+		SELECT rt.refresh_token_id, rt.session_id, s.user_id, rt.expires_at, rt.used_at, s.revoked_at IS NOT NULL
+		FROM refresh_token rt
+		INNER JOIN session s ON s.session_id = rt.session_id
+		WHERE rt.token = $1
+	`
+	// RotateRefreshTokenQuery uses the refresh token once, the session gets a new access token and a new
+	// refresh token. Nothing is returned when the refresh token was already used or the session revoked.
+	RotateRefreshTokenQuery = `
+		WITH used AS (
+		    UPDATE refresh_token SET used_at = NOW()
+		    WHERE refresh_token_id = $1 AND used_at IS NULL
+		    RETURNING session_id
+		), updated AS (
+		    UPDATE session s SET token = $2, expires_at = $3, last_seen_at = NOW()
+		    FROM used u
+		    WHERE s.session_id = u.session_id AND s.revoked_at IS NULL
+		    RETURNING s.session_id, s.user_id, s.expires_at, s.device, s.ip, s.user_agent, s.created_at, s.last_seen_at
+		), refresh AS (
+		    INSERT INTO refresh_token (session_id, token, expires_at)
+		    SELECT session_id, $4, $5 FROM updated
+		)
+		SELECT session_id, user_id, expires_at, device, ip, user_agent, created_at, last_seen_at FROM updated
+	`
+	SelectSessionsByUserIDQuery = `
+		SELECT session_id, user_id, device, ip, user_agent, created_at, last_seen_at
+		FROM session
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY last_seen_at DESC, session_id
+	`
+	RevokeSessionQuery       = `UPDATE session SET revoked_at = NOW() WHERE user_id = $1 AND session_id = $2 AND revoked_at IS NULL`
+	RevokeOtherSessionsQuery = `UPDATE session SET revoked_at = NOW() WHERE user_id = $1 AND session_id <> $2 AND revoked_at IS NULL`
 )
 
-func (a *Auth) Upsert(ctx context.Context, id uuid.UUID, token string) error {
-	_, err := a.db.Exec(ctx, LoginQuery, id, token)
-	return err
+// Insert opens the session, the other sessions of the user are kept.
+func (a *Auth) Insert(ctx context.Context, session *model.Session) error {
+	row := a.db.QueryRow(ctx, InsertSessionQuery, session.UserID, a.hasher.Hash(session.Token), session.ExpiresAt, session.Device, session.IP, session.UserAgent, a.hasher.Hash(session.RefreshToken), session.RefreshExpiresAt)
+	return row.Scan(&session.SessionID, &session.CreatedAt, &session.LastSeenAt)
+}
+
+// EnsureValidToken returns the session of the token, pgx.ErrNoRows when it is unknown or revoked. The
+// caller checks the expiry.
+func (a *Auth) EnsureValidToken(ctx context.Context, token string) (*model.Session, error) {
+	row := a.db.QueryRow(ctx, EnsureValidTokenQuery, a.hasher.Hash(token))
+
+	session := &model.Session{}
+	if err := row.Scan(&session.SessionID, &session.UserID, &session.Role, &session.ExpiresAt, &session.Device, &session.IP, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt); err != nil {
+		return nil, err
+	}
+
+	return session, nil
 }
 
-func (a *Auth) EnsureValidToken(ctx context.Context, token string) (uuid.UUID, error) {
-	row := a.db.QueryRow(ctx, EnsureValidTokenQuery, token)
+func (a *Auth) SelectRefreshToken(ctx context.Context, token string) (*model.RefreshToken, error) {
+	row := a.db.QueryRow(ctx, SelectRefreshTokenQuery, a.hasher.Hash(token))
 
-	var id uuid.UUID
-	if err := row.Scan(&id); err != nil {
-		return uuid.Nil, err
+	refreshToken := &model.RefreshToken{}
+	err := row.Scan(&refreshToken.RefreshTokenID, &refreshToken.SessionID, &refreshToken.UserID, &refreshToken.ExpiresAt, &refreshToken.UsedAt, &refreshToken.SessionRevoked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	return id, nil
+	return refreshToken, nil
+}
+
+// Rotate exchanges the refresh token for the tokens of session, it returns pgx.ErrNoRows when the refresh
+// token was used in between or the session revoked.
+func (a *Auth) Rotate(ctx context.Context, refreshTokenID uuid.UUID, session *model.Session) error {
+	row := a.db.QueryRow(ctx, RotateRefreshTokenQuery, refreshTokenID, a.hasher.Hash(session.Token), session.ExpiresAt, a.hasher.Hash(session.RefreshToken), session.RefreshExpiresAt)
+	return row.Scan(&session.SessionID, &session.UserID, &session.ExpiresAt, &session.Device, &session.IP, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt)
+}
+
+func (a *Auth) SelectSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Session, error) {
+	rows, err := a.db.Query(ctx, SelectSessionsByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*model.Session{}
+	for rows.Next() {
+		session := &model.Session{}
+		if err := rows.Scan(&session.SessionID, &session.UserID, &session.Device, &session.IP, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Revoke ends the session of the user, it returns pgx.ErrNoRows when the user has no such active session.
+func (a *Auth) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	tag, err := a.db.Exec(ctx, RevokeSessionQuery, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
 }
 
-func (a *Auth) Logout(ctx context.Context, id uuid.UUID) error {
-	_, err := a.db.Exec(ctx, LogoutQuery, id)
+// RevokeOthers ends every session of the user but the given one.
+func (a *Auth) RevokeOthers(ctx context.Context, userID, sessionID uuid.UUID) error {
+	_, err := a.db.Exec(ctx, RevokeOtherSessionsQuery, userID, sessionID)
 	return err
 }
@@ -3,10 +3,12 @@ package postgresql
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/utils"
 	"testing"
+	"time"
 )
 
 type SqlAuthTestSuite struct {
@@ -22,24 +24,36 @@ func (s *SqlAuthTestSuite) SetupTest() {
 		Email:        "user42@test.com",
 		HashPassword: "iamuser42forever",
 	}
-	s.Auth = NewAuth(s.DB)
+	s.Auth = NewAuth(s.DB, utils.NewTokenHasher("secret"))
 	s.User = NewUsers(s.DB)
 }
 
 func (s *SqlAuthTestSuite) TearDownTest() {
-	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE user")
-	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE auth")
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE session, refresh_token")
 	s.Require().NoError(err)
 }
 
-func (s *SqlAuthTestSuite) getIDAndTokenByToken(t string) (uuid.UUID, string, bool) {
-	var id uuid.UUID
-	var token string
-	var isActive bool
-	row := s.DB.QueryRow(s.ctx, "SELECT user_id, token, is_active FROM auth WHERE token = $1", t)
-	err := row.Scan(&id, &token, &isActive)
+func (s *SqlAuthTestSuite) newSession(userID uuid.UUID, device string) *model.Session {
+	token, err := utils.GenerateToken(128)
+	s.Require().NoError(err)
+	refreshToken, err := utils.GenerateToken(128)
 	s.Require().NoError(err)
-	return id, token, isActive
+	now := time.Now().UTC()
+	session := &model.Session{
+		UserID:           userID,
+		Token:            token,
+		ExpiresAt:        now.Add(time.Minute),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: now.Add(time.Hour),
+		Device:           device,
+		IP:               "127.0.0.1",
+		UserAgent:        "Mozilla/5.0",
+	}
+	s.Require().NoError(s.Auth.Insert(s.ctx, session))
+	s.NotEqual(uuid.Nil, session.SessionID)
+	return session
 }
 
 func (s *SqlAuthTestSuite) TestAuth() {
@@ -48,58 +62,142 @@ func (s *SqlAuthTestSuite) TestAuth() {
 		s.Require().NoError(s.User.Upsert(s.ctx, &user))
 		s.NotEqual(uuid.Nil, user.ID)
 
-		// Generate a new user ID and token
-		userID := user.ID
-		userToken, err := utils.GenerateToken(128)
-		s.NoError(err)
-
-		// Insert the user ID and token into the auth table
-		s.NoError(s.Auth.Upsert(s.ctx, userID, userToken))
-
-		// Retrieve the inserted user ID, token, and validity from the auth table
-		id, token, isActive := s.getIDAndTokenByToken(userToken)
-
-		// Assert that the retrieved values match the inserted values
-		s.Equal(userID, id)
-		s.Equal(userToken, token)
-		s.True(isActive)
-
-		// EnsureValidToken should return the inserted user ID without error
-		id, err = s.Auth.EnsureValidToken(s.ctx, userToken)
-		s.Equal(userID, id)
-		s.NoError(err)
-
-		// Logout the user by setting the is_valid column to false
-		s.NoError(s.Auth.Logout(s.ctx, userID))
+		// a login on a second device keeps the first session
+		phone := s.newSession(user.ID, "phone")
+		tablet := s.newSession(user.ID, "tablet")
+		laptop := s.newSession(user.ID, "laptop")
+
+		session, err := s.Auth.EnsureValidToken(s.ctx, phone.Token)
+		s.Require().NoError(err)
+		s.Equal(phone.SessionID, session.SessionID)
+		s.Equal(user.ID, session.UserID)
+		s.Equal("phone", session.Device)
+		s.Equal(model.RoleUser, session.Role)
+		s.False(session.LastSeenAt.Before(phone.LastSeenAt))
+
+		// only the hashes of the tokens are stored
+		var token, refreshToken string
+		row := s.DB.QueryRow(s.ctx, "SELECT s.token, rt.token FROM session s INNER JOIN refresh_token rt ON rt.session_id = s.session_id WHERE s.session_id = $1", phone.SessionID)
+		s.Require().NoError(row.Scan(&token, &refreshToken))
+		s.Equal(utils.NewTokenHasher("secret").Hash(phone.Token), token)
+		s.Equal(utils.NewTokenHasher("secret").Hash(phone.RefreshToken), refreshToken)
+		_, err = NewAuth(s.DB, utils.NewTokenHasher("other secret")).EnsureValidToken(s.ctx, phone.Token)
+		s.ErrorIs(err, pgx.ErrNoRows)
+
+		sessions, err := s.Auth.SelectSessionsByUserID(s.ctx, user.ID)
+		s.Require().NoError(err)
+		s.Len(sessions, 3)
+		s.Equal(phone.SessionID, sessions[0].SessionID)
+
+		// a session of an other user is not revoked
+		s.ErrorIs(s.Auth.Revoke(s.ctx, uuid.New(), tablet.SessionID), pgx.ErrNoRows)
+
+		s.Require().NoError(s.Auth.Revoke(s.ctx, user.ID, tablet.SessionID))
+		_, err = s.Auth.EnsureValidToken(s.ctx, tablet.Token)
+		s.ErrorIs(err, pgx.ErrNoRows)
+		s.ErrorIs(s.Auth.Revoke(s.ctx, user.ID, tablet.SessionID), pgx.ErrNoRows)
+
+		s.Require().NoError(s.Auth.RevokeOthers(s.ctx, user.ID, laptop.SessionID))
+		sessions, err = s.Auth.SelectSessionsByUserID(s.ctx, user.ID)
+		s.Require().NoError(err)
+		s.Require().Len(sessions, 1)
+		s.Equal(laptop.SessionID, sessions[0].SessionID)
+	})
 
-		// Retrieve the user ID, token, and validity from the auth table after logout
-		id, token, isActive = s.getIDAndTokenByToken(userToken)
+	s.Run("refresh token rotation", func() {
+		user := s.user
+		user.Login = "rotation"
+		user.Email = "rotation@test.com"
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		phone := s.newSession(user.ID, "phone")
+
+		refreshToken, err := s.Auth.SelectRefreshToken(s.ctx, phone.RefreshToken)
+		s.Require().NoError(err)
+		s.Require().NotNil(refreshToken)
+		s.Equal(phone.SessionID, refreshToken.SessionID)
+		s.Equal(user.ID, refreshToken.UserID)
+		s.Nil(refreshToken.UsedAt)
+		s.False(refreshToken.SessionRevoked)
+
+		now := time.Now().UTC()
+		rotated := &model.Session{Token: "access", ExpiresAt: now.Add(time.Minute), RefreshToken: "refresh", RefreshExpiresAt: now.Add(time.Hour)}
+		s.Require().NoError(s.Auth.Rotate(s.ctx, refreshToken.RefreshTokenID, rotated))
+		s.Equal(phone.SessionID, rotated.SessionID)
+		s.Equal("phone", rotated.Device)
+
+		// the old access token is replaced, the old refresh token is used
+		_, err = s.Auth.EnsureValidToken(s.ctx, phone.Token)
+		s.ErrorIs(err, pgx.ErrNoRows)
+		session, err := s.Auth.EnsureValidToken(s.ctx, "access")
+		s.Require().NoError(err)
+		s.Equal(phone.SessionID, session.SessionID)
+		used, err := s.Auth.SelectRefreshToken(s.ctx, phone.RefreshToken)
+		s.Require().NoError(err)
+		s.NotNil(used.UsedAt)
+		s.ErrorIs(s.Auth.Rotate(s.ctx, refreshToken.RefreshTokenID, &model.Session{Token: "again", RefreshToken: "again"}), pgx.ErrNoRows)
+
+		// revoking the session revokes every refresh token of the family
+		s.Require().NoError(s.Auth.Revoke(s.ctx, user.ID, phone.SessionID))
+		next, err := s.Auth.SelectRefreshToken(s.ctx, "refresh")
+		s.Require().NoError(err)
+		s.True(next.SessionRevoked)
+		s.ErrorIs(s.Auth.Rotate(s.ctx, next.RefreshTokenID, &model.Session{Token: "again", RefreshToken: "again"}), pgx.ErrNoRows)
+
+		unknown, err := s.Auth.SelectRefreshToken(s.ctx, "unknown")
+		s.Require().NoError(err)
+		s.Nil(unknown)
+	})
 
-		// Assert that the validity is now false
-		s.False(isActive)
+	s.Run("expired token", func() {
+		user := s.user
+		user.Login = "expired"
+		user.Email = "expired@test.com"
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		session := s.newSession(user.ID, "phone")
+		_, err := s.DB.Exec(s.ctx, "UPDATE session SET expires_at = NOW() - INTERVAL '1 minute', last_seen_at = NOW() - INTERVAL '1 hour' WHERE session_id = $1", session.SessionID)
+		s.Require().NoError(err)
+
+		expired, err := s.Auth.EnsureValidToken(s.ctx, session.Token)
+		s.Require().NoError(err)
+		s.True(expired.IsExpired(time.Now().UTC()))
+		s.True(expired.LastSeenAt.Before(session.LastSeenAt))
+	})
 
-		// valid again a row
-		userToken, err = utils.GenerateToken(128)
-		s.NoError(err)
+	s.Run("token of a disabled user", func() {
+		user := s.user
+		user.Login = "disabled"
+		user.Email = "disabled@test.com"
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		session := s.newSession(user.ID, "phone")
+		_, err := s.DB.Exec(s.ctx, "UPDATE users SET disabled_at = NOW() WHERE user_id = $1", user.ID)
+		s.Require().NoError(err)
 
-		// Insert the user ID and token into the auth table
-		s.NoError(s.Auth.Upsert(s.ctx, userID, userToken))
+		_, err = s.Auth.EnsureValidToken(s.ctx, session.Token)
+		s.ErrorIs(err, pgx.ErrNoRows)
+	})
 
-		// Retrieve the inserted user ID, token, and validity from the auth table
-		id, token, isActive = s.getIDAndTokenByToken(userToken)
-		s.Equal(userID, id)
-		s.Equal(userToken, token)
-		s.True(isActive)
+	s.Run("token of a deleted user", func() {
+		session := s.newSession(uuid.New(), "phone")
+		_, err := s.Auth.EnsureValidToken(s.ctx, session.Token)
+		s.ErrorIs(err, pgx.ErrNoRows)
 	})
 
 	s.Run("context cancel error", func() {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
-		s.EqualError(s.Auth.Upsert(ctx, uuid.New(), "token"), `context canceled`)
-		s.EqualError(s.Auth.Logout(ctx, uuid.New()), `context canceled`)
-		id, err := s.Auth.EnsureValidToken(ctx, "token")
-		s.Equal(uuid.Nil, id)
+		s.EqualError(s.Auth.Insert(ctx, &model.Session{UserID: uuid.New(), Token: "token"}), `context canceled`)
+		s.EqualError(s.Auth.Revoke(ctx, uuid.New(), uuid.New()), `context canceled`)
+		s.EqualError(s.Auth.RevokeOthers(ctx, uuid.New(), uuid.New()), `context canceled`)
+		session, err := s.Auth.EnsureValidToken(ctx, "token")
+		s.Nil(session)
+		s.EqualError(err, `context canceled`)
+		sessions, err := s.Auth.SelectSessionsByUserID(ctx, uuid.New())
+		s.Nil(sessions)
+		s.EqualError(err, `context canceled`)
+		refreshToken, err := s.Auth.SelectRefreshToken(ctx, "token")
+		s.Nil(refreshToken)
 		s.EqualError(err, `context canceled`)
+		s.EqualError(s.Auth.Rotate(ctx, uuid.New(), &model.Session{}), `context canceled`)
 	})
 }
 
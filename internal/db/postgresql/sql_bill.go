@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"shop-aggregator/internal/model"
@@ -19,28 +20,177 @@ func NewBill(db *Client) *Bill {
 }
 
 const (
+	// InsertBillQuery records the start event of the bill, its user is the actor. The bill is dated $8, now
+	// without date.
 	InsertBillQuery = `
-		INSERT INTO bill (user_id, store_id, amount, bill_state)
-		VALUES ($1, $2, $3, $4)
-		RETURNING bill_id`
-	UpdateBillQuery         = `UPDATE bill SET amount = $1, bill_state = $2 where bill_id = $3`
-	GetBillByUserIDQuery    = `SELECT bill_id, user_id, store_id, amount, bill_state FROM bill WHERE user_id = $1`
-	ExistsUnclosedBillQuery = `SELECT bill_id, user_id, store_id, amount, bill_state FROM bill WHERE user_id = $1 AND bill_state = $2`
+		WITH inserted AS (
+		    INSERT INTO bill (user_id, household_id, store_id, amount, currency, bill_state, created_at)
+		    VALUES ($1, $2, $3, $4, $5, $6, COALESCE($8, NOW()))
+		    RETURNING bill_id, user_id, amount, currency, bill_state, created_at
+		), event AS (
+		    INSERT INTO bill_event (bill_id, actor_id, event, to_state, amount, currency, created_at)
+		    SELECT bill_id, user_id, $7, bill_state, amount, currency, created_at FROM inserted
+		)
+		SELECT bill_id, created_at FROM inserted`
+	UpdateBillQuery = `
+		UPDATE bill SET amount = $1, currency = $2, computed_amount = $3, bill_state = $4, updated_at = NOW()
+		WHERE bill_id = $5
+		RETURNING updated_at`
+	// TransitionBillQuery updates the bill only if it is still in the state $7 and records the event with
+	// the amount declared before. The event $9 drops the split of the bill, its lines can change until it is
+	// closed again.
+	TransitionBillQuery = `
+		WITH previous AS (
+		    SELECT bill_id, amount FROM bill WHERE bill_id = $5 AND bill_state = $7 FOR UPDATE
+		), updated AS (
+		    UPDATE bill b SET amount = $1, currency = $2, computed_amount = $3, bill_state = $4, updated_at = NOW()
+		    FROM previous p
+		    WHERE b.bill_id = p.bill_id
+		    RETURNING b.bill_id, b.amount, b.currency, b.updated_at, p.amount AS previous_amount
+		), event AS (
+		    INSERT INTO bill_event (bill_id, actor_id, event, from_state, to_state, previous_amount, amount, currency, created_at)
+		    SELECT bill_id, $6, $8, $7, $4, previous_amount, amount, currency, updated_at FROM updated
+		), split AS (
+		    DELETE FROM bill_split WHERE bill_id IN (SELECT bill_id FROM updated) AND $8 = $9
+		), split_line AS (
+		    DELETE FROM bill_split_line WHERE bill_id IN (SELECT bill_id FROM updated) AND $8 = $9
+		), split_share AS (
+		    DELETE FROM bill_split_share WHERE bill_id IN (SELECT bill_id FROM updated) AND $8 = $9
+		)
+		SELECT updated_at FROM updated`
+	// SelectBillByIDQuery, like the other selects by user, returns the personal bills of the user and the bills
+	// of the households the user is a member of. A member who leaves a household loses the bills they started
+	// in it.
+	SelectBillByIDQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE ((household_id IS NULL AND user_id = $1) OR household_id IN (SELECT household_id FROM household_member WHERE user_id = $1))
+		AND bill_id = $2`
+	// SelectBillsByUserIDQuery is completed by selectBillsQuery with the sort column, its type, the cursor
+	// comparison and the direction.
+	SelectBillsByUserIDQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE ((household_id IS NULL AND user_id = $1) OR household_id IN (SELECT household_id FROM household_member WHERE user_id = $1))
+		AND (CAST($10 AS uuid) IS NULL OR household_id = $10)
+		AND (CAST($2 AS uuid) IS NULL OR store_id = $2)
+		AND (CAST($3 AS uuid) IS NULL OR store_id IN (SELECT store_id FROM store WHERE company_id = $3))
+		AND (CAST($4 AS text) IS NULL OR bill_state = $4)
+		AND (CAST($5 AS date) IS NULL OR created_at >= $5)
+		AND (CAST($6 AS date) IS NULL OR created_at < CAST($6 AS date) + 1)
+		AND (CAST($7 AS text) IS NULL OR (%[1]s, bill_id) %[3]s (CAST($7 AS %[2]s), $8))
+		ORDER BY %[1]s %[4]s, bill_id %[4]s
+		LIMIT $9`
+	SelectOpenBillsByUserIDQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE ((household_id IS NULL AND user_id = $1) OR household_id IN (SELECT household_id FROM household_member WHERE user_id = $1))
+		AND bill_state = $2
+		ORDER BY created_at DESC, bill_id DESC`
+	// SelectOpenBillByStoreIDQuery looks for the personal bill of the user without household $4, for a bill
+	// of the household, whoever started it, otherwise.
+	SelectOpenBillByStoreIDQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE store_id = $2 AND bill_state = $3
+		AND ((CAST($4 AS uuid) IS NULL AND user_id = $1 AND household_id IS NULL) OR household_id = $4)
+		ORDER BY created_at DESC, bill_id DESC
+		LIMIT 1`
+	SelectDiscrepanciesByUserIDQuery = `
+		SELECT bill_id, user_id, household_id, store_id, amount, computed_amount, currency, bill_state, created_at, updated_at
+		FROM bill
+		WHERE ((household_id IS NULL AND user_id = $1) OR household_id IN (SELECT household_id FROM household_member WHERE user_id = $1))
+		AND bill_state IN ($2, $3)
+		AND discrepancy <> 0
+		ORDER BY ABS(discrepancy) DESC`
 )
 
+// billSortColumns maps the sorts of model.BillFilter to their column and its type.
+var billSortColumns = map[string][2]string{
+	model.BillSortDate:   {"created_at", "timestamp"},
+	model.BillSortAmount: {"amount", "numeric"},
+}
+
 func (b *Bill) Insert(ctx context.Context, bill *model.Bill) error {
-	row := b.db.QueryRow(ctx, InsertBillQuery, bill.UserID, bill.StoreID, bill.Amount, model.BillStateCreate)
-	err := row.Scan(&bill.BillID)
+	row := b.db.QueryRow(ctx, InsertBillQuery, bill.UserID, bill.HouseholdID, bill.StoreID, bill.Amount.Amount, bill.Amount.Currency, model.BillStateCreate, model.BillEventStart, nullTime(bill.CreatedAt))
+	err := row.Scan(&bill.BillID, &bill.CreatedAt)
 	return err
 }
 
 func (b *Bill) Update(ctx context.Context, bill *model.Bill) error {
-	_, err := b.db.Exec(ctx, UpdateBillQuery, bill.Amount, bill.State, bill.BillID)
+	row := b.db.QueryRow(ctx, UpdateBillQuery, bill.Amount.Amount, bill.Amount.Currency, bill.ComputedAmount.Amount, bill.State, bill.BillID)
+	err := row.Scan(&bill.UpdatedAt)
+	return err
+}
+
+// Transition saves the bill moved by the event from the state from, with its event. It returns
+// pgx.ErrNoRows when the bill is no longer in the state from. A reopened bill loses its split.
+func (b *Bill) Transition(ctx context.Context, bill *model.Bill, from, event string, actorID uuid.UUID) error {
+	row := b.db.QueryRow(ctx, TransitionBillQuery, bill.Amount.Amount, bill.Amount.Currency, bill.ComputedAmount.Amount, bill.State, bill.BillID, actorID, from, event, model.BillEventReopen)
+	err := row.Scan(&bill.UpdatedAt)
 	return err
 }
 
-func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
-	rows, err := b.db.Query(ctx, GetBillByUserIDQuery, userID)
+// SelectBillByID returns the bill of the user or of a household of the user whatever its state, nil
+// otherwise.
+func (b *Bill) SelectBillByID(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error) {
+	return b.selectBill(ctx, SelectBillByIDQuery, userID, billID)
+}
+
+// GetBillsByUserID returns at most filter.Limit bills of the user and of its households, the bills after
+// filter.Cursor in the order of filter.Sort and filter.Order, by creation date without sort.
+func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID, filter *model.BillFilter) ([]*model.Bill, error) {
+	var cursorValue, cursorID interface{}
+	if filter.Cursor != nil {
+		cursorValue, cursorID = filter.Cursor.Value, filter.Cursor.BillID
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = model.MaxBillLimit
+	}
+
+	return b.selectBills(ctx, selectBillsQuery(filter.Sort, filter.Order), userID, nullUUID(filter.StoreID), nullUUID(filter.CompanyID), nullString(filter.State), nullTime(filter.From), nullTime(filter.To), cursorValue, cursorID, limit, nullUUID(filter.HouseholdID))
+}
+
+func (b *Bill) SelectDiscrepanciesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	return b.selectBills(ctx, SelectDiscrepanciesByUserIDQuery, userID, model.BillStateCompleted, model.BillStateArchived)
+}
+
+func (b *Bill) SelectOpenBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	return b.selectBills(ctx, SelectOpenBillsByUserIDQuery, userID, model.BillStateCreate)
+}
+
+// SelectOpenBillByStoreID returns the most recent open bill in the store, of the household when householdID
+// is set, the personal one of the user otherwise. It is nil without one.
+func (b *Bill) SelectOpenBillByStoreID(ctx context.Context, userID, householdID, storeID uuid.UUID) (*model.Bill, error) {
+	return b.selectBill(ctx, SelectOpenBillByStoreIDQuery, userID, storeID, model.BillStateCreate, nullUUID(householdID))
+}
+
+func (b *Bill) selectBill(ctx context.Context, query string, args ...interface{}) (*model.Bill, error) {
+	row := b.db.QueryRow(ctx, query, args...)
+	bill, err := scanBill(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return bill, nil
+}
+
+func selectBillsQuery(sort, order string) string {
+	column, ok := billSortColumns[sort]
+	if !ok {
+		column = billSortColumns[model.BillSortDate]
+	}
+	if order == model.BillOrderDesc {
+		return fmt.Sprintf(SelectBillsByUserIDQuery, column[0], column[1], "<", "DESC")
+	}
+	return fmt.Sprintf(SelectBillsByUserIDQuery, column[0], column[1], ">", "ASC")
+}
+
+func (b *Bill) selectBills(ctx context.Context, query string, args ...interface{}) ([]*model.Bill, error) {
+	rows, err := b.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +198,7 @@ func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model
 
 	var bills []*model.Bill
 	for rows.Next() {
-		bill := &model.Bill{}
-		err = rows.Scan(&bill.BillID, &bill.UserID, &bill.StoreID, &bill.Amount, &bill.State)
+		bill, err := scanBill(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -63,15 +212,13 @@ func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model
 	return bills, nil
 }
 
-func (b *Bill) ExistsUnclosedBill(ctx context.Context, userID uuid.UUID) (*model.Bill, error) {
-	row := b.db.QueryRow(ctx, ExistsUnclosedBillQuery, userID, model.BillStateCreate)
+// scanBill reads the columns of the bill selects, both amounts are in the currency of the bill.
+func scanBill(row pgx.Row) (*model.Bill, error) {
 	bill := &model.Bill{}
-
-	if err := row.Scan(&bill.BillID, &bill.UserID, &bill.StoreID, &bill.Amount, &bill.State); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
-		}
+	err := row.Scan(&bill.BillID, &bill.UserID, &bill.HouseholdID, &bill.StoreID, &bill.Amount.Amount, &bill.ComputedAmount.Amount, &bill.Amount.Currency, &bill.State, &bill.CreatedAt, &bill.UpdatedAt)
+	if err != nil {
 		return nil, err
 	}
+	bill.ComputedAmount.Currency = bill.Amount.Currency
 	return bill, nil
 }
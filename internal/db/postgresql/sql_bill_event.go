@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+)
+
+type BillEvent struct {
+	db *Client
+}
+
+func NewBillEvent(db *Client) *BillEvent {
+	return &BillEvent{
+		db: db,
+	}
+}
+
+const (
+	SelectBillEventsByBillIDQuery = `
+		SELECT bill_event_id, bill_id, actor_id, event, from_state, to_state, previous_amount, amount, currency, created_at
+		FROM bill_event
+		WHERE bill_id = $1
+		ORDER BY created_at, bill_event_id`
+)
+
+func (be *BillEvent) SelectByBillID(ctx context.Context, billID uuid.UUID) ([]*model.BillEvent, error) {
+	rows, err := be.db.Query(ctx, SelectBillEventsByBillIDQuery, billID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*model.BillEvent{}
+	for rows.Next() {
+		event := &model.BillEvent{}
+		var currency string
+		err := rows.Scan(&event.BillEventID, &event.BillID, &event.ActorID, &event.Event, &event.FromState, &event.ToState, &event.PreviousAmount.Amount, &event.Amount.Amount, &currency, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		event.PreviousAmount.Currency = currency
+		event.Amount.Currency = currency
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,218 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+)
+
+type BillSplit struct {
+	db *Client
+}
+
+func NewBillSplit(db *Client) *BillSplit {
+	return &BillSplit{
+		db: db,
+	}
+}
+
+// debtsQuery lists who owes what to whom on the splits of the bills in the states $1 and $2. A settlement
+// counts as a debt of its receiver, so it pays back the debts of its payer.
+const debtsQuery = `debts AS (
+		    SELECT bs.payer_id AS creditor_id, s.user_id AS debtor_id, s.amount, bs.currency
+		    FROM bill_split bs
+		    INNER JOIN bill_split_share s ON s.bill_id = bs.bill_id
+		    INNER JOIN bill b ON b.bill_id = bs.bill_id
+		    WHERE s.user_id <> bs.payer_id AND b.bill_state IN ($1, $2)
+		    UNION ALL
+		    SELECT from_user_id, to_user_id, amount, currency FROM settlement
+		)`
+
+const (
+	// ReplaceBillSplitQuery replaces the split of the bill, its allocations and its shares, in one statement.
+	ReplaceBillSplitQuery = `
+		WITH deleted_lines AS (
+		    DELETE FROM bill_split_line WHERE bill_id = $1
+		), deleted_shares AS (
+		    DELETE FROM bill_split_share WHERE bill_id = $1
+		), split AS (
+		    INSERT INTO bill_split (bill_id, payer_id, created_by, currency)
+		    VALUES ($1, $2, $3, $4)
+		    ON CONFLICT (bill_id) DO UPDATE SET
+		        payer_id = EXCLUDED.payer_id,
+		        currency = EXCLUDED.currency,
+		        updated_at = NOW()
+		    RETURNING created_by, created_at, updated_at
+		), lines AS (
+		    INSERT INTO bill_split_line (bill_id, position, user_product_id, participant_type, participant_id, method, value)
+		    SELECT $1, l.position, l.user_product_id, l.participant_type, l.participant_id, l.method, CAST(l.value AS NUMERIC)
+		    FROM unnest($5::UUID[], $6::TEXT[], $7::UUID[], $8::TEXT[], $9::TEXT[])
+		        WITH ORDINALITY AS l(user_product_id, participant_type, participant_id, method, value, position)
+		), shares AS (
+		    INSERT INTO bill_split_share (bill_id, position, user_id, amount)
+		    SELECT $1, s.position, s.user_id, CAST(s.amount AS NUMERIC)
+		    FROM unnest($10::UUID[], $11::TEXT[]) WITH ORDINALITY AS s(user_id, amount, position)
+		)
+		SELECT created_by, created_at, updated_at FROM split
+	`
+	SelectBillSplitQuery      = `SELECT bill_id, payer_id, created_by, currency, created_at, updated_at FROM bill_split WHERE bill_id = $1`
+	SelectBillSplitLinesQuery = `
+		SELECT user_product_id, participant_type, participant_id, method, value
+		FROM bill_split_line
+		WHERE bill_id = $1
+		ORDER BY position
+	`
+	SelectBillSplitSharesQuery = `
+		SELECT s.user_id, COALESCE(u.login, ''), s.amount
+		FROM bill_split_share s
+		LEFT JOIN users u ON u.user_id = s.user_id
+		WHERE s.bill_id = $1
+		ORDER BY s.position
+	`
+	// InsertSettlementQuery records the settlement unless it is more than what its payer owes to its receiver
+	// in the currency, no row is returned then.
+	InsertSettlementQuery = `
+		WITH ` + debtsQuery + `, owed AS (
+		    SELECT COALESCE(SUM(CASE WHEN creditor_id = $4 THEN amount ELSE -amount END), 0) AS amount
+		    FROM debts
+		    WHERE currency = $6 AND ((creditor_id = $4 AND debtor_id = $3) OR (creditor_id = $3 AND debtor_id = $4))
+		)
+		INSERT INTO settlement (from_user_id, to_user_id, amount, currency)
+		SELECT $3, $4, $5::NUMERIC, $6::TEXT FROM owed WHERE owed.amount >= $5::NUMERIC
+		RETURNING settlement_id, created_at
+	`
+	// SelectBalancesQuery sums, by other user and currency, what the others owe to the user on the splits of
+	// the closed bills, minus what the user owes them.
+	SelectBalancesQuery = `
+		WITH ` + debtsQuery + `, balances AS (
+		    SELECT debtor_id AS user_id, amount, currency FROM debts WHERE creditor_id = $3
+		    UNION ALL
+		    SELECT creditor_id, -amount, currency FROM debts WHERE debtor_id = $3
+		)
+		SELECT b.user_id, COALESCE(u.login, ''), b.currency, SUM(b.amount)
+		FROM balances b
+		LEFT JOIN users u ON u.user_id = b.user_id
+		GROUP BY b.user_id, u.login, b.currency
+		HAVING SUM(b.amount) <> 0
+		ORDER BY u.login, b.currency
+	`
+)
+
+// ReplaceSplit saves the split of the bill in place of the previous one, the creator of the first split is kept.
+func (bs *BillSplit) ReplaceSplit(ctx context.Context, split *model.BillSplit) error {
+	var (
+		lineIDs          = make([]uuid.UUID, 0, len(split.Allocations))
+		participantTypes = make([]string, 0, len(split.Allocations))
+		participantIDs   = make([]uuid.UUID, 0, len(split.Allocations))
+		methods          = make([]string, 0, len(split.Allocations))
+		values           = make([]string, 0, len(split.Allocations))
+		userIDs          = make([]uuid.UUID, 0, len(split.Shares))
+		amounts          = make([]string, 0, len(split.Shares))
+	)
+	for _, allocation := range split.Allocations {
+		lineIDs = append(lineIDs, allocation.UserProductID)
+		participantTypes = append(participantTypes, allocation.ParticipantType)
+		participantIDs = append(participantIDs, allocation.ParticipantID)
+		methods = append(methods, allocation.Method)
+		values = append(values, allocation.Value.String())
+	}
+	for _, share := range split.Shares {
+		userIDs = append(userIDs, share.UserID)
+		amounts = append(amounts, share.Amount.Amount.String())
+	}
+
+	row := bs.db.QueryRow(ctx, ReplaceBillSplitQuery, split.BillID, split.PayerID, split.CreatedBy, split.Currency,
+		lineIDs, participantTypes, participantIDs, methods, values, userIDs, amounts)
+	return row.Scan(&split.CreatedBy, &split.CreatedAt, &split.UpdatedAt)
+}
+
+// SelectSplit returns the split of the bill with its allocations and shares, nil when the bill is not split.
+func (bs *BillSplit) SelectSplit(ctx context.Context, billID uuid.UUID) (*model.BillSplit, error) {
+	split := &model.BillSplit{}
+	err := bs.db.QueryRow(ctx, SelectBillSplitQuery, billID).
+		Scan(&split.BillID, &split.PayerID, &split.CreatedBy, &split.Currency, &split.CreatedAt, &split.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if split.Allocations, err = bs.selectAllocations(ctx, billID); err != nil {
+		return nil, err
+	}
+	if split.Shares, err = bs.selectShares(ctx, billID, split.Currency); err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+func (bs *BillSplit) selectAllocations(ctx context.Context, billID uuid.UUID) ([]*model.SplitAllocation, error) {
+	rows, err := bs.db.Query(ctx, SelectBillSplitLinesQuery, billID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := make([]*model.SplitAllocation, 0)
+	for rows.Next() {
+		a := &model.SplitAllocation{}
+		err = rows.Scan(&a.UserProductID, &a.ParticipantType, &a.ParticipantID, &a.Method, &a.Value)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, a)
+	}
+
+	return allocations, rows.Err()
+}
+
+func (bs *BillSplit) selectShares(ctx context.Context, billID uuid.UUID, currency string) ([]*model.SplitShare, error) {
+	rows, err := bs.db.Query(ctx, SelectBillSplitSharesQuery, billID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := make([]*model.SplitShare, 0)
+	for rows.Next() {
+		s := &model.SplitShare{Amount: model.ZeroMoney(currency)}
+		err = rows.Scan(&s.UserID, &s.Login, &s.Amount.Amount)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, s)
+	}
+
+	return shares, rows.Err()
+}
+
+// InsertSettlement records the settlement, pgx.ErrNoRows when its payer owes less than its amount to its receiver.
+func (bs *BillSplit) InsertSettlement(ctx context.Context, settlement *model.Settlement) error {
+	row := bs.db.QueryRow(ctx, InsertSettlementQuery, model.BillStateCompleted, model.BillStateArchived,
+		settlement.FromUserID, settlement.ToUserID, settlement.Amount.Amount, settlement.Amount.Currency)
+	return row.Scan(&settlement.SettlementID, &settlement.CreatedAt)
+}
+
+// SelectBalances returns what each other user owes to the user by currency, the settled ones are left out.
+func (bs *BillSplit) SelectBalances(ctx context.Context, userID uuid.UUID) ([]*model.Balance, error) {
+	rows, err := bs.db.Query(ctx, SelectBalancesQuery, model.BillStateCompleted, model.BillStateArchived, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make([]*model.Balance, 0)
+	for rows.Next() {
+		b := &model.Balance{}
+		err = rows.Scan(&b.UserID, &b.Login, &b.Amount.Currency, &b.Amount.Amount)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, b)
+	}
+
+	return balances, rows.Err()
+}
@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+type SqlBillSplitTestSuite struct {
+	DBTestSuite
+	BillSplit *BillSplit
+	Bill      *Bill
+	User      *User
+	payer     model.User
+	friend    model.User
+}
+
+func (s *SqlBillSplitTestSuite) SetupTest() {
+	s.BillSplit = NewBillSplit(s.DB)
+	s.Bill = NewBill(s.DB)
+	s.User = NewUsers(s.DB)
+	s.payer = model.User{Login: "alice", Email: "alice@test.com", HashPassword: "hash"}
+	s.friend = model.User{Login: "bob", Email: "bob@test.com", HashPassword: "hash"}
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.payer))
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.friend))
+}
+
+func (s *SqlBillSplitTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, bill, bill_event, bill_split, bill_split_line, bill_split_share, settlement")
+	s.Require().NoError(err)
+}
+
+func (s *SqlBillSplitTestSuite) newSplit(state string, owed string) *model.BillSplit {
+	bill := &model.Bill{UserID: s.payer.ID, StoreID: uuid.New(), Amount: money("20"), ComputedAmount: money("20"), State: state}
+	s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+	s.Require().NoError(s.Bill.Update(s.ctx, bill))
+
+	split := &model.BillSplit{
+		BillID:    bill.BillID,
+		PayerID:   s.payer.ID,
+		CreatedBy: s.payer.ID,
+		Currency:  "EUR",
+		Allocations: []*model.SplitAllocation{
+			{UserProductID: uuid.New(), ParticipantType: model.SplitParticipantUser, ParticipantID: s.friend.ID, Method: model.SplitMethodFixed, Value: decimal.RequireFromString(owed)},
+		},
+		Shares: []*model.SplitShare{
+			{UserID: s.payer.ID, Amount: model.NewMoney(decimal.NewFromInt(20).Sub(decimal.RequireFromString(owed)), "EUR")},
+			{UserID: s.friend.ID, Amount: money(owed)},
+		},
+	}
+	s.Require().NoError(s.BillSplit.ReplaceSplit(s.ctx, split))
+	return split
+}
+
+func (s *SqlBillSplitTestSuite) TestSplit() {
+	s.Run("replace and select", func() {
+		split := s.newSplit(model.BillStateCompleted, "5")
+
+		// a new split replaces the lines and the shares
+		split.Allocations[0].Value = decimal.RequireFromString("7.5")
+		split.Shares[0].Amount = money("12.50")
+		split.Shares[1].Amount = money("7.50")
+		s.Require().NoError(s.BillSplit.ReplaceSplit(s.ctx, split))
+
+		got, err := s.BillSplit.SelectSplit(s.ctx, split.BillID)
+		s.Require().NoError(err)
+		s.Require().NotNil(got)
+		s.Equal(s.payer.ID, got.PayerID)
+		s.Require().Len(got.Allocations, 1)
+		s.Equal("7.5", got.Allocations[0].Value.String())
+		s.Require().Len(got.Shares, 2)
+		s.Equal("alice", got.Shares[0].Login)
+		s.Equal("12.5", got.Shares[0].Amount.Amount.String())
+		s.Equal("bob", got.Shares[1].Login)
+		s.Equal("7.5", got.Shares[1].Amount.Amount.String())
+
+		got, err = s.BillSplit.SelectSplit(s.ctx, uuid.New())
+		s.Require().NoError(err)
+		s.Nil(got)
+	})
+
+	s.Run("reopen drops the split", func() {
+		split := s.newSplit(model.BillStateCompleted, "5")
+		bill := &model.Bill{BillID: split.BillID, Amount: money("20"), ComputedAmount: money("20"), State: model.BillStateCreate}
+		s.Require().NoError(s.Bill.Transition(s.ctx, bill, model.BillStateCompleted, model.BillEventReopen, s.payer.ID))
+
+		got, err := s.BillSplit.SelectSplit(s.ctx, split.BillID)
+		s.Require().NoError(err)
+		s.Nil(got)
+		balances, err := s.BillSplit.SelectBalances(s.ctx, s.payer.ID)
+		s.Require().NoError(err)
+		s.Empty(balances)
+	})
+
+	s.Run("balances and settlements", func() {
+		s.newSplit(model.BillStateCompleted, "5")
+		// the split of a reopened bill does not count until it is closed again
+		s.newSplit(model.BillStateCreate, "3")
+
+		balances, err := s.BillSplit.SelectBalances(s.ctx, s.payer.ID)
+		s.Require().NoError(err)
+		s.Require().Len(balances, 1)
+		s.Equal(s.friend.ID, balances[0].UserID)
+		s.Equal("bob", balances[0].Login)
+		s.Equal("5", balances[0].Amount.Amount.String())
+		s.Equal("EUR", balances[0].Amount.Currency)
+
+		settlement := &model.Settlement{FromUserID: s.friend.ID, ToUserID: s.payer.ID, Amount: money("2")}
+		s.Require().NoError(s.BillSplit.InsertSettlement(s.ctx, settlement))
+		s.NotEqual(uuid.Nil, settlement.SettlementID)
+
+		balances, err = s.BillSplit.SelectBalances(s.ctx, s.friend.ID)
+		s.Require().NoError(err)
+		s.Require().Len(balances, 1)
+		s.Equal(s.payer.ID, balances[0].UserID)
+		s.Equal("-3", balances[0].Amount.Amount.String())
+
+		// no one settles more than they owe, nor what they are owed
+		err = s.BillSplit.InsertSettlement(s.ctx, &model.Settlement{FromUserID: s.friend.ID, ToUserID: s.payer.ID, Amount: money("4")})
+		s.ErrorIs(err, pgx.ErrNoRows)
+		err = s.BillSplit.InsertSettlement(s.ctx, &model.Settlement{FromUserID: s.payer.ID, ToUserID: s.friend.ID, Amount: money("1")})
+		s.ErrorIs(err, pgx.ErrNoRows)
+
+		s.Require().NoError(s.BillSplit.InsertSettlement(s.ctx, &model.Settlement{FromUserID: s.friend.ID, ToUserID: s.payer.ID, Amount: money("3")}))
+		balances, err = s.BillSplit.SelectBalances(s.ctx, s.payer.ID)
+		s.Require().NoError(err)
+		s.Empty(balances)
+	})
+}
+
+func TestBillSplitTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlBillSplitTestSuite))
+}
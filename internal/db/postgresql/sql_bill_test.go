@@ -3,22 +3,26 @@ package postgresql
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
+	"time"
 )
 
 type SqlBillTestSuite struct {
 	DBTestSuite
-	Bill *Bill
+	Bill      *Bill
+	BillEvent *BillEvent
 }
 
 func (s *SqlBillTestSuite) SetupTest() {
 	s.Bill = NewBill(s.DB)
+	s.BillEvent = NewBillEvent(s.DB)
 }
 
 func (s *SqlBillTestSuite) TearDownTest() {
-	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE bill")
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE bill, bill_event")
 	s.Require().NoError(err)
 }
 
@@ -27,34 +31,39 @@ func (s *SqlBillTestSuite) TestBill() {
 		userID := uuid.New()
 		bills := []*model.Bill{
 			{
-				UserID:  userID,
-				StoreID: uuid.New(),
-				Amount:  "2",
-				State:   model.BillStateCreate,
+				UserID:         userID,
+				StoreID:        uuid.New(),
+				Amount:         money("2"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  userID,
-				StoreID: uuid.New(),
-				Amount:  "5",
-				State:   model.BillStateCreate,
+				UserID:         userID,
+				StoreID:        uuid.New(),
+				Amount:         money("5"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  userID,
-				StoreID: uuid.New(),
-				Amount:  "245",
-				State:   model.BillStateCreate,
+				UserID:         userID,
+				StoreID:        uuid.New(),
+				Amount:         money("245"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  userID,
-				StoreID: uuid.New(),
-				Amount:  "72",
-				State:   model.BillStateCreate,
+				UserID:         userID,
+				StoreID:        uuid.New(),
+				Amount:         money("72"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  userID,
-				StoreID: uuid.New(),
-				Amount:  "245",
-				State:   model.BillStateCreate,
+				UserID:         userID,
+				StoreID:        uuid.New(),
+				Amount:         money("245"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 		}
 
@@ -62,7 +71,7 @@ func (s *SqlBillTestSuite) TestBill() {
 			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
 		}
 
-		checkBills, err := s.Bill.GetBillsByUserID(s.ctx, userID)
+		checkBills, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{})
 		s.Require().NoError(err)
 		s.ElementsMatch(bills, checkBills)
 
@@ -70,22 +79,25 @@ func (s *SqlBillTestSuite) TestBill() {
 		newUserID := uuid.New()
 		newBills := []*model.Bill{
 			{
-				UserID:  newUserID,
-				StoreID: uuid.New(),
-				Amount:  "2",
-				State:   model.BillStateCreate,
+				UserID:         newUserID,
+				StoreID:        uuid.New(),
+				Amount:         money("2"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  newUserID,
-				StoreID: uuid.New(),
-				Amount:  "5",
-				State:   model.BillStateCreate,
+				UserID:         newUserID,
+				StoreID:        uuid.New(),
+				Amount:         money("5"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 			{
-				UserID:  newUserID,
-				StoreID: uuid.New(),
-				Amount:  "245",
-				State:   model.BillStateCreate,
+				UserID:         newUserID,
+				StoreID:        uuid.New(),
+				Amount:         money("245"),
+				ComputedAmount: money("0"),
+				State:          model.BillStateCreate,
 			},
 		}
 
@@ -93,47 +105,214 @@ func (s *SqlBillTestSuite) TestBill() {
 			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
 		}
 
-		checkNewBills, err := s.Bill.GetBillsByUserID(s.ctx, newUserID)
+		checkNewBills, err := s.Bill.GetBillsByUserID(s.ctx, newUserID, &model.BillFilter{})
 		s.Require().NoError(err)
 		s.ElementsMatch(newBills, checkNewBills)
 	})
 
+	s.Run("insert dated, no error", func() {
+		date := time.Date(2024, 3, 14, 10, 5, 0, 0, time.UTC)
+		bill := &model.Bill{UserID: uuid.New(), StoreID: uuid.New(), Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate, CreatedAt: date}
+		s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+		s.True(date.Equal(bill.CreatedAt))
+	})
+
 	s.Run("insert and update, no error", func() {
 		bill := model.Bill{
-			UserID:  uuid.New(),
-			StoreID: uuid.New(),
-			Amount:  "1234",
-			State:   model.BillStateCreate,
+			UserID:         uuid.New(),
+			StoreID:        uuid.New(),
+			Amount:         money("1234"),
+			ComputedAmount: money("0"),
+			State:          model.BillStateCreate,
 		}
 
 		// insert
 		s.NoError(s.Bill.Insert(s.ctx, &bill))
 
 		// check well insert
-		checkBills, err := s.Bill.GetBillsByUserID(s.ctx, bill.UserID)
+		checkBills, err := s.Bill.GetBillsByUserID(s.ctx, bill.UserID, &model.BillFilter{})
 		s.NoError(err)
 		s.Len(checkBills, 1)
 		s.Equal(bill, *checkBills[0])
 
 		// update
-		bill.Amount = "987"
+		bill.Amount = money("987")
 		s.NoError(s.Bill.Update(s.ctx, &bill))
+		s.NotNil(bill.UpdatedAt)
 
 		// check update
-		checkBills, err = s.Bill.GetBillsByUserID(s.ctx, bill.UserID)
+		checkBills, err = s.Bill.GetBillsByUserID(s.ctx, bill.UserID, &model.BillFilter{})
 		s.NoError(err)
 		s.Len(checkBills, 1)
 		s.Equal(bill, *checkBills[0])
 	})
 
+	s.Run("discrepancies, no error", func() {
+		userID := uuid.New()
+		matching := &model.Bill{UserID: userID, StoreID: uuid.New(), Amount: money("12.5"), ComputedAmount: money("12.5")}
+		missedItem := &model.Bill{UserID: userID, StoreID: uuid.New(), Amount: money("15"), ComputedAmount: money("12.5")}
+		mistyped := &model.Bill{UserID: userID, StoreID: uuid.New(), Amount: money("12.5"), ComputedAmount: money("21.5")}
+		open := &model.Bill{UserID: userID, StoreID: uuid.New(), Amount: money("0"), ComputedAmount: money("3")}
+		for _, bill := range []*model.Bill{matching, missedItem, mistyped, open} {
+			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+			bill.State = model.BillStateCompleted
+			if bill == open {
+				bill.State = model.BillStateCreate
+			}
+			// an archived bill was completed, its discrepancy stays
+			if bill == missedItem {
+				bill.State = model.BillStateArchived
+			}
+			s.Require().NoError(s.Bill.Update(s.ctx, bill))
+		}
+
+		bills, err := s.Bill.SelectDiscrepanciesByUserID(s.ctx, userID)
+		s.Require().NoError(err)
+		s.Equal([]*model.Bill{mistyped, missedItem}, bills)
+		s.Equal("-9.00 EUR", bills[0].Discrepancy().String())
+	})
+
+	s.Run("filters and pagination, no error", func() {
+		userID := uuid.New()
+		storeID := uuid.New()
+		bills := []*model.Bill{
+			{UserID: userID, StoreID: storeID, Amount: money("30"), ComputedAmount: money("0"), State: model.BillStateCompleted},
+			{UserID: userID, StoreID: storeID, Amount: money("10"), ComputedAmount: money("0"), State: model.BillStateCompleted},
+			{UserID: userID, StoreID: uuid.New(), Amount: money("20"), ComputedAmount: money("0"), State: model.BillStateCompleted},
+			{UserID: userID, StoreID: storeID, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate},
+		}
+		for _, bill := range bills {
+			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+			s.Require().NoError(s.Bill.Update(s.ctx, bill))
+		}
+
+		// by date, one page after the other
+		first, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{Sort: model.BillSortDate, Order: model.BillOrderAsc, Limit: 2})
+		s.Require().NoError(err)
+		s.Equal(bills[:2], first)
+		second, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{Sort: model.BillSortDate, Order: model.BillOrderAsc, Limit: 2, Cursor: model.NewBillCursor(model.BillSortDate, first[1])})
+		s.Require().NoError(err)
+		s.Equal(bills[2:], second)
+
+		// by amount, descending
+		byAmount, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{Sort: model.BillSortAmount, Order: model.BillOrderDesc, Limit: 2})
+		s.Require().NoError(err)
+		s.Equal([]*model.Bill{bills[0], bills[2]}, byAmount)
+		next, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{Sort: model.BillSortAmount, Order: model.BillOrderDesc, Limit: 2, Cursor: model.NewBillCursor(model.BillSortAmount, byAmount[1])})
+		s.Require().NoError(err)
+		s.Equal([]*model.Bill{bills[1], bills[3]}, next)
+
+		// filters
+		filtered, err := s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{StoreID: storeID, State: model.BillStateCompleted})
+		s.Require().NoError(err)
+		s.Equal(bills[:2], filtered)
+		filtered, err = s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{From: bills[0].CreatedAt.AddDate(0, 0, 1)})
+		s.Require().NoError(err)
+		s.Empty(filtered)
+		filtered, err = s.Bill.GetBillsByUserID(s.ctx, userID, &model.BillFilter{From: bills[0].CreatedAt, To: bills[0].CreatedAt})
+		s.Require().NoError(err)
+		s.Len(filtered, 4)
+	})
+
+	s.Run("open bills, no error", func() {
+		userID := uuid.New()
+		store := uuid.New()
+		online := uuid.New()
+		closed := &model.Bill{UserID: userID, StoreID: store, Amount: money("3"), ComputedAmount: money("0"), State: model.BillStateCompleted}
+		inStore := &model.Bill{UserID: userID, StoreID: store, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		onlineBill := &model.Bill{UserID: userID, StoreID: online, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		for _, bill := range []*model.Bill{closed, inStore, onlineBill} {
+			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+			s.Require().NoError(s.Bill.Update(s.ctx, bill))
+		}
+
+		bills, err := s.Bill.SelectOpenBillsByUserID(s.ctx, userID)
+		s.Require().NoError(err)
+		s.Equal([]*model.Bill{onlineBill, inStore}, bills)
+
+		bill, err := s.Bill.SelectOpenBillByStoreID(s.ctx, userID, uuid.Nil, store)
+		s.Require().NoError(err)
+		s.Equal(inStore, bill)
+		bill, err = s.Bill.SelectOpenBillByStoreID(s.ctx, uuid.New(), uuid.Nil, store)
+		s.Require().NoError(err)
+		s.Nil(bill)
+
+		bill, err = s.Bill.SelectBillByID(s.ctx, userID, onlineBill.BillID)
+		s.Require().NoError(err)
+		s.Equal(onlineBill, bill)
+		bill, err = s.Bill.SelectBillByID(s.ctx, userID, closed.BillID)
+		s.Require().NoError(err)
+		s.Equal(closed, bill)
+		bill, err = s.Bill.SelectBillByID(s.ctx, uuid.New(), onlineBill.BillID)
+		s.Require().NoError(err)
+		s.Nil(bill)
+	})
+
+	s.Run("transitions and history, no error", func() {
+		userID := uuid.New()
+		bill := &model.Bill{UserID: userID, StoreID: uuid.New(), Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+
+		bill.Amount = money("12.5")
+		bill.ComputedAmount = money("12.5")
+		bill.State = model.BillStateCompleted
+		s.Require().NoError(s.Bill.Transition(s.ctx, bill, model.BillStateCreate, model.BillEventClose, userID))
+		s.NotNil(bill.UpdatedAt)
+
+		// the bill is no longer open, the same transition fails
+		s.ErrorIs(s.Bill.Transition(s.ctx, bill, model.BillStateCreate, model.BillEventClose, userID), pgx.ErrNoRows)
+
+		bill.State = model.BillStateCreate
+		s.Require().NoError(s.Bill.Transition(s.ctx, bill, model.BillStateCompleted, model.BillEventReopen, userID))
+
+		check, err := s.Bill.SelectBillByID(s.ctx, userID, bill.BillID)
+		s.Require().NoError(err)
+		s.Equal(model.BillStateCreate, check.State)
+		s.Equal("12.50 EUR", check.Amount.String())
+		check, err = s.Bill.SelectBillByID(s.ctx, uuid.New(), bill.BillID)
+		s.Require().NoError(err)
+		s.Nil(check)
+
+		events, err := s.BillEvent.SelectByBillID(s.ctx, bill.BillID)
+		s.Require().NoError(err)
+		s.Require().Len(events, 3)
+		s.Equal(model.BillEventStart, events[0].Event)
+		s.Equal("", events[0].FromState)
+		s.Equal(model.BillStateCreate, events[0].ToState)
+		s.Equal(userID, events[0].ActorID)
+		s.Equal(model.BillEventClose, events[1].Event)
+		s.Equal("0.00 EUR", events[1].PreviousAmount.String())
+		s.Equal("12.50 EUR", events[1].Amount.String())
+		s.Equal(model.BillEventReopen, events[2].Event)
+		s.Equal(model.BillStateCompleted, events[2].FromState)
+		s.Equal(model.BillStateCreate, events[2].ToState)
+
+		events, err = s.BillEvent.SelectByBillID(s.ctx, uuid.New())
+		s.Require().NoError(err)
+		s.Empty(events)
+	})
+
 	s.Run("context cancel error", func() {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 		s.EqualError(s.Bill.Insert(ctx, &model.Bill{}), `context canceled`)
 		s.EqualError(s.Bill.Update(ctx, &model.Bill{}), `context canceled`)
-		b, err := s.Bill.GetBillsByUserID(ctx, uuid.New())
+		b, err := s.Bill.GetBillsByUserID(ctx, uuid.New(), &model.BillFilter{})
+		s.Nil(b)
+		s.EqualError(err, `context canceled`)
+		b, err = s.Bill.SelectDiscrepanciesByUserID(ctx, uuid.New())
 		s.Nil(b)
 		s.EqualError(err, `context canceled`)
+		b, err = s.Bill.SelectOpenBillsByUserID(ctx, uuid.New())
+		s.Nil(b)
+		s.EqualError(err, `context canceled`)
+		bill, err := s.Bill.SelectBillByID(ctx, uuid.New(), uuid.New())
+		s.Nil(bill)
+		s.EqualError(err, `context canceled`)
+		s.EqualError(s.Bill.Transition(ctx, &model.Bill{}, model.BillStateCreate, model.BillEventClose, uuid.New()), `context canceled`)
+		events, err := s.BillEvent.SelectByBillID(ctx, uuid.New())
+		s.Nil(events)
+		s.EqualError(err, `context canceled`)
 	})
 }
 
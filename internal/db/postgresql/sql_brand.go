@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"shop-aggregator/internal/model"
 )
@@ -24,6 +25,19 @@ const (
 		RETURNING brand_id`
 	SelectBrandsQuery      = `SELECT brand_id, brand_name FROM brand WHERE brand_name LIKE CONCAT(CAST($1 AS text), '%')`
 	SelectBrandByNameQuery = `SELECT brand_id, brand_name FROM brand WHERE brand_name = $1`
+	UpdateBrandQuery       = `UPDATE brand SET brand_name = $2, updated_at = NOW() WHERE brand_id = $1`
+	// MergeBrandQuery moves the products of the brand $1 to the brand $2 then deletes the brand $1, nothing
+	// is changed when either brand is missing.
+	MergeBrandQuery = `
+		WITH target AS (
+		    SELECT brand_id FROM brand WHERE brand_id = $2 AND brand_id <> $1
+		), moved AS (
+		    UPDATE product p SET brand_id = t.brand_id, updated_at = NOW()
+		    FROM target t
+		    WHERE p.brand_id = $1
+		)
+		DELETE FROM brand b USING target t WHERE b.brand_id = $1
+	`
 )
 
 func (b *Brand) Insert(ctx context.Context, brand *model.Brand) error {
@@ -67,3 +81,28 @@ func (b *Brand) SelectBrandByName(ctx context.Context, name string) (*model.Bran
 	}
 	return &brand, nil
 }
+
+// Update renames the brand, it returns pgx.ErrNoRows when the brand does not exist.
+func (b *Brand) Update(ctx context.Context, brand *model.Brand) error {
+	tag, err := b.db.Exec(ctx, UpdateBrandQuery, brand.BrandID, brand.BrandName)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Merge moves the products of the source brand to the target brand and deletes the source brand, it returns
+// pgx.ErrNoRows when either brand does not exist.
+func (b *Brand) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tag, err := b.db.Exec(ctx, MergeBrandQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
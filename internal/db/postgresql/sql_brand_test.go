@@ -2,6 +2,8 @@ package postgresql
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
@@ -65,6 +67,47 @@ func (s *SqlBrandTestSuite) TestBrand() {
 	})
 }
 
+func (s *SqlBrandTestSuite) TestUpdateAndMerge() {
+	s.Run("no error", func() {
+		source := &model.Brand{BrandName: "nutela"}
+		target := &model.Brand{BrandName: "nutella"}
+		s.Require().NoError(s.Brand.Insert(s.ctx, source))
+		s.Require().NoError(s.Brand.Insert(s.ctx, target))
+		productID := uuid.New()
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO product (product_id, ean, product_name, brand_id) VALUES ($1, 'ean-brand-merge', 'pate', $2)", productID, source.BrandID)
+		s.Require().NoError(err)
+
+		source.BrandName = "nutella old"
+		s.Require().NoError(s.Brand.Update(s.ctx, source))
+		renamed, err := s.Brand.SelectBrandByName(s.ctx, "nutella old")
+		s.Require().NoError(err)
+		s.Equal(source.BrandID, renamed.BrandID)
+
+		// merging into itself or a missing brand changes nothing
+		s.ErrorIs(s.Brand.Merge(s.ctx, source.BrandID, source.BrandID), pgx.ErrNoRows)
+		s.ErrorIs(s.Brand.Merge(s.ctx, source.BrandID, uuid.New()), pgx.ErrNoRows)
+
+		s.Require().NoError(s.Brand.Merge(s.ctx, source.BrandID, target.BrandID))
+		var brandID uuid.UUID
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT brand_id FROM product WHERE product_id = $1", productID).Scan(&brandID))
+		s.Equal(target.BrandID, brandID)
+		merged, err := s.Brand.SelectBrandByName(s.ctx, "nutella old")
+		s.Require().NoError(err)
+		s.Nil(merged)
+
+		s.ErrorIs(s.Brand.Update(s.ctx, source), pgx.ErrNoRows)
+		_, err = s.DB.Exec(s.ctx, "DELETE FROM product WHERE product_id = $1", productID)
+		s.Require().NoError(err)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.Brand.Update(ctx, &model.Brand{}), `context canceled`)
+		s.EqualError(s.Brand.Merge(ctx, uuid.New(), uuid.New()), `context canceled`)
+	})
+}
+
 func TestBrandTestSuite(t *testing.T) {
 	suite.Run(t, new(SqlBrandTestSuite))
 }
@@ -26,6 +26,18 @@ const (
 	SelectCompaniesQuery     = `SELECT company_id, company_name FROM company WHERE company_name LIKE CONCAT(CAST($1 AS text), '%')`
 	SelectCompanyByNameQuery = `SELECT company_id, company_name FROM company WHERE company_name = $1`
 	SelectCompanyByIDQuery   = `SELECT company_id, company_name FROM company WHERE company_id = $1`
+	UpdateCompanyQuery       = `UPDATE company SET company_name = $2, updated_at = NOW() WHERE company_id = $1`
+	// MergeCompanyQuery moves the stores of the company $1 to the company $2 then deletes the company $1.
+	MergeCompanyQuery = `
+		WITH target AS (
+		    SELECT company_id FROM company WHERE company_id = $2 AND company_id <> $1
+		), moved AS (
+		    UPDATE store s SET company_id = t.company_id, updated_at = NOW()
+		    FROM target t
+		    WHERE s.company_id = $1
+		)
+		DELETE FROM company c USING target t WHERE c.company_id = $1
+	`
 )
 
 func (c *Company) Insert(ctx context.Context, company *model.Company) error {
@@ -83,3 +95,28 @@ func (c *Company) SelectCompanies(ctx context.Context, name string) ([]*model.Co
 
 	return companies, nil
 }
+
+// Update renames the company, it returns pgx.ErrNoRows when the company does not exist.
+func (c *Company) Update(ctx context.Context, company *model.Company) error {
+	tag, err := c.db.Exec(ctx, UpdateCompanyQuery, company.CompanyID, company.CompanyName)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Merge moves the stores of the source company to the target company and deletes the source company, it
+// returns pgx.ErrNoRows when either company does not exist.
+func (c *Company) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tag, err := c.db.Exec(ctx, MergeCompanyQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
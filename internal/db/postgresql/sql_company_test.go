@@ -2,6 +2,8 @@ package postgresql
 
 import (
 	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
@@ -65,6 +67,44 @@ func (s *SqlCompanyTestSuite) TestCompany() {
 	})
 }
 
+func (s *SqlCompanyTestSuite) TestUpdateAndMerge() {
+	s.Run("no error", func() {
+		source := &model.Company{CompanyName: "carefour"}
+		target := &model.Company{CompanyName: "carrefour"}
+		s.Require().NoError(s.Company.Insert(s.ctx, source))
+		s.Require().NoError(s.Company.Insert(s.ctx, target))
+		storeID := uuid.New()
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO store (store_id, address, zip_code, city, country, store_name, store_type, url, company_id) VALUES ($1, '', '75001', 'Paris', 'FR', 'carefour', 'shop', '', $2)", storeID, source.CompanyID)
+		s.Require().NoError(err)
+
+		source.CompanyName = "carrefour old"
+		s.Require().NoError(s.Company.Update(s.ctx, source))
+		renamed, err := s.Company.SelectCompanyByID(s.ctx, source.CompanyID)
+		s.Require().NoError(err)
+		s.Equal("carrefour old", renamed.CompanyName)
+
+		s.ErrorIs(s.Company.Merge(s.ctx, source.CompanyID, uuid.New()), pgx.ErrNoRows)
+		s.Require().NoError(s.Company.Merge(s.ctx, source.CompanyID, target.CompanyID))
+		var companyID uuid.UUID
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT company_id FROM store WHERE store_id = $1", storeID).Scan(&companyID))
+		s.Equal(target.CompanyID, companyID)
+		merged, err := s.Company.SelectCompanyByID(s.ctx, source.CompanyID)
+		s.Require().NoError(err)
+		s.Nil(merged)
+
+		s.ErrorIs(s.Company.Update(s.ctx, source), pgx.ErrNoRows)
+		_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE store")
+		s.Require().NoError(err)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.Company.Update(ctx, &model.Company{}), `context canceled`)
+		s.EqualError(s.Company.Merge(ctx, uuid.New(), uuid.New()), `context canceled`)
+	})
+}
+
 func TestCompanyTestSuite(t *testing.T) {
 	suite.Run(t, new(SqlCompanyTestSuite))
 }
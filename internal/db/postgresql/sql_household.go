@@ -0,0 +1,258 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type Household struct {
+	db *Client
+}
+
+func NewHousehold(db *Client) *Household {
+	return &Household{
+		db: db,
+	}
+}
+
+const (
+	// InsertHouseholdQuery makes its creator the first owner of the household.
+	InsertHouseholdQuery = `
+		WITH inserted AS (
+		    INSERT INTO household (name, created_by)
+		    VALUES ($1, $2)
+		    RETURNING household_id, created_by, created_at
+		), member AS (
+		    INSERT INTO household_member (household_id, user_id, role, joined_at)
+		    SELECT household_id, created_by, $3, created_at FROM inserted
+		)
+		SELECT household_id, created_at FROM inserted
+	`
+	SelectHouseholdsByUserIDQuery = `
+		SELECT h.household_id, h.name, h.created_by, m.role, h.created_at
+		FROM household h
+		INNER JOIN household_member m ON m.household_id = h.household_id
+		WHERE m.user_id = $1
+		ORDER BY h.created_at
+	`
+	SelectHouseholdMemberQuery = `
+		SELECT m.household_id, m.user_id, u.login, m.role, m.joined_at
+		FROM household_member m
+		INNER JOIN users u ON u.user_id = m.user_id
+		WHERE m.household_id = $1 AND m.user_id = $2
+	`
+	SelectHouseholdMembersQuery = `
+		SELECT m.household_id, m.user_id, u.login, m.role, m.joined_at
+		FROM household_member m
+		INNER JOIN users u ON u.user_id = m.user_id
+		WHERE m.household_id = $1
+		ORDER BY m.joined_at
+	`
+	UpdateHouseholdMemberRoleQuery = `UPDATE household_member SET role = $3 WHERE household_id = $1 AND user_id = $2`
+	DeleteHouseholdMemberQuery     = `DELETE FROM household_member WHERE household_id = $1 AND user_id = $2`
+	// InsertHouseholdInvitationQuery renews the pending invitation of the user to the household.
+	InsertHouseholdInvitationQuery = `
+		INSERT INTO household_invitation (household_id, user_id, invited_by, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET
+		    invited_by = EXCLUDED.invited_by,
+		    role = EXCLUDED.role,
+		    expires_at = EXCLUDED.expires_at,
+		    created_at = NOW()
+		RETURNING household_invitation_id, created_at
+	`
+	SelectHouseholdInvitationsByUserIDQuery = `
+		SELECT i.household_invitation_id, i.household_id, h.name, i.user_id, i.invited_by, i.role, i.expires_at, i.created_at
+		FROM household_invitation i
+		INNER JOIN household h ON h.household_id = i.household_id
+		WHERE i.user_id = $1 AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC
+	`
+	// AcceptHouseholdInvitationQuery deletes the invitation and adds the member, a user already member keeps
+	// its role.
+	AcceptHouseholdInvitationQuery = `
+		WITH accepted AS (
+		    DELETE FROM household_invitation
+		    WHERE household_invitation_id = $1 AND user_id = $2 AND expires_at > NOW()
+		    RETURNING household_id, user_id, role
+		)
+		INSERT INTO household_member (household_id, user_id, role)
+		SELECT household_id, user_id, role FROM accepted
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = household_member.role
+		RETURNING household_id, user_id, role, joined_at
+	`
+	DeleteHouseholdInvitationQuery = `DELETE FROM household_invitation WHERE household_invitation_id = $1 AND user_id = $2`
+	// SelectHouseholdSpendingQuery sums the closed bills of the household by member and currency.
+	SelectHouseholdSpendingQuery = `
+		SELECT b.user_id, COALESCE(u.login, ''), b.currency, SUM(b.amount), COUNT(*)
+		FROM bill b
+		LEFT JOIN users u ON u.user_id = b.user_id
+		WHERE b.household_id = $1
+		AND b.bill_state IN ($2, $3)
+		AND (CAST($4 AS date) IS NULL OR b.created_at >= $4)
+		AND (CAST($5 AS date) IS NULL OR b.created_at < CAST($5 AS date) + 1)
+		GROUP BY b.user_id, u.login, b.currency
+		ORDER BY b.currency, SUM(b.amount) DESC
+	`
+)
+
+func (h *Household) Insert(ctx context.Context, household *model.Household) error {
+	row := h.db.QueryRow(ctx, InsertHouseholdQuery, household.Name, household.CreatedBy, model.HouseholdRoleOwner)
+	return row.Scan(&household.HouseholdID, &household.CreatedAt)
+}
+
+// SelectHouseholdsByUserID returns the households of the user with its role, the oldest first.
+func (h *Household) SelectHouseholdsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Household, error) {
+	rows, err := h.db.Query(ctx, SelectHouseholdsByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	households := make([]*model.Household, 0)
+	for rows.Next() {
+		household := &model.Household{}
+		err = rows.Scan(&household.HouseholdID, &household.Name, &household.CreatedBy, &household.Role, &household.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		households = append(households, household)
+	}
+
+	return households, rows.Err()
+}
+
+// SelectMember returns the member of the household, nil when the user is not a member.
+func (h *Household) SelectMember(ctx context.Context, householdID, userID uuid.UUID) (*model.HouseholdMember, error) {
+	row := h.db.QueryRow(ctx, SelectHouseholdMemberQuery, householdID, userID)
+
+	member := &model.HouseholdMember{}
+	err := row.Scan(&member.HouseholdID, &member.UserID, &member.Login, &member.Role, &member.JoinedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// SelectMembers returns the members of the household, the first to join first.
+func (h *Household) SelectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error) {
+	rows, err := h.db.Query(ctx, SelectHouseholdMembersQuery, householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]*model.HouseholdMember, 0)
+	for rows.Next() {
+		member := &model.HouseholdMember{}
+		err = rows.Scan(&member.HouseholdID, &member.UserID, &member.Login, &member.Role, &member.JoinedAt)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// UpdateMemberRole returns pgx.ErrNoRows when the user is not a member of the household.
+func (h *Household) UpdateMemberRole(ctx context.Context, householdID, userID uuid.UUID, role string) error {
+	tag, err := h.db.Exec(ctx, UpdateHouseholdMemberRoleQuery, householdID, userID, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteMember returns pgx.ErrNoRows when the user is not a member of the household.
+func (h *Household) DeleteMember(ctx context.Context, householdID, userID uuid.UUID) error {
+	tag, err := h.db.Exec(ctx, DeleteHouseholdMemberQuery, householdID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (h *Household) InsertInvitation(ctx context.Context, invitation *model.HouseholdInvitation) error {
+	row := h.db.QueryRow(ctx, InsertHouseholdInvitationQuery, invitation.HouseholdID, invitation.UserID, invitation.InvitedBy, invitation.Role, invitation.ExpiresAt)
+	return row.Scan(&invitation.HouseholdInvitationID, &invitation.CreatedAt)
+}
+
+// SelectInvitationsByUserID returns the pending invitations of the user, the most recent first.
+func (h *Household) SelectInvitationsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.HouseholdInvitation, error) {
+	rows, err := h.db.Query(ctx, SelectHouseholdInvitationsByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := make([]*model.HouseholdInvitation, 0)
+	for rows.Next() {
+		invitation := &model.HouseholdInvitation{}
+		err = rows.Scan(&invitation.HouseholdInvitationID, &invitation.HouseholdID, &invitation.HouseholdName, &invitation.UserID, &invitation.InvitedBy, &invitation.Role, &invitation.ExpiresAt, &invitation.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// AcceptInvitation returns the new member, pgx.ErrNoRows when the user has no such pending invitation.
+func (h *Household) AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*model.HouseholdMember, error) {
+	row := h.db.QueryRow(ctx, AcceptHouseholdInvitationQuery, invitationID, userID)
+
+	member := &model.HouseholdMember{}
+	if err := row.Scan(&member.HouseholdID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// DeleteInvitation returns pgx.ErrNoRows when the user has no such invitation.
+func (h *Household) DeleteInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	tag, err := h.db.Exec(ctx, DeleteHouseholdInvitationQuery, invitationID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SelectSpending returns what each member spent on the closed bills of the household between the days from
+// and to, both included and optional.
+func (h *Household) SelectSpending(ctx context.Context, householdID uuid.UUID, from, to time.Time) ([]*model.HouseholdSpending, error) {
+	rows, err := h.db.Query(ctx, SelectHouseholdSpendingQuery, householdID, model.BillStateCompleted, model.BillStateArchived, nullTime(from), nullTime(to))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spending := make([]*model.HouseholdSpending, 0)
+	for rows.Next() {
+		s := &model.HouseholdSpending{}
+		err = rows.Scan(&s.UserID, &s.Login, &s.Amount.Currency, &s.Amount.Amount, &s.Bills)
+		if err != nil {
+			return nil, err
+		}
+		spending = append(spending, s)
+	}
+
+	return spending, rows.Err()
+}
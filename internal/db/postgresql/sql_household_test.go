@@ -0,0 +1,206 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+type SqlHouseholdTestSuite struct {
+	DBTestSuite
+	Household *Household
+	Bill      *Bill
+	User      *User
+	owner     model.User
+	member    model.User
+}
+
+func (s *SqlHouseholdTestSuite) SetupTest() {
+	s.Household = NewHousehold(s.DB)
+	s.Bill = NewBill(s.DB)
+	s.User = NewUsers(s.DB)
+	s.owner = model.User{Login: "alice", Email: "alice@test.com", HashPassword: "hash"}
+	s.member = model.User{Login: "bob", Email: "bob@test.com", HashPassword: "hash"}
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.owner))
+	s.Require().NoError(s.User.Upsert(s.ctx, &s.member))
+}
+
+func (s *SqlHouseholdTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, household, household_member, household_invitation, bill, bill_event")
+	s.Require().NoError(err)
+}
+
+func (s *SqlHouseholdTestSuite) newHousehold() *model.Household {
+	household := &model.Household{Name: "home", CreatedBy: s.owner.ID}
+	s.Require().NoError(s.Household.Insert(s.ctx, household))
+	s.NotEqual(uuid.Nil, household.HouseholdID)
+	return household
+}
+
+func (s *SqlHouseholdTestSuite) TestHousehold() {
+	s.Run("create, invite and accept", func() {
+		household := s.newHousehold()
+
+		households, err := s.Household.SelectHouseholdsByUserID(s.ctx, s.owner.ID)
+		s.Require().NoError(err)
+		s.Require().Len(households, 1)
+		s.Equal(model.HouseholdRoleOwner, households[0].Role)
+
+		invitation := &model.HouseholdInvitation{
+			HouseholdID: household.HouseholdID,
+			UserID:      s.member.ID,
+			InvitedBy:   s.owner.ID,
+			Role:        model.HouseholdRoleMember,
+			ExpiresAt:   time.Now().UTC().Add(time.Hour),
+		}
+		s.Require().NoError(s.Household.InsertInvitation(s.ctx, invitation))
+
+		invitations, err := s.Household.SelectInvitationsByUserID(s.ctx, s.member.ID)
+		s.Require().NoError(err)
+		s.Require().Len(invitations, 1)
+		s.Equal("home", invitations[0].HouseholdName)
+
+		// only the invited user accepts
+		_, err = s.Household.AcceptInvitation(s.ctx, s.owner.ID, invitation.HouseholdInvitationID)
+		s.ErrorIs(err, pgx.ErrNoRows)
+
+		member, err := s.Household.AcceptInvitation(s.ctx, s.member.ID, invitation.HouseholdInvitationID)
+		s.Require().NoError(err)
+		s.Equal(model.HouseholdRoleMember, member.Role)
+		_, err = s.Household.AcceptInvitation(s.ctx, s.member.ID, invitation.HouseholdInvitationID)
+		s.ErrorIs(err, pgx.ErrNoRows)
+
+		members, err := s.Household.SelectMembers(s.ctx, household.HouseholdID)
+		s.Require().NoError(err)
+		s.Require().Len(members, 2)
+		s.Equal("alice", members[0].Login)
+		s.Equal("bob", members[1].Login)
+
+		s.Require().NoError(s.Household.UpdateMemberRole(s.ctx, household.HouseholdID, s.member.ID, model.HouseholdRoleOwner))
+		got, err := s.Household.SelectMember(s.ctx, household.HouseholdID, s.member.ID)
+		s.Require().NoError(err)
+		s.True(got.IsOwner())
+
+		s.Require().NoError(s.Household.DeleteMember(s.ctx, household.HouseholdID, s.member.ID))
+		got, err = s.Household.SelectMember(s.ctx, household.HouseholdID, s.member.ID)
+		s.Require().NoError(err)
+		s.Nil(got)
+		s.ErrorIs(s.Household.DeleteMember(s.ctx, household.HouseholdID, s.member.ID), pgx.ErrNoRows)
+	})
+
+	s.Run("expired and declined invitations", func() {
+		household := s.newHousehold()
+		invitation := &model.HouseholdInvitation{
+			HouseholdID: household.HouseholdID,
+			UserID:      s.member.ID,
+			InvitedBy:   s.owner.ID,
+			Role:        model.HouseholdRoleMember,
+			ExpiresAt:   time.Now().UTC().Add(-time.Minute),
+		}
+		s.Require().NoError(s.Household.InsertInvitation(s.ctx, invitation))
+
+		invitations, err := s.Household.SelectInvitationsByUserID(s.ctx, s.member.ID)
+		s.Require().NoError(err)
+		s.Empty(invitations)
+		_, err = s.Household.AcceptInvitation(s.ctx, s.member.ID, invitation.HouseholdInvitationID)
+		s.ErrorIs(err, pgx.ErrNoRows)
+
+		s.Require().NoError(s.Household.DeleteInvitation(s.ctx, s.member.ID, invitation.HouseholdInvitationID))
+		s.ErrorIs(s.Household.DeleteInvitation(s.ctx, s.member.ID, invitation.HouseholdInvitationID), pgx.ErrNoRows)
+	})
+
+	s.Run("bills and spending of the household", func() {
+		household := s.newHousehold()
+		store := uuid.New()
+		shared := &model.Bill{UserID: s.owner.ID, HouseholdID: &household.HouseholdID, StoreID: store, Amount: money("12.50"), ComputedAmount: money("0"), State: model.BillStateCompleted}
+		personal := &model.Bill{UserID: s.owner.ID, StoreID: store, Amount: money("7"), ComputedAmount: money("0"), State: model.BillStateCompleted}
+		for _, bill := range []*model.Bill{shared, personal} {
+			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+			s.Require().NoError(s.Bill.Update(s.ctx, bill))
+		}
+
+		// the bills of a household are not seen before joining it
+		bill, err := s.Bill.SelectBillByID(s.ctx, s.member.ID, shared.BillID)
+		s.Require().NoError(err)
+		s.Nil(bill)
+
+		_, err = s.DB.Exec(s.ctx, "INSERT INTO household_member (household_id, user_id, role) VALUES ($1, $2, $3)", household.HouseholdID, s.member.ID, model.HouseholdRoleMember)
+		s.Require().NoError(err)
+
+		bill, err = s.Bill.SelectBillByID(s.ctx, s.member.ID, shared.BillID)
+		s.Require().NoError(err)
+		s.Equal(shared, bill)
+		bill, err = s.Bill.SelectBillByID(s.ctx, s.member.ID, personal.BillID)
+		s.Require().NoError(err)
+		s.Nil(bill)
+
+		bills, err := s.Bill.GetBillsByUserID(s.ctx, s.owner.ID, &model.BillFilter{HouseholdID: household.HouseholdID})
+		s.Require().NoError(err)
+		s.Equal([]*model.Bill{shared}, bills)
+
+		spending, err := s.Household.SelectSpending(s.ctx, household.HouseholdID, time.Time{}, time.Time{})
+		s.Require().NoError(err)
+		s.Require().Len(spending, 1)
+		s.Equal(s.owner.ID, spending[0].UserID)
+		s.Equal("alice", spending[0].Login)
+		s.Equal("12.5", spending[0].Amount.Amount.String())
+		s.Equal(int64(1), spending[0].Bills)
+	})
+
+	s.Run("bills of a removed member", func() {
+		household := s.newHousehold()
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO household_member (household_id, user_id, role) VALUES ($1, $2, $3)", household.HouseholdID, s.member.ID, model.HouseholdRoleMember)
+		s.Require().NoError(err)
+		store := uuid.New()
+		shared := &model.Bill{UserID: s.member.ID, HouseholdID: &household.HouseholdID, StoreID: store, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		personal := &model.Bill{UserID: s.member.ID, StoreID: store, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		for _, bill := range []*model.Bill{shared, personal} {
+			s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+		}
+
+		s.Require().NoError(s.Household.DeleteMember(s.ctx, household.HouseholdID, s.member.ID))
+
+		// the household bill the member started stays with the household
+		bill, err := s.Bill.SelectBillByID(s.ctx, s.member.ID, shared.BillID)
+		s.Require().NoError(err)
+		s.Nil(bill)
+		bill, err = s.Bill.SelectBillByID(s.ctx, s.owner.ID, shared.BillID)
+		s.Require().NoError(err)
+		s.Require().NotNil(bill)
+		s.Equal(shared.BillID, bill.BillID)
+
+		bill, err = s.Bill.SelectBillByID(s.ctx, s.member.ID, personal.BillID)
+		s.Require().NoError(err)
+		s.Require().NotNil(bill)
+		s.Equal(personal.BillID, bill.BillID)
+
+		bills, err := s.Bill.SelectOpenBillsByUserID(s.ctx, s.member.ID)
+		s.Require().NoError(err)
+		s.Require().Len(bills, 1)
+		s.Equal(personal.BillID, bills[0].BillID)
+	})
+
+	s.Run("open bill of the household in a store", func() {
+		household := s.newHousehold()
+		store := uuid.New()
+		shared := &model.Bill{UserID: s.member.ID, HouseholdID: &household.HouseholdID, StoreID: store, Amount: money("0"), ComputedAmount: money("0"), State: model.BillStateCreate}
+		s.Require().NoError(s.Bill.Insert(s.ctx, shared))
+
+		bill, err := s.Bill.SelectOpenBillByStoreID(s.ctx, s.owner.ID, household.HouseholdID, store)
+		s.Require().NoError(err)
+		s.Require().NotNil(bill)
+		s.Equal(shared.BillID, bill.BillID)
+
+		// a household bill is not the personal bill of the member who started it
+		bill, err = s.Bill.SelectOpenBillByStoreID(s.ctx, s.member.ID, uuid.Nil, store)
+		s.Require().NoError(err)
+		s.Nil(bill)
+	})
+}
+
+func TestHouseholdTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlHouseholdTestSuite))
+}
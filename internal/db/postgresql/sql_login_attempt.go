@@ -0,0 +1,111 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type LoginAttempt struct {
+	db *Client
+}
+
+func NewLoginAttempt(db *Client) *LoginAttempt {
+	return &LoginAttempt{
+		db: db,
+	}
+}
+
+const (
+	InsertLoginAttemptQuery = `
+		INSERT INTO login_attempt (login, ip, user_id, success, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING login_attempt_id, created_at
+	`
+	// SelectLoginAttemptsQuery filters on the login and the ip when they are not empty.
+	SelectLoginAttemptsQuery = `
+		SELECT login_attempt_id, login, ip, user_id, success, reason, created_at
+		FROM login_attempt
+		WHERE ($1 = '' OR login = $1) AND ($2 = '' OR ip = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+	SelectLockedUntilQuery = `
+		SELECT MAX(locked_until) FROM login_throttle
+		WHERE ((scope = 'login' AND key = $1) OR (scope = 'ip' AND key = $2)) AND locked_until > NOW()
+	`
+	// RecordLoginFailureQuery starts counting again when the last failure is older than $3 seconds.
+	RecordLoginFailureQuery = `
+		INSERT INTO login_throttle (scope, key, failures)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (scope, key) DO UPDATE SET
+		    failures = CASE
+		        WHEN login_throttle.updated_at < NOW() - $3 * INTERVAL '1 second' THEN 1
+		        ELSE login_throttle.failures + 1
+		    END,
+		    updated_at = NOW()
+		RETURNING failures
+	`
+	LockLoginQuery  = `UPDATE login_throttle SET locked_until = $3 WHERE scope = $1 AND key = $2`
+	ResetLoginQuery = `DELETE FROM login_throttle WHERE scope = $1 AND key = $2`
+)
+
+func (la *LoginAttempt) Insert(ctx context.Context, attempt *model.LoginAttempt) error {
+	row := la.db.QueryRow(ctx, InsertLoginAttemptQuery, attempt.Login, attempt.IP, attempt.UserID, attempt.Success, attempt.Reason)
+	return row.Scan(&attempt.LoginAttemptID, &attempt.CreatedAt)
+}
+
+// SelectLoginAttempts returns the last attempts, the most recent first.
+func (la *LoginAttempt) SelectLoginAttempts(ctx context.Context, login, ip string, limit int) ([]*model.LoginAttempt, error) {
+	rows, err := la.db.Query(ctx, SelectLoginAttemptsQuery, login, ip, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := make([]*model.LoginAttempt, 0)
+	for rows.Next() {
+		attempt := &model.LoginAttempt{}
+		err = rows.Scan(&attempt.LoginAttemptID, &attempt.Login, &attempt.IP, &attempt.UserID, &attempt.Success, &attempt.Reason, &attempt.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+// SelectLockedUntil returns the end of the lockout of the login or of the ip, nil when neither is locked.
+func (la *LoginAttempt) SelectLockedUntil(ctx context.Context, login, ip string) (*time.Time, error) {
+	var lockedUntil *time.Time
+	err := la.db.QueryRow(ctx, SelectLockedUntilQuery, login, ip).Scan(&lockedUntil)
+	return lockedUntil, err
+}
+
+// RecordFailure counts a failed attempt of the key and returns its failures, the failures older than window
+// are forgotten.
+func (la *LoginAttempt) RecordFailure(ctx context.Context, scope, key string, window time.Duration) (int, error) {
+	var failures int
+	err := la.db.QueryRow(ctx, RecordLoginFailureQuery, scope, key, int64(window/time.Second)).Scan(&failures)
+	return failures, err
+}
+
+// Lock refuses the attempts of the key until the given time.
+func (la *LoginAttempt) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	tag, err := la.db.Exec(ctx, LockLoginQuery, scope, key, until)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Reset forgets the failures of the key.
+func (la *LoginAttempt) Reset(ctx context.Context, scope, key string) error {
+	_, err := la.db.Exec(ctx, ResetLoginQuery, scope, key)
+	return err
+}
@@ -0,0 +1,115 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+type SqlLoginAttemptTestSuite struct {
+	DBTestSuite
+	LoginAttempt *LoginAttempt
+}
+
+func (s *SqlLoginAttemptTestSuite) SetupTest() {
+	s.LoginAttempt = NewLoginAttempt(s.DB)
+}
+
+func (s *SqlLoginAttemptTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE login_attempt, login_throttle")
+	s.Require().NoError(err)
+}
+
+func (s *SqlLoginAttemptTestSuite) TestLoginAttempts() {
+	s.Run("insert and select", func() {
+		userID := uuid.New()
+		s.Require().NoError(s.LoginAttempt.Insert(s.ctx, &model.LoginAttempt{Login: "alice", IP: "10.0.0.1", Reason: model.LoginAttemptInvalidCredentials}))
+		s.Require().NoError(s.LoginAttempt.Insert(s.ctx, &model.LoginAttempt{Login: "alice", IP: "10.0.0.2", UserID: &userID, Success: true}))
+		attempt := &model.LoginAttempt{Login: "bob", IP: "10.0.0.1", Reason: model.LoginAttemptInvalidCredentials}
+		s.Require().NoError(s.LoginAttempt.Insert(s.ctx, attempt))
+		s.NotEqual(uuid.Nil, attempt.LoginAttemptID)
+
+		attempts, err := s.LoginAttempt.SelectLoginAttempts(s.ctx, "alice", "", 10)
+		s.Require().NoError(err)
+		s.Require().Len(attempts, 2)
+		s.True(attempts[0].Success)
+		s.Equal(&userID, attempts[0].UserID)
+		s.Nil(attempts[1].UserID)
+		s.Equal(model.LoginAttemptInvalidCredentials, attempts[1].Reason)
+
+		attempts, err = s.LoginAttempt.SelectLoginAttempts(s.ctx, "", "10.0.0.1", 10)
+		s.Require().NoError(err)
+		s.Len(attempts, 2)
+
+		attempts, err = s.LoginAttempt.SelectLoginAttempts(s.ctx, "", "", 1)
+		s.Require().NoError(err)
+		s.Len(attempts, 1)
+	})
+}
+
+func (s *SqlLoginAttemptTestSuite) TestThrottle() {
+	s.Run("count, lock and reset", func() {
+		for i := 1; i <= 3; i++ {
+			failures, err := s.LoginAttempt.RecordFailure(s.ctx, model.LoginThrottleLogin, "alice", time.Hour)
+			s.Require().NoError(err)
+			s.Equal(i, failures)
+		}
+
+		lockedUntil, err := s.LoginAttempt.SelectLockedUntil(s.ctx, "alice", "10.0.0.1")
+		s.Require().NoError(err)
+		s.Nil(lockedUntil)
+
+		until := time.Now().UTC().Add(time.Minute).Truncate(time.Microsecond)
+		s.Require().NoError(s.LoginAttempt.Lock(s.ctx, model.LoginThrottleLogin, "alice", until))
+		lockedUntil, err = s.LoginAttempt.SelectLockedUntil(s.ctx, "alice", "10.0.0.1")
+		s.Require().NoError(err)
+		s.Require().NotNil(lockedUntil)
+		s.True(until.Equal(*lockedUntil))
+
+		// the lockout of an other login
+		lockedUntil, err = s.LoginAttempt.SelectLockedUntil(s.ctx, "bob", "10.0.0.1")
+		s.Require().NoError(err)
+		s.Nil(lockedUntil)
+
+		s.Require().NoError(s.LoginAttempt.Reset(s.ctx, model.LoginThrottleLogin, "alice"))
+		lockedUntil, err = s.LoginAttempt.SelectLockedUntil(s.ctx, "alice", "10.0.0.1")
+		s.Require().NoError(err)
+		s.Nil(lockedUntil)
+		failures, err := s.LoginAttempt.RecordFailure(s.ctx, model.LoginThrottleLogin, "alice", time.Hour)
+		s.Require().NoError(err)
+		s.Equal(1, failures)
+	})
+
+	s.Run("locked ip", func() {
+		_, err := s.LoginAttempt.RecordFailure(s.ctx, model.LoginThrottleIP, "10.0.0.9", time.Hour)
+		s.Require().NoError(err)
+		s.Require().NoError(s.LoginAttempt.Lock(s.ctx, model.LoginThrottleIP, "10.0.0.9", time.Now().UTC().Add(time.Minute)))
+
+		lockedUntil, err := s.LoginAttempt.SelectLockedUntil(s.ctx, "carol", "10.0.0.9")
+		s.Require().NoError(err)
+		s.NotNil(lockedUntil)
+	})
+
+	s.Run("old failures are forgotten", func() {
+		_, err := s.LoginAttempt.RecordFailure(s.ctx, model.LoginThrottleLogin, "dave", time.Hour)
+		s.Require().NoError(err)
+		_, err = s.DB.Exec(s.ctx, "UPDATE login_throttle SET updated_at = NOW() - INTERVAL '2 hours' WHERE key = 'dave'")
+		s.Require().NoError(err)
+
+		failures, err := s.LoginAttempt.RecordFailure(s.ctx, model.LoginThrottleLogin, "dave", time.Hour)
+		s.Require().NoError(err)
+		s.Equal(1, failures)
+	})
+
+	s.Run("lock an unknown key", func() {
+		err := s.LoginAttempt.Lock(s.ctx, model.LoginThrottleLogin, "unknown", time.Now().UTC())
+		s.ErrorIs(err, pgx.ErrNoRows)
+	})
+}
+
+func TestLoginAttemptTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlLoginAttemptTestSuite))
+}
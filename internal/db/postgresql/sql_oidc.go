@@ -0,0 +1,120 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+)
+
+// OIDC stores the pending authorizations and the provider identities of the users.
+type OIDC struct {
+	db     *Client
+	hasher *utils.TokenHasher
+}
+
+func NewOIDC(db *Client, hasher *utils.TokenHasher) *OIDC {
+	return &OIDC{
+		db:     db,
+		hasher: hasher,
+	}
+}
+
+const (
+	InsertOIDCStateQuery = `
+		INSERT INTO oidc_state (state, nonce, code_verifier, device, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING oidc_state_id
+	`
+	UseOIDCStateQuery = `
+		UPDATE oidc_state SET used_at = NOW()
+		WHERE state = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING oidc_state_id, nonce, code_verifier, device, expires_at
+	`
+	// LoginIdentityQuery records the login of the identity and returns its user.
+	LoginIdentityQuery = `
+		WITH identity AS (
+		    UPDATE user_identity SET last_login_at = NOW()
+		    WHERE issuer = $1 AND subject = $2
+		    RETURNING user_id
+		)
+		SELECT u.user_id, u.login, u.email, u.email_verified, u.role, u.disabled_at IS NOT NULL, u.password
+		FROM users u
+		JOIN identity i ON i.user_id = u.user_id
+	`
+	InsertIdentityQuery = `
+		INSERT INTO user_identity (user_id, issuer, subject, email)
+		VALUES ($1, $2, $3, $4)
+		RETURNING user_identity_id, created_at, last_login_at
+	`
+	// InsertUserWithIdentityQuery creates a user without password and its identity.
+	InsertUserWithIdentityQuery = `
+		WITH created AS (
+		    INSERT INTO users (login, email, email_verified, password)
+		    VALUES ($1, $2, $3, '')
+		    RETURNING user_id, role
+		), identity AS (
+		    INSERT INTO user_identity (user_id, issuer, subject, email)
+		    SELECT user_id, $4, $5, $2 FROM created
+		    RETURNING user_identity_id, user_id, created_at, last_login_at
+		)
+		SELECT c.user_id, c.role, i.user_identity_id, i.created_at, i.last_login_at
+		FROM created c
+		JOIN identity i ON i.user_id = c.user_id
+	`
+)
+
+func (o *OIDC) InsertState(ctx context.Context, state *model.OIDCState) error {
+	row := o.db.QueryRow(ctx, InsertOIDCStateQuery, o.hasher.Hash(state.State), state.Nonce, state.CodeVerifier, state.Device, state.ExpiresAt)
+	return row.Scan(&state.OIDCStateID)
+}
+
+// UseState marks the state as used and returns it, it returns nil when the state is unknown, used or expired.
+func (o *OIDC) UseState(ctx context.Context, state string) (*model.OIDCState, error) {
+	row := o.db.QueryRow(ctx, UseOIDCStateQuery, o.hasher.Hash(state))
+
+	s := &model.OIDCState{State: state}
+	err := row.Scan(&s.OIDCStateID, &s.Nonce, &s.CodeVerifier, &s.Device, &s.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// LoginIdentity returns the user linked to the identity, nil when the identity is not linked yet.
+func (o *OIDC) LoginIdentity(ctx context.Context, issuer, subject string) (*model.User, error) {
+	row := o.db.QueryRow(ctx, LoginIdentityQuery, issuer, subject)
+
+	var m model.User
+	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.EmailVerified, &m.Role, &m.Disabled, &m.HashPassword)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// InsertIdentity links the identity to an existing user.
+func (o *OIDC) InsertIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	row := o.db.QueryRow(ctx, InsertIdentityQuery, identity.UserID, identity.Issuer, identity.Subject, identity.Email)
+	return row.Scan(&identity.UserIdentityID, &identity.CreatedAt, &identity.LastLoginAt)
+}
+
+// InsertUserWithIdentity creates the user and its identity at once, the identity takes the email of the user.
+func (o *OIDC) InsertUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) error {
+	row := o.db.QueryRow(ctx, InsertUserWithIdentityQuery, user.Login, user.Email, user.EmailVerified, identity.Issuer, identity.Subject)
+	err := row.Scan(&user.ID, &user.Role, &identity.UserIdentityID, &identity.CreatedAt, &identity.LastLoginAt)
+	if err != nil {
+		return err
+	}
+	identity.UserID = user.ID
+	identity.Email = user.Email
+	return nil
+}
@@ -0,0 +1,114 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+	"testing"
+	"time"
+)
+
+type SqlOIDCTestSuite struct {
+	DBTestSuite
+	OIDC *OIDC
+	User *User
+}
+
+func (s *SqlOIDCTestSuite) SetupTest() {
+	s.OIDC = NewOIDC(s.DB, utils.NewTokenHasher("secret"))
+	s.User = NewUsers(s.DB)
+}
+
+func (s *SqlOIDCTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, user_identity, oidc_state")
+	s.Require().NoError(err)
+}
+
+func (s *SqlOIDCTestSuite) TestState() {
+	s.Run("no error", func() {
+		state := &model.OIDCState{State: "state", Nonce: "nonce", CodeVerifier: "verifier", Device: "phone", ExpiresAt: time.Now().UTC().Add(time.Minute)}
+		s.Require().NoError(s.OIDC.InsertState(s.ctx, state))
+		s.NotEqual(uuid.Nil, state.OIDCStateID)
+
+		// only the hash of the state is stored
+		var stored string
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT state FROM oidc_state WHERE oidc_state_id = $1", state.OIDCStateID).Scan(&stored))
+		s.Equal(utils.NewTokenHasher("secret").Hash("state"), stored)
+
+		used, err := s.OIDC.UseState(s.ctx, "state")
+		s.Require().NoError(err)
+		s.Require().NotNil(used)
+		s.Equal("nonce", used.Nonce)
+		s.Equal("verifier", used.CodeVerifier)
+		s.Equal("phone", used.Device)
+
+		// a state is used once
+		used, err = s.OIDC.UseState(s.ctx, "state")
+		s.Require().NoError(err)
+		s.Nil(used)
+	})
+
+	s.Run("expired state", func() {
+		s.Require().NoError(s.OIDC.InsertState(s.ctx, &model.OIDCState{State: "expired", Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: time.Now().UTC().Add(-time.Minute)}))
+
+		used, err := s.OIDC.UseState(s.ctx, "expired")
+		s.Require().NoError(err)
+		s.Nil(used)
+	})
+}
+
+func (s *SqlOIDCTestSuite) TestIdentity() {
+	s.Run("link an existing user", func() {
+		user := &model.User{Login: "alice", Email: "alice@test.com", HashPassword: "hash"}
+		s.Require().NoError(s.User.Upsert(s.ctx, user))
+
+		found, err := s.OIDC.LoginIdentity(s.ctx, "http://issuer", "alice-sub")
+		s.Require().NoError(err)
+		s.Nil(found)
+
+		identity := &model.UserIdentity{UserID: user.ID, Issuer: "http://issuer", Subject: "alice-sub", Email: "alice@test.com"}
+		s.Require().NoError(s.OIDC.InsertIdentity(s.ctx, identity))
+		s.NotEqual(uuid.Nil, identity.UserIdentityID)
+
+		found, err = s.OIDC.LoginIdentity(s.ctx, "http://issuer", "alice-sub")
+		s.Require().NoError(err)
+		s.Require().NotNil(found)
+		s.Equal(user.ID, found.ID)
+		s.Equal("alice", found.Login)
+
+		// the subject of an other issuer
+		found, err = s.OIDC.LoginIdentity(s.ctx, "http://other", "alice-sub")
+		s.Require().NoError(err)
+		s.Nil(found)
+
+		s.Error(s.OIDC.InsertIdentity(s.ctx, &model.UserIdentity{UserID: uuid.New(), Issuer: "http://issuer", Subject: "alice-sub"}))
+	})
+
+	s.Run("create a user", func() {
+		user := &model.User{Login: "bob", Email: "bob@test.com", EmailVerified: true}
+		identity := &model.UserIdentity{Issuer: "http://issuer", Subject: "bob-sub"}
+		s.Require().NoError(s.OIDC.InsertUserWithIdentity(s.ctx, user, identity))
+		s.NotEqual(uuid.Nil, user.ID)
+		s.Equal(model.RoleUser, user.Role)
+		s.Equal(user.ID, identity.UserID)
+
+		found, err := s.OIDC.LoginIdentity(s.ctx, "http://issuer", "bob-sub")
+		s.Require().NoError(err)
+		s.Require().NotNil(found)
+		s.Equal(user.ID, found.ID)
+		s.True(found.EmailVerified)
+		s.Empty(found.HashPassword)
+
+		// the login is taken, nothing is created
+		err = s.OIDC.InsertUserWithIdentity(s.ctx, &model.User{Login: "bob", Email: "bob2@test.com"}, &model.UserIdentity{Issuer: "http://issuer", Subject: "bob2-sub"})
+		s.Error(err)
+		found, err = s.OIDC.LoginIdentity(s.ctx, "http://issuer", "bob2-sub")
+		s.Require().NoError(err)
+		s.Nil(found)
+	})
+}
+
+func TestOIDCTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlOIDCTestSuite))
+}
@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+)
+
+type Price struct {
+	db *Client
+}
+
+func NewPrice(db *Client) *Price {
+	return &Price{
+		db: db,
+	}
+}
+
+const (
+	// SelectMostRecentPricesByProductIDsQuery only reads the prices of the closed bills, $4 and $5, the zip
+	// code $2 is an escaped prefix.
+	SelectMostRecentPricesByProductIDsQuery = `
+		WITH RecentProducts AS (
+			SELECT
+				up.user_product_id,
+				ROW_NUMBER() OVER (PARTITION BY b.store_id, up.product_id ORDER BY up.created_at DESC) as rn
+			FROM
+				user_product up
+			JOIN
+				bill b ON up.bill_id = b.bill_id
+			WHERE
+				up.product_id = ANY($1)
+			AND b.bill_state IN ($4, $5)
+		)
+		SELECT
+		    up.user_product_id,
+		    up.product_id,
+		    p.product_name,
+		    p.ean,
+		    s.store_id,
+		    s.store_name,
+		    s.zip_code,
+		    s.city,
+		    c.company_id,
+		    c.company_name,
+		    up.price,
+		    b.currency,
+		    up.product_size,
+		    up.size_format,
+		    up.created_at
+		FROM
+			RecentProducts rp
+		JOIN
+			user_product up ON rp.user_product_id = up.user_product_id
+		JOIN
+			bill b ON up.bill_id = b.bill_id
+		JOIN
+			store s ON b.store_id = s.store_id
+		JOIN
+			company c ON s.company_id = c.company_id
+		JOIN
+			product p ON up.product_id = p.product_id
+		WHERE
+			rp.rn = 1
+		AND s.zip_code LIKE CONCAT(CAST($2 AS text), '%') ESCAPE '\'
+		AND (CAST($3 AS uuid) IS NULL OR s.company_id = $3)
+		ORDER BY up.created_at DESC`
+)
+
+func (p *Price) SelectMostRecentPricesByProductID(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	return p.SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{productID}, filter)
+}
+
+func (p *Price) SelectMostRecentPricesByProductIDs(ctx context.Context, productIDs []uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	rows, err := p.db.Query(ctx, SelectMostRecentPricesByProductIDsQuery, productIDs, escapeLike(filter.ZipCode), nullUUID(filter.CompanyID), model.BillStateCompleted, model.BillStateArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []*model.StorePrice{}
+	for rows.Next() {
+		price := &model.StorePrice{}
+		err := rows.Scan(
+			&price.UserProductID,
+			&price.ProductID,
+			&price.ProductName,
+			&price.Ean,
+			&price.StoreID,
+			&price.StoreName,
+			&price.ZipCode,
+			&price.City,
+			&price.CompanyID,
+			&price.CompanyName,
+			&price.Price.Amount,
+			&price.Price.Currency,
+			&price.ProductSize,
+			&price.SizeFormat,
+			&price.PricedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
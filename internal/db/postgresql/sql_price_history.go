@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+)
+
+type PriceHistory struct {
+	db *Client
+}
+
+func NewPriceHistory(db *Client) *PriceHistory {
+	return &PriceHistory{
+		db: db,
+	}
+}
+
+const (
+	SelectPriceHistoryQuery = `
+		SELECT
+		    CAST(date_trunc(CAST($2 AS text), ph.price_day) AS DATE) AS period,
+		    ph.currency,
+		    MIN(p),
+		    MAX(p),
+		    AVG(p),
+		    percentile_cont(0.5) WITHIN GROUP (ORDER BY p),
+		    COUNT(p)
+		FROM price_history_daily ph, unnest(ph.prices) AS p
+		WHERE ph.product_id = $1
+		AND (CAST($3 AS uuid) IS NULL OR ph.store_id = $3)
+		AND (CAST($4 AS uuid) IS NULL OR ph.company_id = $4)
+		AND (CAST($5 AS date) IS NULL OR ph.price_day >= $5)
+		AND (CAST($6 AS date) IS NULL OR ph.price_day <= $6)
+		GROUP BY period, ph.currency
+		ORDER BY period, ph.currency`
+	RefreshPriceHistoryQuery = `REFRESH MATERIALIZED VIEW CONCURRENTLY price_history_daily`
+)
+
+func (ph *PriceHistory) SelectPriceHistory(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter) ([]*model.PricePoint, error) {
+	rows, err := ph.db.Query(ctx, SelectPriceHistoryQuery, productID, filter.Interval, nullUUID(filter.StoreID), nullUUID(filter.CompanyID), nullTime(filter.From), nullTime(filter.To))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := []*model.PricePoint{}
+	for rows.Next() {
+		point := &model.PricePoint{}
+		var currency string
+		err := rows.Scan(&point.Period, &currency, &point.MinPrice.Amount, &point.MaxPrice.Amount, &point.AvgPrice.Amount, &point.MedianPrice.Amount, &point.Count)
+		if err != nil {
+			return nil, err
+		}
+		point.MinPrice.Currency = currency
+		point.MaxPrice.Currency = currency
+		point.AvgPrice.Currency = currency
+		point.MedianPrice.Currency = currency
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (ph *PriceHistory) Refresh(ctx context.Context) error {
+	_, err := ph.db.Exec(ctx, RefreshPriceHistoryQuery)
+	return err
+}
@@ -0,0 +1,111 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+type SqlPriceHistoryTestSuite struct {
+	DBTestSuite
+	PriceHistory *PriceHistory
+	Company      *Company
+	Store        *Store
+	Bill         *Bill
+	UserProduct  *UserProduct
+}
+
+func (s *SqlPriceHistoryTestSuite) SetupTest() {
+	s.PriceHistory = NewPriceHistory(s.DB)
+	s.Company = NewCompany(s.DB)
+	s.Store = NewStore(s.DB)
+	s.Bill = NewBill(s.DB)
+	s.UserProduct = NewUserProduct(s.DB)
+}
+
+func (s *SqlPriceHistoryTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE company")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE store")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE bill")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_product")
+	s.Require().NoError(err)
+	s.Require().NoError(s.PriceHistory.Refresh(s.ctx))
+}
+
+func (s *SqlPriceHistoryTestSuite) insertPrice(productID uuid.UUID, store *model.Store, price string, state string, pricedAt time.Time) {
+	bill := &model.Bill{UserID: uuid.New(), StoreID: store.StoreID, Amount: money("0")}
+	s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+	bill.State = state
+	s.Require().NoError(s.Bill.Update(s.ctx, bill))
+	up := &model.UserProduct{ProductID: productID, BillID: bill.BillID, Price: money(price), Quantity: 1, ProductType: model.ProductBarcoded}
+	s.Require().NoError(s.UserProduct.Insert(s.ctx, up, bill.UserID))
+	_, err := s.DB.Exec(s.ctx, "UPDATE user_product SET created_at = $1 WHERE user_product_id = $2", pricedAt, up.UserProductID)
+	s.Require().NoError(err)
+}
+
+func (s *SqlPriceHistoryTestSuite) TestSelectPriceHistory() {
+	s.Run("no error", func() {
+		company := &model.Company{CompanyName: "intermarché"}
+		s.Require().NoError(s.Company.Insert(s.ctx, company))
+		store := &model.Store{Address: "address", ZipCode: "02140", City: "vervins", Country: "france", StoreName: "intermarché", StoreType: model.StoreTypeShop, CompanyID: company.CompanyID}
+		s.Require().NoError(s.Store.Insert(s.ctx, store))
+		productID := uuid.New()
+
+		s.insertPrice(productID, store, "1.00", model.BillStateCompleted, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+		s.insertPrice(productID, store, "2,00", model.BillStateCompleted, time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC))
+		s.insertPrice(productID, store, "6.00", model.BillStateCompleted, time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC))
+		s.insertPrice(productID, store, "4.00", model.BillStateCompleted, time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC))
+		s.insertPrice(productID, store, "100", model.BillStateCanceled, time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC))
+		s.Require().NoError(s.PriceHistory.Refresh(s.ctx))
+
+		points, err := s.PriceHistory.SelectPriceHistory(s.ctx, productID, &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalDay})
+		s.Require().NoError(err)
+		s.Require().Len(points, 3)
+		s.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), points[0].Period)
+		s.Equal("1.00", points[0].MinPrice.Amount.StringFixed(2))
+		s.Equal("2.00", points[0].MaxPrice.Amount.StringFixed(2))
+		s.Equal("1.50", points[0].AvgPrice.Amount.StringFixed(2))
+		s.Equal("1.50", points[0].MedianPrice.Amount.StringFixed(2))
+		s.Equal(int64(2), points[0].Count)
+		s.Equal(model.DefaultCurrency, points[0].MinPrice.Currency)
+
+		points, err = s.PriceHistory.SelectPriceHistory(s.ctx, productID, &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalMonth})
+		s.Require().NoError(err)
+		s.Require().Len(points, 2)
+		s.Equal("2.00", points[0].MedianPrice.Amount.StringFixed(2))
+		s.Equal(int64(3), points[0].Count)
+		s.Equal("4.00", points[1].MaxPrice.Amount.StringFixed(2))
+
+		points, err = s.PriceHistory.SelectPriceHistory(s.ctx, productID, &model.PriceHistoryFilter{
+			Interval:  model.PriceHistoryIntervalMonth,
+			CompanyID: company.CompanyID,
+			From:      time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		})
+		s.Require().NoError(err)
+		s.Require().Len(points, 2)
+		s.Equal(int64(1), points[0].Count)
+
+		points, err = s.PriceHistory.SelectPriceHistory(s.ctx, productID, &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalDay, StoreID: uuid.New()})
+		s.Require().NoError(err)
+		s.Empty(points)
+	})
+
+	s.Run("context error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		points, err := s.PriceHistory.SelectPriceHistory(ctx, uuid.New(), &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalDay})
+		s.Require().Nil(points)
+		s.Require().EqualError(err, `context canceled`)
+		s.Require().EqualError(s.PriceHistory.Refresh(ctx), `context canceled`)
+	})
+}
+
+func TestPriceHistoryTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlPriceHistoryTestSuite))
+}
@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+type SqlPriceTestSuite struct {
+	DBTestSuite
+	Price       *Price
+	Company     *Company
+	Store       *Store
+	Bill        *Bill
+	UserProduct *UserProduct
+	Product     *Product
+}
+
+func (s *SqlPriceTestSuite) SetupTest() {
+	s.Price = NewPrice(s.DB)
+	s.Company = NewCompany(s.DB)
+	s.Store = NewStore(s.DB)
+	s.Bill = NewBill(s.DB)
+	s.UserProduct = NewUserProduct(s.DB)
+	s.Product = NewProduct(s.DB)
+}
+
+func (s *SqlPriceTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE company")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE store")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE bill")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE product")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_product")
+	s.Require().NoError(err)
+}
+
+func (s *SqlPriceTestSuite) insertStore(companyName, zipCode string) *model.Store {
+	company := &model.Company{CompanyName: companyName}
+	s.Require().NoError(s.Company.Insert(s.ctx, company))
+	store := &model.Store{
+		Address:   "address",
+		ZipCode:   zipCode,
+		City:      "city",
+		Country:   "france",
+		StoreName: companyName,
+		StoreType: model.StoreTypeShop,
+		CompanyID: company.CompanyID,
+	}
+	s.Require().NoError(s.Store.Insert(s.ctx, store))
+	return store
+}
+
+func (s *SqlPriceTestSuite) insertPrice(userID, productID, storeID uuid.UUID, price string) *model.UserProduct {
+	bill := &model.Bill{UserID: userID, StoreID: storeID, Amount: money("0")}
+	s.Require().NoError(s.Bill.Insert(s.ctx, bill))
+	up := &model.UserProduct{
+		ProductID:   productID,
+		BillID:      bill.BillID,
+		Price:       money(price),
+		Quantity:    1,
+		ProductType: model.ProductBarcoded,
+	}
+	s.Require().NoError(s.UserProduct.Insert(s.ctx, up, userID))
+	// only the prices of the closed bills are compared
+	bill.State = model.BillStateCompleted
+	s.Require().NoError(s.Bill.Update(s.ctx, bill))
+	return up
+}
+
+func (s *SqlPriceTestSuite) TestSelectMostRecentPricesByProductID() {
+	s.Run("no error", func() {
+		userID := uuid.New()
+		product := &model.Product{EAN: "3017620422003", ProductName: "nutella", BrandID: uuid.New()}
+		s.Require().NoError(s.Product.Insert(s.ctx, product))
+		intermarche := s.insertStore("intermarché", "02140")
+		carrefour := s.insertStore("carrefour", "75011")
+
+		s.insertPrice(userID, product.ProductID, intermarche.StoreID, "4.99")
+		lastIntermarche := s.insertPrice(userID, product.ProductID, intermarche.StoreID, "5.19")
+		lastCarrefour := s.insertPrice(uuid.New(), product.ProductID, carrefour.StoreID, "4,79")
+		s.insertPrice(userID, uuid.New(), carrefour.StoreID, "1")
+		// the price of an open bill is not final
+		open := &model.Bill{UserID: userID, StoreID: carrefour.StoreID, Amount: money("0")}
+		s.Require().NoError(s.Bill.Insert(s.ctx, open))
+		s.Require().NoError(s.UserProduct.Insert(s.ctx, &model.UserProduct{ProductID: product.ProductID, BillID: open.BillID, Price: money("0.01"), Quantity: 1}, userID))
+
+		prices, err := s.Price.SelectMostRecentPricesByProductID(s.ctx, product.ProductID, &model.PriceFilter{})
+		s.Require().NoError(err)
+		s.Require().Len(prices, 2)
+		s.ElementsMatch(
+			[]uuid.UUID{lastIntermarche.UserProductID, lastCarrefour.UserProductID},
+			[]uuid.UUID{prices[0].UserProductID, prices[1].UserProductID},
+		)
+
+		prices, err = s.Price.SelectMostRecentPricesByProductID(s.ctx, product.ProductID, &model.PriceFilter{ZipCode: "02"})
+		s.Require().NoError(err)
+		s.Require().Len(prices, 1)
+		s.Equal(lastIntermarche.UserProductID, prices[0].UserProductID)
+		s.Equal(money("5.19"), prices[0].Price)
+		s.Equal("intermarché", prices[0].CompanyName)
+		s.Equal("nutella", prices[0].ProductName)
+
+		// the wildcards of the zip code are not wildcards
+		prices, err = s.Price.SelectMostRecentPricesByProductID(s.ctx, product.ProductID, &model.PriceFilter{ZipCode: "_2%"})
+		s.Require().NoError(err)
+		s.Empty(prices)
+
+		prices, err = s.Price.SelectMostRecentPricesByProductID(s.ctx, product.ProductID, &model.PriceFilter{CompanyID: carrefour.CompanyID})
+		s.Require().NoError(err)
+		s.Require().Len(prices, 1)
+		s.Equal(lastCarrefour.UserProductID, prices[0].UserProductID)
+	})
+
+	s.Run("context error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		prices, err := s.Price.SelectMostRecentPricesByProductID(ctx, uuid.New(), &model.PriceFilter{})
+		s.Require().Nil(prices)
+		s.Require().EqualError(err, `context canceled`)
+	})
+}
+
+func (s *SqlPriceTestSuite) TestSelectMostRecentPricesByProductIDs() {
+	s.Run("no error", func() {
+		userID := uuid.New()
+		nutella := &model.Product{EAN: "3017620422003", ProductName: "nutella", BrandID: uuid.New()}
+		s.Require().NoError(s.Product.Insert(s.ctx, nutella))
+		milk := &model.Product{EAN: "3428273950013", ProductName: "milk", BrandID: uuid.New()}
+		s.Require().NoError(s.Product.Insert(s.ctx, milk))
+		store := s.insertStore("intermarché", "02140")
+
+		s.insertPrice(userID, nutella.ProductID, store.StoreID, "4.99")
+		lastNutella := s.insertPrice(userID, nutella.ProductID, store.StoreID, "5.19")
+		lastMilk := s.insertPrice(userID, milk.ProductID, store.StoreID, "1.05")
+
+		prices, err := s.Price.SelectMostRecentPricesByProductIDs(s.ctx, []uuid.UUID{nutella.ProductID, milk.ProductID}, &model.PriceFilter{})
+		s.Require().NoError(err)
+		s.Require().Len(prices, 2)
+		s.ElementsMatch(
+			[]uuid.UUID{lastNutella.UserProductID, lastMilk.UserProductID},
+			[]uuid.UUID{prices[0].UserProductID, prices[1].UserProductID},
+		)
+	})
+
+	s.Run("context error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		prices, err := s.Price.SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{uuid.New()}, &model.PriceFilter{})
+		s.Require().Nil(prices)
+		s.Require().EqualError(err, `context canceled`)
+	})
+}
+
+func TestPriceTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlPriceTestSuite))
+}
@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 	"shop-aggregator/internal/model"
 )
@@ -26,6 +27,18 @@ const (
 		SELECT p.product_id, p.ean, p.product_name, p.brand_id
 		FROM product p 
 		WHERE p.ean = $1`
+	UpdateProductQuery = `UPDATE product SET ean = $2, product_name = $3, updated_at = NOW() WHERE product_id = $1`
+	// MergeProductQuery moves the lines of the product $1 to the product $2 then deletes the product $1.
+	MergeProductQuery = `
+		WITH target AS (
+		    SELECT product_id FROM product WHERE product_id = $2 AND product_id <> $1
+		), moved AS (
+		    UPDATE user_product up SET product_id = t.product_id, updated_at = NOW()
+		    FROM target t
+		    WHERE up.product_id = $1
+		)
+		DELETE FROM product p USING target t WHERE p.product_id = $1
+	`
 )
 
 func (p *Product) Insert(ctx context.Context, product *model.Product) error {
@@ -46,3 +59,28 @@ func (p *Product) GetProductByEAN(ctx context.Context, ean string) (*model.Produ
 	}
 	return product, nil
 }
+
+// Update fixes the EAN and the name of the product, it returns pgx.ErrNoRows when the product does not exist.
+func (p *Product) Update(ctx context.Context, product *model.Product) error {
+	tag, err := p.db.Exec(ctx, UpdateProductQuery, product.ProductID, product.EAN, product.ProductName)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Merge moves the lines of the source product to the target product and deletes the source product, it
+// returns pgx.ErrNoRows when either product does not exist.
+func (p *Product) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tag, err := p.db.Exec(ctx, MergeProductQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
@@ -59,6 +60,46 @@ func (s *SqlProductTestSuite) TestProduct() {
 	})
 }
 
+func (s *SqlProductTestSuite) TestUpdateAndMerge() {
+	s.Run("no error", func() {
+		source := &model.Product{EAN: "3017620422004", ProductName: "nutela", BrandID: uuid.New()}
+		target := &model.Product{EAN: "3017620422003", ProductName: "nutella", BrandID: source.BrandID}
+		s.Require().NoError(s.Product.Insert(s.ctx, source))
+		s.Require().NoError(s.Product.Insert(s.ctx, target))
+		userProductID := uuid.New()
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO user_product (user_product_id, product_id, user_id, bill_id, price, quantity) VALUES ($1, $2, $3, $4, 3.49, 1)", userProductID, source.ProductID, uuid.New(), uuid.New())
+		s.Require().NoError(err)
+
+		source.EAN = "3017620422005"
+		source.ProductName = "nutella old"
+		s.Require().NoError(s.Product.Update(s.ctx, source))
+		updated, err := s.Product.GetProductByEAN(s.ctx, source.EAN)
+		s.Require().NoError(err)
+		s.Equal(source.ProductID, updated.ProductID)
+		s.Equal("nutella old", updated.ProductName)
+
+		s.ErrorIs(s.Product.Merge(s.ctx, source.ProductID, uuid.New()), pgx.ErrNoRows)
+		s.Require().NoError(s.Product.Merge(s.ctx, source.ProductID, target.ProductID))
+		var productID uuid.UUID
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT product_id FROM user_product WHERE user_product_id = $1", userProductID).Scan(&productID))
+		s.Equal(target.ProductID, productID)
+		merged, err := s.Product.GetProductByEAN(s.ctx, source.EAN)
+		s.Require().NoError(err)
+		s.Nil(merged)
+
+		s.ErrorIs(s.Product.Update(s.ctx, source), pgx.ErrNoRows)
+		_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_product")
+		s.Require().NoError(err)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.Product.Update(ctx, &model.Product{}), `context canceled`)
+		s.EqualError(s.Product.Merge(ctx, uuid.New(), uuid.New()), `context canceled`)
+	})
+}
+
 func TestProductTestSuite(t *testing.T) {
 	suite.Run(t, new(SqlProductTestSuite))
 }
@@ -26,6 +26,21 @@ const (
 	SelectStoresByZipCodeQuery = `SELECT store_id,address, zip_code, city, country, store_name, store_type, url, company_id FROM store where zip_code LIKE CONCAT(CAST($1 AS text), '%')`
 	SelectStoresByNameQuery    = `SELECT store_id,address, zip_code, city, country, store_name, store_type, url, company_id FROM store where store_type = $1 AND store_name LIKE CONCAT('%', CAST($2 AS text), '%')`
 	SelectStoresByIDQuery      = `SELECT store_id,address, zip_code, city, country, store_name, store_type, url, company_id FROM store where store_id = $1`
+	UpdateStoreQuery           = `
+		UPDATE store
+		SET address = $2, zip_code = $3, city = $4, country = $5, store_name = $6, store_type = $7, url = $8, updated_at = NOW()
+		WHERE store_id = $1`
+	// MergeStoreQuery moves the bills of the store $1 to the store $2 then deletes the store $1.
+	MergeStoreQuery = `
+		WITH target AS (
+		    SELECT store_id FROM store WHERE store_id = $2 AND store_id <> $1
+		), moved AS (
+		    UPDATE bill b SET store_id = t.store_id
+		    FROM target t
+		    WHERE b.store_id = $1
+		)
+		DELETE FROM store s USING target t WHERE s.store_id = $1
+	`
 )
 
 func (s *Store) Insert(ctx context.Context, store *model.Store) error {
@@ -75,3 +90,29 @@ func (s *Store) SelectStoreByID(ctx context.Context, storeID uuid.UUID) (*model.
 	}
 	return store, nil
 }
+
+// Update fixes the address and the name of the store, the company is kept. It returns pgx.ErrNoRows when the
+// store does not exist.
+func (s *Store) Update(ctx context.Context, store *model.Store) error {
+	tag, err := s.db.Exec(ctx, UpdateStoreQuery, store.StoreID, store.Address, store.ZipCode, store.City, store.Country, store.StoreName, store.StoreType, store.Url)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Merge moves the bills of the source store to the target store and deletes the source store, it returns
+// pgx.ErrNoRows when either store does not exist.
+func (s *Store) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tag, err := s.db.Exec(ctx, MergeStoreQuery, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
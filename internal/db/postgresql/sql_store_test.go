@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
@@ -86,6 +87,45 @@ func (s *SqlStoreTestSuite) TestStore() {
 	})
 }
 
+func (s *SqlStoreTestSuite) TestUpdateAndMerge() {
+	s.Run("no error", func() {
+		companyID := uuid.New()
+		source := &model.Store{Address: "1 rue de la paix", ZipCode: "75001", City: "Pari", Country: "FR", StoreName: "market", StoreType: model.StoreTypeShop, CompanyID: companyID}
+		target := &model.Store{Address: "1 rue de la Paix", ZipCode: "75001", City: "Paris", Country: "FR", StoreName: "market", StoreType: model.StoreTypeShop, CompanyID: companyID}
+		s.Require().NoError(s.Store.Insert(s.ctx, source))
+		s.Require().NoError(s.Store.Insert(s.ctx, target))
+		billID := uuid.New()
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO bill (bill_id, user_id, store_id, amount) VALUES ($1, $2, $3, 0)", billID, uuid.New(), source.StoreID)
+		s.Require().NoError(err)
+
+		source.City = "Paris"
+		s.Require().NoError(s.Store.Update(s.ctx, source))
+		updated, err := s.Store.SelectStoreByID(s.ctx, source.StoreID)
+		s.Require().NoError(err)
+		s.Equal(source, updated)
+
+		s.ErrorIs(s.Store.Merge(s.ctx, source.StoreID, uuid.New()), pgx.ErrNoRows)
+		s.Require().NoError(s.Store.Merge(s.ctx, source.StoreID, target.StoreID))
+		var storeID uuid.UUID
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT store_id FROM bill WHERE bill_id = $1", billID).Scan(&storeID))
+		s.Equal(target.StoreID, storeID)
+		merged, err := s.Store.SelectStoreByID(s.ctx, source.StoreID)
+		s.Require().NoError(err)
+		s.Nil(merged)
+
+		s.ErrorIs(s.Store.Update(s.ctx, source), pgx.ErrNoRows)
+		_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE bill")
+		s.Require().NoError(err)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.Store.Update(ctx, &model.Store{}), `context canceled`)
+		s.EqualError(s.Store.Merge(ctx, uuid.New(), uuid.New()), `context canceled`)
+	})
+}
+
 func TestStoreTestSuite(t *testing.T) {
 	suite.Run(t, new(SqlStoreTestSuite))
 }
@@ -0,0 +1,200 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+)
+
+// TwoFactor stores the encrypted TOTP secrets, the keyed hashes of the recovery codes and the login
+// challenges.
+type TwoFactor struct {
+	db     *Client
+	hasher *utils.TokenHasher
+	cipher *utils.SecretCipher
+}
+
+func NewTwoFactor(db *Client, hasher *utils.TokenHasher, cipher *utils.SecretCipher) *TwoFactor {
+	return &TwoFactor{
+		db:     db,
+		hasher: hasher,
+		cipher: cipher,
+	}
+}
+
+const (
+	SelectTOTPQuery = `SELECT user_id, secret, enabled_at, last_step FROM user_totp WHERE user_id = $1`
+	// UpsertTOTPQuery replaces the secret of an enrolment that is not confirmed, never an enabled one.
+	UpsertTOTPQuery = `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_step = 0, created_at = NOW()
+		WHERE user_totp.enabled_at IS NULL
+	`
+	// EnableTOTPQuery enables the 2FA and replaces the recovery codes.
+	EnableTOTPQuery = `
+		WITH enabled AS (
+		    UPDATE user_totp SET enabled_at = NOW(), last_step = $2
+		    WHERE user_id = $1 AND enabled_at IS NULL
+		    RETURNING user_id
+		), deleted AS (
+		    DELETE FROM recovery_code WHERE user_id IN (SELECT user_id FROM enabled)
+		), inserted AS (
+		    INSERT INTO recovery_code (user_id, code)
+		    SELECT e.user_id, c.code FROM enabled e, unnest($3::TEXT[]) AS c(code)
+		)
+		SELECT user_id FROM enabled
+	`
+	UseTOTPStepQuery     = `UPDATE user_totp SET last_step = $2 WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_step < $2`
+	UseRecoveryCodeQuery = `UPDATE recovery_code SET used_at = NOW() WHERE user_id = $1 AND code = $2 AND used_at IS NULL`
+	DeleteTOTPQuery      = `
+		WITH codes AS (
+		    DELETE FROM recovery_code WHERE user_id = $1
+		)
+		DELETE FROM user_totp WHERE user_id = $1
+	`
+	InsertLoginChallengeQuery = `
+		INSERT INTO login_challenge (user_id, login, challenge, device, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING login_challenge_id
+	`
+	SelectLoginChallengeQuery = `
+		SELECT login_challenge_id, user_id, login, device, failures, expires_at
+		FROM login_challenge
+		WHERE challenge = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+	// FailLoginChallengeQuery counts a wrong code, the challenge is used up after maxFailures.
+	FailLoginChallengeQuery = `
+		UPDATE login_challenge
+		SET failures = failures + 1, used_at = CASE WHEN failures + 1 >= $2 THEN NOW() ELSE used_at END
+		WHERE login_challenge_id = $1
+	`
+	UseLoginChallengeQuery = `UPDATE login_challenge SET used_at = NOW() WHERE login_challenge_id = $1 AND used_at IS NULL AND expires_at > NOW()`
+)
+
+// SelectTOTP returns the TOTP of the user with its secret decrypted, nil when the user never enrolled.
+func (tf *TwoFactor) SelectTOTP(ctx context.Context, userID uuid.UUID) (*model.TOTP, error) {
+	row := tf.db.QueryRow(ctx, SelectTOTPQuery, userID)
+
+	t := &model.TOTP{}
+	err := row.Scan(&t.UserID, &t.Secret, &t.EnabledAt, &t.LastStep)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if t.Secret, err = tf.cipher.Decrypt(t.Secret); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// UpsertTOTP stores the secret of a new enrolment, it returns pgx.ErrNoRows when the 2FA is already enabled.
+func (tf *TwoFactor) UpsertTOTP(ctx context.Context, userID uuid.UUID, secret string) error {
+	encrypted, err := tf.cipher.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+
+	tag, err := tf.db.Exec(ctx, UpsertTOTPQuery, userID, encrypted)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// EnableTOTP enables the 2FA with the period of the confirming code used and stores the new recovery codes,
+// it returns pgx.ErrNoRows when there is no enrolment to confirm.
+func (tf *TwoFactor) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodes []string) error {
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, tf.hasher.Hash(code))
+	}
+
+	var id uuid.UUID
+	return tf.db.QueryRow(ctx, EnableTOTPQuery, userID, step, hashes).Scan(&id)
+}
+
+// UseStep records the period of a code, it returns pgx.ErrNoRows when a code of this period or of a later
+// one was already used.
+func (tf *TwoFactor) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	tag, err := tf.db.Exec(ctx, UseTOTPStepQuery, userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// UseRecoveryCode uses up the code, it returns pgx.ErrNoRows when the user has no such unused code.
+func (tf *TwoFactor) UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error {
+	tag, err := tf.db.Exec(ctx, UseRecoveryCodeQuery, userID, tf.hasher.Hash(code))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteTOTP turns the 2FA off and deletes the recovery codes, it returns pgx.ErrNoRows when the user never
+// enrolled.
+func (tf *TwoFactor) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	tag, err := tf.db.Exec(ctx, DeleteTOTPQuery, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (tf *TwoFactor) InsertChallenge(ctx context.Context, challenge *model.LoginChallenge) error {
+	row := tf.db.QueryRow(ctx, InsertLoginChallengeQuery, challenge.UserID, challenge.Login, tf.hasher.Hash(challenge.Challenge), challenge.Device, challenge.ExpiresAt)
+	return row.Scan(&challenge.LoginChallengeID)
+}
+
+// SelectChallenge returns the challenge, nil when it is unknown, used or expired.
+func (tf *TwoFactor) SelectChallenge(ctx context.Context, challenge string) (*model.LoginChallenge, error) {
+	row := tf.db.QueryRow(ctx, SelectLoginChallengeQuery, tf.hasher.Hash(challenge))
+
+	c := &model.LoginChallenge{Challenge: challenge}
+	err := row.Scan(&c.LoginChallengeID, &c.UserID, &c.Login, &c.Device, &c.Failures, &c.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// FailChallenge counts a wrong code, the challenge can no longer be used after maxFailures.
+func (tf *TwoFactor) FailChallenge(ctx context.Context, challengeID uuid.UUID, maxFailures int) error {
+	_, err := tf.db.Exec(ctx, FailLoginChallengeQuery, challengeID, maxFailures)
+	return err
+}
+
+// UseChallenge uses up the challenge, it returns pgx.ErrNoRows when an other request used it first.
+func (tf *TwoFactor) UseChallenge(ctx context.Context, challengeID uuid.UUID) error {
+	tag, err := tf.db.Exec(ctx, UseLoginChallengeQuery, challengeID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
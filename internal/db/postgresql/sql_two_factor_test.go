@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+	"testing"
+	"time"
+)
+
+type SqlTwoFactorTestSuite struct {
+	DBTestSuite
+	TwoFactor *TwoFactor
+	userID    uuid.UUID
+}
+
+func (s *SqlTwoFactorTestSuite) SetupTest() {
+	s.TwoFactor = NewTwoFactor(s.DB, utils.NewTokenHasher("secret"), utils.NewSecretCipher("secret"))
+	s.userID = uuid.New()
+}
+
+func (s *SqlTwoFactorTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE user_totp, recovery_code, login_challenge")
+	s.Require().NoError(err)
+}
+
+func (s *SqlTwoFactorTestSuite) TestTOTP() {
+	s.Run("enrol, enable and delete", func() {
+		t, err := s.TwoFactor.SelectTOTP(s.ctx, s.userID)
+		s.Require().NoError(err)
+		s.Nil(t)
+
+		s.Require().NoError(s.TwoFactor.UpsertTOTP(s.ctx, s.userID, "FIRSTSECRET"))
+		s.Require().NoError(s.TwoFactor.UpsertTOTP(s.ctx, s.userID, "SECONDSECRET"))
+
+		// the secret is stored encrypted
+		var stored string
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT secret FROM user_totp WHERE user_id = $1", s.userID).Scan(&stored))
+		s.NotEqual("SECONDSECRET", stored)
+
+		t, err = s.TwoFactor.SelectTOTP(s.ctx, s.userID)
+		s.Require().NoError(err)
+		s.Require().NotNil(t)
+		s.Equal("SECONDSECRET", t.Secret)
+		s.False(t.IsEnabled())
+
+		s.Require().NoError(s.TwoFactor.EnableTOTP(s.ctx, s.userID, 100, []string{"code-1", "code-2"}))
+		s.ErrorIs(s.TwoFactor.EnableTOTP(s.ctx, s.userID, 100, []string{"code-3"}), pgx.ErrNoRows)
+		s.ErrorIs(s.TwoFactor.UpsertTOTP(s.ctx, s.userID, "THIRDSECRET"), pgx.ErrNoRows)
+
+		t, err = s.TwoFactor.SelectTOTP(s.ctx, s.userID)
+		s.Require().NoError(err)
+		s.True(t.IsEnabled())
+		s.Equal(int64(100), t.LastStep)
+		s.Equal("SECONDSECRET", t.Secret)
+
+		s.Require().NoError(s.TwoFactor.DeleteTOTP(s.ctx, s.userID))
+		s.ErrorIs(s.TwoFactor.DeleteTOTP(s.ctx, s.userID), pgx.ErrNoRows)
+		var codes int
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT COUNT(*) FROM recovery_code WHERE user_id = $1", s.userID).Scan(&codes))
+		s.Zero(codes)
+	})
+
+	s.Run("a step and a recovery code are used once", func() {
+		s.Require().NoError(s.TwoFactor.UpsertTOTP(s.ctx, s.userID, "SECRET"))
+		s.ErrorIs(s.TwoFactor.UseStep(s.ctx, s.userID, 101), pgx.ErrNoRows)
+		s.Require().NoError(s.TwoFactor.EnableTOTP(s.ctx, s.userID, 100, []string{"code-1"}))
+
+		s.ErrorIs(s.TwoFactor.UseStep(s.ctx, s.userID, 100), pgx.ErrNoRows)
+		s.Require().NoError(s.TwoFactor.UseStep(s.ctx, s.userID, 101))
+		s.ErrorIs(s.TwoFactor.UseStep(s.ctx, s.userID, 101), pgx.ErrNoRows)
+
+		s.ErrorIs(s.TwoFactor.UseRecoveryCode(s.ctx, s.userID, "unknown"), pgx.ErrNoRows)
+		s.ErrorIs(s.TwoFactor.UseRecoveryCode(s.ctx, uuid.New(), "code-1"), pgx.ErrNoRows)
+		s.Require().NoError(s.TwoFactor.UseRecoveryCode(s.ctx, s.userID, "code-1"))
+		s.ErrorIs(s.TwoFactor.UseRecoveryCode(s.ctx, s.userID, "code-1"), pgx.ErrNoRows)
+	})
+}
+
+func (s *SqlTwoFactorTestSuite) TestChallenge() {
+	s.Run("no error", func() {
+		challenge := &model.LoginChallenge{UserID: s.userID, Login: "alice", Challenge: "challenge", Device: "phone", ExpiresAt: time.Now().UTC().Add(time.Minute)}
+		s.Require().NoError(s.TwoFactor.InsertChallenge(s.ctx, challenge))
+		s.NotEqual(uuid.Nil, challenge.LoginChallengeID)
+
+		found, err := s.TwoFactor.SelectChallenge(s.ctx, "challenge")
+		s.Require().NoError(err)
+		s.Require().NotNil(found)
+		s.Equal(s.userID, found.UserID)
+		s.Equal("alice", found.Login)
+		s.Equal("phone", found.Device)
+
+		s.Require().NoError(s.TwoFactor.UseChallenge(s.ctx, found.LoginChallengeID))
+		s.ErrorIs(s.TwoFactor.UseChallenge(s.ctx, found.LoginChallengeID), pgx.ErrNoRows)
+		found, err = s.TwoFactor.SelectChallenge(s.ctx, "challenge")
+		s.Require().NoError(err)
+		s.Nil(found)
+	})
+
+	s.Run("used up by the failures", func() {
+		challenge := &model.LoginChallenge{UserID: s.userID, Login: "alice", Challenge: "failed", ExpiresAt: time.Now().UTC().Add(time.Minute)}
+		s.Require().NoError(s.TwoFactor.InsertChallenge(s.ctx, challenge))
+
+		s.Require().NoError(s.TwoFactor.FailChallenge(s.ctx, challenge.LoginChallengeID, 2))
+		found, err := s.TwoFactor.SelectChallenge(s.ctx, "failed")
+		s.Require().NoError(err)
+		s.Require().NotNil(found)
+		s.Equal(1, found.Failures)
+
+		s.Require().NoError(s.TwoFactor.FailChallenge(s.ctx, challenge.LoginChallengeID, 2))
+		found, err = s.TwoFactor.SelectChallenge(s.ctx, "failed")
+		s.Require().NoError(err)
+		s.Nil(found)
+	})
+
+	s.Run("expired challenge", func() {
+		s.Require().NoError(s.TwoFactor.InsertChallenge(s.ctx, &model.LoginChallenge{UserID: s.userID, Login: "alice", Challenge: "expired", ExpiresAt: time.Now().UTC().Add(-time.Minute)}))
+
+		found, err := s.TwoFactor.SelectChallenge(s.ctx, "expired")
+		s.Require().NoError(err)
+		s.Nil(found)
+	})
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlTwoFactorTestSuite))
+}
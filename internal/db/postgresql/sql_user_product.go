@@ -19,9 +19,10 @@ func NewUserProduct(db *Client) *UserProduct {
 }
 
 const (
+	// InsertUserProductQuery dates the line $9, now without date.
 	InsertUserProductQuery = `
-		INSERT INTO user_product (product_id, user_id, bill_id, price, quantity, product_type, product_size, size_format)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO user_product (product_id, user_id, bill_id, price, quantity, product_type, product_size, size_format, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, NOW()))
 		RETURNING user_product_id;`
 	SelectProductsByUserIDQuery = `
 		SELECT 
@@ -35,6 +36,7 @@ const (
 		    s.store_id,
 		    s.store_name,
 		    up.price,
+		    b.currency,
 		    up.quantity,
 		    up.product_type,
 		    up.product_size,
@@ -59,6 +61,7 @@ const (
 		    s.store_id,
 		    s.store_name,
 		    up.price,
+		    b.currency,
 		    up.quantity,
 		    up.product_type,
 		    up.product_size,
@@ -83,6 +86,7 @@ const (
 		    s.store_id,
 		    s.store_name,
 		    up.price,
+		    b.currency,
 		    up.quantity,
 		    up.product_type,
 		    up.product_size,
@@ -108,6 +112,7 @@ const (
 		    s.store_id,
 		    s.store_name,
 		    up.price,
+		    b.currency,
 		    up.quantity,
 		    up.product_type,
 		    up.product_size,
@@ -120,6 +125,7 @@ const (
 		WHERE up.bill_id = $1
 		ORDER BY up.created_at`
 
+	// SelectMostRecentUserProductByStoreIDQuery only reads the prices of the closed bills, $2 and $3.
 	SelectMostRecentUserProductByStoreIDQuery = `
 		WITH RecentProducts AS (
 			SELECT 
@@ -133,6 +139,7 @@ const (
 				bill b ON up.bill_id = b.bill_id
 			WHERE 
 				b.store_id = $1
+			AND b.bill_state IN ($2, $3)
 		)
 		SELECT 
 		    up.user_product_id, 
@@ -145,6 +152,7 @@ const (
 		    s.store_id,
 		    s.store_name,
 		    up.price,
+		    b.currency,
 		    up.quantity,
 		    up.product_type,
 		    up.product_size,
@@ -164,12 +172,37 @@ const (
 		WHERE 
 			rp.rn = 1
 		ORDER BY up.created_at`
-	UpdateUserProductQuantityQuery = `UPDATE user_product set quantity = $1, product_type = $2, product_size = $3, size_format = $4 where user_product_id = $5`
-	DeleteUserProduct              = `DELETE FROM user_product where user_product_id = $1 RETURNING bill_id`
+	SelectMostRecentProductsByUserIDAndCompanyIDQuery = `
+		SELECT DISTINCT ON (up.product_id)
+		    up.user_product_id, 
+		    up.product_id,
+		    p.product_name,
+		    p.ean,
+		    br.brand_id,
+		    br.brand_name,
+		    up.bill_id, 
+		    s.store_id,
+		    s.store_name,
+		    up.price,
+		    b.currency,
+		    up.quantity,
+		    up.product_type,
+		    up.product_size,
+		    up.size_format
+		FROM user_product up 
+		INNER JOIN product p ON up.product_id = p.product_id
+		INNER JOIN brand br ON p.brand_id = br.brand_id
+		INNER JOIN bill b ON up.bill_id = b.bill_id
+		INNER JOIN store s ON b.store_id = s.store_id
+		WHERE up.user_id = $1
+		AND s.company_id = $2
+		ORDER BY up.product_id, up.created_at DESC`
+	UpdateUserProductQuantityQuery = `UPDATE user_product set quantity = $1, product_type = $2, product_size = $3, size_format = $4 where user_product_id = $5 AND bill_id = $6`
+	DeleteUserProduct              = `DELETE FROM user_product where user_product_id = $1 AND bill_id = $2`
 )
 
 func (up *UserProduct) Insert(ctx context.Context, userProduct *model.UserProduct, userID uuid.UUID) error {
-	row := up.db.QueryRow(ctx, InsertUserProductQuery, userProduct.ProductID, userID, userProduct.BillID, userProduct.Price, userProduct.Quantity, userProduct.ProductType, userProduct.ProductSize, userProduct.SizeFormat)
+	row := up.db.QueryRow(ctx, InsertUserProductQuery, userProduct.ProductID, userID, userProduct.BillID, userProduct.Price.Amount, userProduct.Quantity, userProduct.ProductType, userProduct.ProductSize, userProduct.SizeFormat, nullTime(userProduct.CreatedAt))
 	err := row.Scan(&userProduct.UserProductID)
 	return err
 }
@@ -194,7 +227,8 @@ func (up *UserProduct) SelectProductsByUserID(ctx context.Context, userID uuid.U
 			&userProduct.BillID,
 			&userProduct.StoreID,
 			&userProduct.StoreName,
-			&userProduct.Price,
+			&userProduct.Price.Amount,
+			&userProduct.Price.Currency,
 			&userProduct.Quantity,
 			&userProduct.ProductType,
 			&userProduct.ProductSize,
@@ -233,7 +267,8 @@ func (up *UserProduct) SelectProductsByUserIDAndStoreID(ctx context.Context, use
 			&userProduct.BillID,
 			&userProduct.StoreID,
 			&userProduct.StoreName,
-			&userProduct.Price,
+			&userProduct.Price.Amount,
+			&userProduct.Price.Currency,
 			&userProduct.Quantity,
 			&userProduct.ProductType,
 			&userProduct.ProductSize,
@@ -272,7 +307,8 @@ func (up *UserProduct) SelectProductsByBillID(ctx context.Context, billID uuid.U
 			&userProduct.BillID,
 			&userProduct.StoreID,
 			&userProduct.StoreName,
-			&userProduct.Price,
+			&userProduct.Price.Amount,
+			&userProduct.Price.Currency,
 			&userProduct.Quantity,
 			&userProduct.ProductType,
 			&userProduct.ProductSize,
@@ -292,7 +328,7 @@ func (up *UserProduct) SelectProductsByBillID(ctx context.Context, billID uuid.U
 }
 
 func (up *UserProduct) SelectMostRecentUserProductByStoreID(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error) {
-	rows, err := up.db.Query(ctx, SelectMostRecentUserProductByStoreIDQuery, storeID)
+	rows, err := up.db.Query(ctx, SelectMostRecentUserProductByStoreIDQuery, storeID, model.BillStateCompleted, model.BillStateArchived)
 	if err != nil {
 		return nil, err
 	}
@@ -311,7 +347,48 @@ func (up *UserProduct) SelectMostRecentUserProductByStoreID(ctx context.Context,
 			&userProduct.BillID,
 			&userProduct.StoreID,
 			&userProduct.StoreName,
-			&userProduct.Price,
+			&userProduct.Price.Amount,
+			&userProduct.Price.Currency,
+			&userProduct.Quantity,
+			&userProduct.ProductType,
+			&userProduct.ProductSize,
+			&userProduct.SizeFormat,
+		)
+		if err != nil {
+			return nil, err
+		}
+		userProducts = append(userProducts, &userProduct)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userProducts, nil
+}
+
+func (up *UserProduct) SelectMostRecentProductsByUserIDAndCompanyID(ctx context.Context, userID, companyID uuid.UUID) ([]*model.UserProduct, error) {
+	rows, err := up.db.Query(ctx, SelectMostRecentProductsByUserIDAndCompanyIDQuery, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userProducts []*model.UserProduct
+	for rows.Next() {
+		userProduct := model.UserProduct{}
+		err := rows.Scan(
+			&userProduct.UserProductID,
+			&userProduct.ProductID,
+			&userProduct.ProductName,
+			&userProduct.Ean,
+			&userProduct.BrandID,
+			&userProduct.BrandName,
+			&userProduct.BillID,
+			&userProduct.StoreID,
+			&userProduct.StoreName,
+			&userProduct.Price.Amount,
+			&userProduct.Price.Currency,
 			&userProduct.Quantity,
 			&userProduct.ProductType,
 			&userProduct.ProductSize,
@@ -343,7 +420,8 @@ func (up *UserProduct) SelectProductByID(ctx context.Context, id uuid.UUID) (*mo
 		&userProduct.BillID,
 		&userProduct.StoreID,
 		&userProduct.StoreName,
-		&userProduct.Price,
+		&userProduct.Price.Amount,
+		&userProduct.Price.Currency,
 		&userProduct.Quantity,
 		&userProduct.ProductType,
 		&userProduct.ProductSize,
@@ -359,15 +437,26 @@ func (up *UserProduct) SelectProductByID(ctx context.Context, id uuid.UUID) (*mo
 	return &userProduct, nil
 }
 
-func (up *UserProduct) UpdateQuantity(ctx context.Context, quantity int64, productType, productSize, sizeFormat string, userProductID uuid.UUID) error {
-	_, err := up.db.Exec(ctx, UpdateUserProductQuantityQuery, quantity, productType, productSize, sizeFormat, userProductID)
-	return err
+// UpdateQuantity returns pgx.ErrNoRows when the user product is not a line of the bill.
+func (up *UserProduct) UpdateQuantity(ctx context.Context, quantity int64, productType, productSize, sizeFormat string, billID, userProductID uuid.UUID) error {
+	tag, err := up.db.Exec(ctx, UpdateUserProductQuantityQuery, quantity, productType, productSize, sizeFormat, userProductID, billID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
 }
-func (up *UserProduct) DeleteUserProduct(ctx context.Context, userProductID uuid.UUID) (uuid.UUID, error) {
-	row := up.db.QueryRow(ctx, DeleteUserProduct, userProductID)
-	var billID uuid.UUID
-	if err := row.Scan(&billID); err != nil {
-		return uuid.Nil, err
+
+// DeleteUserProduct returns pgx.ErrNoRows when the user product is not a line of the bill.
+func (up *UserProduct) DeleteUserProduct(ctx context.Context, billID, userProductID uuid.UUID) error {
+	tag, err := up.db.Exec(ctx, DeleteUserProduct, userProductID, billID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
 	}
-	return billID, nil
+	return nil
 }
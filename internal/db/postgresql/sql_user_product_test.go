@@ -30,7 +30,7 @@ func (s *SqlUserProductTestSuite) insertNewBill(userID, storeID uuid.UUID, amoun
 		BillID:  uuid.New(),
 		UserID:  userID,
 		StoreID: storeID,
-		Amount:  amount,
+		Amount:  money(amount),
 	}
 	s.Require().NoError(s.Bill.Insert(s.ctx, bill))
 	return bill
@@ -73,7 +73,7 @@ func (s *SqlUserProductTestSuite) insertNewUserProduct(userID, productID, billID
 	up := &model.UserProduct{
 		ProductID: productID,
 		BillID:    billID,
-		Price:     price,
+		Price:     money(price),
 		Quantity:  quantity,
 	}
 
@@ -84,7 +84,7 @@ func (s *SqlUserProductTestSuite) insertNewUserProduct(userID, productID, billID
 func (s *SqlUserProductTestSuite) getUserProductByID(userProductID uuid.UUID) *model.UserProduct {
 	up := &model.UserProduct{}
 	row := s.DB.QueryRow(s.ctx, "SELECT user_product_id,product_id,bill_id,price,quantity FROM user_product where user_product_id = $1", userProductID)
-	err := row.Scan(&up.UserProductID, &up.ProductID, &up.BillID, &up.Price, &up.Quantity)
+	err := row.Scan(&up.UserProductID, &up.ProductID, &up.BillID, &up.Price.Amount, &up.Quantity)
 	s.Require().NoError(err)
 	return up
 }
@@ -115,7 +115,7 @@ func (s *SqlUserProductTestSuite) TestSelectProductsByUserID() {
 		expectedUserProduct := model.UserProduct{
 			ProductID:   product.ProductID,
 			BillID:      bill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         product.EAN,
@@ -153,7 +153,7 @@ func (s *SqlUserProductTestSuite) TestSelectProductsByUserIDAndStoreID() {
 		expectedUserProduct := model.UserProduct{
 			ProductID:   product.ProductID,
 			BillID:      bill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         product.EAN,
@@ -191,7 +191,7 @@ func (s *SqlUserProductTestSuite) TestSelectProductsByBillID() {
 		expectedUserProduct := model.UserProduct{
 			ProductID:   product.ProductID,
 			BillID:      bill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         product.EAN,
@@ -231,7 +231,7 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 		expectedUserProduct := model.UserProduct{
 			ProductID:   product.ProductID,
 			BillID:      bill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         product.EAN,
@@ -246,7 +246,7 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 		expectedSecondUserProduct := model.UserProduct{
 			ProductID:   product.ProductID,
 			BillID:      bill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         product.EAN,
@@ -261,7 +261,7 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 		expectedThirdUserProduct := model.UserProduct{
 			ProductID:   secondProduct.ProductID,
 			BillID:      secondBill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         secondProduct.EAN,
@@ -276,7 +276,7 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 		expectedFourthUserProduct := model.UserProduct{
 			ProductID:   secondProduct.ProductID,
 			BillID:      secondBill.BillID,
-			Price:       "42",
+			Price:       money("42"),
 			Quantity:    42,
 			ProductName: "productName",
 			Ean:         secondProduct.EAN,
@@ -288,6 +288,14 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 
 		s.Require().NoError(s.UserProduct.Insert(s.ctx, &expectedFourthUserProduct, userID))
 
+		// the prices of the open bills are not published
+		for _, closed := range []*model.Bill{bill, secondBill} {
+			closed.State = model.BillStateCompleted
+			s.Require().NoError(s.Bill.Update(s.ctx, closed))
+		}
+		openBill := s.insertNewBill(userID, store.StoreID, "0")
+		s.insertNewUserProduct(userID, product.ProductID, openBill.BillID, "1", 1)
+
 		userProducts, err := s.UserProduct.SelectMostRecentUserProductByStoreID(s.ctx, store.StoreID)
 		s.Require().NoError(err)
 		s.Require().Len(userProducts, 2)
@@ -307,6 +315,38 @@ func (s *SqlUserProductTestSuite) TestSelectMostRecentUserProductByStoreID() {
 	})
 }
 
+func (s *SqlUserProductTestSuite) TestSelectMostRecentProductsByUserIDAndCompanyID() {
+	s.Run("no error", func() {
+		userID := uuid.New()
+		store := s.insertNewStore("7 rue du labrador", "02140", "intermarché", "vervins", "france")
+		otherStore := s.insertNewStore("2 rue de la gare", "02140", "carrefour", "vervins", "france")
+		brand := s.insertNewBrand("brandName")
+		product := s.insertNewProduct("ean13", "productName", brand.BrandID)
+		bill := s.insertNewBill(userID, store.StoreID, "185")
+		otherBill := s.insertNewBill(userID, otherStore.StoreID, "12")
+
+		s.insertNewUserProduct(userID, product.ProductID, bill.BillID, "40", 1)
+		last := s.insertNewUserProduct(userID, product.ProductID, bill.BillID, "42", 2)
+		s.insertNewUserProduct(userID, product.ProductID, otherBill.BillID, "12", 1)
+		s.insertNewUserProduct(uuid.New(), product.ProductID, bill.BillID, "38", 1)
+
+		userProducts, err := s.UserProduct.SelectMostRecentProductsByUserIDAndCompanyID(s.ctx, userID, store.CompanyID)
+		s.Require().NoError(err)
+		s.Require().Len(userProducts, 1)
+		s.Equal(last.UserProductID, userProducts[0].UserProductID)
+		s.Equal(money("42"), userProducts[0].Price)
+		s.Equal("productName", userProducts[0].ProductName)
+	})
+
+	s.Run("context error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		us, err := s.UserProduct.SelectMostRecentProductsByUserIDAndCompanyID(ctx, uuid.New(), uuid.New())
+		s.Require().Nil(us)
+		s.Require().EqualError(err, `context canceled`)
+	})
+}
+
 func (s *SqlUserProductTestSuite) TearDownTest() {
 	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE store")
 	s.Require().NoError(err)
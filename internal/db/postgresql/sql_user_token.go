@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v4"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+)
+
+// UserToken stores the keyed hashes of the tokens sent by mail.
+type UserToken struct {
+	db     *Client
+	hasher *utils.TokenHasher
+}
+
+func NewUserToken(db *Client, hasher *utils.TokenHasher) *UserToken {
+	return &UserToken{
+		db:     db,
+		hasher: hasher,
+	}
+}
+
+const (
+	// InsertUserTokenQuery leaves a single usable token per user and purpose, the previous links stop working.
+	InsertUserTokenQuery = `
+		WITH discarded AS (
+		    UPDATE user_token SET used_at = NOW()
+		    WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+		)
+		INSERT INTO user_token (user_id, purpose, token, email, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING user_token_id, created_at
+	`
+	UseUserTokenQuery = `
+		UPDATE user_token SET used_at = NOW()
+		WHERE token = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_token_id, user_id, purpose, email, expires_at, used_at, created_at
+	`
+)
+
+func (ut *UserToken) Insert(ctx context.Context, userToken *model.UserToken) error {
+	row := ut.db.QueryRow(ctx, InsertUserTokenQuery, userToken.UserID, userToken.Purpose, ut.hasher.Hash(userToken.Token), userToken.Email, userToken.ExpiresAt)
+	return row.Scan(&userToken.UserTokenID, &userToken.CreatedAt)
+}
+
+// Use marks the token as used and returns it, it returns nil when the token is unknown, used, expired or
+// issued for an other purpose.
+func (ut *UserToken) Use(ctx context.Context, purpose, token string) (*model.UserToken, error) {
+	row := ut.db.QueryRow(ctx, UseUserTokenQuery, ut.hasher.Hash(token), purpose)
+
+	userToken := &model.UserToken{}
+	err := row.Scan(&userToken.UserTokenID, &userToken.UserID, &userToken.Purpose, &userToken.Email, &userToken.ExpiresAt, &userToken.UsedAt, &userToken.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return userToken, nil
+}
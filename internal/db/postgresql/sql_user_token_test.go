@@ -0,0 +1,104 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+	"testing"
+	"time"
+)
+
+type SqlUserTokenTestSuite struct {
+	DBTestSuite
+	UserToken *UserToken
+}
+
+func (s *SqlUserTokenTestSuite) SetupTest() {
+	s.UserToken = NewUserToken(s.DB, utils.NewTokenHasher("secret"))
+}
+
+func (s *SqlUserTokenTestSuite) TearDownTest() {
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE user_token")
+	s.Require().NoError(err)
+}
+
+func (s *SqlUserTokenTestSuite) newUserToken(userID uuid.UUID, purpose, token string, expiresAt time.Time) *model.UserToken {
+	userToken := &model.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		Token:     token,
+		Email:     "new@test.com",
+		ExpiresAt: expiresAt,
+	}
+	s.Require().NoError(s.UserToken.Insert(s.ctx, userToken))
+	s.NotEqual(uuid.Nil, userToken.UserTokenID)
+	return userToken
+}
+
+func (s *SqlUserTokenTestSuite) TestUse() {
+	s.Run("no error", func() {
+		userID := uuid.New()
+		expiresAt := time.Now().UTC().Add(time.Hour)
+		inserted := s.newUserToken(userID, model.UserTokenEmailVerification, "verify", expiresAt)
+
+		// only the hash of the token is stored
+		var token string
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT token FROM user_token WHERE user_token_id = $1", inserted.UserTokenID).Scan(&token))
+		s.Equal(utils.NewTokenHasher("secret").Hash("verify"), token)
+
+		// a token of an other purpose is not used
+		userToken, err := s.UserToken.Use(s.ctx, model.UserTokenPasswordReset, "verify")
+		s.Require().NoError(err)
+		s.Nil(userToken)
+
+		userToken, err = s.UserToken.Use(s.ctx, model.UserTokenEmailVerification, "verify")
+		s.Require().NoError(err)
+		s.Require().NotNil(userToken)
+		s.Equal(inserted.UserTokenID, userToken.UserTokenID)
+		s.Equal(userID, userToken.UserID)
+		s.Equal("new@test.com", userToken.Email)
+		s.NotNil(userToken.UsedAt)
+
+		// the token is used once
+		userToken, err = s.UserToken.Use(s.ctx, model.UserTokenEmailVerification, "verify")
+		s.Require().NoError(err)
+		s.Nil(userToken)
+	})
+
+	s.Run("new token discards the previous one", func() {
+		userID := uuid.New()
+		expiresAt := time.Now().UTC().Add(time.Hour)
+		s.newUserToken(userID, model.UserTokenPasswordReset, "first", expiresAt)
+		s.newUserToken(userID, model.UserTokenPasswordReset, "second", expiresAt)
+
+		userToken, err := s.UserToken.Use(s.ctx, model.UserTokenPasswordReset, "first")
+		s.Require().NoError(err)
+		s.Nil(userToken)
+		userToken, err = s.UserToken.Use(s.ctx, model.UserTokenPasswordReset, "second")
+		s.Require().NoError(err)
+		s.NotNil(userToken)
+	})
+
+	s.Run("expired token", func() {
+		s.newUserToken(uuid.New(), model.UserTokenPasswordReset, "expired", time.Now().UTC().Add(-time.Minute))
+
+		userToken, err := s.UserToken.Use(s.ctx, model.UserTokenPasswordReset, "expired")
+		s.Require().NoError(err)
+		s.Nil(userToken)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.UserToken.Insert(ctx, &model.UserToken{}), `context canceled`)
+		userToken, err := s.UserToken.Use(ctx, model.UserTokenPasswordReset, "token")
+		s.Nil(userToken)
+		s.EqualError(err, `context canceled`)
+	})
+}
+
+func TestUserTokenTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlUserTokenTestSuite))
+}
@@ -19,17 +19,43 @@ func NewUsers(db *Client) *User {
 }
 
 const (
+	// UpsertUserQuery keeps the email verified only when the email is not changed.
 	UpsertUserQuery = `
 		INSERT INTO users(login, email, password) 
 		VALUES ($1,$2,$3)
 		ON CONFLICT(login)
-		DO UPDATE SET email = EXCLUDED.email
+		DO UPDATE SET email = EXCLUDED.email, email_verified = users.email_verified AND users.email = EXCLUDED.email
 		RETURNING user_id`
-	GetUserByLoginQuery = `SELECT user_id, login, email, password FROM users WHERE login = $1`
-	GetUserByIDQuery    = `SELECT user_id, login, email, password FROM users WHERE user_id = $1`
-	GetUserByEmailQuery = `SELECT user_id, login, email, password FROM users WHERE email = $1`
+	GetUserByLoginQuery = `SELECT user_id, login, email, email_verified, role, disabled_at IS NOT NULL, password FROM users WHERE login = $1`
+	GetUserByIDQuery    = `SELECT user_id, login, email, email_verified, role, disabled_at IS NOT NULL, password FROM users WHERE user_id = $1`
+	GetUserByEmailQuery = `SELECT user_id, login, email, email_verified, role, disabled_at IS NOT NULL, password FROM users WHERE email = $1`
 	UpdatePasswordQuery = `UPDATE users set password = $2  WHERE user_id = $1`
-	UpdateEmailQuery    = `UPDATE users set email = $2  WHERE user_id = $1`
+	// ResetPasswordQuery also revokes the sessions of the user, whoever knew the old password is logged out.
+	ResetPasswordQuery = `
+		WITH updated AS (
+		    UPDATE users SET password = $2 WHERE user_id = $1
+		    RETURNING user_id
+		)
+		UPDATE session s SET revoked_at = NOW()
+		FROM updated u
+		WHERE s.user_id = u.user_id AND s.revoked_at IS NULL
+	`
+	VerifyEmailQuery    = `UPDATE users set email = $2, email_verified = TRUE WHERE user_id = $1`
+	SetRoleQuery        = `UPDATE users SET role = $2 WHERE user_id = $1`
+	SetRoleByLoginQuery = `UPDATE users SET role = $2 WHERE login = $1`
+	// DisableUserQuery also revokes the sessions of the user.
+	DisableUserQuery = `
+		WITH disabled AS (
+		    UPDATE users SET disabled_at = COALESCE(disabled_at, NOW()) WHERE user_id = $1
+		    RETURNING user_id
+		), revoked AS (
+		    UPDATE session s SET revoked_at = NOW()
+		    FROM disabled d
+		    WHERE s.user_id = d.user_id AND s.revoked_at IS NULL
+		)
+		SELECT user_id FROM disabled
+	`
+	EnableUserQuery = `UPDATE users SET disabled_at = NULL WHERE user_id = $1`
 )
 
 func (u *User) Upsert(ctx context.Context, m *model.User) error {
@@ -41,7 +67,7 @@ func (u *User) Upsert(ctx context.Context, m *model.User) error {
 func (u *User) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	row := u.db.QueryRow(ctx, GetUserByIDQuery, id)
 	var m model.User
-	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.HashPassword)
+	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.EmailVerified, &m.Role, &m.Disabled, &m.HashPassword)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -54,7 +80,7 @@ func (u *User) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, erro
 func (u *User) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
 	row := u.db.QueryRow(ctx, GetUserByLoginQuery, login)
 	var m model.User
-	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.HashPassword)
+	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.EmailVerified, &m.Role, &m.Disabled, &m.HashPassword)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -67,7 +93,7 @@ func (u *User) GetUserByLogin(ctx context.Context, login string) (*model.User, e
 func (u *User) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	row := u.db.QueryRow(ctx, GetUserByEmailQuery, email)
 	var m model.User
-	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.HashPassword)
+	err := row.Scan(&m.ID, &m.Login, &m.Email, &m.EmailVerified, &m.Role, &m.Disabled, &m.HashPassword)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -82,7 +108,63 @@ func (u *User) UpdatePassword(ctx context.Context, id uuid.UUID, password string
 	return err
 }
 
-func (u *User) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
-	_, err := u.db.Exec(ctx, UpdateEmailQuery, id, email)
+// ResetPassword sets the password of a user who forgot it and revokes the sessions of the user.
+func (u *User) ResetPassword(ctx context.Context, id uuid.UUID, password string) error {
+	_, err := u.db.Exec(ctx, ResetPasswordQuery, id, password)
 	return err
 }
+
+// VerifyEmail sets the email the user confirmed, it returns pgx.ErrNoRows when the user does not exist.
+func (u *User) VerifyEmail(ctx context.Context, id uuid.UUID, email string) error {
+	tag, err := u.db.Exec(ctx, VerifyEmailQuery, id, email)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SetRole returns pgx.ErrNoRows when the user does not exist.
+func (u *User) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	tag, err := u.db.Exec(ctx, SetRoleQuery, id, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SetRoleByLogin returns pgx.ErrNoRows when no user has the login.
+func (u *User) SetRoleByLogin(ctx context.Context, login, role string) error {
+	tag, err := u.db.Exec(ctx, SetRoleByLoginQuery, login, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// Disable stops the user from logging in and revokes the sessions of the user, it returns pgx.ErrNoRows when
+// the user does not exist.
+func (u *User) Disable(ctx context.Context, id uuid.UUID) error {
+	row := u.db.QueryRow(ctx, DisableUserQuery, id)
+	return row.Scan(&id)
+}
+
+// Enable returns pgx.ErrNoRows when the user does not exist.
+func (u *User) Enable(ctx context.Context, id uuid.UUID) error {
+	tag, err := u.db.Exec(ctx, EnableUserQuery, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
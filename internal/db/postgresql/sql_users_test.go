@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/suite"
 	"shop-aggregator/internal/model"
 	"testing"
@@ -19,12 +20,13 @@ func (s *SqlUserTestSuite) SetupTest() {
 		Login:        "user42",
 		Email:        "user42@test.com",
 		HashPassword: "iamuser42forever",
+		Role:         model.RoleUser,
 	}
 	s.User = NewUsers(s.DB)
 }
 
 func (s *SqlUserTestSuite) TearDownTest() {
-	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users")
+	_, err := s.DB.Exec(s.ctx, "TRUNCATE TABLE users, session")
 	s.Require().NoError(err)
 }
 
@@ -151,24 +153,106 @@ func (s *SqlUserTestSuite) TestUpdatePassword() {
 	})
 }
 
-func (s *SqlUserTestSuite) TestUpdateEmail() {
+func (s *SqlUserTestSuite) TestResetPassword() {
+	s.Run("no error", func() {
+		user := s.user
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		s.NotEqual(uuid.Nil, user.ID)
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO session (user_id, token) VALUES ($1, 'phone'), ($1, 'tablet')", user.ID)
+		s.Require().NoError(err)
+
+		user.HashPassword = "thisismynewpassword"
+		s.Require().NoError(s.User.ResetPassword(s.ctx, user.ID, user.HashPassword))
+
+		checkUser, err := s.User.GetUserByEmail(s.ctx, user.Email)
+		s.Require().NoError(err)
+		s.Equal(user, *checkUser)
+
+		var active int
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT COUNT(*) FROM session WHERE user_id = $1 AND revoked_at IS NULL", user.ID).Scan(&active))
+		s.Zero(active)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.User.ResetPassword(ctx, uuid.New(), "niet"), `context canceled`)
+	})
+}
+
+func (s *SqlUserTestSuite) TestVerifyEmail() {
 	s.Run("no error", func() {
 		user := s.user
 		s.Require().NoError(s.User.Upsert(s.ctx, &user))
 		s.NotEqual(uuid.Nil, user.ID)
 
 		user.Email = "new@test.email"
-		s.Require().NoError(s.User.UpdateEmail(s.ctx, user.ID, user.Email))
+		user.EmailVerified = true
+		s.Require().NoError(s.User.VerifyEmail(s.ctx, user.ID, user.Email))
 
 		checkUser, err := s.User.GetUserByEmail(s.ctx, user.Email)
 		s.Require().NoError(err)
 		s.Equal(user, *checkUser)
+
+		// an other email set by Upsert is no longer verified
+		user.Email = "other@test.email"
+		user.EmailVerified = false
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		checkUser, err = s.User.GetUserByID(s.ctx, user.ID)
+		s.Require().NoError(err)
+		s.Equal(user, *checkUser)
+
+		s.ErrorIs(s.User.VerifyEmail(s.ctx, uuid.New(), "unknown@test.email"), pgx.ErrNoRows)
 	})
 
 	s.Run("context cancel error", func() {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
-		s.EqualError(s.User.UpdatePassword(ctx, uuid.New(), "niet"), `context canceled`)
+		s.EqualError(s.User.VerifyEmail(ctx, uuid.New(), "niet"), `context canceled`)
+	})
+}
+
+func (s *SqlUserTestSuite) TestRoleAndDisable() {
+	s.Run("no error", func() {
+		user := s.user
+		s.Require().NoError(s.User.Upsert(s.ctx, &user))
+		_, err := s.DB.Exec(s.ctx, "INSERT INTO session (user_id, token) VALUES ($1, 'phone')", user.ID)
+		s.Require().NoError(err)
+
+		s.Require().NoError(s.User.SetRole(s.ctx, user.ID, model.RoleModerator))
+		s.Require().NoError(s.User.SetRoleByLogin(s.ctx, user.Login, model.RoleAdmin))
+		s.Require().NoError(s.User.Disable(s.ctx, user.ID))
+		user.Role = model.RoleAdmin
+		user.Disabled = true
+		checkUser, err := s.User.GetUserByID(s.ctx, user.ID)
+		s.Require().NoError(err)
+		s.Equal(user, *checkUser)
+
+		// the sessions of a disabled user are revoked
+		var active int
+		s.Require().NoError(s.DB.QueryRow(s.ctx, "SELECT COUNT(*) FROM session WHERE user_id = $1 AND revoked_at IS NULL", user.ID).Scan(&active))
+		s.Zero(active)
+
+		s.Require().NoError(s.User.Enable(s.ctx, user.ID))
+		user.Disabled = false
+		checkUser, err = s.User.GetUserByID(s.ctx, user.ID)
+		s.Require().NoError(err)
+		s.Equal(user, *checkUser)
+
+		s.Error(s.User.SetRole(s.ctx, user.ID, "root"))
+		s.ErrorIs(s.User.SetRole(s.ctx, uuid.New(), model.RoleAdmin), pgx.ErrNoRows)
+		s.ErrorIs(s.User.SetRoleByLogin(s.ctx, "unknown", model.RoleAdmin), pgx.ErrNoRows)
+		s.ErrorIs(s.User.Disable(s.ctx, uuid.New()), pgx.ErrNoRows)
+		s.ErrorIs(s.User.Enable(s.ctx, uuid.New()), pgx.ErrNoRows)
+	})
+
+	s.Run("context cancel error", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.EqualError(s.User.SetRole(ctx, uuid.New(), model.RoleAdmin), `context canceled`)
+		s.EqualError(s.User.SetRoleByLogin(ctx, "niet", model.RoleAdmin), `context canceled`)
+		s.EqualError(s.User.Disable(ctx, uuid.New()), `context canceled`)
+		s.EqualError(s.User.Enable(ctx, uuid.New()), `context canceled`)
 	})
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
 	"shop-aggregator/tools/migrations"
 	"strconv"
 	"strings"
@@ -74,3 +75,11 @@ func (s *DBTestSuite) TearDownSuite() {
 	err := s.Container.Terminate(s.ctx)
 	s.Require().NoError(err)
 }
+
+func money(amount string) model.Money {
+	m, err := model.ParseMoney(amount)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,154 @@
+// Package export writes the personal data of a user as a ZIP archive: the profile in JSON, the sessions, bills
+// and lines both in JSON and in CSV.
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"shop-aggregator/internal/model"
+	"strconv"
+	"time"
+)
+
+type profile struct {
+	UserID        string `json:"user_id"`
+	Login         string `json:"login"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role"`
+	ExportedAt    string `json:"exported_at"`
+}
+
+// table is a list of records with the same columns, written as an array of objects in JSON.
+type table struct {
+	name    string
+	columns []string
+	records [][]string
+}
+
+// WriteZip writes the archive to w as it is built, nothing is kept in memory but the current file.
+func WriteZip(w io.Writer, data *model.PersonalData) error {
+	archive := zip.NewWriter(w)
+
+	f, err := archive.Create("profile.json")
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(profile{
+		UserID:        data.User.ID.String(),
+		Login:         data.User.Login,
+		Email:         data.User.Email,
+		EmailVerified: data.User.EmailVerified,
+		Role:          data.User.Role,
+		ExportedAt:    formatTime(data.ExportedAt),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range []*table{sessionTable(data.Sessions), billTable(data.Bills), lineTable(data.Lines)} {
+		if err = writeJSON(archive, t); err != nil {
+			return err
+		}
+		if err = writeCSV(archive, t); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func writeJSON(archive *zip.Writer, t *table) error {
+	f, err := archive.Create(t.name + ".json")
+	if err != nil {
+		return err
+	}
+	objects := make([]map[string]string, 0, len(t.records))
+	for _, record := range t.records {
+		object := make(map[string]string, len(t.columns))
+		for i, column := range t.columns {
+			object[column] = record[i]
+		}
+		objects = append(objects, object)
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(objects)
+}
+
+func writeCSV(archive *zip.Writer, t *table) error {
+	f, err := archive.Create(t.name + ".csv")
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err = w.Write(t.columns); err != nil {
+		return err
+	}
+	if err = w.WriteAll(t.records); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func sessionTable(sessions []*model.Session) *table {
+	t := &table{
+		name:    "sessions",
+		columns: []string{"session_id", "device", "ip", "user_agent", "created_at", "last_seen_at"},
+	}
+	for _, s := range sessions {
+		t.records = append(t.records, []string{
+			s.SessionID.String(), s.Device, s.IP, s.UserAgent, formatTime(s.CreatedAt), formatTime(s.LastSeenAt),
+		})
+	}
+	return t
+}
+
+func billTable(bills []*model.Bill) *table {
+	t := &table{
+		name:    "bills",
+		columns: []string{"bill_id", "household_id", "store_id", "amount", "computed_amount", "currency", "state", "created_at", "updated_at"},
+	}
+	for _, b := range bills {
+		householdID := ""
+		if b.HouseholdID != nil {
+			householdID = b.HouseholdID.String()
+		}
+		updatedAt := ""
+		if b.UpdatedAt != nil {
+			updatedAt = formatTime(*b.UpdatedAt)
+		}
+		t.records = append(t.records, []string{
+			b.BillID.String(), householdID, b.StoreID.String(), b.Amount.Amount.StringFixed(2),
+			b.ComputedAmount.Amount.StringFixed(2), b.Amount.Currency, b.State, formatTime(b.CreatedAt), updatedAt,
+		})
+	}
+	return t
+}
+
+func lineTable(lines []*model.UserProduct) *table {
+	t := &table{
+		name: "lines",
+		columns: []string{"user_product_id", "bill_id", "product_id", "product_name", "ean", "brand_name", "store_name",
+			"price", "currency", "quantity", "product_type", "product_size", "size_format"},
+	}
+	for _, l := range lines {
+		t.records = append(t.records, []string{
+			l.UserProductID.String(), l.BillID.String(), l.ProductID.String(), l.ProductName, l.Ean, l.BrandName,
+			l.StoreName, l.Price.Amount.StringFixed(2), l.Price.Currency, strconv.FormatInt(l.Quantity, 10),
+			l.ProductType, l.ProductSize, l.SizeFormat,
+		})
+	}
+	return t
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
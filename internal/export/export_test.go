@@ -0,0 +1,60 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"shop-aggregator/internal/export"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+func TestWriteZip(t *testing.T) {
+	billID := uuid.New()
+	data := &model.PersonalData{
+		User:     &model.User{ID: uuid.New(), Login: "alice", Email: "alice@test.com", Role: model.RoleUser},
+		Sessions: []*model.Session{{SessionID: uuid.New(), Device: "phone, android"}},
+		Bills: []*model.Bill{{
+			BillID: billID, Amount: model.NewMoney(decimal.RequireFromString("12.5"), "EUR"), State: model.BillStateCompleted,
+			CreatedAt: time.Date(2024, 3, 12, 18, 42, 0, 0, time.UTC),
+		}},
+		Lines:      []*model.UserProduct{{BillID: billID, ProductName: "milk", Price: model.NewMoney(decimal.RequireFromString("1.2"), "EUR"), Quantity: 2}},
+		ExportedAt: time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.WriteZip(&buf, data))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+	assert.Len(t, files, 7)
+
+	var profile map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(files["profile.json"]), &profile))
+	assert.Equal(t, "alice@test.com", profile["email"])
+	assert.Equal(t, "2024-03-13T00:00:00Z", profile["exported_at"])
+
+	assert.Contains(t, files["sessions.csv"], `"phone, android"`)
+	assert.Contains(t, files["bills.csv"], billID.String()+",,00000000-0000-0000-0000-000000000000,12.50,0.00,EUR,complete,2024-03-12T18:42:00Z,")
+
+	var lines []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(files["lines.json"]), &lines))
+	require.Len(t, lines, 1)
+	assert.Equal(t, "milk", lines[0]["product_name"])
+	assert.Equal(t, "1.20", lines[0]["price"])
+	assert.Equal(t, "2", lines[0]["quantity"])
+}
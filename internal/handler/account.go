@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"shop-aggregator/internal/export"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type AccountUseCase interface {
+	Export(ctx context.Context, userID uuid.UUID) (*model.PersonalData, error)
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+}
+
+type Account struct {
+	AccountUseCase AccountUseCase
+}
+
+func NewAccount(au AccountUseCase) *Account {
+	return &Account{
+		AccountUseCase: au,
+	}
+}
+
+// Export streams the ZIP archive of the personal data, an error met once it is started cuts the archive.
+func (a *Account) Export(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	data, err := a.AccountUseCase.Export(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="shop-aggregator-export.zip"`)
+	c.Status(http.StatusOK)
+	if err = export.WriteZip(c.Writer, data); err != nil {
+		log.Error().Caller().Err(err).Msg("Export.WriteZip")
+	}
+}
+
+func (a *Account) ScheduleDeletion(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	at, err := a.AccountUseCase.ScheduleDeletion(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account deletion scheduled", "data": gin.H{"deletion_scheduled_at": at}})
+}
+
+func (a *Account) CancelDeletion(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := a.AccountUseCase.CancelDeletion(c.Request.Context(), uuid.MustParse(id.(string))); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "account deletion canceled"})
+}
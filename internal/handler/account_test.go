@@ -0,0 +1,35 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+)
+
+func (s *HandlerTestSuite) TestAccount() {
+	s.Run("export", func() {
+		token := s.createUserAndGenerateToken("alice", "password", "alice@test.com")
+
+		w := s.requestWithToken("GET", "/user/export", token, nil)
+		s.Require().Equal(200, w.Code)
+		s.Equal("application/zip", w.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		s.Require().NoError(err)
+		names := []string{}
+		for _, f := range archive.File {
+			names = append(names, f.Name)
+		}
+		s.Equal([]string{"profile.json", "sessions.json", "sessions.csv", "bills.json", "bills.csv", "lines.json", "lines.csv"}, names)
+	})
+
+	s.Run("schedule and cancel the deletion", func() {
+		token := s.createUserAndGenerateToken("bob", "password", "bob@test.com")
+
+		s.Require().Equal(200, s.requestWithToken("POST", "/user/deletion", token, nil).Code)
+		s.Equal(409, s.requestWithToken("POST", "/user/deletion", token, nil).Code)
+		s.Require().Equal(200, s.requestWithToken("DELETE", "/user/deletion", token, nil).Code)
+
+		w := s.requestWithToken("DELETE", "/user/deletion", token, nil)
+		s.assertProblem(w, 404, "account_deletion_not_found")
+	})
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type AdminUseCase interface {
+	SetRole(ctx context.Context, adminID, userID uuid.UUID, role string) error
+	DisableUser(ctx context.Context, adminID, userID uuid.UUID) error
+	EnableUser(ctx context.Context, userID uuid.UUID) error
+	UpdateBrand(ctx context.Context, brand *model.Brand) error
+	MergeBrands(ctx context.Context, sourceID, targetID uuid.UUID) error
+	UpdateCompany(ctx context.Context, company *model.Company) error
+	MergeCompanies(ctx context.Context, sourceID, targetID uuid.UUID) error
+	UpdateStore(ctx context.Context, store *model.Store) error
+	MergeStores(ctx context.Context, sourceID, targetID uuid.UUID) error
+	UpdateProduct(ctx context.Context, product *model.Product) error
+	MergeProducts(ctx context.Context, sourceID, targetID uuid.UUID) error
+	GetStats(ctx context.Context) (*model.Stats, error)
+	GetLoginAttempts(ctx context.Context, login, ip string, limit int) ([]*model.LoginAttempt, error)
+}
+
+type Admin struct {
+	AdminUseCase AdminUseCase
+}
+
+func NewAdmin(au AdminUseCase) *Admin {
+	return &Admin{
+		AdminUseCase: au,
+	}
+}
+
+// pathID parses the uuid of the path parameter, the request is answered when it is invalid.
+func pathID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.Error(invalidRequest("invalid " + param))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (a *Admin) SetRole(c *gin.Context) {
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var sr request.SetRole
+	if err := c.ShouldBindJSON(&sr); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := a.AdminUseCase.SetRole(c.Request.Context(), uuid.MustParse(c.GetString("userID")), userID, sr.Role); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role updated"})
+}
+
+func (a *Admin) DisableUser(c *gin.Context) {
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := a.AdminUseCase.DisableUser(c.Request.Context(), uuid.MustParse(c.GetString("userID")), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user disabled"})
+}
+
+func (a *Admin) EnableUser(c *gin.Context) {
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := a.AdminUseCase.EnableUser(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user enabled"})
+}
+
+func (a *Admin) UpdateBrand(c *gin.Context) {
+	brandID, ok := pathID(c, "brand_id")
+	if !ok {
+		return
+	}
+
+	var ub request.UpdateBrand
+	if err := c.ShouldBindJSON(&ub); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := a.AdminUseCase.UpdateBrand(c.Request.Context(), &model.Brand{BrandID: brandID, BrandName: ub.BrandName}); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "brand updated"})
+}
+
+func (a *Admin) MergeBrands(c *gin.Context) {
+	a.merge(c, "brand_id", a.AdminUseCase.MergeBrands, "brands merged")
+}
+
+func (a *Admin) UpdateCompany(c *gin.Context) {
+	companyID, ok := pathID(c, "company_id")
+	if !ok {
+		return
+	}
+
+	var uc request.UpdateCompany
+	if err := c.ShouldBindJSON(&uc); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := a.AdminUseCase.UpdateCompany(c.Request.Context(), &model.Company{CompanyID: companyID, CompanyName: uc.CompanyName}); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "company updated"})
+}
+
+func (a *Admin) MergeCompanies(c *gin.Context) {
+	a.merge(c, "company_id", a.AdminUseCase.MergeCompanies, "companies merged")
+}
+
+func (a *Admin) UpdateStore(c *gin.Context) {
+	storeID, ok := pathID(c, "store_id")
+	if !ok {
+		return
+	}
+
+	var us request.UpdateStore
+	if err := c.ShouldBindJSON(&us); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	store := &model.Store{
+		StoreID:   storeID,
+		Address:   us.Address,
+		ZipCode:   us.ZipCode,
+		City:      us.City,
+		Country:   us.Country,
+		StoreName: us.StoreName,
+		StoreType: us.StoreType,
+		Url:       us.Url,
+	}
+	if err := a.AdminUseCase.UpdateStore(c.Request.Context(), store); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "store updated"})
+}
+
+func (a *Admin) MergeStores(c *gin.Context) {
+	a.merge(c, "store_id", a.AdminUseCase.MergeStores, "stores merged")
+}
+
+func (a *Admin) UpdateProduct(c *gin.Context) {
+	productID, ok := pathID(c, "product_id")
+	if !ok {
+		return
+	}
+
+	var up request.UpdateProduct
+	if err := c.ShouldBindJSON(&up); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := a.AdminUseCase.UpdateProduct(c.Request.Context(), &model.Product{ProductID: productID, EAN: up.EAN, ProductName: up.ProductName}); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "product updated"})
+}
+
+func (a *Admin) MergeProducts(c *gin.Context) {
+	a.merge(c, "product_id", a.AdminUseCase.MergeProducts, "products merged")
+}
+
+func (a *Admin) GetStats(c *gin.Context) {
+	stats, err := a.AdminUseCase.GetStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "stats", "data": response.NewStatsFromModel(stats)})
+}
+
+func (a *Admin) GetLoginAttempts(c *gin.Context) {
+	var sla request.SearchLoginAttempts
+	if err := c.ShouldBindQuery(&sla); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	attempts, err := a.AdminUseCase.GetLoginAttempts(c.Request.Context(), sla.Login, sla.IP, sla.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "login attempts", "data": response.NewLoginAttemptsFromModels(attempts)})
+}
+
+// merge merges the entity of the path into the target of the body.
+func (a *Admin) merge(c *gin.Context, param string, merge func(ctx context.Context, sourceID, targetID uuid.UUID) error, message string) {
+	sourceID, ok := pathID(c, param)
+	if !ok {
+		return
+	}
+
+	var mc request.MergeCatalog
+	if err := c.ShouldBindJSON(&mc); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := merge(c.Request.Context(), sourceID, mc.TargetID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+func (s *HandlerTestSuite) TestAdmin() {
+	s.Run("a user is forbidden", func() {
+		token := s.createUserAndGenerateToken("plainuser", "password", "plainuser@test.com")
+
+		wStats := s.requestWithToken("GET", "/admin/stats", token, nil)
+
+		s.assertProblem(wStats, 403, "forbidden")
+	})
+
+	s.Run("a moderator fixes the catalog but not the users", func() {
+		token := s.createUserAndGenerateToken("moderator", "password", "moderator@test.com")
+		s.Require().NoError(s.HandlerRepositories.Users.SetRoleByLogin(s.ctx, "moderator", model.RoleModerator))
+		source := &model.Brand{BrandName: "Danon"}
+		s.Require().NoError(s.HandlerRepositories.Brand.Insert(s.ctx, source))
+		target := &model.Brand{BrandName: "Danone"}
+		s.Require().NoError(s.HandlerRepositories.Brand.Insert(s.ctx, target))
+
+		body, err := json.Marshal(request.MergeCatalog{TargetID: target.BrandID})
+		s.Require().NoError(err)
+		wMerge := s.requestWithToken("POST", fmt.Sprintf("/admin/brand/%s/merge", source.BrandID), token, body)
+
+		s.Equal(200, wMerge.Code)
+		s.Equal(`{"message":"brands merged"}`, wMerge.Body.String())
+		brand, err := s.HandlerRepositories.Brand.SelectBrandByName(s.ctx, "Danon")
+		s.NoError(err)
+		s.Nil(brand)
+
+		wMerge = s.requestWithToken("POST", fmt.Sprintf("/admin/brand/%s/merge", source.BrandID), token, body)
+		s.assertProblem(wMerge, 404, "catalog_not_found")
+
+		s.Equal(403, s.requestWithToken("GET", "/admin/stats", token, nil).Code)
+	})
+
+	s.Run("an admin disables a user", func() {
+		token := s.createUserAndGenerateToken("admin", "password", "admin@test.com")
+		s.Require().NoError(s.HandlerRepositories.Users.SetRoleByLogin(s.ctx, "admin", model.RoleAdmin))
+		userToken := s.createUserAndGenerateToken("disabled", "password", "disabled@test.com")
+		user, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "disabled")
+		s.Require().NoError(err)
+
+		wDisable := s.requestWithToken("POST", fmt.Sprintf("/admin/users/%s/disable", user.ID), token, nil)
+
+		s.Equal(200, wDisable.Code)
+		s.Equal(`{"message":"user disabled"}`, wDisable.Body.String())
+		s.Equal(401, s.requestWithToken("GET", "/user/sessions", userToken, nil).Code)
+
+		wStats := s.requestWithToken("GET", "/admin/stats", token, nil)
+		s.Equal(200, wStats.Code)
+		var stats struct {
+			Data *response.Stats `json:"data"`
+		}
+		s.NoError(json.Unmarshal(wStats.Body.Bytes(), &stats))
+		s.Require().NotNil(stats.Data)
+		s.EqualValues(1, stats.Data.DisabledUsers)
+
+		admin, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "admin")
+		s.Require().NoError(err)
+		wDisable = s.requestWithToken("POST", fmt.Sprintf("/admin/users/%s/disable", admin.ID), token, nil)
+		s.assertProblem(wDisable, 403, "own_account")
+	})
+
+	s.Run("an admin views the login attempts", func() {
+		token := s.createUserAndGenerateToken("admin3", "password", "admin3@test.com")
+		s.Require().NoError(s.HandlerRepositories.Users.SetRoleByLogin(s.ctx, "admin3", model.RoleAdmin))
+		wrong, err := json.Marshal(request.Login{Login: "admin3", Password: "wrong"})
+		s.Require().NoError(err)
+		s.Equal(401, s.request("POST", "/login", wrong).Code)
+
+		wAttempts := s.requestWithToken("GET", "/admin/login-attempts?login=admin3", token, nil)
+
+		s.Equal(200, wAttempts.Code)
+		var attempts struct {
+			Data []*response.LoginAttempt `json:"data"`
+		}
+		s.NoError(json.Unmarshal(wAttempts.Body.Bytes(), &attempts))
+		s.Require().Len(attempts.Data, 2)
+		s.False(attempts.Data[0].Success)
+		s.Equal(model.LoginAttemptInvalidCredentials, attempts.Data[0].Reason)
+		s.True(attempts.Data[1].Success)
+	})
+
+	s.Run("invalid id", func() {
+		token := s.createUserAndGenerateToken("admin2", "password", "admin2@test.com")
+		s.Require().NoError(s.HandlerRepositories.Users.SetRoleByLogin(s.ctx, "admin2", model.RoleAdmin))
+
+		wRole := s.requestWithToken("PUT", "/admin/users/notanid/role", token, []byte(`{"role":"moderator"}`))
+
+		s.assertProblem(wRole, 400, "invalid_request")
+	})
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+	"time"
+)
+
+type APIKeyUseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*model.APIKey, error)
+	GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]*model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, apiKeyID uuid.UUID) error
+}
+
+type APIKey struct {
+	APIKeyUseCase APIKeyUseCase
+}
+
+func NewAPIKey(aku APIKeyUseCase) *APIKey {
+	return &APIKey{
+		APIKeyUseCase: aku,
+	}
+}
+
+func (ak *APIKey) Create(c *gin.Context) {
+	var cak request.CreateAPIKey
+	if err := c.ShouldBindJSON(&cak); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	apiKey, err := ak.APIKeyUseCase.Create(c.Request.Context(), uuid.MustParse(id.(string)), cak.Name, cak.Scopes, cak.ExpiresAt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "api key created, it will not be shown again", "data": response.NewAPIKeyFromModel(apiKey)})
+}
+
+func (ak *APIKey) GetAPIKeys(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	apiKeys, err := ak.APIKeyUseCase.GetAPIKeys(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "api keys", "data": response.NewAPIKeysFromModels(apiKeys)})
+}
+
+func (ak *APIKey) Revoke(c *gin.Context) {
+	apiKeyID, err := uuid.Parse(c.Param("api_key_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid api key id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := ak.APIKeyUseCase.RevokeAPIKey(c.Request.Context(), uuid.MustParse(id.(string)), apiKeyID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "api key revoked"})
+}
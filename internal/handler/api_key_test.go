@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"shop-aggregator/internal/model/response"
+)
+
+func (s *HandlerTestSuite) TestAPIKey() {
+	s.Run("a key reaches its scopes only", func() {
+		token := s.createUserAndGenerateToken("script", "password", "script@test.com")
+
+		wCreate := s.requestWithToken("POST", "/user/api-keys", token, []byte(`{"name":"backup","scopes":["bills:read"]}`))
+		s.Require().Equal(200, wCreate.Code)
+		var created struct {
+			Data response.APIKey `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wCreate.Body.Bytes(), &created))
+		key := created.Data.Key
+		s.Require().NotEmpty(key)
+		s.Equal(key[:len(created.Data.Prefix)], created.Data.Prefix)
+
+		s.Equal(200, s.requestWithToken("GET", "/bill/open", key, nil).Code)
+		wStart := s.requestWithToken("POST", "/bill/start", key, []byte(`{}`))
+		s.assertProblem(wStart, 403, "forbidden")
+		s.Equal(403, s.requestWithToken("GET", "/brand/get/test", key, nil).Code)
+		s.Equal(403, s.requestWithToken("GET", "/user/get", key, nil).Code)
+
+		// the key is never shown again
+		wList := s.requestWithToken("GET", "/user/api-keys", token, nil)
+		s.Require().Equal(200, wList.Code)
+		var listed struct {
+			Data []response.APIKey `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wList.Body.Bytes(), &listed))
+		s.Require().Len(listed.Data, 1)
+		s.Empty(listed.Data[0].Key)
+		s.NotNil(listed.Data[0].LastUsedAt)
+
+		s.Equal(200, s.requestWithToken("DELETE", "/user/api-keys/"+created.Data.APIKeyID.String(), token, nil).Code)
+		s.Equal(401, s.requestWithToken("GET", "/bill/open", key, nil).Code)
+		s.Equal(404, s.requestWithToken("DELETE", "/user/api-keys/"+created.Data.APIKeyID.String(), token, nil).Code)
+	})
+
+	s.Run("invalid scope", func() {
+		token := s.createUserAndGenerateToken("scope", "password", "scope@test.com")
+
+		wCreate := s.requestWithToken("POST", "/user/api-keys", token, []byte(`{"name":"backup","scopes":["admin"]}`))
+
+		s.assertProblem(wCreate, 422, "invalid_scope")
+	})
+}
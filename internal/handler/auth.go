@@ -5,13 +5,19 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/model/request"
 	"shop-aggregator/internal/model/response"
 )
 
 type AuthUsecase interface {
-	Login(context.Context, string, string) (string, error)
-	Logout(context.Context, uuid.UUID) error
+	Login(context.Context, string, string, *model.Session) (*response.Login, *model.LoginChallenge, error)
+	LoginTwoFactor(context.Context, string, string, *model.Session) (*response.Login, error)
+	Refresh(context.Context, string) (*response.Login, error)
+	Logout(context.Context, uuid.UUID, uuid.UUID) error
+	GetSessions(context.Context, uuid.UUID) ([]*model.Session, error)
+	RevokeSession(context.Context, uuid.UUID, uuid.UUID) error
+	RevokeOtherSessions(context.Context, uuid.UUID, uuid.UUID) error
 }
 
 type Auth struct {
@@ -28,31 +34,142 @@ func (a *Auth) Login(c *gin.Context) {
 	var login request.Login
 
 	if err := c.ShouldBindJSON(&login); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
-	token, err := a.AuthUsecase.Login(c.Request.Context(), login.Login, login.Password)
+	session := &model.Session{
+		Device:    login.Device,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	tokens, challenge, err := a.AuthUsecase.Login(c.Request.Context(), login.Login, login.Password, session)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusOK, response.NewLoginChallengeFromModel(challenge))
 		return
 	}
 
-	c.JSON(http.StatusOK, response.Login{Token: token})
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (a *Auth) LoginTwoFactor(c *gin.Context) {
+	var login request.LoginTwoFactor
+	if err := c.ShouldBindJSON(&login); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	session := &model.Session{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	tokens, err := a.AuthUsecase.LoginTwoFactor(c.Request.Context(), login.Challenge, login.Code, session)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (a *Auth) Refresh(c *gin.Context) {
+	var refresh request.Refresh
+	if err := c.ShouldBindJSON(&refresh); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	tokens, err := a.AuthUsecase.Refresh(c.Request.Context(), refresh.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
 }
 
 func (a *Auth) Logout(c *gin.Context) {
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	sessionID, exists := c.Get("sessionID")
+	if !exists {
+		c.Error(model.ErrSessionNotFoundError)
 		return
 	}
 
-	err := a.AuthUsecase.Logout(c.Request.Context(), uuid.MustParse(id.(string)))
+	err := a.AuthUsecase.Logout(c.Request.Context(), uuid.MustParse(id.(string)), uuid.MustParse(sessionID.(string)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
 }
+
+func (a *Auth) GetSessions(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	sessionID, exists := c.Get("sessionID")
+	if !exists {
+		c.Error(model.ErrSessionNotFoundError)
+		return
+	}
+
+	sessions, err := a.AuthUsecase.GetSessions(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "sessions", "data": response.NewSessionsFromModels(sessions, uuid.MustParse(sessionID.(string)))})
+}
+
+func (a *Auth) RevokeSession(c *gin.Context) {
+	revokedID, err := uuid.Parse(c.Param("session_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid session id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := a.AuthUsecase.RevokeSession(c.Request.Context(), uuid.MustParse(id.(string)), revokedID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked"})
+}
+
+func (a *Auth) RevokeOtherSessions(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	sessionID, exists := c.Get("sessionID")
+	if !exists {
+		c.Error(model.ErrSessionNotFoundError)
+		return
+	}
+
+	if err := a.AuthUsecase.RevokeOtherSessions(c.Request.Context(), uuid.MustParse(id.(string)), uuid.MustParse(sessionID.(string))); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "other sessions revoked"})
+}
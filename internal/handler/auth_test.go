@@ -1,7 +1,11 @@
 package handler_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/model/request"
 	"shop-aggregator/internal/model/response"
 )
@@ -28,11 +32,11 @@ func (s *HandlerTestSuite) TestLogin() {
 		var login response.Login
 		s.NoError(json.Unmarshal(wLogin.Body.Bytes(), &login))
 
-		row := s.DB.QueryRow(s.ctx, "SELECT token,is_active from auth WHERE user_id = $1", user.ID)
+		row := s.DB.QueryRow(s.ctx, "SELECT token, revoked_at IS NULL from session WHERE user_id = $1", user.ID)
 		var expectedToken string
 		var isActive bool
 		s.NoError(row.Scan(&expectedToken, &isActive))
-		s.Equal(expectedToken, login.Token)
+		s.Equal(expectedToken, s.TokenHasher.Hash(login.Token))
 		s.True(isActive)
 	})
 
@@ -48,8 +52,7 @@ func (s *HandlerTestSuite) TestLogin() {
 		bodyLoginBytes, err := json.Marshal(bodyLogin)
 		s.Require().NoError(err)
 		wLogin := s.request("POST", "/login", bodyLoginBytes)
-		s.Equal(400, wLogin.Code)
-		s.Equal(`{"error":"invalid password"}`, wLogin.Body.String())
+		s.assertProblem(wLogin, 401, "invalid_credentials")
 	})
 
 	s.Run("login not exists", func() {
@@ -61,8 +64,48 @@ func (s *HandlerTestSuite) TestLogin() {
 		bodyLoginBytes, err := json.Marshal(bodyLogin)
 		s.Require().NoError(err)
 		wLogin := s.request("POST", "/login", bodyLoginBytes)
-		s.Equal(400, wLogin.Code)
-		s.Equal(`{"error":"user not found"}`, wLogin.Body.String())
+		s.assertProblem(wLogin, 401, "invalid_credentials")
+	})
+
+	s.Run("locked after too many failures", func() {
+		s.createUser("login4", "password4", "test4@test.com")
+		wrong, err := json.Marshal(request.Login{Login: "login4", Password: "nananère"})
+		s.Require().NoError(err)
+		for i := 0; i < 5; i++ {
+			s.Equal(401, s.request("POST", "/login", wrong).Code)
+		}
+
+		right, err := json.Marshal(request.Login{Login: "login4", Password: "password4"})
+		s.Require().NoError(err)
+		wLogin := s.request("POST", "/login", right)
+		s.assertProblem(wLogin, 429, "too_many_attempts")
+
+		attempts, err := s.HandlerRepositories.LoginAttempt.SelectLoginAttempts(s.ctx, "login4", "", 10)
+		s.Require().NoError(err)
+		s.Require().Len(attempts, 6)
+		s.Equal(model.LoginAttemptLocked, attempts[0].Reason)
+	})
+
+	s.Run("spoofed forwarded ip", func() {
+		wrong, err := json.Marshal(request.Login{Login: "login5", Password: "nananère"})
+		s.Require().NoError(err)
+		for _, ip := range []string{"203.0.113.1", "203.0.113.2"} {
+			req, _ := http.NewRequest("POST", "/login", bytes.NewReader(wrong))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", ip)
+			req.RemoteAddr = "192.0.2.1:4242"
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+			s.Equal(401, w.Code)
+		}
+
+		// the failures are counted against the address of the connection, not the header
+		attempts, err := s.HandlerRepositories.LoginAttempt.SelectLoginAttempts(s.ctx, "login5", "", 10)
+		s.Require().NoError(err)
+		s.Require().Len(attempts, 2)
+		for _, attempt := range attempts {
+			s.Equal("192.0.2.1", attempt.IP)
+		}
 	})
 }
 
@@ -79,16 +122,70 @@ func (s *HandlerTestSuite) TestLogOut() {
 
 		s.Equal(200, wLogout.Code)
 		var isActive bool
-		row := s.DB.QueryRow(s.ctx, "SELECT is_active from auth WHERE user_id = $1", user.ID)
+		row := s.DB.QueryRow(s.ctx, "SELECT revoked_at IS NULL from session WHERE user_id = $1", user.ID)
 		s.NoError(row.Scan(&isActive))
 		s.False(isActive)
 	})
 
+	s.Run("other device stays logged in", func() {
+		phone := s.createUserAndGenerateToken("devices", "password", "devices@test.com")
+		tablet := s.login("devices", "password")
+
+		s.Equal(200, s.requestWithToken("POST", "/user/logout", tablet, nil).Code)
+		s.Equal(401, s.requestWithToken("GET", "/user/sessions", tablet, nil).Code)
+
+		wSessions := s.requestWithToken("GET", "/user/sessions", phone, nil)
+		s.Equal(200, wSessions.Code)
+		var sessions struct {
+			Data []*response.Session `json:"data"`
+		}
+		s.NoError(json.Unmarshal(wSessions.Body.Bytes(), &sessions))
+		s.Require().Len(sessions.Data, 1)
+		s.True(sessions.Data[0].Current)
+	})
+
 	s.Run("invalid token", func() {
 		// logout
 		wLogout := s.requestWithToken("POST", "/user/logout", "notavalidtoken", nil)
 
-		s.Equal(401, wLogout.Code)
-		s.Equal(`{"error":"invalid authorization token"}`, wLogout.Body.String())
+		s.assertProblem(wLogout, 401, "invalid_token")
+	})
+}
+
+func (s *HandlerTestSuite) TestRefresh() {
+	s.Run("rotation and reuse", func() {
+		s.createUser("refresh", "password", "refresh@test.com")
+		bodyLoginBytes, err := json.Marshal(request.Login{Login: "refresh", Password: "password"})
+		s.Require().NoError(err)
+		wLogin := s.request("POST", "/login", bodyLoginBytes)
+		s.Require().Equal(200, wLogin.Code)
+		var login response.Login
+		s.NoError(json.Unmarshal(wLogin.Body.Bytes(), &login))
+		s.NotEmpty(login.RefreshToken)
+
+		bodyRefreshBytes, err := json.Marshal(request.Refresh{RefreshToken: login.RefreshToken})
+		s.Require().NoError(err)
+		wRefresh := s.request("POST", "/refresh", bodyRefreshBytes)
+		s.Require().Equal(200, wRefresh.Code)
+		var refreshed response.Login
+		s.NoError(json.Unmarshal(wRefresh.Body.Bytes(), &refreshed))
+		s.NotEqual(login.Token, refreshed.Token)
+		s.NotEqual(login.RefreshToken, refreshed.RefreshToken)
+		s.Equal(401, s.requestWithToken("GET", "/user/sessions", login.Token, nil).Code)
+		s.Equal(200, s.requestWithToken("GET", "/user/sessions", refreshed.Token, nil).Code)
+
+		// the rotated refresh token is used again, the whole session is revoked
+		wReuse := s.request("POST", "/refresh", bodyRefreshBytes)
+		s.assertProblem(wReuse, 401, "refresh_token_reused")
+		s.Equal(401, s.requestWithToken("GET", "/user/sessions", refreshed.Token, nil).Code)
+	})
+
+	s.Run("expired token", func() {
+		token := s.createUserAndGenerateToken("expired", "password", "expired@test.com")
+		_, err := s.DB.Exec(s.ctx, "UPDATE session SET expires_at = NOW() - INTERVAL '1 minute' WHERE token = $1", s.TokenHasher.Hash(token))
+		s.Require().NoError(err)
+
+		w := s.requestWithToken("GET", "/user/sessions", token, nil)
+		s.assertProblem(w, 401, "token_expired")
 	})
 }
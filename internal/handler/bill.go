@@ -11,11 +11,17 @@ import (
 )
 
 type BillUseCase interface {
-	CloseBill(ctx context.Context, userID, billID uuid.UUID, amount string) error
-	StartBill(ctx context.Context, userID, storeID uuid.UUID) (*response.Bill, error)
-	GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
+	CloseBill(ctx context.Context, userID, billID uuid.UUID, amount model.Money) (*response.Bill, error)
+	StartBill(ctx context.Context, userID, householdID, storeID uuid.UUID) (*response.Bill, error)
+	GetBillsByUserID(ctx context.Context, userID uuid.UUID, filter *model.BillFilter) (*model.BillPage, error)
 	GetLastBill(ctx context.Context, userID uuid.UUID) (*response.Bill, error)
+	GetOpenBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error)
+	GetOpenBills(ctx context.Context, userID uuid.UUID) ([]*response.Bill, error)
+	GetDiscrepancies(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
 	CancelBill(ctx context.Context, userID, billID uuid.UUID) error
+	ReopenBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error)
+	ArchiveBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error)
+	GetBillHistory(ctx context.Context, userID, billID uuid.UUID) ([]*model.BillEvent, error)
 }
 
 type Bill struct {
@@ -31,18 +37,18 @@ func NewBill(bu BillUseCase) *Bill {
 func (b *Bill) Start(c *gin.Context) {
 	var sb request.StartBill
 	if err := c.ShouldBindJSON(&sb); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
-	bill, err := b.BillUseCase.StartBill(c.Request.Context(), uuid.MustParse(id.(string)), sb.StoreID)
+	bill, err := b.BillUseCase.StartBill(c.Request.Context(), uuid.MustParse(id.(string)), sb.HouseholdID, sb.StoreID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -52,69 +58,173 @@ func (b *Bill) Start(c *gin.Context) {
 func (b *Bill) Close(c *gin.Context) {
 	var sb request.CloseBill
 	if err := c.ShouldBindJSON(&sb); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
-	if err := b.BillUseCase.CloseBill(c.Request.Context(), uuid.MustParse(id.(string)), sb.BillID, sb.Amount); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	bill, err := b.BillUseCase.CloseBill(c.Request.Context(), uuid.MustParse(id.(string)), sb.BillID, *sb.Amount)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "bill close"})
+	c.JSON(http.StatusOK, gin.H{"message": "bill close", "data": bill})
 }
 
 func (b *Bill) Cancel(c *gin.Context) {
 	var sb request.CancelBill
 	if err := c.ShouldBindJSON(&sb); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
 	if err := b.BillUseCase.CancelBill(c.Request.Context(), uuid.MustParse(id.(string)), sb.BillID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "bill canceled"})
 }
 
+func (b *Bill) Reopen(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	bill, err := b.BillUseCase.ReopenBill(c.Request.Context(), uuid.MustParse(id.(string)), billID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill reopened", "data": bill})
+}
+
+func (b *Bill) Archive(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	bill, err := b.BillUseCase.ArchiveBill(c.Request.Context(), uuid.MustParse(id.(string)), billID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill archived", "data": bill})
+}
+
+func (b *Bill) GetHistory(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	events, err := b.BillUseCase.GetBillHistory(c.Request.Context(), uuid.MustParse(id.(string)), billID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill history", "data": response.NewBillEventsFromModels(events)})
+}
+
 func (b *Bill) GetBillsByUserID(c *gin.Context) {
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	var sb request.SearchBills
+	if err := c.ShouldBindQuery(&sb); err != nil {
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
-	bills, err := b.BillUseCase.GetBillsByUserID(c.Request.Context(), uuid.MustParse(id.(string)))
+	filter, err := newBillFilterFromRequest(&sb)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
-	billResponse := response.NewBillsFromModels(bills)
+	page, err := b.BillUseCase.GetBillsByUserID(c.Request.Context(), uuid.MustParse(id.(string)), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	billResponse := response.NewBillsFromModels(page.Bills)
+
+	c.JSON(http.StatusOK, gin.H{"message": "get bills", "data": billResponse, "next_cursor": page.NextCursor})
+}
+
+func newBillFilterFromRequest(r *request.SearchBills) (*model.BillFilter, error) {
+	filter := &model.BillFilter{
+		State: r.State,
+		From:  r.From,
+		To:    r.To,
+		Sort:  r.Sort,
+		Order: r.Order,
+		Limit: r.Limit,
+	}
+	if r.HouseholdID != "" {
+		filter.HouseholdID = uuid.MustParse(r.HouseholdID)
+	}
+	if r.StoreID != "" {
+		filter.StoreID = uuid.MustParse(r.StoreID)
+	}
+	if r.CompanyID != "" {
+		filter.CompanyID = uuid.MustParse(r.CompanyID)
+	}
+	if r.Cursor != "" {
+		cursor, err := model.DecodeBillCursor(r.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = cursor
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "get bills", "data": billResponse})
+	return filter, nil
 }
 
 func (b *Bill) GetLastBill(c *gin.Context) {
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 	bill, err := b.BillUseCase.GetLastBill(c.Request.Context(), uuid.MustParse(id.(string)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -125,3 +235,53 @@ func (b *Bill) GetLastBill(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "get last bill", "data": bill})
 }
+
+func (b *Bill) GetOpenBill(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	bill, err := b.BillUseCase.GetOpenBill(c.Request.Context(), uuid.MustParse(id.(string)), billID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "open bill", "data": bill})
+}
+
+func (b *Bill) GetOpenBills(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	bills, err := b.BillUseCase.GetOpenBills(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "open bills", "data": bills})
+}
+
+func (b *Bill) GetDiscrepancies(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	bills, err := b.BillUseCase.GetDiscrepancies(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill discrepancies", "data": response.NewBillsFromModels(bills)})
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type BillSplitUseCase interface {
+	SplitBill(ctx context.Context, userID, billID uuid.UUID, allocations []*model.SplitAllocation) (*model.BillSplit, error)
+	GetSplit(ctx context.Context, userID, billID uuid.UUID) (*model.BillSplit, error)
+	GetBalances(ctx context.Context, userID uuid.UUID) ([]*model.Balance, error)
+	Settle(ctx context.Context, userID, toUserID uuid.UUID, amount model.Money) (*model.Settlement, error)
+}
+
+type BillSplit struct {
+	BillSplitUseCase BillSplitUseCase
+}
+
+func NewBillSplit(bsu BillSplitUseCase) *BillSplit {
+	return &BillSplit{
+		BillSplitUseCase: bsu,
+	}
+}
+
+func (bs *BillSplit) SplitBill(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	var sb request.SplitBill
+	if err = c.ShouldBindJSON(&sb); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	allocations := make([]*model.SplitAllocation, 0, len(sb.Allocations))
+	for _, a := range sb.Allocations {
+		if (a.UserID == uuid.Nil) == (a.HouseholdID == uuid.Nil) {
+			c.Error(invalidRequest("user_id or household_id needed"))
+			return
+		}
+		allocation := &model.SplitAllocation{
+			UserProductID:   a.UserProductID,
+			ParticipantType: model.SplitParticipantUser,
+			ParticipantID:   a.UserID,
+			Method:          a.Method,
+			Value:           a.Value,
+		}
+		if a.HouseholdID != uuid.Nil {
+			allocation.ParticipantType = model.SplitParticipantHousehold
+			allocation.ParticipantID = a.HouseholdID
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	split, err := bs.BillSplitUseCase.SplitBill(c.Request.Context(), uuid.MustParse(id.(string)), billID, allocations)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill split", "data": response.NewBillSplitFromModel(split)})
+}
+
+func (bs *BillSplit) GetSplit(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	split, err := bs.BillSplitUseCase.GetSplit(c.Request.Context(), uuid.MustParse(id.(string)), billID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "bill split", "data": response.NewBillSplitFromModel(split)})
+}
+
+func (bs *BillSplit) GetBalances(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	balances, err := bs.BillSplitUseCase.GetBalances(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "balances", "data": response.NewBalancesFromModels(balances)})
+}
+
+func (bs *BillSplit) Settle(c *gin.Context) {
+	var s request.Settle
+	if err := c.ShouldBindJSON(&s); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	settlement, err := bs.BillSplitUseCase.Settle(c.Request.Context(), uuid.MustParse(id.(string)), s.UserID, *s.Amount)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "settlement recorded", "data": response.NewSettlementFromModel(settlement)})
+}
@@ -0,0 +1,42 @@
+package handler_test
+
+import (
+	"github.com/google/uuid"
+)
+
+func (s *HandlerTestSuite) TestBillSplit() {
+	s.Run("split an unknown bill", func() {
+		token := s.createUserAndGenerateToken("alice", "password", "alice@test.com")
+		path := "/bill/" + uuid.New().String() + "/split"
+
+		s.Equal(404, s.requestWithToken("GET", path, token, nil).Code)
+		body := []byte(`{"allocations":[{"user_product_id":"` + uuid.New().String() + `","user_id":"` + uuid.New().String() + `","method":"share","value":1}]}`)
+		s.Equal(404, s.requestWithToken("PUT", path, token, body).Code)
+
+		// a line goes to a user or to a household, not both
+		body = []byte(`{"allocations":[{"user_product_id":"` + uuid.New().String() + `","method":"share","value":1}]}`)
+		w := s.requestWithToken("PUT", path, token, body)
+		s.assertProblem(w, 400, "invalid_request")
+	})
+
+	s.Run("balances and settlements", func() {
+		alice := s.createUserAndGenerateToken("carol", "password", "carol@test.com")
+
+		w := s.requestWithToken("GET", "/balance", alice, nil)
+		s.Require().Equal(200, w.Code)
+		s.Equal(`{"data":[],"message":"balances"}`, w.Body.String())
+
+		w = s.requestWithToken("POST", "/balance/settle", alice, []byte(`{"user_id":"`+uuid.New().String()+`","amount":"0"}`))
+		s.assertProblem(w, 422, "invalid_settlement")
+
+		// carol owes nothing to dave, there is no debt to settle
+		s.createUser("dave", "password", "dave@test.com")
+		dave, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "dave")
+		s.Require().NoError(err)
+		w = s.requestWithToken("POST", "/balance/settle", alice, []byte(`{"user_id":"`+dave.ID.String()+`","amount":"10"}`))
+		s.assertProblem(w, 422, "invalid_settlement")
+		w = s.requestWithToken("GET", "/balance", s.login("dave", "password"), nil)
+		s.Require().Equal(200, w.Code)
+		s.Equal(`{"data":[],"message":"balances"}`, w.Body.String())
+	})
+}
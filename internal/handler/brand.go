@@ -27,12 +27,12 @@ func NewBrand(bu BrandUseCase) *Brand {
 func (b *Brand) Create(c *gin.Context) {
 	var br request.CreateBrand
 	if err := c.ShouldBindJSON(&br); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	bm, err := b.BrandUseCase.Create(c.Request.Context(), br.BrandName)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -43,7 +43,7 @@ func (b *Brand) GetByPartialName(c *gin.Context) {
 	name := c.Param("name")
 	bm, err := b.BrandUseCase.SelectByPartialName(c.Request.Context(), name)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -26,7 +26,7 @@ func (co *Company) GetByPartialName(c *gin.Context) {
 	name := c.Param("name")
 	cm, err := co.CompanyUseCase.SelectByPartialName(c.Request.Context(), name)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,11 @@
+package handler
+
+import (
+	"fmt"
+	"shop-aggregator/internal/model"
+)
+
+// invalidRequest is a body, query or path parameter the handler can not read, reason tells which.
+func invalidRequest(reason string) error {
+	return fmt.Errorf("%w: %s", model.ErrInvalidRequestError, reason)
+}
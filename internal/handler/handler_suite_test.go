@@ -12,13 +12,22 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
 	"shop-aggregator/internal/config"
 	"shop-aggregator/internal/db/postgresql"
 	"shop-aggregator/internal/handler"
+	"shop-aggregator/internal/mailer"
 	"shop-aggregator/internal/model/request"
 	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/ocr"
+	"shop-aggregator/internal/oidc"
+	"shop-aggregator/internal/oidc/oidctest"
+	"shop-aggregator/internal/problem"
+	"shop-aggregator/internal/receipt/retailer"
 	"shop-aggregator/internal/router"
 	"shop-aggregator/internal/usecase"
+	"shop-aggregator/internal/utils"
 	"shop-aggregator/tools/migrations"
 	"strconv"
 	"strings"
@@ -26,25 +35,49 @@ import (
 )
 
 type HandlerRepositories struct {
-	Users       *postgresql.User
-	Auth        *postgresql.Auth
-	Company     *postgresql.Company
-	Brand       *postgresql.Brand
-	Store       *postgresql.Store
-	Bill        *postgresql.Bill
-	UserProduct *postgresql.UserProduct
-	Product     *postgresql.Product
+	Users        *postgresql.User
+	Auth         *postgresql.Auth
+	UserToken    *postgresql.UserToken
+	Company      *postgresql.Company
+	Brand        *postgresql.Brand
+	Store        *postgresql.Store
+	Bill         *postgresql.Bill
+	BillEvent    *postgresql.BillEvent
+	UserProduct  *postgresql.UserProduct
+	Product      *postgresql.Product
+	Price        *postgresql.Price
+	PriceHistory *postgresql.PriceHistory
+	Admin        *postgresql.Admin
+	LoginAttempt *postgresql.LoginAttempt
+	OIDC         *postgresql.OIDC
+	APIKey       *postgresql.APIKey
+	TwoFactor    *postgresql.TwoFactor
+	Household    *postgresql.Household
+	BillSplit    *postgresql.BillSplit
+	Account      *postgresql.Account
 }
 
 type HandlerUseCases struct {
-	AuthUseCase        handler.AuthUsecase
-	UserUseCase        handler.UserUsecase
-	BrandUseCase       handler.BrandUseCase
-	CompanyUseCase     handler.CompanyUseCase
-	BillUseCase        handler.BillUseCase
-	StoreUseCase       handler.StoreUseCase
-	ProductUseCase     handler.ProductUseCase
-	ProductUserProduct handler.UserProductUseCase
+	AuthUseCase          handler.AuthUsecase
+	UserUseCase          handler.UserUsecase
+	BrandUseCase         handler.BrandUseCase
+	CompanyUseCase       handler.CompanyUseCase
+	BillUseCase          handler.BillUseCase
+	StoreUseCase         handler.StoreUseCase
+	ProductUseCase       handler.ProductUseCase
+	ProductUserProduct   handler.UserProductUseCase
+	PriceUseCase         handler.PriceUseCase
+	PriceHistoryUseCase  handler.PriceHistoryUseCase
+	ShoppingListUseCase  handler.ShoppingListUseCase
+	ReceiptUseCase       handler.ReceiptUseCase
+	ReceiptImportUseCase handler.ReceiptImportUseCase
+	AdminUseCase         handler.AdminUseCase
+	OIDCUseCase          handler.OIDCUseCase
+	APIKeyUseCase        handler.APIKeyUseCase
+	TwoFactorUseCase     handler.TwoFactorUseCase
+	HouseholdUseCase     handler.HouseholdUseCase
+	BillSplitUseCase     handler.BillSplitUseCase
+	AccountUseCase       handler.AccountUseCase
 }
 
 type Handlers struct {
@@ -57,6 +90,17 @@ type Handlers struct {
 	Product        *handler.Product
 	UserProduct    *handler.UserProduct
 	Initialisation *handler.Initialisation
+	Price          *handler.Price
+	PriceHistory   *handler.PriceHistory
+	ShoppingList   *handler.ShoppingList
+	Receipt        *handler.Receipt
+	Admin          *handler.Admin
+	OIDC           *handler.OIDC
+	APIKey         *handler.APIKey
+	TwoFactor      *handler.TwoFactor
+	Household      *handler.Household
+	BillSplit      *handler.BillSplit
+	Account        *handler.Account
 }
 
 type HandlerTestSuite struct {
@@ -67,6 +111,9 @@ type HandlerTestSuite struct {
 	HandlerRepositories HandlerRepositories
 	HandlerUseCases     HandlerUseCases
 	Handlers            Handlers
+	TokenHasher         *utils.TokenHasher
+	Mails               *bytes.Buffer
+	Issuer              *oidctest.Issuer
 	router              *gin.Engine
 }
 
@@ -121,23 +168,61 @@ func (s *HandlerTestSuite) SetupSuite() {
 
 	// load db requests
 	s.HandlerRepositories.Users = postgresql.NewUsers(s.DB)
-	s.HandlerRepositories.Auth = postgresql.NewAuth(s.DB)
+	s.TokenHasher = utils.NewTokenHasher("secret")
+	s.HandlerRepositories.Auth = postgresql.NewAuth(s.DB, s.TokenHasher)
+	s.HandlerRepositories.UserToken = postgresql.NewUserToken(s.DB, s.TokenHasher)
 	s.HandlerRepositories.Company = postgresql.NewCompany(s.DB)
 	s.HandlerRepositories.Brand = postgresql.NewBrand(s.DB)
 	s.HandlerRepositories.Store = postgresql.NewStore(s.DB)
 	s.HandlerRepositories.Bill = postgresql.NewBill(s.DB)
+	s.HandlerRepositories.BillEvent = postgresql.NewBillEvent(s.DB)
 	s.HandlerRepositories.UserProduct = postgresql.NewUserProduct(s.DB)
 	s.HandlerRepositories.Product = postgresql.NewProduct(s.DB)
+	s.HandlerRepositories.Price = postgresql.NewPrice(s.DB)
+	s.HandlerRepositories.PriceHistory = postgresql.NewPriceHistory(s.DB)
+	s.HandlerRepositories.Admin = postgresql.NewAdmin(s.DB)
+	s.HandlerRepositories.LoginAttempt = postgresql.NewLoginAttempt(s.DB)
+	s.HandlerRepositories.OIDC = postgresql.NewOIDC(s.DB, s.TokenHasher)
+	s.HandlerRepositories.APIKey = postgresql.NewAPIKey(s.DB, s.TokenHasher)
+	s.HandlerRepositories.TwoFactor = postgresql.NewTwoFactor(s.DB, s.TokenHasher, utils.NewSecretCipher("secret"))
+	s.HandlerRepositories.Household = postgresql.NewHousehold(s.DB)
+	s.HandlerRepositories.BillSplit = postgresql.NewBillSplit(s.DB)
+	s.HandlerRepositories.Account = postgresql.NewAccount(s.DB)
 
 	// load usecases
-	s.HandlerUseCases.AuthUseCase = usecase.NewAuth(s.HandlerRepositories.Auth, s.HandlerRepositories.Users)
-	s.HandlerUseCases.UserUseCase = usecase.NewUsers(s.HandlerRepositories.Users)
+	passwordHasher := utils.NewArgon2idHasher(64, 1, 1)
+	authorizer := usecase.NewAuthorizer(s.HandlerRepositories.Bill, s.HandlerRepositories.UserProduct, s.HandlerRepositories.Household)
+	authUseCase := usecase.NewAuth(s.HandlerRepositories.Auth, s.HandlerRepositories.Users, s.HandlerRepositories.LoginAttempt, s.HandlerRepositories.TwoFactor, passwordHasher, &config.AuthConfig{})
+	s.HandlerUseCases.AuthUseCase = authUseCase
+	s.Issuer = oidctest.NewIssuer("shop-aggregator", "secret")
+	oidcConfig := &config.OIDCConfig{
+		Issuer:       s.Issuer.URL,
+		ClientID:     s.Issuer.ClientID,
+		ClientSecret: s.Issuer.ClientSecret,
+		RedirectURL:  "http://localhost/oidc/callback",
+		AutoCreate:   true,
+	}
+	s.HandlerUseCases.OIDCUseCase = usecase.NewOIDC(oidc.NewProvider(oidcConfig, nil), s.HandlerRepositories.OIDC, s.HandlerRepositories.Users, authUseCase, oidcConfig)
+	s.HandlerUseCases.APIKeyUseCase = usecase.NewAPIKey(s.HandlerRepositories.APIKey)
+	s.HandlerUseCases.TwoFactorUseCase = usecase.NewTwoFactor(s.HandlerRepositories.TwoFactor, s.HandlerRepositories.Users, passwordHasher, &config.AuthConfig{})
+	s.HandlerUseCases.HouseholdUseCase = usecase.NewHousehold(s.HandlerRepositories.Household, s.HandlerRepositories.Users, authorizer)
+	s.HandlerUseCases.AccountUseCase = usecase.NewAccount(s.HandlerRepositories.Account, s.HandlerRepositories.Users, s.HandlerRepositories.Auth, s.HandlerRepositories.UserProduct, &config.AuthConfig{})
+	s.HandlerUseCases.BillSplitUseCase = usecase.NewBillSplit(s.HandlerRepositories.BillSplit, s.HandlerRepositories.UserProduct, s.HandlerRepositories.Household, s.HandlerRepositories.Users, authorizer)
+	s.Mails = &bytes.Buffer{}
+	s.HandlerUseCases.UserUseCase = usecase.NewUsers(s.HandlerRepositories.Users, s.HandlerRepositories.UserToken, mailer.NewLog(s.Mails, "no-reply@test.com"), passwordHasher, &config.AuthConfig{LinkBaseURL: "http://localhost"})
 	s.HandlerUseCases.BrandUseCase = usecase.NewBrand(s.HandlerRepositories.Brand)
 	s.HandlerUseCases.CompanyUseCase = usecase.NewCompany(s.HandlerRepositories.Company)
-	s.HandlerUseCases.BillUseCase = usecase.NewBill(s.HandlerRepositories.Bill, s.HandlerRepositories.Store, s.HandlerRepositories.Company, s.HandlerRepositories.UserProduct)
+	useCaseBill := usecase.NewBill(s.HandlerRepositories.Bill, s.HandlerRepositories.Store, s.HandlerRepositories.Company, s.HandlerRepositories.UserProduct, s.HandlerRepositories.PriceHistory, s.HandlerRepositories.BillEvent, authorizer)
+	s.HandlerUseCases.BillUseCase = useCaseBill
 	s.HandlerUseCases.StoreUseCase = usecase.NewStore(s.HandlerRepositories.Store, s.HandlerRepositories.Company)
 	s.HandlerUseCases.ProductUseCase = usecase.NewProduct(s.HandlerRepositories.Product, s.HandlerRepositories.Brand)
-	s.HandlerUseCases.ProductUserProduct = usecase.NewUserProduct(s.HandlerRepositories.UserProduct)
+	s.HandlerUseCases.ProductUserProduct = usecase.NewUserProduct(s.HandlerRepositories.UserProduct, authorizer)
+	s.HandlerUseCases.PriceUseCase = usecase.NewPrice(s.HandlerRepositories.Price, s.HandlerRepositories.UserProduct, s.HandlerRepositories.Product)
+	s.HandlerUseCases.PriceHistoryUseCase = usecase.NewPriceHistory(s.HandlerRepositories.PriceHistory)
+	s.HandlerUseCases.ShoppingListUseCase = usecase.NewShoppingList(s.HandlerRepositories.Price, s.HandlerRepositories.Product)
+	s.HandlerUseCases.ReceiptUseCase = usecase.NewReceipt(ocr.NewTesseract(&config.OCRConfig{}), authorizer, s.HandlerRepositories.Store, s.HandlerRepositories.UserProduct)
+	s.HandlerUseCases.ReceiptImportUseCase = usecase.NewReceiptImport(retailer.NewRegistry(), s.HandlerRepositories.Store, s.HandlerRepositories.Company, s.HandlerRepositories.UserProduct, useCaseBill, s.HandlerUseCases.ProductUserProduct)
+	s.HandlerUseCases.AdminUseCase = usecase.NewAdmin(s.HandlerRepositories.Users, s.HandlerRepositories.Brand, s.HandlerRepositories.Company, s.HandlerRepositories.Store, s.HandlerRepositories.Product, s.HandlerRepositories.Admin, s.HandlerRepositories.PriceHistory, s.HandlerRepositories.LoginAttempt)
 
 	// load handlers
 	s.Handlers.User = handler.NewUser(s.HandlerUseCases.UserUseCase)
@@ -149,11 +234,25 @@ func (s *HandlerTestSuite) SetupSuite() {
 	s.Handlers.Product = handler.NewProduct(s.HandlerUseCases.ProductUseCase)
 	s.Handlers.UserProduct = handler.NewUserProduct(s.HandlerUseCases.ProductUserProduct)
 	s.Handlers.Initialisation = handler.NewInitialisation()
+	s.Handlers.Price = handler.NewPrice(s.HandlerUseCases.PriceUseCase)
+	s.Handlers.PriceHistory = handler.NewPriceHistory(s.HandlerUseCases.PriceHistoryUseCase)
+	s.Handlers.ShoppingList = handler.NewShoppingList(s.HandlerUseCases.ShoppingListUseCase)
+	s.Handlers.Receipt = handler.NewReceipt(s.HandlerUseCases.ReceiptUseCase, s.HandlerUseCases.ReceiptImportUseCase)
+	s.Handlers.Admin = handler.NewAdmin(s.HandlerUseCases.AdminUseCase)
+	s.Handlers.OIDC = handler.NewOIDC(s.HandlerUseCases.OIDCUseCase)
+	s.Handlers.APIKey = handler.NewAPIKey(s.HandlerUseCases.APIKeyUseCase)
+	s.Handlers.TwoFactor = handler.NewTwoFactor(s.HandlerUseCases.TwoFactorUseCase)
+	s.Handlers.Household = handler.NewHousehold(s.HandlerUseCases.HouseholdUseCase)
+	s.Handlers.BillSplit = handler.NewBillSplit(s.HandlerUseCases.BillSplitUseCase)
+	s.Handlers.Account = handler.NewAccount(s.HandlerUseCases.AccountUseCase)
 
 	s.router = gin.New()
+	// no proxy is trusted, as in the default server config
+	s.Require().NoError(s.router.SetTrustedProxies(nil))
 	s.router = router.NewRouter(
 		s.router,
 		s.HandlerRepositories.Auth,
+		s.HandlerRepositories.APIKey,
 		s.Handlers.Auth,
 		s.Handlers.User,
 		s.Handlers.Brand,
@@ -163,10 +262,22 @@ func (s *HandlerTestSuite) SetupSuite() {
 		s.Handlers.Product,
 		s.Handlers.UserProduct,
 		s.Handlers.Initialisation,
+		s.Handlers.Price,
+		s.Handlers.PriceHistory,
+		s.Handlers.ShoppingList,
+		s.Handlers.Receipt,
+		s.Handlers.Admin,
+		s.Handlers.OIDC,
+		s.Handlers.APIKey,
+		s.Handlers.TwoFactor,
+		s.Handlers.Household,
+		s.Handlers.BillSplit,
+		s.Handlers.Account,
 	)
 }
 
 func (s *HandlerTestSuite) TearDownSuite() {
+	s.Issuer.Close()
 	s.DB.Close()
 	err := s.Container.Terminate(s.ctx)
 	s.Require().NoError(err)
@@ -183,7 +294,21 @@ func (s *HandlerTestSuite) TearDownTest() {
 	s.Require().NoError(err)
 	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE users")
 	s.Require().NoError(err)
-	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE auth")
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE session, refresh_token")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_token")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE login_attempt, login_throttle")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_identity, oidc_state")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE api_key")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE user_totp, recovery_code, login_challenge")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE household, household_member, household_invitation")
+	s.Require().NoError(err)
+	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE bill_split, bill_split_line, bill_split_share, settlement")
 	s.Require().NoError(err)
 	_, err = s.DB.Exec(s.ctx, "TRUNCATE TABLE brand")
 	s.Require().NoError(err)
@@ -209,6 +334,16 @@ func (s *HandlerTestSuite) requestWithToken(requestType, path, token string, bod
 	return w
 }
 
+// assertProblem checks that w answers the problem details of the error of code.
+func (s *HandlerTestSuite) assertProblem(w *httptest.ResponseRecorder, status int, code string) {
+	s.Equal(status, w.Code)
+	s.Equal(problem.ContentType, w.Header().Get("Content-Type"))
+	var details problem.Details
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &details))
+	s.Equal(status, details.Status)
+	s.Equal(code, details.Code)
+}
+
 func (s *HandlerTestSuite) createUserAndGenerateToken(login, password, email string) string {
 	// create user
 	s.createUser(login, password, email)
@@ -256,6 +391,23 @@ func (s *HandlerTestSuite) logout(token string) {
 	s.Equal(200, wLogout.Code)
 }
 
+// mailToken returns the token of the last link mailed to the address.
+func (s *HandlerTestSuite) mailToken(to string) string {
+	mails := strings.Split(s.Mails.String(), "----\n")
+	for i := len(mails) - 1; i >= 0; i-- {
+		if !strings.Contains(mails[i], "To: "+to+"\r\n") {
+			continue
+		}
+		match := regexp.MustCompile(`token=(\S+)`).FindStringSubmatch(mails[i])
+		s.Require().Len(match, 2)
+		token, err := url.QueryUnescape(match[1])
+		s.Require().NoError(err)
+		return token
+	}
+	s.FailNow("no mail sent to " + to)
+	return ""
+}
+
 func TestUserProductTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
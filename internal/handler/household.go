@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+	"time"
+)
+
+type HouseholdUseCase interface {
+	Create(ctx context.Context, userID uuid.UUID, name string) (*model.Household, error)
+	GetHouseholds(ctx context.Context, userID uuid.UUID) ([]*model.Household, error)
+	GetMembers(ctx context.Context, userID, householdID uuid.UUID) ([]*model.HouseholdMember, error)
+	Invite(ctx context.Context, userID, householdID uuid.UUID, login, role string) (*model.HouseholdInvitation, error)
+	GetInvitations(ctx context.Context, userID uuid.UUID) ([]*model.HouseholdInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*model.HouseholdMember, error)
+	DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+	SetMemberRole(ctx context.Context, userID, householdID, memberID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, userID, householdID, memberID uuid.UUID) error
+	GetSpending(ctx context.Context, userID, householdID uuid.UUID, from, to time.Time) ([]*model.HouseholdSpending, error)
+}
+
+type Household struct {
+	HouseholdUseCase HouseholdUseCase
+}
+
+func NewHousehold(hu HouseholdUseCase) *Household {
+	return &Household{
+		HouseholdUseCase: hu,
+	}
+}
+
+func (h *Household) Create(c *gin.Context) {
+	var ch request.CreateHousehold
+	if err := c.ShouldBindJSON(&ch); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	household, err := h.HouseholdUseCase.Create(c.Request.Context(), uuid.MustParse(id.(string)), ch.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household created", "data": response.NewHouseholdFromModel(household)})
+}
+
+func (h *Household) GetHouseholds(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	households, err := h.HouseholdUseCase.GetHouseholds(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "households", "data": response.NewHouseholdsFromModels(households)})
+}
+
+func (h *Household) GetMembers(c *gin.Context) {
+	householdID, err := uuid.Parse(c.Param("household_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid household id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	members, err := h.HouseholdUseCase.GetMembers(c.Request.Context(), uuid.MustParse(id.(string)), householdID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household members", "data": response.NewHouseholdMembersFromModels(members)})
+}
+
+func (h *Household) Invite(c *gin.Context) {
+	householdID, err := uuid.Parse(c.Param("household_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid household id"))
+		return
+	}
+	var ihm request.InviteHouseholdMember
+	if err = c.ShouldBindJSON(&ihm); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	invitation, err := h.HouseholdUseCase.Invite(c.Request.Context(), uuid.MustParse(id.(string)), householdID, ihm.Login, ihm.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invitation sent", "data": response.NewHouseholdInvitationFromModel(invitation)})
+}
+
+func (h *Household) GetInvitations(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	invitations, err := h.HouseholdUseCase.GetInvitations(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household invitations", "data": response.NewHouseholdInvitationsFromModels(invitations)})
+}
+
+func (h *Household) AcceptInvitation(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid invitation id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	member, err := h.HouseholdUseCase.AcceptInvitation(c.Request.Context(), uuid.MustParse(id.(string)), invitationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invitation accepted", "data": gin.H{"household_id": member.HouseholdID, "role": member.Role}})
+}
+
+func (h *Household) DeclineInvitation(c *gin.Context) {
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid invitation id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err = h.HouseholdUseCase.DeclineInvitation(c.Request.Context(), uuid.MustParse(id.(string)), invitationID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "invitation declined"})
+}
+
+func (h *Household) SetMemberRole(c *gin.Context) {
+	householdID, memberID, ok := householdMemberParams(c)
+	if !ok {
+		return
+	}
+	var shr request.SetHouseholdRole
+	if err := c.ShouldBindJSON(&shr); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := h.HouseholdUseCase.SetMemberRole(c.Request.Context(), uuid.MustParse(id.(string)), householdID, memberID, shr.Role); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household role updated"})
+}
+
+func (h *Household) RemoveMember(c *gin.Context) {
+	householdID, memberID, ok := householdMemberParams(c)
+	if !ok {
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := h.HouseholdUseCase.RemoveMember(c.Request.Context(), uuid.MustParse(id.(string)), householdID, memberID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household member removed"})
+}
+
+func (h *Household) GetSpending(c *gin.Context) {
+	householdID, err := uuid.Parse(c.Param("household_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid household id"))
+		return
+	}
+	var shs request.SearchHouseholdSpending
+	if err = c.ShouldBindQuery(&shs); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	spending, err := h.HouseholdUseCase.GetSpending(c.Request.Context(), uuid.MustParse(id.(string)), householdID, shs.From, shs.To)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "household spending", "data": response.NewHouseholdSpendingFromModels(spending)})
+}
+
+// householdMemberParams reads the household and the member of the path, it answers the request when one
+// of them is invalid.
+func householdMemberParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	householdID, err := uuid.Parse(c.Param("household_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid household id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid user id"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return householdID, memberID, true
+}
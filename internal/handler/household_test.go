@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"shop-aggregator/internal/model/response"
+)
+
+func (s *HandlerTestSuite) TestHousehold() {
+	s.Run("invite, accept and leave", func() {
+		alice := s.createUserAndGenerateToken("alice", "password", "alice@test.com")
+		bob := s.createUserAndGenerateToken("bob", "password", "bob@test.com")
+
+		wCreate := s.requestWithToken("POST", "/household", alice, []byte(`{"name":"home"}`))
+		s.Require().Equal(200, wCreate.Code)
+		var created struct {
+			Data response.Household `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wCreate.Body.Bytes(), &created))
+		s.Equal("owner", created.Data.Role)
+		householdPath := "/household/" + created.Data.HouseholdID.String()
+
+		// the household is not found by the others
+		s.Equal(404, s.requestWithToken("GET", householdPath+"/members", bob, nil).Code)
+		s.Equal(404, s.requestWithToken("GET", householdPath+"/spending", bob, nil).Code)
+
+		s.Require().Equal(200, s.requestWithToken("POST", householdPath+"/invitations", alice, []byte(`{"login":"bob"}`)).Code)
+
+		wInvitations := s.requestWithToken("GET", "/household/invitations", bob, nil)
+		s.Require().Equal(200, wInvitations.Code)
+		var invitations struct {
+			Data []response.HouseholdInvitation `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wInvitations.Body.Bytes(), &invitations))
+		s.Require().Len(invitations.Data, 1)
+		s.Equal("home", invitations.Data[0].HouseholdName)
+
+		invitationPath := "/household/invitations/" + invitations.Data[0].HouseholdInvitationID.String()
+		s.Equal(404, s.requestWithToken("POST", invitationPath+"/accept", alice, nil).Code)
+		s.Require().Equal(200, s.requestWithToken("POST", invitationPath+"/accept", bob, nil).Code)
+
+		wMembers := s.requestWithToken("GET", householdPath+"/members", bob, nil)
+		s.Require().Equal(200, wMembers.Code)
+		var members struct {
+			Data []response.HouseholdMember `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wMembers.Body.Bytes(), &members))
+		s.Require().Len(members.Data, 2)
+		aliceID := members.Data[0].UserID.String()
+		bobID := members.Data[1].UserID.String()
+
+		wSpending := s.requestWithToken("GET", householdPath+"/spending", bob, nil)
+		s.Require().Equal(200, wSpending.Code)
+		s.Equal(`{"data":{"totals":[],"members":[]},"message":"household spending"}`, wSpending.Body.String())
+
+		// only an owner manages the members, the last one stays
+		s.Equal(403, s.requestWithToken("DELETE", householdPath+"/members/"+aliceID, bob, nil).Code)
+		wLastOwner := s.requestWithToken("DELETE", householdPath+"/members/"+aliceID, alice, nil)
+		s.assertProblem(wLastOwner, 409, "household_last_owner")
+
+		s.Equal(200, s.requestWithToken("DELETE", householdPath+"/members/"+bobID, bob, nil).Code)
+		s.Equal(404, s.requestWithToken("GET", householdPath+"/members", bob, nil).Code)
+	})
+
+	s.Run("invite an unknown role", func() {
+		token := s.createUserAndGenerateToken("carol", "password", "carol@test.com")
+		wCreate := s.requestWithToken("POST", "/household", token, []byte(`{"name":"flat"}`))
+		s.Require().Equal(200, wCreate.Code)
+		var created struct {
+			Data response.Household `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wCreate.Body.Bytes(), &created))
+
+		w := s.requestWithToken("POST", "/household/"+created.Data.HouseholdID.String()+"/invitations", token, []byte(`{"login":"carol","role":"admin"}`))
+		s.Equal(400, w.Code)
+	})
+}
@@ -2,16 +2,12 @@ package handler_test
 
 import (
 	context "context"
-	mock "github.com/stretchr/testify/mock"
 	uuid "github.com/google/uuid"
-	context "context"
 	mock "github.com/stretchr/testify/mock"
 	model "shop-aggregator/internal/model"
-	uuid "github.com/google/uuid"
+	response "shop-aggregator/internal/model/response"
 )
 
-
-
 // AuthUsecase is an autogenerated mock type for the AuthUsecase type
 type AuthUsecase struct {
 	mock.Mock
@@ -25,27 +21,29 @@ func (_m *AuthUsecase) EXPECT() *AuthUsecase_Expecter {
 	return &AuthUsecase_Expecter{mock: &_m.Mock}
 }
 
-// Login provides a mock function with given fields: _a0, _a1, _a2
-func (_m *AuthUsecase) Login(_a0 context.Context, _a1 string, _a2 string) (string, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// GetSessions provides a mock function with given fields: _a0, _a1
+func (_m *AuthUsecase) GetSessions(_a0 context.Context, _a1 uuid.UUID) ([]*model.Session, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for GetSessions")
 	}
 
-	var r0 string
+	var r0 []*model.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Session, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Session); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,6 +51,74 @@ func (_m *AuthUsecase) Login(_a0 context.Context, _a1 string, _a2 string) (strin
 	return r0, r1
 }
 
+// AuthUsecase_GetSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessions'
+type AuthUsecase_GetSessions_Call struct {
+	*mock.Call
+}
+
+// GetSessions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uuid.UUID
+func (_e *AuthUsecase_Expecter) GetSessions(_a0 interface{}, _a1 interface{}) *AuthUsecase_GetSessions_Call {
+	return &AuthUsecase_GetSessions_Call{Call: _e.mock.On("GetSessions", _a0, _a1)}
+}
+
+func (_c *AuthUsecase_GetSessions_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID)) *AuthUsecase_GetSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthUsecase_GetSessions_Call) Return(_a0 []*model.Session, _a1 error) *AuthUsecase_GetSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecase_GetSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Session, error)) *AuthUsecase_GetSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *AuthUsecase) Login(_a0 context.Context, _a1 string, _a2 string, _a3 *model.Session) (*response.Login, *model.LoginChallenge, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *response.Login
+	var r1 *model.LoginChallenge
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.Session) (*response.Login, *model.LoginChallenge, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.Session) *response.Login); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Login)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.Session) *model.LoginChallenge); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.LoginChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *model.Session) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AuthUsecase_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
 type AuthUsecase_Login_Call struct {
 	*mock.Call
@@ -62,38 +128,100 @@ type AuthUsecase_Login_Call struct {
 //   - _a0 context.Context
 //   - _a1 string
 //   - _a2 string
-func (_e *AuthUsecase_Expecter) Login(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthUsecase_Login_Call {
-	return &AuthUsecase_Login_Call{Call: _e.mock.On("Login", _a0, _a1, _a2)}
+//   - _a3 *model.Session
+func (_e *AuthUsecase_Expecter) Login(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *AuthUsecase_Login_Call {
+	return &AuthUsecase_Login_Call{Call: _e.mock.On("Login", _a0, _a1, _a2, _a3)}
 }
 
-func (_c *AuthUsecase_Login_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string)) *AuthUsecase_Login_Call {
+func (_c *AuthUsecase_Login_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 *model.Session)) *AuthUsecase_Login_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*model.Session))
+	})
+	return _c
+}
+
+func (_c *AuthUsecase_Login_Call) Return(_a0 *response.Login, _a1 *model.LoginChallenge, _a2 error) *AuthUsecase_Login_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AuthUsecase_Login_Call) RunAndReturn(run func(context.Context, string, string, *model.Session) (*response.Login, *model.LoginChallenge, error)) *AuthUsecase_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginTwoFactor provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *AuthUsecase) LoginTwoFactor(_a0 context.Context, _a1 string, _a2 string, _a3 *model.Session) (*response.Login, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginTwoFactor")
+	}
+
+	var r0 *response.Login
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.Session) (*response.Login, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.Session) *response.Login); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Login)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *model.Session) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecase_LoginTwoFactor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginTwoFactor'
+type AuthUsecase_LoginTwoFactor_Call struct {
+	*mock.Call
+}
+
+// LoginTwoFactor is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 string
+//   - _a3 *model.Session
+func (_e *AuthUsecase_Expecter) LoginTwoFactor(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *AuthUsecase_LoginTwoFactor_Call {
+	return &AuthUsecase_LoginTwoFactor_Call{Call: _e.mock.On("LoginTwoFactor", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *AuthUsecase_LoginTwoFactor_Call) Run(run func(_a0 context.Context, _a1 string, _a2 string, _a3 *model.Session)) *AuthUsecase_LoginTwoFactor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*model.Session))
 	})
 	return _c
 }
 
-func (_c *AuthUsecase_Login_Call) Return(_a0 string, _a1 error) *AuthUsecase_Login_Call {
+func (_c *AuthUsecase_LoginTwoFactor_Call) Return(_a0 *response.Login, _a1 error) *AuthUsecase_LoginTwoFactor_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthUsecase_Login_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *AuthUsecase_Login_Call {
+func (_c *AuthUsecase_LoginTwoFactor_Call) RunAndReturn(run func(context.Context, string, string, *model.Session) (*response.Login, error)) *AuthUsecase_LoginTwoFactor_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Logout provides a mock function with given fields: _a0, _a1
-func (_m *AuthUsecase) Logout(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// Logout provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AuthUsecase) Logout(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Logout")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,13 +237,14 @@ type AuthUsecase_Logout_Call struct {
 // Logout is a helper method to define mock.On call
 //   - _a0 context.Context
 //   - _a1 uuid.UUID
-func (_e *AuthUsecase_Expecter) Logout(_a0 interface{}, _a1 interface{}) *AuthUsecase_Logout_Call {
-	return &AuthUsecase_Logout_Call{Call: _e.mock.On("Logout", _a0, _a1)}
+//   - _a2 uuid.UUID
+func (_e *AuthUsecase_Expecter) Logout(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthUsecase_Logout_Call {
+	return &AuthUsecase_Logout_Call{Call: _e.mock.On("Logout", _a0, _a1, _a2)}
 }
 
-func (_c *AuthUsecase_Logout_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID)) *AuthUsecase_Logout_Call {
+func (_c *AuthUsecase_Logout_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID)) *AuthUsecase_Logout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
@@ -125,7 +254,162 @@ func (_c *AuthUsecase_Logout_Call) Return(_a0 error) *AuthUsecase_Logout_Call {
 	return _c
 }
 
-func (_c *AuthUsecase_Logout_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AuthUsecase_Logout_Call {
+func (_c *AuthUsecase_Logout_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AuthUsecase_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: _a0, _a1
+func (_m *AuthUsecase) Refresh(_a0 context.Context, _a1 string) (*response.Login, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *response.Login
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Login, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Login); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Login)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUsecase_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type AuthUsecase_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *AuthUsecase_Expecter) Refresh(_a0 interface{}, _a1 interface{}) *AuthUsecase_Refresh_Call {
+	return &AuthUsecase_Refresh_Call{Call: _e.mock.On("Refresh", _a0, _a1)}
+}
+
+func (_c *AuthUsecase_Refresh_Call) Run(run func(_a0 context.Context, _a1 string)) *AuthUsecase_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthUsecase_Refresh_Call) Return(_a0 *response.Login, _a1 error) *AuthUsecase_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUsecase_Refresh_Call) RunAndReturn(run func(context.Context, string) (*response.Login, error)) *AuthUsecase_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOtherSessions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AuthUsecase) RevokeOtherSessions(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOtherSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUsecase_RevokeOtherSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOtherSessions'
+type AuthUsecase_RevokeOtherSessions_Call struct {
+	*mock.Call
+}
+
+// RevokeOtherSessions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uuid.UUID
+//   - _a2 uuid.UUID
+func (_e *AuthUsecase_Expecter) RevokeOtherSessions(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthUsecase_RevokeOtherSessions_Call {
+	return &AuthUsecase_RevokeOtherSessions_Call{Call: _e.mock.On("RevokeOtherSessions", _a0, _a1, _a2)}
+}
+
+func (_c *AuthUsecase_RevokeOtherSessions_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID)) *AuthUsecase_RevokeOtherSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthUsecase_RevokeOtherSessions_Call) Return(_a0 error) *AuthUsecase_RevokeOtherSessions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUsecase_RevokeOtherSessions_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AuthUsecase_RevokeOtherSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeSession provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AuthUsecase) RevokeSession(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUsecase_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type AuthUsecase_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uuid.UUID
+//   - _a2 uuid.UUID
+func (_e *AuthUsecase_Expecter) RevokeSession(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthUsecase_RevokeSession_Call {
+	return &AuthUsecase_RevokeSession_Call{Call: _e.mock.On("RevokeSession", _a0, _a1, _a2)}
+}
+
+func (_c *AuthUsecase_RevokeSession_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID, _a2 uuid.UUID)) *AuthUsecase_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthUsecase_RevokeSession_Call) Return(_a0 error) *AuthUsecase_RevokeSession_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUsecase_RevokeSession_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AuthUsecase_RevokeSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -143,9 +427,6 @@ func NewAuthUsecase(t interface {
 
 	return mock
 }
-// Code generated by mockery v2.42.2. DO NOT EDIT.
-
-
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
 type UserUsecase struct {
@@ -207,6 +488,53 @@ func (_c *UserUsecase_CreateOrUpdateUser_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ForgotPassword provides a mock function with given fields: ctx, email
+func (_m *UserUsecase) ForgotPassword(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForgotPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_ForgotPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForgotPassword'
+type UserUsecase_ForgotPassword_Call struct {
+	*mock.Call
+}
+
+// ForgotPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *UserUsecase_Expecter) ForgotPassword(ctx interface{}, email interface{}) *UserUsecase_ForgotPassword_Call {
+	return &UserUsecase_ForgotPassword_Call{Call: _e.mock.On("ForgotPassword", ctx, email)}
+}
+
+func (_c *UserUsecase_ForgotPassword_Call) Run(run func(ctx context.Context, email string)) *UserUsecase_ForgotPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ForgotPassword_Call) Return(_a0 error) *UserUsecase_ForgotPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_ForgotPassword_Call) RunAndReturn(run func(context.Context, string) error) *UserUsecase_ForgotPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByID provides a mock function with given fields: ctx, id
 func (_m *UserUsecase) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	ret := _m.Called(ctx, id)
@@ -266,6 +594,101 @@ func (_c *UserUsecase_GetUserByID_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// ResendVerification provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) ResendVerification(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type UserUsecase_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserUsecase_Expecter) ResendVerification(ctx interface{}, id interface{}) *UserUsecase_ResendVerification_Call {
+	return &UserUsecase_ResendVerification_Call{Call: _e.mock.On("ResendVerification", ctx, id)}
+}
+
+func (_c *UserUsecase_ResendVerification_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserUsecase_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ResendVerification_Call) Return(_a0 error) *UserUsecase_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_ResendVerification_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *UserUsecase_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, password
+func (_m *UserUsecase) ResetPassword(ctx context.Context, token string, password string) error {
+	ret := _m.Called(ctx, token, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type UserUsecase_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - password string
+func (_e *UserUsecase_Expecter) ResetPassword(ctx interface{}, token interface{}, password interface{}) *UserUsecase_ResetPassword_Call {
+	return &UserUsecase_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, token, password)}
+}
+
+func (_c *UserUsecase_ResetPassword_Call) Run(run func(ctx context.Context, token string, password string)) *UserUsecase_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_ResetPassword_Call) Return(_a0 error) *UserUsecase_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_ResetPassword_Call) RunAndReturn(run func(context.Context, string, string) error) *UserUsecase_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateEmail provides a mock function with given fields: ctx, ue
 func (_m *UserUsecase) UpdateEmail(ctx context.Context, ue *model.UpdateEmail) error {
 	ret := _m.Called(ctx, ue)
@@ -360,6 +783,53 @@ func (_c *UserUsecase_UpdatePassword_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *UserUsecase) VerifyEmail(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserUsecase_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type UserUsecase_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *UserUsecase_Expecter) VerifyEmail(ctx interface{}, token interface{}) *UserUsecase_VerifyEmail_Call {
+	return &UserUsecase_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, token)}
+}
+
+func (_c *UserUsecase_VerifyEmail_Call) Run(run func(ctx context.Context, token string)) *UserUsecase_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserUsecase_VerifyEmail_Call) Return(_a0 error) *UserUsecase_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserUsecase_VerifyEmail_Call) RunAndReturn(run func(context.Context, string) error) *UserUsecase_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserUsecase creates a new instance of UserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserUsecase(t interface {
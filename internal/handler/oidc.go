@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type OIDCUseCase interface {
+	AuthURL(ctx context.Context, device string) (string, error)
+	Callback(ctx context.Context, state, code string, session *model.Session) (*response.Login, *model.LoginChallenge, error)
+}
+
+type OIDC struct {
+	OIDCUseCase OIDCUseCase
+}
+
+func NewOIDC(ou OIDCUseCase) *OIDC {
+	return &OIDC{
+		OIDCUseCase: ou,
+	}
+}
+
+// Login redirects to the provider, the optional device query names the session.
+func (o *OIDC) Login(c *gin.Context) {
+	authURL, err := o.OIDCUseCase.AuthURL(c.Request.Context(), c.Query("device"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback answers the redirection of the provider with the tokens of a new session, or with the login
+// challenge of a user who enabled two-factor, as Login.
+func (o *OIDC) Callback(c *gin.Context) {
+	var callback request.OIDCCallback
+	if err := c.ShouldBindQuery(&callback); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	session := &model.Session{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	tokens, challenge, err := o.OIDCUseCase.Callback(c.Request.Context(), callback.State, callback.Code, session)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusOK, response.NewLoginChallengeFromModel(challenge))
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/oidc/oidctest"
+	"shop-aggregator/internal/totp"
+	"time"
+)
+
+// oidcCallback logs in through the fake issuer and returns the callback query.
+func (s *HandlerTestSuite) oidcCallback(user oidctest.User) string {
+	s.Issuer.Login(user)
+	wLogin := s.request("GET", "/oidc/login?device=laptop", nil)
+	s.Require().Equal(302, wLogin.Code)
+
+	callback, err := s.Issuer.Authorize(wLogin.Header().Get("Location"))
+	s.Require().NoError(err)
+	s.Require().Equal("/oidc/callback", callback.Path)
+	return callback.RawQuery
+}
+
+func (s *HandlerTestSuite) TestOIDC() {
+	s.Run("first login creates the account", func() {
+		query := s.oidcCallback(oidctest.User{Subject: "sub-1", Email: "sso@test.com", EmailVerified: true, PreferredUsername: "sso"})
+
+		wCallback := s.request("GET", "/oidc/callback?"+query, nil)
+
+		s.Equal(200, wCallback.Code)
+		var login response.Login
+		s.NoError(json.Unmarshal(wCallback.Body.Bytes(), &login))
+		s.Equal(200, s.requestWithToken("GET", "/user/get", login.Token, nil).Code)
+
+		user, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "sso")
+		s.Require().NoError(err)
+		s.Require().NotNil(user)
+		s.Equal("sso@test.com", user.Email)
+		s.True(user.EmailVerified)
+		var device string
+		s.NoError(s.DB.QueryRow(s.ctx, "SELECT device FROM session WHERE user_id = $1", user.ID).Scan(&device))
+		s.Equal("laptop", device)
+
+		// the state is used once
+		wCallback = s.request("GET", "/oidc/callback?"+query, nil)
+		s.assertProblem(wCallback, 401, "invalid_oidc_state")
+	})
+
+	s.Run("verified email links the existing account", func() {
+		s.createUser("local", "password", "local@test.com")
+		user, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "local")
+		s.Require().NoError(err)
+		s.Require().NoError(s.HandlerRepositories.Users.VerifyEmail(s.ctx, user.ID, "local@test.com"))
+
+		wCallback := s.request("GET", "/oidc/callback?"+s.oidcCallback(oidctest.User{Subject: "sub-2", Email: "local@test.com", EmailVerified: true}), nil)
+
+		s.Equal(200, wCallback.Code)
+		var sessions int
+		s.NoError(s.DB.QueryRow(s.ctx, "SELECT COUNT(*) FROM session WHERE user_id = $1 AND device = 'laptop'", user.ID).Scan(&sessions))
+		s.Equal(1, sessions)
+	})
+
+	s.Run("unverified email is not linked", func() {
+		s.createUser("unverified", "password", "unverified@test.com")
+
+		wCallback := s.request("GET", "/oidc/callback?"+s.oidcCallback(oidctest.User{Subject: "sub-3", Email: "unverified@test.com", EmailVerified: true}), nil)
+
+		s.assertProblem(wCallback, 403, "oidc_account_not_found")
+	})
+
+	s.Run("two-factor account gets a challenge", func() {
+		token := s.createUserAndGenerateToken("totp", "password", "totp@test.com")
+		user, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "totp")
+		s.Require().NoError(err)
+		s.Require().NoError(s.HandlerRepositories.Users.VerifyEmail(s.ctx, user.ID, "totp@test.com"))
+		wEnroll := s.requestWithToken("POST", "/user/2fa/enroll", token, nil)
+		s.Require().Equal(200, wEnroll.Code)
+		var enrolment struct {
+			Data response.TOTPEnrolment `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wEnroll.Body.Bytes(), &enrolment))
+		step := totp.Step(time.Now())
+		wConfirm := s.requestWithToken("POST", "/user/2fa/confirm", token, []byte(`{"code":"`+s.totpCode(enrolment.Data.Secret, step)+`"}`))
+		s.Require().Equal(200, wConfirm.Code)
+
+		// the provider proves the first factor only
+		wCallback := s.request("GET", "/oidc/callback?"+s.oidcCallback(oidctest.User{Subject: "sub-4", Email: "totp@test.com", EmailVerified: true}), nil)
+		s.Require().Equal(200, wCallback.Code)
+		var challenge response.LoginChallenge
+		s.Require().NoError(json.Unmarshal(wCallback.Body.Bytes(), &challenge))
+		s.True(challenge.TwoFactorRequired)
+		s.NotContains(wCallback.Body.String(), `"token"`)
+
+		wCode := s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+s.totpCode(enrolment.Data.Secret, step+1)+`"}`))
+		s.Require().Equal(200, wCode.Code)
+		var sessions int
+		s.NoError(s.DB.QueryRow(s.ctx, "SELECT COUNT(*) FROM session WHERE user_id = $1 AND device = 'laptop'", user.ID).Scan(&sessions))
+		s.Equal(1, sessions)
+	})
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type PriceUseCase interface {
+	CompareByProductID(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error)
+	CompareByEAN(ctx context.Context, ean string, filter *model.PriceFilter) ([]*model.StorePrice, error)
+	SelectStorePrices(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error)
+	SelectUserStorePrices(ctx context.Context, userID, storeID uuid.UUID) ([]*model.UserProduct, error)
+}
+
+type Price struct {
+	PriceUseCase PriceUseCase
+}
+
+func NewPrice(pu PriceUseCase) *Price {
+	return &Price{
+		PriceUseCase: pu,
+	}
+}
+
+func (p *Price) CompareByProductID(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid product id"))
+		return
+	}
+	filter, err := newPriceFilterFromQuery(c)
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	prices, err := p.PriceUseCase.CompareByProductID(c.Request.Context(), productID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "product prices", "data": response.NewStorePricesFromModels(prices)})
+}
+
+func (p *Price) CompareByEAN(c *gin.Context) {
+	filter, err := newPriceFilterFromQuery(c)
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	prices, err := p.PriceUseCase.CompareByEAN(c.Request.Context(), c.Param("ean"), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "product prices", "data": response.NewStorePricesFromModels(prices)})
+}
+
+func (p *Price) SelectStorePrices(c *gin.Context) {
+	storeID, err := uuid.Parse(c.Param("store_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid store id"))
+		return
+	}
+
+	ups, err := p.PriceUseCase.SelectStorePrices(c.Request.Context(), storeID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "store prices", "data": response.NewStoreProductPricesFromModels(ups)})
+}
+
+func (p *Price) SelectUserStorePrices(c *gin.Context) {
+	storeID, err := uuid.Parse(c.Param("store_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid store id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	ups, err := p.PriceUseCase.SelectUserStorePrices(c.Request.Context(), uuid.MustParse(id.(string)), storeID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user store prices", "data": response.NewUserProductsFromModel(ups)})
+}
+
+func newPriceFilterFromQuery(c *gin.Context) (*model.PriceFilter, error) {
+	var sp request.SearchPrice
+	if err := c.ShouldBindQuery(&sp); err != nil {
+		return nil, err
+	}
+
+	filter := &model.PriceFilter{
+		ZipCode: sp.ZipCode,
+		Sort:    sp.Sort,
+	}
+	if sp.CompanyID != "" {
+		filter.CompanyID = uuid.MustParse(sp.CompanyID)
+	}
+
+	return filter, nil
+}
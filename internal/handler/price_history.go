@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type PriceHistoryUseCase interface {
+	SelectPriceHistory(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter) ([]*model.PricePoint, error)
+}
+
+type PriceHistory struct {
+	PriceHistoryUseCase PriceHistoryUseCase
+}
+
+func NewPriceHistory(phu PriceHistoryUseCase) *PriceHistory {
+	return &PriceHistory{
+		PriceHistoryUseCase: phu,
+	}
+}
+
+func (ph *PriceHistory) GetPriceHistory(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid product id"))
+		return
+	}
+	var sph request.SearchPriceHistory
+	if err := c.ShouldBindQuery(&sph); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	points, err := ph.PriceHistoryUseCase.SelectPriceHistory(c.Request.Context(), productID, newPriceHistoryFilterFromRequest(&sph))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "price history", "data": response.NewPricePointsFromModels(points)})
+}
+
+func newPriceHistoryFilterFromRequest(r *request.SearchPriceHistory) *model.PriceHistoryFilter {
+	filter := &model.PriceHistoryFilter{
+		Interval: r.Interval,
+		From:     r.From,
+		To:       r.To,
+	}
+	if r.StoreID != "" {
+		filter.StoreID = uuid.MustParse(r.StoreID)
+	}
+	if r.CompanyID != "" {
+		filter.CompanyID = uuid.MustParse(r.CompanyID)
+	}
+
+	return filter
+}
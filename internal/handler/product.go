@@ -2,7 +2,6 @@ package handler
 
 import (
 	"context"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"shop-aggregator/internal/model"
@@ -28,13 +27,13 @@ func NewProduct(pu ProductUseCase) *Product {
 func (p *Product) Create(c *gin.Context) {
 	var cp request.CreateProduct
 	if err := c.ShouldBindJSON(&cp); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	pm := newProductFromRequest(&cp)
 	pm, err := p.ProductUseCase.Create(c.Request.Context(), pm, cp.BrandName)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "company created", "data": response.NewProductFromModel(pm)})
@@ -44,11 +43,7 @@ func (p *Product) GetProductByEAN(c *gin.Context) {
 	ean := c.Param("ean")
 	product, err := p.ProductUseCase.GetProductByEAN(c.Request.Context(), ean)
 	if err != nil {
-		if errors.Is(err, model.ErrNotExistsError) {
-			c.JSON(http.StatusNoContent, gin.H{"message": "product not found"})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "company created", "data": response.NewProductFromModel(product)})
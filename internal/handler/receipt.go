@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
+	"strings"
+)
+
+const (
+	receiptMaxSize       = 10 << 20
+	receiptImportMaxSize = 1 << 20
+)
+
+var receiptContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/bmp":  true,
+	"image/webp": true,
+}
+
+type ReceiptUseCase interface {
+	Scan(ctx context.Context, userID, billID uuid.UUID, image io.Reader) ([]*model.ReceiptDraft, error)
+}
+
+type ReceiptImportUseCase interface {
+	Import(ctx context.Context, userID, storeID uuid.UUID, currency string, raw []byte) (*response.ReceiptImport, error)
+}
+
+type Receipt struct {
+	ReceiptUseCase       ReceiptUseCase
+	ReceiptImportUseCase ReceiptImportUseCase
+}
+
+func NewReceipt(ru ReceiptUseCase, riu ReceiptImportUseCase) *Receipt {
+	return &Receipt{
+		ReceiptUseCase:       ru,
+		ReceiptImportUseCase: riu,
+	}
+}
+
+func (r *Receipt) Scan(c *gin.Context) {
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, receiptMaxSize)
+	fileHeader, err := c.FormFile("receipt")
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	if !receiptContentTypes[http.DetectContentType(head[:n])] {
+		c.Error(fmt.Errorf("%w: receipt must be an image", model.ErrUnsupportedMediaTypeError))
+		return
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	drafts, err := r.ReceiptUseCase.Scan(c.Request.Context(), uuid.MustParse(id.(string)), billID, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "receipt draft", "data": response.NewReceiptDraftLinesFromModels(drafts)})
+}
+
+// Import reads a text, HTML or e-mail (.eml) receipt sent in the "receipt" form file, "store_id" is the
+// store of the receipt and "currency" its currency, model.DefaultCurrency by default.
+func (r *Receipt) Import(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, receiptImportMaxSize)
+	storeID, err := uuid.Parse(c.PostForm("store_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid store id"))
+		return
+	}
+	currency, err := model.ParseCurrency(c.DefaultPostForm("currency", model.DefaultCurrency))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	fileHeader, err := c.FormFile("receipt")
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	if !strings.HasPrefix(http.DetectContentType(raw), "text/") {
+		c.Error(fmt.Errorf("%w: receipt must be a text, html or eml file", model.ErrUnsupportedMediaTypeError))
+		return
+	}
+
+	ri, err := r.ReceiptImportUseCase.Import(c.Request.Context(), uuid.MustParse(id.(string)), storeID, currency, raw)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "receipt imported", "data": ri})
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type ShoppingListUseCase interface {
+	Optimize(ctx context.Context, items []*model.ShoppingListItem, maxStores int, currency string, filter *model.PriceFilter) (*model.ShoppingListOptimization, error)
+}
+
+type ShoppingList struct {
+	ShoppingListUseCase ShoppingListUseCase
+}
+
+func NewShoppingList(slu ShoppingListUseCase) *ShoppingList {
+	return &ShoppingList{
+		ShoppingListUseCase: slu,
+	}
+}
+
+func (sl *ShoppingList) Optimize(c *gin.Context) {
+	var osl request.OptimizeShoppingList
+	if err := c.ShouldBindJSON(&osl); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	items := make([]*model.ShoppingListItem, 0, len(osl.Items))
+	for _, i := range osl.Items {
+		if i.ProductID == uuid.Nil && i.Ean == "" {
+			c.Error(invalidRequest("product_id or ean needed"))
+			return
+		}
+		items = append(items, &model.ShoppingListItem{
+			ProductID: i.ProductID,
+			Ean:       i.Ean,
+			Quantity:  i.Quantity,
+		})
+	}
+	currency := model.DefaultCurrency
+	if osl.Currency != "" {
+		var err error
+		if currency, err = model.ParseCurrency(osl.Currency); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	filter := &model.PriceFilter{
+		ZipCode:   osl.ZipCode,
+		CompanyID: osl.CompanyID,
+	}
+
+	optimization, err := sl.ShoppingListUseCase.Optimize(c.Request.Context(), items, osl.MaxStores, currency, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "shopping list optimized", "data": response.NewShoppingListOptimizationFromModel(optimization)})
+}
@@ -8,6 +8,7 @@ import (
 	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/model/request"
 	"shop-aggregator/internal/model/response"
+	"strings"
 )
 
 type StoreUseCase interface {
@@ -28,38 +29,34 @@ func NewStore(su StoreUseCase) *Store {
 func (s *Store) CreateStore(c *gin.Context) {
 	var cs request.CreateStore
 	if err := c.ShouldBindJSON(&cs); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
 	if cs.StoreType == model.StoreTypeShop {
-		var errStore error
+		var missing []string
 		if cs.Address == "" {
-			errStore = fmt.Errorf("%w address needed \n", errStore)
+			missing = append(missing, "address")
 		}
 		if cs.ZipCode == "" {
-			errStore = fmt.Errorf("%w zip code needed \n", errStore)
+			missing = append(missing, "zip code")
 		}
 		if cs.Country == "" {
-			errStore = fmt.Errorf("%w zip country needed \n", errStore)
+			missing = append(missing, "country")
 		}
 		if cs.City == "" {
-			errStore = fmt.Errorf("%w zip city needed \n", errStore)
+			missing = append(missing, "city")
 		}
-		if errStore != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": errStore.Error()})
+		if len(missing) > 0 {
+			c.Error(fmt.Errorf("%w: %s needed", model.ErrInvalidStoreError, strings.Join(missing, ", ")))
 			return
 		}
 		cs.Url = ""
 	}
 
 	if cs.StoreType == model.StoreTypeWeb {
-		var errStore error
 		if cs.Url == "" {
-			errStore = fmt.Errorf("%w url needed \n", errStore)
-		}
-		if errStore != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": errStore.Error()})
+			c.Error(fmt.Errorf("%w: url needed", model.ErrInvalidStoreError))
 			return
 		}
 
@@ -73,7 +70,7 @@ func (s *Store) CreateStore(c *gin.Context) {
 	sm := newStoreFromRequest(cs)
 	sm, err := s.StoreUseCase.CreateStore(c.Request.Context(), sm, cs.CompanyName)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -85,7 +82,7 @@ func (s *Store) GetStoreByZipCodeOrName(c *gin.Context) {
 	search := c.Param("search")
 	stores, err := s.StoreUseCase.GetStoreByZipCodeOrName(c.Request.Context(), storeType, search)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "company created", "data": response.NewStoresFromModels(stores)})
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/request"
+	"shop-aggregator/internal/model/response"
+)
+
+type TwoFactorUseCase interface {
+	Enroll(ctx context.Context, userID uuid.UUID) (*model.TOTPEnrolment, error)
+	Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, password, code string) error
+}
+
+type TwoFactor struct {
+	TwoFactorUseCase TwoFactorUseCase
+}
+
+func NewTwoFactor(tfu TwoFactorUseCase) *TwoFactor {
+	return &TwoFactor{
+		TwoFactorUseCase: tfu,
+	}
+}
+
+func (tf *TwoFactor) Enroll(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	enrolment, err := tf.TwoFactorUseCase.Enroll(c.Request.Context(), uuid.MustParse(id.(string)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "scan the provisioning uri then confirm a code", "data": response.NewTOTPEnrolmentFromModel(enrolment)})
+}
+
+func (tf *TwoFactor) Confirm(c *gin.Context) {
+	var ctf request.ConfirmTwoFactor
+	if err := c.ShouldBindJSON(&ctf); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	recoveryCodes, err := tf.TwoFactorUseCase.Confirm(c.Request.Context(), uuid.MustParse(id.(string)), ctf.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor enabled, the recovery codes will not be shown again", "data": gin.H{"recovery_codes": recoveryCodes}})
+}
+
+func (tf *TwoFactor) Disable(c *gin.Context) {
+	var dtf request.DisableTwoFactor
+	if err := c.ShouldBindJSON(&dtf); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := tf.TwoFactorUseCase.Disable(c.Request.Context(), uuid.MustParse(id.(string)), dtf.Password, dtf.Code); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor disabled"})
+}
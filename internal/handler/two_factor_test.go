@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/totp"
+	"time"
+)
+
+// totpCode returns the code of the app for the period.
+func (s *HandlerTestSuite) totpCode(secret string, step int64) string {
+	code, err := totp.Code(secret, step)
+	s.Require().NoError(err)
+	return code
+}
+
+func (s *HandlerTestSuite) TestTwoFactor() {
+	s.Run("enrol, log in with a code then a recovery code and disable", func() {
+		token := s.createUserAndGenerateToken("secure", "password", "secure@test.com")
+
+		wEnroll := s.requestWithToken("POST", "/user/2fa/enroll", token, nil)
+		s.Require().Equal(200, wEnroll.Code)
+		var enrolment struct {
+			Data response.TOTPEnrolment `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wEnroll.Body.Bytes(), &enrolment))
+		s.Contains(enrolment.Data.ProvisioningURI, "otpauth://totp/")
+		secret := enrolment.Data.Secret
+		step := totp.Step(time.Now())
+
+		wConfirm := s.requestWithToken("POST", "/user/2fa/confirm", token, []byte(`{"code":"000000x"}`))
+		s.Equal(401, wConfirm.Code)
+		wConfirm = s.requestWithToken("POST", "/user/2fa/confirm", token, []byte(`{"code":"`+s.totpCode(secret, step)+`"}`))
+		s.Require().Equal(200, wConfirm.Code)
+		var confirmed struct {
+			Data struct {
+				RecoveryCodes []string `json:"recovery_codes"`
+			} `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(wConfirm.Body.Bytes(), &confirmed))
+		s.Require().Len(confirmed.Data.RecoveryCodes, 10)
+		s.Equal(409, s.requestWithToken("POST", "/user/2fa/enroll", token, nil).Code)
+
+		// the password alone gets a challenge
+		wLogin := s.request("POST", "/login", []byte(`{"login":"secure","password":"password","device":"phone"}`))
+		s.Require().Equal(200, wLogin.Code)
+		var challenge response.LoginChallenge
+		s.Require().NoError(json.Unmarshal(wLogin.Body.Bytes(), &challenge))
+		s.True(challenge.TwoFactorRequired)
+		s.Require().NotEmpty(challenge.Challenge)
+		s.NotContains(wLogin.Body.String(), `"token"`)
+
+		// the code that confirmed the enrolment is used
+		wCode := s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+s.totpCode(secret, step)+`"}`))
+		s.assertProblem(wCode, 401, "invalid_two_factor_code")
+
+		wCode = s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+s.totpCode(secret, step+1)+`"}`))
+		s.Require().Equal(200, wCode.Code)
+		var login response.Login
+		s.Require().NoError(json.Unmarshal(wCode.Body.Bytes(), &login))
+		s.Equal(200, s.requestWithToken("GET", "/user/get", login.Token, nil).Code)
+
+		// a challenge is used once
+		wCode = s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+confirmed.Data.RecoveryCodes[0]+`"}`))
+		s.assertProblem(wCode, 401, "invalid_login_challenge")
+
+		// a recovery code replaces the app, once
+		s.Require().NoError(json.Unmarshal(s.request("POST", "/login", []byte(`{"login":"secure","password":"password"}`)).Body.Bytes(), &challenge))
+		wCode = s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+confirmed.Data.RecoveryCodes[0]+`"}`))
+		s.Equal(200, wCode.Code)
+		s.Require().NoError(json.Unmarshal(s.request("POST", "/login", []byte(`{"login":"secure","password":"password"}`)).Body.Bytes(), &challenge))
+		wCode = s.request("POST", "/login/2fa", []byte(`{"challenge":"`+challenge.Challenge+`","code":"`+confirmed.Data.RecoveryCodes[0]+`"}`))
+		s.Equal(401, wCode.Code)
+
+		// disabling needs the password again
+		wDisable := s.requestWithToken("POST", "/user/2fa/disable", login.Token, []byte(`{"password":"wrong","code":"`+confirmed.Data.RecoveryCodes[1]+`"}`))
+		s.Equal(401, wDisable.Code)
+		wDisable = s.requestWithToken("POST", "/user/2fa/disable", login.Token, []byte(`{"password":"password","code":"`+confirmed.Data.RecoveryCodes[1]+`"}`))
+		s.Equal(200, wDisable.Code)
+		s.NotEmpty(s.login("secure", "password"))
+	})
+}
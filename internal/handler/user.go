@@ -15,6 +15,10 @@ type UserUsecase interface {
 	UpdatePassword(ctx context.Context, up *model.UpdatePassword) error
 	UpdateEmail(ctx context.Context, ue *model.UpdateEmail) error
 	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	ResendVerification(ctx context.Context, id uuid.UUID) error
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
 }
 
 type User struct {
@@ -30,7 +34,7 @@ func NewUser(uu UserUsecase) *User {
 func (u *User) CreateUser(c *gin.Context) {
 	var cu request.CreateUser
 	if err := c.ShouldBindJSON(&cu); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 	user := model.User{
@@ -40,7 +44,7 @@ func (u *User) CreateUser(c *gin.Context) {
 	}
 
 	if err := u.UserUsecase.CreateOrUpdateUser(c.Request.Context(), &user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -50,13 +54,13 @@ func (u *User) CreateUser(c *gin.Context) {
 func (u *User) UpdatePassword(c *gin.Context) {
 	var up request.UpdatePassword
 	if err := c.ShouldBindJSON(&up); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
@@ -67,7 +71,7 @@ func (u *User) UpdatePassword(c *gin.Context) {
 	}
 
 	if err := u.UserUsecase.UpdatePassword(c.Request.Context(), &updatePassword); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -77,13 +81,13 @@ func (u *User) UpdatePassword(c *gin.Context) {
 func (u *User) UpdateEmail(c *gin.Context) {
 	var ue request.UpdateEmail
 	if err := c.ShouldBindJSON(&ue); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
@@ -93,23 +97,84 @@ func (u *User) UpdateEmail(c *gin.Context) {
 	}
 
 	if err := u.UserUsecase.UpdateEmail(c.Request.Context(), &updateEmail); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "email updated"})
+	c.JSON(http.StatusOK, gin.H{"message": "verification email sent"})
+}
+
+func (u *User) ResendVerification(c *gin.Context) {
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+
+	if err := u.UserUsecase.ResendVerification(c.Request.Context(), uuid.MustParse(id.(string))); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "verification email sent"})
+}
+
+func (u *User) VerifyEmail(c *gin.Context) {
+	var ve request.VerifyEmail
+	if err := c.ShouldBindJSON(&ve); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := u.UserUsecase.VerifyEmail(c.Request.Context(), ve.Token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified"})
+}
+
+// ForgotPassword answers the same whether the email is known or not.
+func (u *User) ForgotPassword(c *gin.Context) {
+	var fp request.ForgotPassword
+	if err := c.ShouldBindJSON(&fp); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := u.UserUsecase.ForgotPassword(c.Request.Context(), fp.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "if the email is known, a reset link was sent"})
+}
+
+func (u *User) ResetPassword(c *gin.Context) {
+	var rp request.ResetPassword
+	if err := c.ShouldBindJSON(&rp); err != nil {
+		c.Error(invalidRequest(err.Error()))
+		return
+	}
+
+	if err := u.UserUsecase.ResetPassword(c.Request.Context(), rp.Token, rp.Password); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password reset"})
 }
 
 func (u *User) GetUser(c *gin.Context) {
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
 	user, err := u.UserUsecase.GetUserByID(c.Request.Context(), uuid.MustParse(id.(string)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -12,9 +12,9 @@ import (
 
 type UserProductUseCase interface {
 	Create(ctx context.Context, um *model.UserProduct, userID uuid.UUID) (*model.UserProduct, error)
-	SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error)
-	UpdateQuantity(ctx context.Context, billID, userProductID uuid.UUID, productType, productSize, sizeFormat string, quantity int64) ([]*model.UserProduct, error)
-	DeleteUserProduct(ctx context.Context, userProductID uuid.UUID) ([]*model.UserProduct, error)
+	SelectProductsByBillID(ctx context.Context, userID, billID uuid.UUID) ([]*model.UserProduct, error)
+	UpdateQuantity(ctx context.Context, userID, billID, userProductID uuid.UUID, productType, productSize, sizeFormat string, quantity int64) ([]*model.UserProduct, error)
+	DeleteUserProduct(ctx context.Context, userID, billID, userProductID uuid.UUID) ([]*model.UserProduct, error)
 }
 
 type UserProduct struct {
@@ -30,35 +30,40 @@ func NewUserProduct(upu UserProductUseCase) *UserProduct {
 func (up *UserProduct) Create(c *gin.Context) {
 	var cp request.CreateUserProduct
 	if err := c.ShouldBindJSON(&cp); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
 
 	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
 	pum := newUserProductFromRequest(&cp)
 	pum, err := up.UserProductUseCase.Create(c.Request.Context(), pum, uuid.MustParse(id.(string)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "user product created", "data": response.NewUserProductFromModel(pum)})
 }
 
 func (up *UserProduct) SelectProductsByBillID(c *gin.Context) {
-	id, exists := c.Get("bill_id")
+	billID, err := uuid.Parse(c.Param("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "bill not found"})
+		c.Error(model.ErrUserNotFound)
 		return
 	}
 
-	pum, err := up.UserProductUseCase.SelectProductsByBillID(c.Request.Context(), uuid.MustParse(id.(string)))
+	pum, err := up.UserProductUseCase.SelectProductsByBillID(c.Request.Context(), uuid.MustParse(id.(string)), billID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "user products", "data": response.NewUserProductsFromModel(pum)})
@@ -67,22 +72,41 @@ func (up *UserProduct) SelectProductsByBillID(c *gin.Context) {
 func (up *UserProduct) UpdateQuantity(c *gin.Context) {
 	var uupq request.UpdateUserProductQuantity
 	if err := c.ShouldBindJSON(&uupq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidRequest(err.Error()))
 		return
 	}
-	pum, err := up.UserProductUseCase.UpdateQuantity(c.Request.Context(), uupq.BillID, uupq.UserProductID, uupq.ProductType, uupq.ProductSize, uupq.SizeFormat, uupq.Quantity)
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	pum, err := up.UserProductUseCase.UpdateQuantity(c.Request.Context(), uuid.MustParse(id.(string)), uupq.BillID, uupq.UserProductID, uupq.ProductType, uupq.ProductSize, uupq.SizeFormat, uupq.Quantity)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "user products", "data": response.NewUserProductsFromModel(pum)})
 }
 
 func (up *UserProduct) Delete(c *gin.Context) {
-	userProductID := c.Param("user_product_id")
-	pum, err := up.UserProductUseCase.DeleteUserProduct(c.Request.Context(), uuid.MustParse(userProductID))
+	userProductID, err := uuid.Parse(c.Param("user_product_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid user product id"))
+		return
+	}
+	billID, err := uuid.Parse(c.Query("bill_id"))
+	if err != nil {
+		c.Error(invalidRequest("invalid bill id"))
+		return
+	}
+	id, exists := c.Get("userID")
+	if !exists {
+		c.Error(model.ErrUserNotFound)
+		return
+	}
+	pum, err := up.UserProductUseCase.DeleteUserProduct(c.Request.Context(), uuid.MustParse(id.(string)), billID, userProductID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "user products", "data": response.NewUserProductsFromModel(pum)})
@@ -92,7 +116,7 @@ func newUserProductFromRequest(r *request.CreateUserProduct) *model.UserProduct
 	return &model.UserProduct{
 		ProductID:   r.ProductID,
 		BillID:      r.BillID,
-		Price:       r.Price,
+		Price:       *r.Price,
 		Quantity:    r.Quantity,
 		ProductSize: r.ProductSize,
 		ProductType: r.ProductType,
@@ -39,8 +39,7 @@ func (s *HandlerTestSuite) TestCreateUser() {
 
 		wCreate := s.request("POST", "/create-user", bodyCreateBytes)
 
-		s.Equal(400, wCreate.Code)
-		s.Equal(`{"error":"user exist for email test2@test.com"}`, wCreate.Body.String())
+		s.assertProblem(wCreate, 409, "email_exists")
 	})
 
 	s.Run("login already exists, return an error", func() {
@@ -55,8 +54,7 @@ func (s *HandlerTestSuite) TestCreateUser() {
 
 		wCreate := s.request("POST", "/create-user", bodyCreateBytes)
 
-		s.Equal(400, wCreate.Code)
-		s.Equal(`{"error":"user exist for login login3"}`, wCreate.Body.String())
+		s.assertProblem(wCreate, 409, "login_exists")
 	})
 
 	s.Run("no login, return an error", func() {
@@ -70,8 +68,7 @@ func (s *HandlerTestSuite) TestCreateUser() {
 
 		wCreate := s.request("POST", "/create-user", bodyCreateBytes)
 
-		s.Equal(400, wCreate.Code)
-		s.Equal(`{"error":"Key: 'CreateUser.Login' Error:Field validation for 'Login' failed on the 'required' tag"}`, wCreate.Body.String())
+		s.assertProblem(wCreate, 400, "invalid_request")
 	})
 
 	s.Run("no password, return an error", func() {
@@ -85,8 +82,7 @@ func (s *HandlerTestSuite) TestCreateUser() {
 
 		wCreate := s.request("POST", "/create-user", bodyCreateBytes)
 
-		s.Equal(400, wCreate.Code)
-		s.Equal(`{"error":"Key: 'CreateUser.Password' Error:Field validation for 'Password' failed on the 'required' tag"}`, wCreate.Body.String())
+		s.assertProblem(wCreate, 400, "invalid_request")
 	})
 
 	s.Run("no email, return an error", func() {
@@ -100,8 +96,7 @@ func (s *HandlerTestSuite) TestCreateUser() {
 
 		wCreate := s.request("POST", "/create-user", bodyCreateBytes)
 
-		s.Equal(400, wCreate.Code)
-		s.Equal(`{"error":"Key: 'CreateUser.Email' Error:Field validation for 'Email' failed on the 'required' tag"}`, wCreate.Body.String())
+		s.assertProblem(wCreate, 400, "invalid_request")
 	})
 }
 
@@ -144,8 +139,7 @@ func (s *HandlerTestSuite) TestResetPassword() {
 		s.Require().NoError(err)
 		wUpdatePassword := s.requestWithToken("POST", "/user/reset-password", token, bodyUpdatePasswordBytes)
 		s.T().Log(wUpdatePassword.Body.String())
-		s.Equal(400, wUpdatePassword.Code)
-		s.Equal(`{"error":"Key: 'UpdatePassword.OldPassword' Error:Field validation for 'OldPassword' failed on the 'required' tag"}`, wUpdatePassword.Body.String())
+		s.assertProblem(wUpdatePassword, 400, "invalid_request")
 	})
 
 	s.Run("new password is missing", func() {
@@ -160,8 +154,7 @@ func (s *HandlerTestSuite) TestResetPassword() {
 		s.Require().NoError(err)
 		wUpdatePassword := s.requestWithToken("POST", "/user/reset-password", token, bodyUpdatePasswordBytes)
 		s.T().Log(wUpdatePassword.Body.String())
-		s.Equal(400, wUpdatePassword.Code)
-		s.Equal(`{"error":"Key: 'UpdatePassword.Password' Error:Field validation for 'Password' failed on the 'required' tag"}`, wUpdatePassword.Body.String())
+		s.assertProblem(wUpdatePassword, 400, "invalid_request")
 	})
 
 	s.Run("old password is wrong", func() {
@@ -176,7 +169,86 @@ func (s *HandlerTestSuite) TestResetPassword() {
 		s.Require().NoError(err)
 		wUpdatePassword := s.requestWithToken("POST", "/user/reset-password", token, bodyUpdatePasswordBytes)
 		s.T().Log(wUpdatePassword.Body.String())
-		s.Equal(400, wUpdatePassword.Code)
-		s.Equal(`{"error":"invalid old password"}`, wUpdatePassword.Body.String())
+		s.assertProblem(wUpdatePassword, 422, "invalid_old_password")
+	})
+}
+
+func (s *HandlerTestSuite) TestVerifyEmail() {
+	s.Run("sign-up and email change", func() {
+		token := s.createUserAndGenerateToken("verify", "password", "verify@test.com")
+		user, err := s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "verify")
+		s.Require().NoError(err)
+		s.False(user.EmailVerified)
+
+		body, err := json.Marshal(request.VerifyEmail{Token: s.mailToken("verify@test.com")})
+		s.Require().NoError(err)
+		w := s.request("POST", "/verify-email", body)
+		s.Equal(200, w.Code)
+		s.Equal(`{"message":"email verified"}`, w.Body.String())
+
+		// the link is used once
+		w = s.request("POST", "/verify-email", body)
+		s.assertProblem(w, 422, "invalid_user_token")
+
+		// the new email is set once it is verified
+		bodyUpdateEmail, err := json.Marshal(request.UpdateEmail{Email: "new@test.com"})
+		s.Require().NoError(err)
+		w = s.requestWithToken("POST", "/user/update-email", token, bodyUpdateEmail)
+		s.Equal(200, w.Code)
+		user, err = s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "verify")
+		s.Require().NoError(err)
+		s.Equal("verify@test.com", user.Email)
+		s.True(user.EmailVerified)
+
+		body, err = json.Marshal(request.VerifyEmail{Token: s.mailToken("new@test.com")})
+		s.Require().NoError(err)
+		s.Equal(200, s.request("POST", "/verify-email", body).Code)
+		user, err = s.HandlerRepositories.Users.GetUserByLogin(s.ctx, "verify")
+		s.Require().NoError(err)
+		s.Equal("new@test.com", user.Email)
+		s.True(user.EmailVerified)
+	})
+
+	s.Run("email of an other user", func() {
+		s.createUser("taken", "password", "taken@test.com")
+		token := s.createUserAndGenerateToken("taker", "password", "taker@test.com")
+
+		body, err := json.Marshal(request.UpdateEmail{Email: "taken@test.com"})
+		s.Require().NoError(err)
+		w := s.requestWithToken("POST", "/user/update-email", token, body)
+		s.assertProblem(w, 409, "email_exists")
+	})
+}
+
+func (s *HandlerTestSuite) TestForgotPassword() {
+	s.Run("reset with the mailed token", func() {
+		token := s.createUserAndGenerateToken("forgot", "password", "forgot@test.com")
+
+		body, err := json.Marshal(request.ForgotPassword{Email: "forgot@test.com"})
+		s.Require().NoError(err)
+		w := s.request("POST", "/forgot-password", body)
+		s.Equal(200, w.Code)
+
+		body, err = json.Marshal(request.ResetPassword{Token: s.mailToken("forgot@test.com"), Password: "new-password"})
+		s.Require().NoError(err)
+		w = s.request("POST", "/reset-password", body)
+		s.Equal(200, w.Code)
+		s.Equal(`{"message":"password reset"}`, w.Body.String())
+
+		// the sessions opened with the old password are revoked
+		s.Equal(401, s.requestWithToken("GET", "/user/get", token, nil).Code)
+		s.login("forgot", "new-password")
+
+		w = s.request("POST", "/reset-password", body)
+		s.assertProblem(w, 422, "invalid_user_token")
+	})
+
+	s.Run("unknown email", func() {
+		body, err := json.Marshal(request.ForgotPassword{Email: "unknown@test.com"})
+		s.Require().NoError(err)
+		w := s.request("POST", "/forgot-password", body)
+		s.Equal(200, w.Code)
+		s.Equal(`{"message":"if the email is known, a reset link was sent"}`, w.Body.String())
+		s.NotContains(s.Mails.String(), "To: unknown@test.com")
 	})
 }
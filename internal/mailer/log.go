@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Log writes the mails instead of sending them, the links of the local development are read there.
+type Log struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewLog(w io.Writer, from string) *Log {
+	return &Log{
+		w:    w,
+		from: from,
+	}
+}
+
+func (l *Log) Send(ctx context.Context, m *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := write(l.w, l.from, m); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(l.w, "----")
+	return err
+}
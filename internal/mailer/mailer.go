@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"shop-aggregator/internal/config"
+)
+
+// Message is a plain text mail.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// New returns the mailer of the configured driver, the log mailer when none is set.
+func New(cfg *config.MailerConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTP(cfg), nil
+	case "", "log":
+		if cfg.LogPath == "" {
+			return NewLog(os.Stderr, cfg.From), nil
+		}
+		f, err := os.OpenFile(cfg.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return NewLog(f, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %q", cfg.Driver)
+	}
+}
+
+// write formats the message as sent, headers first.
+func write(w io.Writer, from string, m *Message) error {
+	_, err := fmt.Fprintf(w, "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, m.To, m.Subject, m.Body)
+	return err
+}
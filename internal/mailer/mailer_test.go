@@ -0,0 +1,131 @@
+package mailer_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"path/filepath"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/mailer"
+	"strings"
+	"testing"
+)
+
+var message = &mailer.Message{
+	To:      "user@test.com",
+	Subject: "Verify your email",
+	Body:    "http://localhost/verify-email?token=token",
+}
+
+func TestLog_Send(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, mailer.NewLog(&buf, "no-reply@test.com").Send(context.Background(), message))
+		assert.Contains(t, buf.String(), "From: no-reply@test.com\r\nTo: user@test.com\r\nSubject: Verify your email\r\n")
+		assert.Contains(t, buf.String(), "\r\n\r\nhttp://localhost/verify-email?token=token\r\n")
+	})
+
+	t.Run("context cancel error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var buf bytes.Buffer
+		assert.EqualError(t, mailer.NewLog(&buf, "no-reply@test.com").Send(ctx, message), `context canceled`)
+		assert.Empty(t, buf.String())
+	})
+}
+
+// fakeSMTP accepts one mail without STARTTLS nor AUTH and returns the commands and the data it received.
+func fakeSMTP(t *testing.T) (int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var session strings.Builder
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				received <- session.String()
+				return
+			}
+			session.WriteString(line)
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 end with .")
+				for {
+					line, err = r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					session.WriteString(line)
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				received <- session.String()
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSMTP_Send(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		port, received := fakeSMTP(t)
+		smtp := mailer.NewSMTP(&config.MailerConfig{Host: "127.0.0.1", Port: port, From: "no-reply@test.com"})
+		require.NoError(t, smtp.Send(context.Background(), message))
+
+		session := <-received
+		assert.Contains(t, session, "MAIL FROM:<no-reply@test.com>")
+		assert.Contains(t, session, "RCPT TO:<user@test.com>")
+		assert.Contains(t, session, "Subject: Verify your email\r\n")
+		assert.Contains(t, session, "http://localhost/verify-email?token=token\r\n")
+	})
+
+	t.Run("dial error", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		smtp := mailer.NewSMTP(&config.MailerConfig{Host: "127.0.0.1", Port: port})
+		assert.ErrorContains(t, smtp.Send(context.Background(), message), "connection refused")
+	})
+}
+
+func TestNew(t *testing.T) {
+	m, err := mailer.New(&config.MailerConfig{})
+	require.NoError(t, err)
+	assert.IsType(t, &mailer.Log{}, m)
+
+	m, err = mailer.New(&config.MailerConfig{Driver: "smtp", Host: "localhost", Port: 25})
+	require.NoError(t, err)
+	assert.IsType(t, &mailer.SMTP{}, m)
+
+	path := filepath.Join(t.TempDir(), "mails.log")
+	m, err = mailer.New(&config.MailerConfig{Driver: "log", LogPath: path})
+	require.NoError(t, err)
+	assert.IsType(t, &mailer.Log{}, m)
+
+	_, err = mailer.New(&config.MailerConfig{Driver: "pigeon"})
+	assert.EqualError(t, err, `unknown mailer driver "pigeon"`)
+}
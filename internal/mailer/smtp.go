@@ -0,0 +1,103 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"shop-aggregator/internal/config"
+	"time"
+)
+
+const (
+	defaultSMTPPort    = 587
+	defaultSMTPTimeout = 30 * time.Second
+)
+
+// SMTP sends the mails through a relay, STARTTLS is used when the relay offers it and the credentials are
+// only sent once the connection is encrypted or to a local relay.
+type SMTP struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	timeout  time.Duration
+}
+
+func NewSMTP(cfg *config.MailerConfig) *SMTP {
+	s := &SMTP{
+		host:     cfg.Host,
+		port:     cfg.Port,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+		timeout:  defaultSMTPTimeout,
+	}
+	if s.port == 0 {
+		s.port = defaultSMTPPort
+	}
+
+	return s
+}
+
+func (s *SMTP) Send(ctx context.Context, m *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", s.host, s.port))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(s.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(m.To); err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	if err = write(&body, s.from, m); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(body.Bytes()); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// PersonalData is what the user gets in the export of the account: the profile, the open sessions, the bills
+// started by the user and the lines the user added.
+type PersonalData struct {
+	User       *User
+	Sessions   []*Session
+	Bills      []*Bill
+	Lines      []*UserProduct
+	ExportedAt time.Time
+}
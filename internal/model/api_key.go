@@ -0,0 +1,67 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, the middleware tells them from the session tokens.
+const APIKeyPrefix = "sak_"
+
+// The scopes of an API key, a write scope also grants the read scope of the same resource.
+const (
+	ScopeBillsRead    = "bills:read"
+	ScopeBillsWrite   = "bills:write"
+	ScopeCatalogRead  = "catalog:read"
+	ScopeCatalogWrite = "catalog:write"
+)
+
+var scopeGrants = map[string][]string{
+	ScopeBillsRead:    {ScopeBillsRead},
+	ScopeBillsWrite:   {ScopeBillsWrite, ScopeBillsRead},
+	ScopeCatalogRead:  {ScopeCatalogRead},
+	ScopeCatalogWrite: {ScopeCatalogWrite, ScopeCatalogRead},
+}
+
+// APIKey authenticates the scripts of a user with the given scopes, Key is only known when it is created.
+// Prefix is the start of the key, enough to recognize it in a list.
+type APIKey struct {
+	APIKeyID   uuid.UUID
+	UserID     uuid.UUID
+	Role       string
+	Name       string
+	Key        string
+	Prefix     string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// IsExpired tells if the key can no longer be used, a key without expiry never expires.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+func IsValidScope(scope string) bool {
+	_, ok := scopeGrants[scope]
+	return ok
+}
+
+// ScopesAllow tells if one of the scopes grants required.
+func ScopesAllow(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		for _, granted := range scopeGrants[scope] {
+			if granted == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsAPIKey tells if the token is an API key rather than a session token.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
@@ -0,0 +1,42 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+func TestScopesAllow(t *testing.T) {
+	tests := []struct {
+		scopes   []string
+		required string
+		allowed  bool
+	}{
+		{[]string{model.ScopeBillsRead}, model.ScopeBillsRead, true},
+		{[]string{model.ScopeBillsRead}, model.ScopeBillsWrite, false},
+		{[]string{model.ScopeBillsWrite}, model.ScopeBillsRead, true},
+		{[]string{model.ScopeBillsWrite}, model.ScopeCatalogRead, false},
+		{[]string{model.ScopeBillsRead, model.ScopeCatalogWrite}, model.ScopeCatalogRead, true},
+		{nil, model.ScopeBillsRead, false},
+		{[]string{"admin"}, model.ScopeBillsRead, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, model.ScopesAllow(tt.scopes, tt.required), "%v for %s", tt.scopes, tt.required)
+	}
+
+	assert.True(t, model.IsValidScope(model.ScopeCatalogWrite))
+	assert.False(t, model.IsValidScope("admin"))
+}
+
+func TestAPIKey_IsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False(t, (&model.APIKey{}).IsExpired(now))
+	assert.True(t, (&model.APIKey{ExpiresAt: &past}).IsExpired(now))
+	assert.False(t, (&model.APIKey{ExpiresAt: &future}).IsExpired(now))
+	assert.True(t, model.IsAPIKey("sak_abc"))
+	assert.False(t, model.IsAPIKey("abc"))
+}
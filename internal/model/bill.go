@@ -1,17 +1,116 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Bill is started by UserID, for HouseholdID when it is shared with the members of a household.
+type Bill struct {
+	BillID         uuid.UUID
+	UserID         uuid.UUID
+	HouseholdID    *uuid.UUID
+	StoreID        uuid.UUID
+	Amount         Money
+	ComputedAmount Money
+	State          string
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+}
+
+// IsClosed tells if the declared amount of the bill is final, completed or archived after completion.
+func (b *Bill) IsClosed() bool {
+	return b.State == BillStateCompleted || b.State == BillStateArchived
+}
+
+// Discrepancy is the declared amount minus the sum of the lines, rounded to the cent. The lines are in the
+// currency of the bill, so is the sum.
+func (b *Bill) Discrepancy() Money {
+	return NewMoney(b.Amount.Amount.Sub(b.ComputedAmount.Amount).Round(2), b.Amount.Currency)
+}
+
+func (b *Bill) HasDiscrepancy() bool {
+	return !b.Discrepancy().IsZero()
+}
+
+// SumUserProducts is the total of the lines, price × quantity, ErrCurrencyMismatchError when a line is not in
+// the currency.
+func SumUserProducts(ups []*UserProduct, currency string) (Money, error) {
+	total := ZeroMoney(currency)
+	for _, up := range ups {
+		var err error
+		if total, err = total.Add(up.Price.Mul(up.Quantity)); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
 
 const (
-	BillStateCreate    = "create"
-	BillStateCompleted = "complete"
-	BillStateCanceled  = "cancel"
+	BillSortDate     = "date"
+	BillSortAmount   = "amount"
+	BillOrderAsc     = "asc"
+	BillOrderDesc    = "desc"
+	DefaultBillLimit = 50
+	MaxBillLimit     = 200
 )
 
-type Bill struct {
-	BillID  uuid.UUID
-	UserID  uuid.UUID
-	StoreID uuid.UUID
-	Amount  string
-	State   string
+// BillFilter selects a page of the bills of a user, HouseholdID keeps only the bills of one of its
+// households. From and To are days, both included. Cursor is the position after the last bill of the
+// previous page, nil for the first page.
+type BillFilter struct {
+	HouseholdID uuid.UUID
+	StoreID     uuid.UUID
+	CompanyID   uuid.UUID
+	State       string
+	From        time.Time
+	To          time.Time
+	Sort        string
+	Order       string
+	Limit       int
+	Cursor      *BillCursor
+}
+
+// BillPage is a page of bills, NextCursor is empty on the last page.
+type BillPage struct {
+	Bills      []*Bill
+	NextCursor string
+}
+
+// BillCursor is the sort key and the id of the last bill of a page. Value is the creation time or the
+// amount of the bill, depending on Sort.
+type BillCursor struct {
+	Sort   string    `json:"s"`
+	Value  string    `json:"v"`
+	BillID uuid.UUID `json:"id"`
+}
+
+func NewBillCursor(sort string, b *Bill) *BillCursor {
+	bc := &BillCursor{Sort: sort, BillID: b.BillID}
+	if sort == BillSortAmount {
+		bc.Value = b.Amount.Amount.String()
+	} else {
+		bc.Value = b.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return bc
+}
+
+// Encode returns the opaque token given to the client.
+func (bc *BillCursor) Encode() string {
+	data, _ := json.Marshal(bc)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeBillCursor(s string) (*BillCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursorError
+	}
+	bc := &BillCursor{}
+	if err = json.Unmarshal(data, bc); err != nil || bc.BillID == uuid.Nil || bc.Value == "" {
+		return nil, ErrInvalidCursorError
+	}
+	return bc, nil
 }
@@ -0,0 +1,214 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"sort"
+	"time"
+)
+
+const (
+	// SplitMethodShare splits a line in whole parts, 1 and 2 gives a third and two thirds.
+	SplitMethodShare = "share"
+	// SplitMethodWeight splits a line in proportion to decimal weights, the kilos each one took for example.
+	SplitMethodWeight = "weight"
+	// SplitMethodFixed gives an amount of the line to each participant, the payer keeps the rest.
+	SplitMethodFixed = "fixed"
+)
+
+const (
+	SplitParticipantUser      = "user"
+	SplitParticipantHousehold = "household"
+)
+
+func IsValidSplitMethod(method string) bool {
+	return method == SplitMethodShare || method == SplitMethodWeight || method == SplitMethodFixed
+}
+
+// SplitAllocation assigns a part of a line of the bill to a user or to a household, the part of a household
+// goes to its members in equal parts.
+type SplitAllocation struct {
+	UserProductID   uuid.UUID
+	ParticipantType string
+	ParticipantID   uuid.UUID
+	Method          string
+	Value           decimal.Decimal
+}
+
+// BillSplit is the split of a closed bill, Shares holds what each user owes for it. The payer started the bill,
+// the shares of the others are what they owe to the payer.
+type BillSplit struct {
+	BillID      uuid.UUID
+	PayerID     uuid.UUID
+	CreatedBy   uuid.UUID
+	Currency    string
+	Allocations []*SplitAllocation
+	Shares      []*SplitShare
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type SplitShare struct {
+	UserID uuid.UUID
+	Login  string
+	Amount Money
+}
+
+// Balance is what an other user owes to the user it was read for, negative when the user owes it.
+type Balance struct {
+	UserID uuid.UUID
+	Login  string
+	Amount Money
+}
+
+// Settlement is a payment from a user to an other one, it settles what the payer owed.
+type Settlement struct {
+	SettlementID uuid.UUID
+	FromUserID   uuid.UUID
+	ToUserID     uuid.UUID
+	Amount       Money
+	CreatedAt    time.Time
+}
+
+// SplitMoney splits the amount to the cent in proportion to the weights. The cents left by the rounding go
+// to the largest remainders, so the parts always sum to the amount.
+func SplitMoney(amount Money, weights []decimal.Decimal) []Money {
+	parts := make([]Money, len(weights))
+	total := decimal.Zero
+	for _, weight := range weights {
+		total = total.Add(weight)
+	}
+	if len(weights) == 0 || !total.IsPositive() {
+		return parts
+	}
+
+	value := amount.Amount.Abs()
+	remainders := make([]decimal.Decimal, len(weights))
+	left := value
+	for i, weight := range weights {
+		exact := value.Mul(weight).Div(total)
+		part := exact.Truncate(2)
+		parts[i] = NewMoney(part, amount.Currency)
+		remainders[i] = exact.Sub(part)
+		left = left.Sub(part)
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].GreaterThan(remainders[order[b]])
+	})
+	cent := decimal.New(1, -2)
+	for i := 0; left.GreaterThanOrEqual(cent); i++ {
+		index := order[i%len(order)]
+		parts[index].Amount = parts[index].Amount.Add(cent)
+		left = left.Sub(cent)
+	}
+
+	if amount.IsNegative() {
+		for i := range parts {
+			parts[i].Amount = parts[i].Amount.Neg()
+		}
+	}
+	return parts
+}
+
+// ComputeSplit returns what each user owes for the lines of the bill, the payer first. A line without
+// allocation, and the rest of a line split by fixed amounts, goes to the payer. The members of the households
+// of the allocations are given by households. It returns ErrInvalidSplitError when an allocation does not
+// match a line of the bill, the methods of a line differ or the fixed amounts exceed the line.
+func ComputeSplit(payerID uuid.UUID, lines []*UserProduct, allocations []*SplitAllocation, households map[uuid.UUID][]uuid.UUID, currency string) ([]*SplitShare, error) {
+	byLine := make(map[uuid.UUID][]*SplitAllocation)
+	for _, allocation := range allocations {
+		if !IsValidSplitMethod(allocation.Method) || !allocation.Value.IsPositive() {
+			return nil, ErrInvalidSplitError
+		}
+		if allocation.Method == SplitMethodShare && !allocation.Value.Equal(allocation.Value.Truncate(0)) {
+			return nil, ErrInvalidSplitError
+		}
+		if allocation.Method == SplitMethodFixed && !allocation.Value.Equal(allocation.Value.Truncate(2)) {
+			return nil, ErrInvalidSplitError
+		}
+		byLine[allocation.UserProductID] = append(byLine[allocation.UserProductID], allocation)
+	}
+
+	amounts := map[uuid.UUID]decimal.Decimal{payerID: decimal.Zero}
+	order := []uuid.UUID{payerID}
+	add := func(userID uuid.UUID, amount decimal.Decimal) {
+		if _, ok := amounts[userID]; !ok {
+			order = append(order, userID)
+		}
+		amounts[userID] = amounts[userID].Add(amount)
+	}
+	assign := func(allocation *SplitAllocation, part Money) error {
+		if allocation.ParticipantType == SplitParticipantUser {
+			add(allocation.ParticipantID, part.Amount)
+			return nil
+		}
+		members := households[allocation.ParticipantID]
+		if allocation.ParticipantType != SplitParticipantHousehold || len(members) == 0 {
+			return ErrInvalidSplitError
+		}
+		weights := make([]decimal.Decimal, len(members))
+		for i := range weights {
+			weights[i] = decimal.NewFromInt(1)
+		}
+		for i, memberPart := range SplitMoney(part, weights) {
+			add(members[i], memberPart.Amount)
+		}
+		return nil
+	}
+
+	for _, line := range lines {
+		total := NewMoney(line.Price.Mul(line.Quantity).Amount, currency)
+		lineAllocations := byLine[line.UserProductID]
+		delete(byLine, line.UserProductID)
+		if len(lineAllocations) == 0 {
+			add(payerID, total.Amount)
+			continue
+		}
+
+		method := lineAllocations[0].Method
+		for _, allocation := range lineAllocations {
+			if allocation.Method != method {
+				return nil, ErrInvalidSplitError
+			}
+		}
+
+		if method == SplitMethodFixed {
+			rest := total.Amount
+			for _, allocation := range lineAllocations {
+				rest = rest.Sub(allocation.Value)
+				if err := assign(allocation, NewMoney(allocation.Value, currency)); err != nil {
+					return nil, err
+				}
+			}
+			if rest.IsNegative() {
+				return nil, ErrInvalidSplitError
+			}
+			add(payerID, rest)
+			continue
+		}
+
+		weights := make([]decimal.Decimal, len(lineAllocations))
+		for i, allocation := range lineAllocations {
+			weights[i] = allocation.Value
+		}
+		for i, part := range SplitMoney(total, weights) {
+			if err := assign(lineAllocations[i], part); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(byLine) > 0 {
+		return nil, ErrInvalidSplitError
+	}
+
+	shares := make([]*SplitShare, 0, len(order))
+	for _, userID := range order {
+		shares = append(shares, &SplitShare{UserID: userID, Amount: NewMoney(amounts[userID], currency)})
+	}
+	return shares, nil
+}
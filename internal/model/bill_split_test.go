@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+func TestSplitMoney(t *testing.T) {
+	amount := model.NewMoney(decimal.RequireFromString("10"), "EUR")
+	parts := model.SplitMoney(amount, []decimal.Decimal{decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(1)})
+	require.Len(t, parts, 3)
+	assert.Equal(t, "3.34 EUR", parts[0].String())
+	assert.Equal(t, "3.33 EUR", parts[1].String())
+	assert.Equal(t, "3.33 EUR", parts[2].String())
+
+	parts = model.SplitMoney(model.NewMoney(decimal.RequireFromString("-1"), "EUR"), []decimal.Decimal{decimal.RequireFromString("0.5"), decimal.RequireFromString("1")})
+	assert.Equal(t, "-0.33 EUR", parts[0].String())
+	assert.Equal(t, "-0.67 EUR", parts[1].String())
+}
+
+func TestComputeSplit(t *testing.T) {
+	payer := uuid.New()
+	bob := uuid.New()
+	carol := uuid.New()
+	household := uuid.New()
+	wine := &model.UserProduct{UserProductID: uuid.New(), Price: model.NewMoney(decimal.RequireFromString("6"), "EUR"), Quantity: 2}
+	bread := &model.UserProduct{UserProductID: uuid.New(), Price: model.NewMoney(decimal.RequireFromString("1.50"), "EUR"), Quantity: 1}
+	cheese := &model.UserProduct{UserProductID: uuid.New(), Price: model.NewMoney(decimal.RequireFromString("9"), "EUR"), Quantity: 1}
+	lines := []*model.UserProduct{wine, bread, cheese}
+	households := map[uuid.UUID][]uuid.UUID{household: {bob, carol}}
+
+	t.Run("share, weight, fixed and households", func(t *testing.T) {
+		shares, err := model.ComputeSplit(payer, lines, []*model.SplitAllocation{
+			{UserProductID: wine.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: payer, Method: model.SplitMethodShare, Value: decimal.NewFromInt(1)},
+			{UserProductID: wine.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodShare, Value: decimal.NewFromInt(2)},
+			{UserProductID: cheese.UserProductID, ParticipantType: model.SplitParticipantHousehold, ParticipantID: household, Method: model.SplitMethodFixed, Value: decimal.RequireFromString("5")},
+		}, households, "EUR")
+		require.NoError(t, err)
+		require.Len(t, shares, 3)
+		assert.Equal(t, payer, shares[0].UserID)
+		// a third of the wine, the bread and the rest of the cheese
+		assert.Equal(t, "9.50 EUR", shares[0].Amount.String())
+		assert.Equal(t, bob, shares[1].UserID)
+		assert.Equal(t, "10.50 EUR", shares[1].Amount.String())
+		assert.Equal(t, carol, shares[2].UserID)
+		assert.Equal(t, "2.50 EUR", shares[2].Amount.String())
+	})
+
+	t.Run("invalid splits", func(t *testing.T) {
+		tests := map[string]*model.SplitAllocation{
+			"unknown line":       {UserProductID: uuid.New(), ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodShare, Value: decimal.NewFromInt(1)},
+			"fixed above line":   {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodFixed, Value: decimal.NewFromInt(2)},
+			"partial share":      {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodShare, Value: decimal.RequireFromString("0.5")},
+			"zero weight":        {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodWeight, Value: decimal.Zero},
+			"unknown household":  {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantHousehold, ParticipantID: uuid.New(), Method: model.SplitMethodWeight, Value: decimal.NewFromInt(1)},
+			"unknown method":     {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: "percent", Value: decimal.NewFromInt(1)},
+			"fixed under a cent": {UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodFixed, Value: decimal.RequireFromString("0.001")},
+		}
+		for name, allocation := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := model.ComputeSplit(payer, lines, []*model.SplitAllocation{allocation}, households, "EUR")
+				assert.ErrorIs(t, err, model.ErrInvalidSplitError)
+			})
+		}
+
+		_, err := model.ComputeSplit(payer, lines, []*model.SplitAllocation{
+			{UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: bob, Method: model.SplitMethodShare, Value: decimal.NewFromInt(1)},
+			{UserProductID: bread.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: carol, Method: model.SplitMethodWeight, Value: decimal.NewFromInt(1)},
+		}, households, "EUR")
+		assert.ErrorIs(t, err, model.ErrInvalidSplitError)
+	})
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	BillStateCreate    = "create"
+	BillStateCompleted = "complete"
+	BillStateCanceled  = "cancel"
+	BillStateArchived  = "archive"
+)
+
+const (
+	BillEventStart   = "start"
+	BillEventClose   = "close"
+	BillEventCancel  = "cancel"
+	BillEventReopen  = "reopen"
+	BillEventArchive = "archive"
+)
+
+// billTransitions gives, for each event, the state reached from each state allowing it. A started bill is
+// closed or canceled, a closed bill is reopened for corrections or archived. A canceled bill is not archived,
+// so an archived bill was always completed.
+var billTransitions = map[string]map[string]string{
+	BillEventClose:   {BillStateCreate: BillStateCompleted},
+	BillEventCancel:  {BillStateCreate: BillStateCanceled},
+	BillEventReopen:  {BillStateCompleted: BillStateCreate},
+	BillEventArchive: {BillStateCompleted: BillStateArchived},
+}
+
+// NextBillState returns the state reached by the event, ErrBillTransitionError when the event is not
+// allowed in the state.
+func NextBillState(state, event string) (string, error) {
+	next, ok := billTransitions[event][state]
+	if !ok {
+		return "", ErrBillTransitionError
+	}
+	return next, nil
+}
+
+// BillEvent is a transition of a bill. ActorID is the user who made it, PreviousAmount and Amount are the
+// declared amounts before and after it. FromState is empty for the start event.
+type BillEvent struct {
+	BillEventID    uuid.UUID
+	BillID         uuid.UUID
+	ActorID        uuid.UUID
+	Event          string
+	FromState      string
+	ToState        string
+	PreviousAmount Money
+	Amount         Money
+	CreatedAt      time.Time
+}
@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+func TestNextBillState(t *testing.T) {
+	allowed := []struct {
+		state, event, next string
+	}{
+		{model.BillStateCreate, model.BillEventClose, model.BillStateCompleted},
+		{model.BillStateCreate, model.BillEventCancel, model.BillStateCanceled},
+		{model.BillStateCompleted, model.BillEventReopen, model.BillStateCreate},
+		{model.BillStateCompleted, model.BillEventArchive, model.BillStateArchived},
+	}
+	for _, a := range allowed {
+		next, err := model.NextBillState(a.state, a.event)
+		assert.NoError(t, err, a.state+" "+a.event)
+		assert.Equal(t, a.next, next, a.state+" "+a.event)
+	}
+
+	forbidden := []struct {
+		state, event string
+	}{
+		{model.BillStateCreate, model.BillEventReopen},
+		{model.BillStateCreate, model.BillEventArchive},
+		{model.BillStateCompleted, model.BillEventClose},
+		{model.BillStateCanceled, model.BillEventReopen},
+		{model.BillStateCanceled, model.BillEventArchive},
+		{model.BillStateArchived, model.BillEventReopen},
+		{model.BillStateArchived, model.BillEventArchive},
+		{model.BillStateCreate, "unknown"},
+	}
+	for _, f := range forbidden {
+		_, err := model.NextBillState(f.state, f.event)
+		assert.ErrorIs(t, err, model.ErrBillTransitionError, f.state+" "+f.event)
+	}
+}
@@ -0,0 +1,23 @@
+package model_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"testing"
+	"time"
+)
+
+func TestBillCursor(t *testing.T) {
+	bill := &model.Bill{BillID: uuid.New(), CreatedAt: time.Date(2024, 3, 12, 18, 42, 0, 123456000, time.UTC)}
+
+	cursor, err := model.DecodeBillCursor(model.NewBillCursor(model.BillSortDate, bill).Encode())
+	require.NoError(t, err)
+	assert.Equal(t, &model.BillCursor{Sort: model.BillSortDate, Value: "2024-03-12T18:42:00.123456Z", BillID: bill.BillID}, cursor)
+
+	_, err = model.DecodeBillCursor("not a cursor")
+	assert.ErrorIs(t, err, model.ErrInvalidCursorError)
+	_, err = model.DecodeBillCursor("e30")
+	assert.ErrorIs(t, err, model.ErrInvalidCursorError)
+}
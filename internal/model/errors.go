@@ -2,20 +2,140 @@ package model
 
 import "errors"
 
+// ErrorKind tells what went wrong, the API answers the same status for all the errors of a kind.
+type ErrorKind int
+
+const (
+	// KindInternal is a failure of the server or of a service it depends on, the request may be retried.
+	KindInternal ErrorKind = iota
+	// KindInvalidRequest is a request that can not be read: a malformed body, query or path parameter.
+	KindInvalidRequest
+	// KindInvalid is a well-formed request whose values are refused.
+	KindInvalid
+	KindNotFound
+	KindConflict
+	KindUnauthorized
+	KindForbidden
+	KindTooManyRequests
+	KindUnavailable
+	KindUnsupportedMediaType
+)
+
+// Error is a domain error. Code is stable and machine-readable, the clients branch on it, Message can be
+// shown to the user.
+type Error struct {
+	Code    string
+	Kind    ErrorKind
+	Message string
+}
+
+func NewError(code string, kind ErrorKind, message string) error {
+	return &Error{
+		Code:    code,
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// AsError returns the domain error in the chain of err, nil when err is not one.
+func AsError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return nil
+}
+
 var (
-	ErrUserError            = errors.New("an error occurred on user")
-	ErrUserNotFound         = errors.New("user not found")
-	ErrPasswordError        = errors.New("invalid password")
-	ErrOldPasswordError     = errors.New("invalid old password")
-	ErrInsertCompanyError   = errors.New("error on insert company")
-	ErrSelectCompaniesError = errors.New("error on select companies")
-	ErrBrandExists          = errors.New("error brand exists")
-	ErrBrandError           = errors.New("brand error")
-	ErrCompanyExists        = errors.New("error company exists")
-	ErrCompanyError         = errors.New("error company error")
-	ErrBillError            = errors.New("bill error")
-	ErrStoreError           = errors.New("store error")
-	ErrProductError         = errors.New("product error")
-	ErrNotExistsError       = errors.New("product not exists")
-	ErrUserProductError     = errors.New("user product error")
+	ErrInvalidRequestError              = NewError("invalid_request", KindInvalidRequest, "malformed request")
+	ErrUnsupportedMediaTypeError        = NewError("unsupported_media_type", KindUnsupportedMediaType, "unsupported media type")
+	ErrInternalError                    = NewError("internal_error", KindInternal, "internal server error")
+	ErrUserError                        = NewError("user_error", KindInternal, "an error occurred on user")
+	ErrUserNotFound                     = NewError("user_not_found", KindNotFound, "user not found")
+	ErrOldPasswordError                 = NewError("invalid_old_password", KindInvalid, "invalid old password")
+	ErrInsertCompanyError               = NewError("company_insert_error", KindInternal, "error on insert company")
+	ErrSelectCompaniesError             = NewError("company_select_error", KindInternal, "error on select companies")
+	ErrBrandExists                      = NewError("brand_exists", KindConflict, "error brand exists")
+	ErrBrandError                       = NewError("brand_error", KindInternal, "brand error")
+	ErrCompanyExists                    = NewError("company_exists", KindConflict, "error company exists")
+	ErrCompanyError                     = NewError("company_error", KindInternal, "error company error")
+	ErrBillError                        = NewError("bill_error", KindInternal, "bill error")
+	ErrStoreError                       = NewError("store_error", KindInternal, "store error")
+	ErrInvalidStoreError                = NewError("invalid_store", KindInvalid, "invalid store")
+	ErrProductError                     = NewError("product_error", KindInternal, "product error")
+	ErrNotExistsError                   = NewError("product_not_found", KindNotFound, "product not exists")
+	ErrUserProductError                 = NewError("user_product_error", KindInternal, "user product error")
+	ErrInvalidQuantityError             = NewError("invalid_quantity", KindInvalid, "the quantity must be positive")
+	ErrInvalidPriceError                = NewError("invalid_price", KindInvalid, "the price can't be negative")
+	ErrPriceError                       = NewError("price_error", KindInternal, "price error")
+	ErrPriceHistoryError                = NewError("price_history_error", KindInternal, "price history error")
+	ErrPriceHistoryRangeError           = NewError("invalid_price_history_range", KindInvalid, "price history range error")
+	ErrShoppingListError                = NewError("shopping_list_error", KindInternal, "shopping list error")
+	ErrShoppingListTooLongError         = NewError("shopping_list_too_long", KindInvalid, "too many items in the shopping list")
+	ErrInvalidMoneyError                = NewError("invalid_money", KindInvalid, "invalid money")
+	ErrCurrencyMismatchError            = NewError("currency_mismatch", KindInvalid, "amounts in different currencies")
+	ErrInvalidCurrencyError             = NewError("invalid_currency", KindInvalid, "unknown currency")
+	ErrReceiptError                     = NewError("receipt_error", KindInternal, "receipt error")
+	ErrInvalidReceiptError              = NewError("invalid_receipt", KindInvalid, "the receipt could not be read")
+	ErrBillRangeError                   = NewError("invalid_bill_range", KindInvalid, "bill range error")
+	ErrInvalidCursorError               = NewError("invalid_cursor", KindInvalid, "invalid cursor")
+	ErrBillNotOpenError                 = NewError("bill_not_open", KindConflict, "bill not open")
+	ErrBillNotFoundError                = NewError("bill_not_found", KindNotFound, "bill not found")
+	ErrBillTransitionError              = NewError("bill_transition_not_allowed", KindConflict, "bill transition not allowed")
+	ErrUserProductNotFoundError         = NewError("user_product_not_found", KindNotFound, "user product not found")
+	ErrSessionError                     = NewError("session_error", KindInternal, "session error")
+	ErrSessionNotFoundError             = NewError("session_not_found", KindNotFound, "session not found")
+	ErrTokenMissingError                = NewError("token_missing", KindUnauthorized, "authorization token not provided")
+	ErrInvalidTokenError                = NewError("invalid_token", KindUnauthorized, "invalid authorization token")
+	ErrTokenExpiredError                = NewError("token_expired", KindUnauthorized, "token expired")
+	ErrInvalidRefreshTokenError         = NewError("invalid_refresh_token", KindUnauthorized, "invalid refresh token")
+	ErrRefreshTokenReusedError          = NewError("refresh_token_reused", KindUnauthorized, "refresh token reused")
+	ErrInvalidUserTokenError            = NewError("invalid_user_token", KindInvalid, "invalid or expired token")
+	ErrEmailExistError                  = NewError("email_exists", KindConflict, "email already used")
+	ErrLoginExistError                  = NewError("login_exists", KindConflict, "login already used")
+	ErrMailError                        = NewError("mail_error", KindUnavailable, "mail error")
+	ErrForbiddenError                   = NewError("forbidden", KindForbidden, "forbidden")
+	ErrUserDisabledError                = NewError("user_disabled", KindForbidden, "user disabled")
+	ErrInvalidRoleError                 = NewError("invalid_role", KindInvalid, "invalid role")
+	ErrOwnAccountError                  = NewError("own_account", KindForbidden, "cannot change own account")
+	ErrAdminError                       = NewError("admin_error", KindInternal, "admin error")
+	ErrCatalogNotFoundError             = NewError("catalog_not_found", KindNotFound, "catalog entity not found")
+	ErrCatalogConflictError             = NewError("catalog_conflict", KindConflict, "catalog entity already exists")
+	ErrInvalidCredentialsError          = NewError("invalid_credentials", KindUnauthorized, "invalid credentials")
+	ErrTooManyAttemptsError             = NewError("too_many_attempts", KindTooManyRequests, "too many failed login attempts, retry later")
+	ErrOIDCDisabledError                = NewError("oidc_disabled", KindNotFound, "oidc login disabled")
+	ErrInvalidOIDCStateError            = NewError("invalid_oidc_state", KindUnauthorized, "invalid or expired login state")
+	ErrOIDCError                        = NewError("oidc_error", KindUnauthorized, "oidc login failed")
+	ErrOIDCAccountNotFoundError         = NewError("oidc_account_not_found", KindForbidden, "no account linked to this identity")
+	ErrAPIKeyError                      = NewError("api_key_error", KindInternal, "api key error")
+	ErrAPIKeyNotFoundError              = NewError("api_key_not_found", KindNotFound, "api key not found")
+	ErrAPIKeyExpiredError               = NewError("api_key_expired", KindUnauthorized, "api key expired")
+	ErrInvalidScopeError                = NewError("invalid_scope", KindInvalid, "invalid scope")
+	ErrInvalidExpiryError               = NewError("invalid_expiry", KindInvalid, "expiry must be in the future")
+	ErrTwoFactorError                   = NewError("two_factor_error", KindInternal, "two-factor error")
+	ErrInvalidTwoFactorCodeError        = NewError("invalid_two_factor_code", KindUnauthorized, "invalid two-factor code")
+	ErrInvalidLoginChallengeError       = NewError("invalid_login_challenge", KindUnauthorized, "invalid or expired login challenge")
+	ErrTwoFactorEnabledError            = NewError("two_factor_enabled", KindConflict, "two-factor already enabled")
+	ErrTwoFactorNotEnrolledError        = NewError("two_factor_not_enrolled", KindNotFound, "two-factor not enrolled")
+	ErrHouseholdError                   = NewError("household_error", KindInternal, "household error")
+	ErrHouseholdNotFoundError           = NewError("household_not_found", KindNotFound, "household not found")
+	ErrInvalidHouseholdNameError        = NewError("invalid_household_name", KindInvalid, "the household needs a name")
+	ErrHouseholdOwnerRequiredError      = NewError("household_owner_required", KindForbidden, "only an owner of the household can do this")
+	ErrHouseholdMemberNotFoundError     = NewError("household_member_not_found", KindNotFound, "household member not found")
+	ErrHouseholdMemberExistsError       = NewError("household_member_exists", KindConflict, "already a member of the household")
+	ErrHouseholdLastOwnerError          = NewError("household_last_owner", KindConflict, "the household needs an other owner first")
+	ErrInvalidHouseholdRoleError        = NewError("invalid_household_role", KindInvalid, "invalid household role")
+	ErrHouseholdInvitationNotFoundError = NewError("household_invitation_not_found", KindNotFound, "household invitation not found")
+	ErrBillNotClosedError               = NewError("bill_not_closed", KindConflict, "bill not closed")
+	ErrBillSplitError                   = NewError("bill_split_error", KindInternal, "bill split error")
+	ErrBillSplitNotFoundError           = NewError("bill_split_not_found", KindNotFound, "bill split not found")
+	ErrInvalidSplitError                = NewError("invalid_split", KindInvalid, "invalid bill split")
+	ErrInvalidSettlementError           = NewError("invalid_settlement", KindInvalid, "invalid settlement")
+	ErrAccountError                     = NewError("account_error", KindInternal, "an error occurred on the account")
+	ErrAccountDeletionScheduledError    = NewError("account_deletion_scheduled", KindConflict, "account deletion already scheduled")
+	ErrAccountDeletionNotFoundError     = NewError("account_deletion_not_found", KindNotFound, "no account deletion scheduled")
 )
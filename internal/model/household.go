@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	HouseholdRoleOwner  = "owner"
+	HouseholdRoleMember = "member"
+)
+
+func IsValidHouseholdRole(role string) bool {
+	return role == HouseholdRoleOwner || role == HouseholdRoleMember
+}
+
+// Household is a group of users sharing their bills. Role is the role of the user it was read for.
+type Household struct {
+	HouseholdID uuid.UUID
+	Name        string
+	CreatedBy   uuid.UUID
+	Role        string
+	CreatedAt   time.Time
+}
+
+// HouseholdMember sees and changes every bill of the household, its owners also manage the members.
+type HouseholdMember struct {
+	HouseholdID uuid.UUID
+	UserID      uuid.UUID
+	Login       string
+	Role        string
+	JoinedAt    time.Time
+}
+
+func (hm *HouseholdMember) IsOwner() bool {
+	return hm.Role == HouseholdRoleOwner
+}
+
+// HouseholdInvitation asks a user to join the household with the role, until it is answered or expires.
+type HouseholdInvitation struct {
+	HouseholdInvitationID uuid.UUID
+	HouseholdID           uuid.UUID
+	HouseholdName         string
+	UserID                uuid.UUID
+	InvitedBy             uuid.UUID
+	Role                  string
+	ExpiresAt             time.Time
+	CreatedAt             time.Time
+}
+
+// HouseholdSpending is the total of the closed bills of the household started by a member, in one currency.
+type HouseholdSpending struct {
+	UserID uuid.UUID
+	Login  string
+	Amount Money
+	Bills  int64
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// The failed attempts are counted per login and per ip.
+const (
+	LoginThrottleLogin = "login"
+	LoginThrottleIP    = "ip"
+)
+
+// The reasons of a failed login attempt.
+const (
+	LoginAttemptInvalidCredentials = "invalid_credentials"
+	LoginAttemptLocked             = "locked"
+	LoginAttemptDisabled           = "disabled"
+	// the password was right, the login waits for its second factor
+	LoginAttemptTwoFactorRequired    = "two_factor_required"
+	LoginAttemptInvalidTwoFactorCode = "invalid_two_factor_code"
+)
+
+// LoginAttempt is one call to the login, UserID is only known when the login exists.
+type LoginAttempt struct {
+	LoginAttemptID uuid.UUID
+	Login          string
+	IP             string
+	UserID         *uuid.UUID
+	Success        bool
+	Reason         string
+	CreatedAt      time.Time
+}
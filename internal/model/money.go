@@ -0,0 +1,224 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const DefaultCurrency = "EUR"
+
+var (
+	currencySymbols = map[string]string{
+		"€": "EUR",
+		"$": "USD",
+		"£": "GBP",
+	}
+	// currencyCodes are the ISO 4217 codes of the circulating currencies, without the funds and metals.
+	currencyCodes = map[string]bool{
+		"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true, "AWG": true, "AZN": true,
+		"BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true, "BOB": true, "BRL": true,
+		"BSD": true, "BTN": true, "BWP": true, "BYN": true, "BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true,
+		"COP": true, "CRC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true,
+		"ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true, "GIP": true, "GMD": true,
+		"GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true,
+		"IQD": true, "IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true,
+		"KPW": true, "KRW": true, "KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true,
+		"LYD": true, "MAD": true, "MDL": true, "MGA": true, "MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true,
+		"MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true, "NGN": true, "NIO": true, "NOK": true, "NPR": true,
+		"NZD": true, "OMR": true, "PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true,
+		"RON": true, "RSD": true, "RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+		"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true, "SYP": true, "SZL": true, "THB": true,
+		"TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true, "UAH": true, "UGX": true,
+		"USD": true, "UYU": true, "UZS": true, "VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XCG": true,
+		"XOF": true, "XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWG": true,
+	}
+	// 2.49 or 2,49
+	plainAmountRegexp = regexp.MustCompile(`^-?[0-9]+([.,][0-9]+)?$`)
+	// 1.234,56
+	dotGroupedAmountRegexp = regexp.MustCompile(`^-?[0-9]{1,3}(\.[0-9]{3})+(,[0-9]+)?$`)
+	// 1,234.56
+	commaGroupedAmountRegexp = regexp.MustCompile(`^-?[0-9]{1,3}(,[0-9]{3})+(\.[0-9]+)?$`)
+)
+
+// Money is a decimal amount in a currency, the currency is an ISO 4217 code.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Amount: amount, Currency: currency}
+}
+
+func ZeroMoney(currency string) Money {
+	return NewMoney(decimal.Zero, currency)
+}
+
+// ParseMoney reads an amount as typed by a user: "2.49", "2,49", "1 234,56 €", "1,234.56 USD".
+// Without currency in the input the DefaultCurrency is used.
+func ParseMoney(s string) (Money, error) {
+	value := strings.TrimSpace(s)
+	currency := ""
+	for symbol, code := range currencySymbols {
+		if strings.Contains(value, symbol) {
+			currency = code
+			value = strings.ReplaceAll(value, symbol, "")
+		}
+	}
+	if currency == "" {
+		value, currency = cutCurrencyCode(value)
+	}
+
+	amount, err := ParseDecimal(value)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoneyError, s)
+	}
+
+	return NewMoney(amount, currency), nil
+}
+
+// ParseDecimal reads a number written with "." or "," as decimal separator, the other one can group
+// the thousands. It follows the parse_money function of the money migration.
+func ParseDecimal(s string) (decimal.Decimal, error) {
+	value := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\'' {
+			return -1
+		}
+		return r
+	}, s)
+
+	switch {
+	case plainAmountRegexp.MatchString(value):
+		value = strings.Replace(value, ",", ".", 1)
+	case dotGroupedAmountRegexp.MatchString(value):
+		value = strings.Replace(strings.ReplaceAll(value, ".", ""), ",", ".", 1)
+	case commaGroupedAmountRegexp.MatchString(value):
+		value = strings.ReplaceAll(value, ",", "")
+	default:
+		return decimal.Zero, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	return decimal.NewFromString(value)
+}
+
+// ParseCurrency upper cases an ISO 4217 code, ErrInvalidCurrencyError when it isn't a known currency.
+func ParseCurrency(code string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(code))
+	if !currencyCodes[currency] {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrencyError, code)
+	}
+	return currency, nil
+}
+
+func cutCurrencyCode(value string) (string, string) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return value, ""
+	}
+	if code := strings.ToUpper(fields[0]); currencyCodes[code] {
+		return strings.Join(fields[1:], ""), code
+	}
+	if code := strings.ToUpper(fields[len(fields)-1]); currencyCodes[code] {
+		return strings.Join(fields[:len(fields)-1], ""), code
+	}
+	return value, ""
+}
+
+// Add sums two amounts of the same currency, ErrCurrencyMismatchError otherwise. An amount without currency
+// takes the one of the other.
+func (m Money) Add(o Money) (Money, error) {
+	currency := m.Currency
+	if currency == "" {
+		currency = o.Currency
+	}
+	if o.Currency != "" && o.Currency != currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatchError, currency, o.Currency)
+	}
+	return NewMoney(m.Amount.Add(o.Amount), currency), nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(NewMoney(o.Amount.Neg(), o.Currency))
+}
+
+func (m Money) Mul(quantity int64) Money {
+	return NewMoney(m.Amount.Mul(decimal.NewFromInt(quantity)), m.Currency)
+}
+
+func (m Money) Cmp(o Money) int {
+	return m.Amount.Cmp(o.Amount)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount.IsNegative()
+}
+
+func (m Money) String() string {
+	return m.Amount.StringFixed(2) + " " + m.Currency
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return json.Marshal(moneyJSON{Amount: m.Amount.StringFixed(2), Currency: currency})
+}
+
+// UnmarshalJSON accepts a number (2.49), a string ("2,49 €") or an object ({"amount": "2,49", "currency": "EUR"}).
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+	case bytes.HasPrefix(data, []byte("{")):
+		var mj moneyJSON
+		if err := json.Unmarshal(data, &mj); err != nil {
+			return err
+		}
+		money, err := ParseMoney(mj.Amount)
+		if err != nil {
+			return err
+		}
+		if mj.Currency != "" {
+			if money.Currency, err = ParseCurrency(mj.Currency); err != nil {
+				return err
+			}
+		}
+		*m = money
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		money, err := ParseMoney(s)
+		if err != nil {
+			return err
+		}
+		*m = money
+	default:
+		money, err := ParseMoney(string(data))
+		if err != nil {
+			return err
+		}
+		*m = money
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package model_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		amount   string
+		currency string
+	}{
+		{input: "2.49", amount: "2.49", currency: "EUR"},
+		{input: "2,49", amount: "2.49", currency: "EUR"},
+		{input: " 2,49 € ", amount: "2.49", currency: "EUR"},
+		{input: "1 234,56", amount: "1234.56", currency: "EUR"},
+		{input: "1.234,56", amount: "1234.56", currency: "EUR"},
+		{input: "1,234.56 USD", amount: "1234.56", currency: "USD"},
+		{input: "£3", amount: "3", currency: "GBP"},
+		{input: "1.234.567", amount: "1234567", currency: "EUR"},
+		{input: "-0,50", amount: "-0.5", currency: "EUR"},
+		{input: "chf 12", amount: "12", currency: "CHF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m, err := model.ParseMoney(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, m.Amount.String())
+			assert.Equal(t, tt.currency, m.Currency)
+		})
+	}
+
+	for _, input := range []string{"", "n/a", "2,4,9.1.2", "1..2", "EUR", "2.49 ABC"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := model.ParseMoney(input)
+			assert.ErrorIs(t, err, model.ErrInvalidMoneyError)
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var req struct {
+		Number model.Money `json:"number"`
+		String model.Money `json:"string"`
+		Object model.Money `json:"object"`
+	}
+	err := json.Unmarshal([]byte(`{"number": 2.49, "string": "2,49", "object": {"amount": "2,49", "currency": "usd"}}`), &req)
+	require.NoError(t, err)
+	assert.Equal(t, "2.49 EUR", req.Number.String())
+	assert.Equal(t, "2.49 EUR", req.String.String())
+	assert.Equal(t, "2.49 USD", req.Object.String())
+
+	err = json.Unmarshal([]byte(`{"string": "two euros"}`), &req)
+	assert.ErrorIs(t, err, model.ErrInvalidMoneyError)
+
+	err = json.Unmarshal([]byte(`{"object": {"amount": "2,49", "currency": "xyz"}}`), &req)
+	assert.ErrorIs(t, err, model.ErrInvalidCurrencyError)
+
+	data, err := json.Marshal(req.Object.Mul(3))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": "7.47", "currency": "USD"}`, string(data))
+}
+
+func TestParseCurrency(t *testing.T) {
+	currency, err := model.ParseCurrency(" usd")
+	require.NoError(t, err)
+	assert.Equal(t, "USD", currency)
+
+	for _, code := range []string{"", "EURO", "XAU", "ABC"} {
+		t.Run("invalid "+code, func(t *testing.T) {
+			_, err := model.ParseCurrency(code)
+			assert.ErrorIs(t, err, model.ErrInvalidCurrencyError)
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := money(t, "2,49").Add(money(t, "0,51"))
+	require.NoError(t, err)
+	assert.Equal(t, "3.00 EUR", sum.String())
+
+	diff, err := money(t, "2,49").Sub(money(t, "3"))
+	require.NoError(t, err)
+	assert.Equal(t, "-0.51 EUR", diff.String())
+
+	_, err = money(t, "2,49").Add(money(t, "1 USD"))
+	assert.ErrorIs(t, err, model.ErrCurrencyMismatchError)
+	_, err = money(t, "2,49").Sub(money(t, "1 USD"))
+	assert.ErrorIs(t, err, model.ErrCurrencyMismatchError)
+}
+
+func TestUnitPrice(t *testing.T) {
+	unitPrice, ok := model.UnitPrice(money(t, "1,50"), "500", model.SizeFormatWeightGr)
+	require.True(t, ok)
+	assert.Equal(t, "3.00 EUR", unitPrice.String())
+
+	_, ok = model.UnitPrice(money(t, "1,50"), "", model.SizeFormatWeightGr)
+	assert.False(t, ok)
+}
+
+func money(t *testing.T, amount string) model.Money {
+	m, err := model.ParseMoney(amount)
+	require.NoError(t, err)
+	return m
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	PriceSortPrice     = "price"
+	PriceSortUnitPrice = "unit_price"
+)
+
+type PriceFilter struct {
+	ZipCode   string
+	CompanyID uuid.UUID
+	Sort      string
+}
+
+type StorePrice struct {
+	UserProductID uuid.UUID
+	ProductID     uuid.UUID
+	ProductName   string
+	Ean           string
+	StoreID       uuid.UUID
+	StoreName     string
+	ZipCode       string
+	City          string
+	CompanyID     uuid.UUID
+	CompanyName   string
+	Price         Money
+	ProductSize   string
+	SizeFormat    string
+	PricedAt      time.Time
+}
+
+// UnitPrice returns the price per kilogram or per liter, false when the size of the product is unknown.
+func UnitPrice(price Money, productSize, sizeFormat string) (Money, bool) {
+	size, err := ParseDecimal(productSize)
+	if err != nil || !size.IsPositive() {
+		return Money{}, false
+	}
+
+	switch sizeFormat {
+	case SizeFormatWeightGr, SizeFormatVolumeMl:
+		size = size.Div(decimal.NewFromInt(1000))
+	case SizeFormatWeightKg, SizeFormatVolumeL:
+	default:
+		return Money{}, false
+	}
+
+	return NewMoney(price.Amount.Div(size), price.Currency), true
+}
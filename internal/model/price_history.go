@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	PriceHistoryIntervalDay   = "day"
+	PriceHistoryIntervalWeek  = "week"
+	PriceHistoryIntervalMonth = "month"
+)
+
+type PriceHistoryFilter struct {
+	Interval  string
+	StoreID   uuid.UUID
+	CompanyID uuid.UUID
+	From      time.Time
+	To        time.Time
+}
+
+type PricePoint struct {
+	Period      time.Time
+	MinPrice    Money
+	MaxPrice    Money
+	AvgPrice    Money
+	MedianPrice Money
+	Count       int64
+}
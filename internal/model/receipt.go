@@ -0,0 +1,51 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReceiptLine is an item read on a receipt, Total is the amount printed on the line.
+type ReceiptLine struct {
+	Name      string
+	Quantity  int64
+	UnitPrice Money
+	Total     Money
+}
+
+// ReceiptDraft is a receipt line waiting for the user to confirm it as a user product of the bill.
+// Match is the closest product the user already bought at the company of the store, nil without match.
+type ReceiptDraft struct {
+	BillID uuid.UUID
+	Line   *ReceiptLine
+	Match  *UserProduct
+	Score  float64
+}
+
+// ParsedReceipt is a whole receipt read by a retailer parser. Discounts hold the negative lines, Total is the
+// amount paid, the sum of the lines and discounts when the receipt does not print it.
+type ParsedReceipt struct {
+	StoreName string
+	Date      time.Time
+	Lines     []*ReceiptLine
+	Discounts []*ReceiptLine
+	Total     Money
+}
+
+// Sum adds the lines and the discounts of the receipt, ErrCurrencyMismatchError when they are not all in the
+// currency of the total.
+func (pr *ParsedReceipt) Sum() (Money, error) {
+	sum := ZeroMoney(pr.Total.Currency)
+	var err error
+	for _, line := range pr.Lines {
+		if sum, err = sum.Add(line.Total); err != nil {
+			return Money{}, err
+		}
+	}
+	for _, discount := range pr.Discounts {
+		if sum, err = sum.Add(discount.Total); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
@@ -1,8 +1,11 @@
 package request
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+)
 
 type CloseBill struct {
-	BillID uuid.UUID `json:"bill_id" binding:"required"`
-	Amount string    `json:"amount" binding:"required"`
+	BillID uuid.UUID    `json:"bill_id" binding:"required"`
+	Amount *model.Money `json:"amount" binding:"required"`
 }
@@ -0,0 +1,9 @@
+package request
+
+import "time"
+
+type CreateAPIKey struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type ForgotPassword struct {
+	Email string `json:"email" binding:"required"`
+}
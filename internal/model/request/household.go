@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+type CreateHousehold struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// InviteHouseholdMember invites the user of the login, as a member without role.
+type InviteHouseholdMember struct {
+	Login string `json:"login" binding:"required"`
+	Role  string `json:"role" binding:"omitempty,oneof=owner member"`
+}
+
+type SetHouseholdRole struct {
+	Role string `json:"role" binding:"required,oneof=owner member"`
+}
+
+type SearchHouseholdSpending struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
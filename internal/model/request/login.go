@@ -3,4 +3,5 @@ package request
 type Login struct {
 	Login    string `json:"login" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	Device   string `json:"device" binding:"max=100"`
 }
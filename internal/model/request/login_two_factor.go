@@ -0,0 +1,6 @@
+package request
+
+type LoginTwoFactor struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
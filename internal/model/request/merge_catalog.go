@@ -0,0 +1,8 @@
+package request
+
+import "github.com/google/uuid"
+
+// MergeCatalog names the entity that replaces the one of the path.
+type MergeCatalog struct {
+	TargetID uuid.UUID `json:"target_id" binding:"required"`
+}
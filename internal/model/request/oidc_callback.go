@@ -0,0 +1,6 @@
+package request
+
+type OIDCCallback struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
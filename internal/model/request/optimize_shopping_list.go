@@ -0,0 +1,17 @@
+package request
+
+import "github.com/google/uuid"
+
+type OptimizeShoppingList struct {
+	Items     []ShoppingListItem `json:"items" binding:"required,min=1,dive"`
+	MaxStores int                `json:"max_stores" binding:"omitempty,min=1,max=5"`
+	ZipCode   string             `json:"zip_code"`
+	CompanyID uuid.UUID          `json:"company_id"`
+	Currency  string             `json:"currency"`
+}
+
+type ShoppingListItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Ean       string    `json:"ean"`
+	Quantity  int64     `json:"quantity" binding:"required,min=1"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type Refresh struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
@@ -0,0 +1,16 @@
+package request
+
+import "time"
+
+type SearchBills struct {
+	HouseholdID string    `form:"household_id" binding:"omitempty,uuid"`
+	StoreID     string    `form:"store_id" binding:"omitempty,uuid"`
+	CompanyID   string    `form:"company_id" binding:"omitempty,uuid"`
+	State       string    `form:"state" binding:"omitempty,oneof=create complete cancel archive"`
+	From        time.Time `form:"from" time_format:"2006-01-02"`
+	To          time.Time `form:"to" time_format:"2006-01-02"`
+	Sort        string    `form:"sort" binding:"omitempty,oneof=date amount"`
+	Order       string    `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit       int       `form:"limit" binding:"omitempty,min=1,max=200"`
+	Cursor      string    `form:"cursor"`
+}
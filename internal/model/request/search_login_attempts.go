@@ -0,0 +1,7 @@
+package request
+
+type SearchLoginAttempts struct {
+	Login string `form:"login"`
+	IP    string `form:"ip"`
+	Limit int    `form:"limit" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type SearchPrice struct {
+	ZipCode   string `form:"zip_code"`
+	CompanyID string `form:"company_id" binding:"omitempty,uuid"`
+	Sort      string `form:"sort" binding:"omitempty,oneof=price unit_price"`
+}
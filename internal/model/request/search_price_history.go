@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+type SearchPriceHistory struct {
+	Interval  string    `form:"interval" binding:"omitempty,oneof=day week month"`
+	StoreID   string    `form:"store_id" binding:"omitempty,uuid"`
+	CompanyID string    `form:"company_id" binding:"omitempty,uuid"`
+	From      time.Time `form:"from" time_format:"2006-01-02"`
+	To        time.Time `form:"to" time_format:"2006-01-02"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type SetRole struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"shop-aggregator/internal/model"
+)
+
+// SplitBill assigns the lines of a closed bill, a line without allocation stays to the payer.
+type SplitBill struct {
+	Allocations []SplitAllocation `json:"allocations" binding:"dive"`
+}
+
+// SplitAllocation gives a part of the line to a user or to a household, the value is a share, a weight or an
+// amount depending on the method.
+type SplitAllocation struct {
+	UserProductID uuid.UUID       `json:"user_product_id" binding:"required"`
+	UserID        uuid.UUID       `json:"user_id"`
+	HouseholdID   uuid.UUID       `json:"household_id"`
+	Method        string          `json:"method" binding:"required,oneof=share weight fixed"`
+	Value         decimal.Decimal `json:"value"`
+}
+
+type Settle struct {
+	UserID uuid.UUID    `json:"user_id" binding:"required"`
+	Amount *model.Money `json:"amount" binding:"required"`
+}
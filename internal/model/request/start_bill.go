@@ -2,6 +2,8 @@ package request
 
 import "github.com/google/uuid"
 
+// StartBill starts a personal bill, or a bill of the household when HouseholdID is set.
 type StartBill struct {
-	StoreID uuid.UUID `json:"store_id" binding:"required"`
+	StoreID     uuid.UUID `json:"store_id" binding:"required"`
+	HouseholdID uuid.UUID `json:"household_id"`
 }
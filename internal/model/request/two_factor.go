@@ -0,0 +1,11 @@
+package request
+
+type ConfirmTwoFactor struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactor asks for the password of the users who have one.
+type DisableTwoFactor struct {
+	Password string `json:"password"`
+	Code     string `json:"code" binding:"required"`
+}
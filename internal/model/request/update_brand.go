@@ -0,0 +1,5 @@
+package request
+
+type UpdateBrand struct {
+	BrandName string `json:"name" binding:"required"`
+}
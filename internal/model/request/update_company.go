@@ -0,0 +1,5 @@
+package request
+
+type UpdateCompany struct {
+	CompanyName string `json:"company_name" binding:"required"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type UpdateProduct struct {
+	EAN         string `json:"ean" binding:"required"`
+	ProductName string `json:"product_name" binding:"required"`
+}
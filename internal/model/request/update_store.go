@@ -0,0 +1,11 @@
+package request
+
+type UpdateStore struct {
+	Address   string `json:"address"`
+	ZipCode   string `json:"zip_code"`
+	City      string `json:"city"`
+	Country   string `json:"country"`
+	Url       string `json:"url"`
+	StoreName string `json:"store_name"`
+	StoreType string `json:"store_type" binding:"required,oneof=web shop"`
+}
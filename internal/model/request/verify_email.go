@@ -0,0 +1,5 @@
+package request
+
+type VerifyEmail struct {
+	Token string `json:"token" binding:"required"`
+}
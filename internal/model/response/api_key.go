@@ -0,0 +1,42 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+// APIKey carries the key itself only in the answer of its creation.
+type APIKey struct {
+	APIKeyID   uuid.UUID  `json:"api_key_id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewAPIKeyFromModel(m *model.APIKey) *APIKey {
+	return &APIKey{
+		APIKeyID:   m.APIKeyID,
+		Name:       m.Name,
+		Key:        m.Key,
+		Prefix:     m.Prefix,
+		Scopes:     m.Scopes,
+		ExpiresAt:  m.ExpiresAt,
+		LastUsedAt: m.LastUsedAt,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func NewAPIKeysFromModels(ms []*model.APIKey) []*APIKey {
+	apiKeys := []*APIKey{}
+	for _, m := range ms {
+		apiKey := NewAPIKeyFromModel(m)
+		apiKey.Key = ""
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys
+}
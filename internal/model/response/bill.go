@@ -3,14 +3,20 @@ package response
 import (
 	"github.com/google/uuid"
 	"shop-aggregator/internal/model"
+	"time"
 )
 
 type Bill struct {
-	BillID   uuid.UUID      `json:"bill_id"`
-	Amount   string         `json:"amount"`
-	State    string         `json:"state"`
-	Store    *BillStore     `json:"store"`
-	Products []*UserProduct `json:"products"`
+	BillID         uuid.UUID      `json:"bill_id"`
+	HouseholdID    *uuid.UUID     `json:"household_id,omitempty"`
+	Amount         model.Money    `json:"amount"`
+	ComputedAmount model.Money    `json:"computed_amount"`
+	Discrepancy    *model.Money   `json:"discrepancy,omitempty"`
+	State          string         `json:"state"`
+	Store          *BillStore     `json:"store"`
+	Products       []*UserProduct `json:"products"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      *time.Time     `json:"updated_at,omitempty"`
 }
 
 type BillStore struct {
@@ -34,11 +40,19 @@ func NewBillStore(s *model.Store, c *model.Company) *BillStore {
 
 func NewBillFromModel(m *model.Bill, s *model.Store, c *model.Company, ps []*model.UserProduct) *Bill {
 	b := &Bill{
-		BillID:   m.BillID,
-		Amount:   m.Amount,
-		State:    m.State,
-		Store:    NewBillStore(s, c),
-		Products: NewUserProductsFromModel(ps),
+		BillID:         m.BillID,
+		HouseholdID:    m.HouseholdID,
+		Amount:         m.Amount,
+		ComputedAmount: m.ComputedAmount,
+		State:          m.State,
+		Store:          NewBillStore(s, c),
+		Products:       NewUserProductsFromModel(ps),
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	if m.IsClosed() {
+		discrepancy := m.Discrepancy()
+		b.Discrepancy = &discrepancy
 	}
 
 	return b
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type BillEvent struct {
+	BillEventID    uuid.UUID   `json:"bill_event_id"`
+	ActorID        uuid.UUID   `json:"actor_id"`
+	Event          string      `json:"event"`
+	FromState      string      `json:"from_state,omitempty"`
+	ToState        string      `json:"to_state"`
+	PreviousAmount model.Money `json:"previous_amount"`
+	Amount         model.Money `json:"amount"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+func NewBillEventFromModel(m *model.BillEvent) *BillEvent {
+	return &BillEvent{
+		BillEventID:    m.BillEventID,
+		ActorID:        m.ActorID,
+		Event:          m.Event,
+		FromState:      m.FromState,
+		ToState:        m.ToState,
+		PreviousAmount: m.PreviousAmount,
+		Amount:         m.Amount,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func NewBillEventsFromModels(ms []*model.BillEvent) []*BillEvent {
+	events := []*BillEvent{}
+	for _, m := range ms {
+		events = append(events, NewBillEventFromModel(m))
+	}
+	return events
+}
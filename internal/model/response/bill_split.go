@@ -0,0 +1,97 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type BillSplit struct {
+	BillID      uuid.UUID          `json:"bill_id"`
+	PayerID     uuid.UUID          `json:"payer_id"`
+	Allocations []*SplitAllocation `json:"allocations"`
+	Shares      []*SplitShare      `json:"shares"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type SplitAllocation struct {
+	UserProductID uuid.UUID       `json:"user_product_id"`
+	UserID        *uuid.UUID      `json:"user_id,omitempty"`
+	HouseholdID   *uuid.UUID      `json:"household_id,omitempty"`
+	Method        string          `json:"method"`
+	Value         decimal.Decimal `json:"value"`
+}
+
+type SplitShare struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Login  string      `json:"login"`
+	Amount model.Money `json:"amount"`
+}
+
+func NewBillSplitFromModel(m *model.BillSplit) *BillSplit {
+	split := &BillSplit{
+		BillID:      m.BillID,
+		PayerID:     m.PayerID,
+		Allocations: []*SplitAllocation{},
+		Shares:      []*SplitShare{},
+		UpdatedAt:   m.UpdatedAt,
+	}
+	for _, a := range m.Allocations {
+		participantID := a.ParticipantID
+		allocation := &SplitAllocation{
+			UserProductID: a.UserProductID,
+			Method:        a.Method,
+			Value:         a.Value,
+		}
+		if a.ParticipantType == model.SplitParticipantHousehold {
+			allocation.HouseholdID = &participantID
+		} else {
+			allocation.UserID = &participantID
+		}
+		split.Allocations = append(split.Allocations, allocation)
+	}
+	for _, s := range m.Shares {
+		split.Shares = append(split.Shares, &SplitShare{
+			UserID: s.UserID,
+			Login:  s.Login,
+			Amount: s.Amount,
+		})
+	}
+	return split
+}
+
+// Balance is what the user owes to the current user, negative when the current user owes it.
+type Balance struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Login  string      `json:"login"`
+	Amount model.Money `json:"amount"`
+}
+
+func NewBalancesFromModels(ms []*model.Balance) []*Balance {
+	balances := []*Balance{}
+	for _, m := range ms {
+		balances = append(balances, &Balance{
+			UserID: m.UserID,
+			Login:  m.Login,
+			Amount: m.Amount,
+		})
+	}
+	return balances
+}
+
+type Settlement struct {
+	SettlementID uuid.UUID   `json:"settlement_id"`
+	ToUserID     uuid.UUID   `json:"to_user_id"`
+	Amount       model.Money `json:"amount"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+func NewSettlementFromModel(m *model.Settlement) *Settlement {
+	return &Settlement{
+		SettlementID: m.SettlementID,
+		ToUserID:     m.ToUserID,
+		Amount:       m.Amount,
+		CreatedAt:    m.CreatedAt,
+	}
+}
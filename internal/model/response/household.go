@@ -0,0 +1,111 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type Household struct {
+	HouseholdID uuid.UUID `json:"household_id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewHouseholdFromModel(m *model.Household) *Household {
+	return &Household{
+		HouseholdID: m.HouseholdID,
+		Name:        m.Name,
+		Role:        m.Role,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func NewHouseholdsFromModels(ms []*model.Household) []*Household {
+	households := []*Household{}
+	for _, m := range ms {
+		households = append(households, NewHouseholdFromModel(m))
+	}
+	return households
+}
+
+type HouseholdMember struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Login    string    `json:"login"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+func NewHouseholdMembersFromModels(ms []*model.HouseholdMember) []*HouseholdMember {
+	members := []*HouseholdMember{}
+	for _, m := range ms {
+		members = append(members, &HouseholdMember{
+			UserID:   m.UserID,
+			Login:    m.Login,
+			Role:     m.Role,
+			JoinedAt: m.JoinedAt,
+		})
+	}
+	return members
+}
+
+type HouseholdInvitation struct {
+	HouseholdInvitationID uuid.UUID `json:"household_invitation_id"`
+	HouseholdID           uuid.UUID `json:"household_id"`
+	HouseholdName         string    `json:"household_name,omitempty"`
+	Role                  string    `json:"role"`
+	ExpiresAt             time.Time `json:"expires_at"`
+}
+
+func NewHouseholdInvitationFromModel(m *model.HouseholdInvitation) *HouseholdInvitation {
+	return &HouseholdInvitation{
+		HouseholdInvitationID: m.HouseholdInvitationID,
+		HouseholdID:           m.HouseholdID,
+		HouseholdName:         m.HouseholdName,
+		Role:                  m.Role,
+		ExpiresAt:             m.ExpiresAt,
+	}
+}
+
+func NewHouseholdInvitationsFromModels(ms []*model.HouseholdInvitation) []*HouseholdInvitation {
+	invitations := []*HouseholdInvitation{}
+	for _, m := range ms {
+		invitations = append(invitations, NewHouseholdInvitationFromModel(m))
+	}
+	return invitations
+}
+
+// HouseholdSpending gives the total of the household in each currency and the share of each member.
+type HouseholdSpending struct {
+	Totals  []model.Money              `json:"totals"`
+	Members []*HouseholdMemberSpending `json:"members"`
+}
+
+type HouseholdMemberSpending struct {
+	UserID uuid.UUID   `json:"user_id"`
+	Login  string      `json:"login"`
+	Amount model.Money `json:"amount"`
+	Bills  int64       `json:"bills"`
+}
+
+func NewHouseholdSpendingFromModels(ms []*model.HouseholdSpending) *HouseholdSpending {
+	hs := &HouseholdSpending{Totals: []model.Money{}, Members: []*HouseholdMemberSpending{}}
+	totals := map[string]int{}
+	for _, m := range ms {
+		hs.Members = append(hs.Members, &HouseholdMemberSpending{
+			UserID: m.UserID,
+			Login:  m.Login,
+			Amount: m.Amount,
+			Bills:  m.Bills,
+		})
+		i, ok := totals[m.Amount.Currency]
+		if !ok {
+			totals[m.Amount.Currency] = len(hs.Totals)
+			hs.Totals = append(hs.Totals, m.Amount)
+			continue
+		}
+		hs.Totals[i].Amount = hs.Totals[i].Amount.Add(m.Amount.Amount)
+	}
+	return hs
+}
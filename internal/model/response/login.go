@@ -1,5 +1,22 @@
 package response
 
+import (
+	"shop-aggregator/internal/model"
+	"time"
+)
+
 type Login struct {
-	Token string `json:"token"`
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func NewLoginFromModel(m *model.Session) *Login {
+	return &Login{
+		Token:            m.Token,
+		ExpiresAt:        m.ExpiresAt,
+		RefreshToken:     m.RefreshToken,
+		RefreshExpiresAt: m.RefreshExpiresAt,
+	}
 }
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type LoginAttempt struct {
+	LoginAttemptID uuid.UUID  `json:"login_attempt_id"`
+	Login          string     `json:"login"`
+	IP             string     `json:"ip"`
+	UserID         *uuid.UUID `json:"user_id"`
+	Success        bool       `json:"success"`
+	Reason         string     `json:"reason"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewLoginAttemptsFromModels(ms []*model.LoginAttempt) []*LoginAttempt {
+	attempts := []*LoginAttempt{}
+	for _, m := range ms {
+		attempts = append(attempts, &LoginAttempt{
+			LoginAttemptID: m.LoginAttemptID,
+			Login:          m.Login,
+			IP:             m.IP,
+			UserID:         m.UserID,
+			Success:        m.Success,
+			Reason:         m.Reason,
+			CreatedAt:      m.CreatedAt,
+		})
+	}
+	return attempts
+}
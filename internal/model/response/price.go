@@ -0,0 +1,89 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type StorePrice struct {
+	StoreID       uuid.UUID    `json:"store_id"`
+	StoreName     string       `json:"store_name"`
+	ZipCode       string       `json:"zip_code"`
+	City          string       `json:"city"`
+	CompanyID     uuid.UUID    `json:"company_id"`
+	CompanyName   string       `json:"company_name"`
+	UserProductID uuid.UUID    `json:"user_product_id"`
+	ProductID     uuid.UUID    `json:"product_id"`
+	ProductName   string       `json:"product_name"`
+	Ean           string       `json:"ean"`
+	Price         model.Money  `json:"price"`
+	UnitPrice     *model.Money `json:"unit_price,omitempty"`
+	ProductSize   string       `json:"product_size"`
+	SizeFormat    string       `json:"size_format"`
+	PricedAt      time.Time    `json:"priced_at"`
+}
+
+func NewStorePriceFromModel(m *model.StorePrice) *StorePrice {
+	sp := &StorePrice{
+		StoreID:       m.StoreID,
+		StoreName:     m.StoreName,
+		ZipCode:       m.ZipCode,
+		City:          m.City,
+		CompanyID:     m.CompanyID,
+		CompanyName:   m.CompanyName,
+		UserProductID: m.UserProductID,
+		ProductID:     m.ProductID,
+		ProductName:   m.ProductName,
+		Ean:           m.Ean,
+		Price:         m.Price,
+		ProductSize:   m.ProductSize,
+		SizeFormat:    m.SizeFormat,
+		PricedAt:      m.PricedAt,
+	}
+	if unitPrice, ok := model.UnitPrice(m.Price, m.ProductSize, m.SizeFormat); ok {
+		sp.UnitPrice = &unitPrice
+	}
+
+	return sp
+}
+
+func NewStorePricesFromModels(ms []*model.StorePrice) []*StorePrice {
+	sps := []*StorePrice{}
+	for _, m := range ms {
+		sps = append(sps, NewStorePriceFromModel(m))
+	}
+	return sps
+}
+
+// StoreProductPrice is the last price of a product in a store, the bill and the quantity of the user who
+// paid it are not shown.
+type StoreProductPrice struct {
+	ProductID   uuid.UUID   `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	Ean         string      `json:"ean"`
+	BrandID     uuid.UUID   `json:"brand_id"`
+	BrandName   string      `json:"brand_name"`
+	Price       model.Money `json:"price"`
+	ProductType string      `json:"product_type"`
+	ProductSize string      `json:"product_size"`
+	SizeFormat  string      `json:"size_format"`
+}
+
+func NewStoreProductPricesFromModels(ms []*model.UserProduct) []*StoreProductPrice {
+	sps := []*StoreProductPrice{}
+	for _, m := range ms {
+		sps = append(sps, &StoreProductPrice{
+			ProductID:   m.ProductID,
+			ProductName: m.ProductName,
+			Ean:         m.Ean,
+			BrandID:     m.BrandID,
+			BrandName:   m.BrandName,
+			Price:       m.Price,
+			ProductType: m.ProductType,
+			ProductSize: m.ProductSize,
+			SizeFormat:  m.SizeFormat,
+		})
+	}
+	return sps
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"shop-aggregator/internal/model"
+)
+
+type PricePoint struct {
+	Period      string      `json:"period"`
+	MinPrice    model.Money `json:"min_price"`
+	MaxPrice    model.Money `json:"max_price"`
+	AvgPrice    model.Money `json:"avg_price"`
+	MedianPrice model.Money `json:"median_price"`
+	Count       int64       `json:"count"`
+}
+
+func NewPricePointFromModel(m *model.PricePoint) *PricePoint {
+	return &PricePoint{
+		Period:      m.Period.Format("2006-01-02"),
+		MinPrice:    m.MinPrice,
+		MaxPrice:    m.MaxPrice,
+		AvgPrice:    m.AvgPrice,
+		MedianPrice: m.MedianPrice,
+		Count:       m.Count,
+	}
+}
+
+func NewPricePointsFromModels(ms []*model.PricePoint) []*PricePoint {
+	pps := []*PricePoint{}
+	for _, m := range ms {
+		pps = append(pps, NewPricePointFromModel(m))
+	}
+	return pps
+}
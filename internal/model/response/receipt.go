@@ -0,0 +1,81 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+// ReceiptDraftLine holds the fields of a user product creation, prefilled with the matched product.
+type ReceiptDraftLine struct {
+	BillID      uuid.UUID   `json:"bill_id"`
+	ReceiptName string      `json:"receipt_name"`
+	Quantity    int64       `json:"quantity"`
+	Price       model.Money `json:"price"`
+	Total       model.Money `json:"total"`
+	ProductID   *uuid.UUID  `json:"product_id,omitempty"`
+	ProductName string      `json:"product_name,omitempty"`
+	Ean         string      `json:"ean,omitempty"`
+	BrandName   string      `json:"brand_name,omitempty"`
+	ProductType string      `json:"product_type,omitempty"`
+	ProductSize string      `json:"product_size,omitempty"`
+	SizeFormat  string      `json:"size_format,omitempty"`
+	Score       float64     `json:"score"`
+}
+
+func NewReceiptDraftLineFromModel(m *model.ReceiptDraft) *ReceiptDraftLine {
+	rdl := &ReceiptDraftLine{
+		BillID:      m.BillID,
+		ReceiptName: m.Line.Name,
+		Quantity:    m.Line.Quantity,
+		Price:       m.Line.UnitPrice,
+		Total:       m.Line.Total,
+		Score:       m.Score,
+	}
+	if m.Match != nil {
+		rdl.ProductID = &m.Match.ProductID
+		rdl.ProductName = m.Match.ProductName
+		rdl.Ean = m.Match.Ean
+		rdl.BrandName = m.Match.BrandName
+		rdl.ProductType = m.Match.ProductType
+		rdl.ProductSize = m.Match.ProductSize
+		rdl.SizeFormat = m.Match.SizeFormat
+	}
+
+	return rdl
+}
+
+func NewReceiptDraftLinesFromModels(ms []*model.ReceiptDraft) []*ReceiptDraftLine {
+	rdls := []*ReceiptDraftLine{}
+	for _, m := range ms {
+		rdls = append(rdls, NewReceiptDraftLineFromModel(m))
+	}
+	return rdls
+}
+
+type ReceiptImport struct {
+	StoreName string              `json:"store_name"`
+	Date      *time.Time          `json:"date,omitempty"`
+	Total     model.Money         `json:"total"`
+	Discounts []*ReceiptDraftLine `json:"discounts"`
+	Unmatched []*ReceiptDraftLine `json:"unmatched"`
+	Bill      *Bill               `json:"bill"`
+}
+
+func NewReceiptImportFromModel(pr *model.ParsedReceipt, b *Bill, unmatched []*model.ReceiptDraft) *ReceiptImport {
+	ri := &ReceiptImport{
+		StoreName: pr.StoreName,
+		Total:     pr.Total,
+		Discounts: []*ReceiptDraftLine{},
+		Unmatched: NewReceiptDraftLinesFromModels(unmatched),
+		Bill:      b,
+	}
+	if !pr.Date.IsZero() {
+		ri.Date = &pr.Date
+	}
+	for _, discount := range pr.Discounts {
+		ri.Discounts = append(ri.Discounts, NewReceiptDraftLineFromModel(&model.ReceiptDraft{BillID: b.BillID, Line: discount}))
+	}
+
+	return ri
+}
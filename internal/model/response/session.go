@@ -0,0 +1,34 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type Session struct {
+	SessionID  uuid.UUID `json:"session_id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// NewSessionsFromModels never returns the tokens, current flags the session of the request.
+func NewSessionsFromModels(ms []*model.Session, current uuid.UUID) []*Session {
+	sessions := []*Session{}
+	for _, m := range ms {
+		sessions = append(sessions, &Session{
+			SessionID:  m.SessionID,
+			Device:     m.Device,
+			IP:         m.IP,
+			UserAgent:  m.UserAgent,
+			Current:    m.SessionID == current,
+			CreatedAt:  m.CreatedAt,
+			LastSeenAt: m.LastSeenAt,
+		})
+	}
+	return sessions
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"github.com/google/uuid"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+type ShoppingListOptimization struct {
+	SingleStore *ShoppingPlan `json:"single_store"`
+	Split       *ShoppingPlan `json:"split"`
+}
+
+type ShoppingPlan struct {
+	Stores        []*ShoppingPlanStore `json:"stores"`
+	Unpriced      []*ShoppingListItem  `json:"unpriced"`
+	Total         model.Money          `json:"total"`
+	OldestPriceAt *time.Time           `json:"oldest_price_at"`
+}
+
+type ShoppingPlanStore struct {
+	StoreID     uuid.UUID           `json:"store_id"`
+	StoreName   string              `json:"store_name"`
+	ZipCode     string              `json:"zip_code"`
+	City        string              `json:"city"`
+	CompanyID   uuid.UUID           `json:"company_id"`
+	CompanyName string              `json:"company_name"`
+	Subtotal    model.Money         `json:"subtotal"`
+	Lines       []*ShoppingPlanLine `json:"lines"`
+}
+
+type ShoppingPlanLine struct {
+	UserProductID uuid.UUID   `json:"user_product_id"`
+	ProductID     uuid.UUID   `json:"product_id"`
+	ProductName   string      `json:"product_name"`
+	Ean           string      `json:"ean"`
+	Quantity      int64       `json:"quantity"`
+	Price         model.Money `json:"price"`
+	LineTotal     model.Money `json:"line_total"`
+	PricedAt      time.Time   `json:"priced_at"`
+}
+
+type ShoppingListItem struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Ean       string    `json:"ean"`
+	Quantity  int64     `json:"quantity"`
+}
+
+func NewShoppingListOptimizationFromModel(m *model.ShoppingListOptimization) *ShoppingListOptimization {
+	return &ShoppingListOptimization{
+		SingleStore: NewShoppingPlanFromModel(m.SingleStore),
+		Split:       NewShoppingPlanFromModel(m.Split),
+	}
+}
+
+func NewShoppingPlanFromModel(m *model.ShoppingPlan) *ShoppingPlan {
+	sp := &ShoppingPlan{
+		Stores:   []*ShoppingPlanStore{},
+		Unpriced: []*ShoppingListItem{},
+		Total:    m.Total,
+	}
+	if !m.OldestPriceAt.IsZero() {
+		sp.OldestPriceAt = &m.OldestPriceAt
+	}
+	for _, s := range m.Stores {
+		store := &ShoppingPlanStore{
+			StoreID:     s.StoreID,
+			StoreName:   s.StoreName,
+			ZipCode:     s.ZipCode,
+			City:        s.City,
+			CompanyID:   s.CompanyID,
+			CompanyName: s.CompanyName,
+			Subtotal:    s.Subtotal,
+			Lines:       []*ShoppingPlanLine{},
+		}
+		for _, l := range s.Lines {
+			store.Lines = append(store.Lines, &ShoppingPlanLine{
+				UserProductID: l.UserProductID,
+				ProductID:     l.ProductID,
+				ProductName:   l.ProductName,
+				Ean:           l.Ean,
+				Quantity:      l.Quantity,
+				Price:         l.Price,
+				LineTotal:     l.LineTotal,
+				PricedAt:      l.PricedAt,
+			})
+		}
+		sp.Stores = append(sp.Stores, store)
+	}
+	for _, i := range m.Unpriced {
+		sp.Unpriced = append(sp.Unpriced, &ShoppingListItem{
+			ProductID: i.ProductID,
+			Ean:       i.Ean,
+			Quantity:  i.Quantity,
+		})
+	}
+
+	return sp
+}
@@ -0,0 +1,31 @@
+package response
+
+import "shop-aggregator/internal/model"
+
+type Stats struct {
+	Users          int64 `json:"users"`
+	DisabledUsers  int64 `json:"disabled_users"`
+	ActiveSessions int64 `json:"active_sessions"`
+	Bills          int64 `json:"bills"`
+	OpenBills      int64 `json:"open_bills"`
+	UserProducts   int64 `json:"user_products"`
+	Products       int64 `json:"products"`
+	Brands         int64 `json:"brands"`
+	Companies      int64 `json:"companies"`
+	Stores         int64 `json:"stores"`
+}
+
+func NewStatsFromModel(m *model.Stats) *Stats {
+	return &Stats{
+		Users:          m.Users,
+		DisabledUsers:  m.DisabledUsers,
+		ActiveSessions: m.ActiveSessions,
+		Bills:          m.Bills,
+		OpenBills:      m.OpenBills,
+		UserProducts:   m.UserProducts,
+		Products:       m.Products,
+		Brands:         m.Brands,
+		Companies:      m.Companies,
+		Stores:         m.Stores,
+	}
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+// LoginChallenge answers the password of a user with 2FA, the challenge is sent back with the code.
+type LoginChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	Challenge         string    `json:"challenge"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+func NewLoginChallengeFromModel(m *model.LoginChallenge) *LoginChallenge {
+	return &LoginChallenge{
+		TwoFactorRequired: true,
+		Challenge:         m.Challenge,
+		ExpiresAt:         m.ExpiresAt,
+	}
+}
+
+type TOTPEnrolment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func NewTOTPEnrolmentFromModel(m *model.TOTPEnrolment) *TOTPEnrolment {
+	return &TOTPEnrolment{
+		Secret:          m.Secret,
+		ProvisioningURI: m.URI,
+	}
+}
@@ -3,13 +3,15 @@ package response
 import "shop-aggregator/internal/model"
 
 type User struct {
-	Login string `json:"login"`
-	Email string `json:"email"`
+	Login         string `json:"login"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 func NewUserFromModel(m model.User) User {
 	return User{
-		Login: m.Login,
-		Email: m.Email,
+		Login:         m.Login,
+		Email:         m.Email,
+		EmailVerified: m.EmailVerified,
 	}
 }
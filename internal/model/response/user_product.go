@@ -6,18 +6,18 @@ import (
 )
 
 type UserProduct struct {
-	UserProductID uuid.UUID `json:"user_product_id"`
-	ProductID     uuid.UUID `json:"product_id"`
-	ProductName   string    `json:"product_name"`
-	Ean           string    `json:"ean"`
-	BrandID       uuid.UUID `json:"brand_id"`
-	BrandName     string    `json:"brand_name"`
-	BillID        uuid.UUID `json:"bill_id"`
-	Price         string    `json:"price"`
-	Quantity      int64     `json:"quantity"`
-	ProductType   string    `json:"product_type"`
-	ProductSize   string    `json:"product_size"`
-	SizeFormat    string    `json:"size_format"`
+	UserProductID uuid.UUID   `json:"user_product_id"`
+	ProductID     uuid.UUID   `json:"product_id"`
+	ProductName   string      `json:"product_name"`
+	Ean           string      `json:"ean"`
+	BrandID       uuid.UUID   `json:"brand_id"`
+	BrandName     string      `json:"brand_name"`
+	BillID        uuid.UUID   `json:"bill_id"`
+	Price         model.Money `json:"price"`
+	Quantity      int64       `json:"quantity"`
+	ProductType   string      `json:"product_type"`
+	ProductSize   string      `json:"product_size"`
+	SizeFormat    string      `json:"size_format"`
 }
 
 func NewUserProductFromModel(m *model.UserProduct) *UserProduct {
@@ -0,0 +1,29 @@
+package model_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"testing"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role, required string
+		allowed        bool
+	}{
+		{model.RoleUser, model.RoleUser, true},
+		{model.RoleUser, model.RoleModerator, false},
+		{model.RoleModerator, model.RoleModerator, true},
+		{model.RoleModerator, model.RoleAdmin, false},
+		{model.RoleAdmin, model.RoleUser, true},
+		{model.RoleAdmin, model.RoleModerator, true},
+		{"", model.RoleUser, false},
+		{"root", model.RoleUser, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, model.RoleAllows(tt.role, tt.required), "%s for %s", tt.role, tt.required)
+	}
+
+	assert.True(t, model.IsValidRole(model.RoleModerator))
+	assert.False(t, model.IsValidRole("root"))
+}
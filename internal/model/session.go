@@ -0,0 +1,39 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Session is a login of the user on one device, Token authenticates its requests until ExpiresAt or until
+// the session is revoked. RefreshToken is only known when it is issued, it gets a new Token.
+type Session struct {
+	SessionID        uuid.UUID
+	UserID           uuid.UUID
+	Role             string
+	Token            string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+	Device           string
+	IP               string
+	UserAgent        string
+	CreatedAt        time.Time
+	LastSeenAt       time.Time
+}
+
+// IsExpired tells if the access token of the session can no longer be used.
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one of the refresh tokens issued to a session, the token family. UsedAt is set once it is
+// exchanged, SessionRevoked once the whole family is revoked.
+type RefreshToken struct {
+	RefreshTokenID uuid.UUID
+	SessionID      uuid.UUID
+	UserID         uuid.UUID
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	SessionRevoked bool
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	ShoppingListMaxStores = 5
+	// ShoppingListMaxItems bounds the items of a list, each combination of stores prices all of them.
+	ShoppingListMaxItems = 100
+)
+
+type ShoppingListItem struct {
+	ProductID uuid.UUID
+	Ean       string
+	Quantity  int64
+}
+
+type ShoppingPlanLine struct {
+	UserProductID uuid.UUID
+	ProductID     uuid.UUID
+	ProductName   string
+	Ean           string
+	Quantity      int64
+	Price         Money
+	LineTotal     Money
+	PricedAt      time.Time
+}
+
+type ShoppingPlanStore struct {
+	StoreID     uuid.UUID
+	StoreName   string
+	ZipCode     string
+	City        string
+	CompanyID   uuid.UUID
+	CompanyName string
+	Lines       []*ShoppingPlanLine
+	Subtotal    Money
+}
+
+type ShoppingPlan struct {
+	Stores        []*ShoppingPlanStore
+	Unpriced      []*ShoppingListItem
+	Total         Money
+	OldestPriceAt time.Time
+}
+
+type ShoppingListOptimization struct {
+	SingleStore *ShoppingPlan
+	Split       *ShoppingPlan
+}
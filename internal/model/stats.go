@@ -0,0 +1,15 @@
+package model
+
+// Stats counts the rows of the main tables for the admins.
+type Stats struct {
+	Users          int64
+	DisabledUsers  int64
+	ActiveSessions int64
+	Bills          int64
+	OpenBills      int64
+	UserProducts   int64
+	Products       int64
+	Brands         int64
+	Companies      int64
+	Stores         int64
+}
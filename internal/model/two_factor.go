@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TOTP is the authenticator app of a user, the 2FA is on once the first code confirms it. LastStep is the last
+// period a code was used for.
+type TOTP struct {
+	UserID    uuid.UUID
+	Secret    string
+	EnabledAt *time.Time
+	LastStep  int64
+}
+
+func (t *TOTP) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TOTPEnrolment is the secret of a new authenticator app, URI is the content of its QR code.
+type TOTPEnrolment struct {
+	Secret string
+	URI    string
+}
+
+// LoginChallenge is a login whose password was checked and that waits for its second factor, Challenge is
+// only known when it is issued.
+type LoginChallenge struct {
+	LoginChallengeID uuid.UUID
+	UserID           uuid.UUID
+	Login            string
+	Challenge        string
+	Device           string
+	Failures         int
+	ExpiresAt        time.Time
+}
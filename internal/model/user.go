@@ -3,11 +3,19 @@ package model
 import "github.com/google/uuid"
 
 type User struct {
-	ID           uuid.UUID
-	Login        string
-	Password     string
-	HashPassword string
-	Email        string
+	ID            uuid.UUID
+	Login         string
+	Password      string
+	HashPassword  string
+	Email         string
+	EmailVerified bool
+	Role          string
+	Disabled      bool
+}
+
+// HasPassword tells if the user can log in with a password, a user created by an OpenID Connect login has none.
+func (u *User) HasPassword() bool {
+	return u.HashPassword != ""
 }
 
 type UpdatePassword struct {
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UserIdentity links the subject of an OpenID Connect provider to a user.
+type UserIdentity struct {
+	UserIdentityID uuid.UUID
+	UserID         uuid.UUID
+	Issuer         string
+	Subject        string
+	Email          string
+	CreatedAt      time.Time
+	LastLoginAt    time.Time
+}
+
+// OIDCState is a pending authorization, State comes back with the code that is exchanged with CodeVerifier.
+type OIDCState struct {
+	OIDCStateID  uuid.UUID
+	State        string
+	Nonce        string
+	CodeVerifier string
+	Device       string
+	ExpiresAt    time.Time
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/google/uuid"
+	"time"
 )
 
 const (
@@ -35,9 +36,11 @@ type UserProduct struct {
 	StoreID       uuid.UUID
 	StoreName     string
 	BillID        uuid.UUID
-	Price         string
+	Price         Money
 	ProductType   string
 	ProductSize   string
 	SizeFormat    string
 	Quantity      int64
+	// CreatedAt is the date of the purchase, the time the line is added when zero
+	CreatedAt time.Time
 }
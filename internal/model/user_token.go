@@ -0,0 +1,24 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	UserTokenPasswordReset     = "password_reset"
+	UserTokenEmailVerification = "email_verification"
+)
+
+// UserToken is a single-use token sent by mail. Token is only known when it is issued, Email is the
+// address an email verification token confirms.
+type UserToken struct {
+	UserTokenID uuid.UUID
+	UserID      uuid.UUID
+	Purpose     string
+	Token       string
+	Email       string
+	ExpiresAt   time.Time
+	UsedAt      *time.Time
+	CreatedAt   time.Time
+}
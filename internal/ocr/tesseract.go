@@ -0,0 +1,65 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"shop-aggregator/internal/config"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTesseractPath = "tesseract"
+	defaultLanguage      = "fra+eng"
+	defaultTimeout       = 30 * time.Second
+)
+
+// Tesseract runs the local tesseract binary, the image is sent on stdin and the text read on stdout.
+type Tesseract struct {
+	path     string
+	language string
+	timeout  time.Duration
+}
+
+func NewTesseract(cfg *config.OCRConfig) *Tesseract {
+	t := &Tesseract{
+		path:     cfg.TesseractPath,
+		language: cfg.Language,
+		timeout:  time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+	if t.path == "" {
+		t.path = defaultTesseractPath
+	}
+	if t.language == "" {
+		t.language = defaultLanguage
+	}
+	if t.timeout <= 0 {
+		t.timeout = defaultTimeout
+	}
+
+	return t
+}
+
+func (t *Tesseract) Recognize(ctx context.Context, image io.Reader) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	// --psm 6 reads the image as a single block of text, which keeps receipt columns on the same line
+	cmd := exec.CommandContext(ctx, t.path, "stdin", "stdout", "-l", t.language, "--psm", "6")
+	cmd.Stdin = image
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,52 @@
+package ocr_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"runtime"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/ocr"
+	"strings"
+	"testing"
+)
+
+func fakeTesseract(t *testing.T, script string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script")
+	}
+	path := filepath.Join(t.TempDir(), "tesseract")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestTesseract_Recognize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no error", func(t *testing.T) {
+		// echoes the arguments then the image
+		path := fakeTesseract(t, "echo \"$@\"\ncat\n")
+		tesseract := ocr.NewTesseract(&config.OCRConfig{TesseractPath: path})
+		text, err := tesseract.Recognize(ctx, strings.NewReader("NUTELLA 400G 3,49"))
+		require.NoError(t, err)
+		assert.Equal(t, "stdin stdout -l fra+eng --psm 6\nNUTELLA 400G 3,49", text)
+	})
+
+	t.Run("tesseract error", func(t *testing.T) {
+		path := fakeTesseract(t, "echo 'Error in pixReadStream' >&2\nexit 1\n")
+		tesseract := ocr.NewTesseract(&config.OCRConfig{TesseractPath: path, Language: "eng"})
+		_, err := tesseract.Recognize(ctx, strings.NewReader("not an image"))
+		assert.ErrorContains(t, err, "Error in pixReadStream")
+	})
+
+	t.Run("context error", func(t *testing.T) {
+		path := fakeTesseract(t, "cat\n")
+		tesseract := ocr.NewTesseract(&config.OCRConfig{TesseractPath: path})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := tesseract.Recognize(ctx, strings.NewReader(""))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
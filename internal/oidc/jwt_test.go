@@ -0,0 +1,70 @@
+package oidc
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/oidc/oidctest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func verifyToken(issuer *oidctest.Issuer, token string) (*Claims, error) {
+	p := NewProvider(&config.OIDCConfig{Issuer: issuer.URL, ClientID: issuer.ClientID}, nil)
+	d, err := p.getDiscovery(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return p.verify(context.Background(), d, token, "nonce")
+}
+
+func TestProvider_Verify(t *testing.T) {
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   issuer.URL,
+			"sub":   "42",
+			"aud":   []string{"other", "client"},
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+	}
+	tests := []struct {
+		name   string
+		change func(claims map[string]interface{})
+		token  func(token string) string
+		err    bool
+	}{
+		{name: "valid with an audience list"},
+		{name: "other issuer", change: func(c map[string]interface{}) { c["iss"] = "http://evil" }, err: true},
+		{name: "other audience", change: func(c map[string]interface{}) { c["aud"] = "other" }, err: true},
+		{name: "expired", change: func(c map[string]interface{}) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() }, err: true},
+		{name: "no subject", change: func(c map[string]interface{}) { delete(c, "sub") }, err: true},
+		{name: "tampered", token: func(token string) string { return token[:len(token)-4] + "AAAA" }, err: true},
+		{name: "alg none", token: func(token string) string { return "eyJhbGciOiJub25lIn0" + token[strings.Index(token, "."):] }, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			if tt.change != nil {
+				tt.change(claims)
+			}
+			token := issuer.Sign(claims)
+			if tt.token != nil {
+				token = tt.token(token)
+			}
+
+			got, err := verifyToken(issuer, token)
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInvalidIDToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "42", got.Subject)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package oidc_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/oidc"
+	"shop-aggregator/internal/oidc/oidctest"
+	"testing"
+)
+
+const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func newProvider(issuer *oidctest.Issuer) *oidc.Provider {
+	return oidc.NewProvider(&config.OIDCConfig{
+		Issuer:       issuer.URL,
+		ClientID:     issuer.ClientID,
+		ClientSecret: issuer.ClientSecret,
+		RedirectURL:  "http://localhost/oidc/callback",
+		Scopes:       []string{"email", "profile"},
+	}, nil)
+}
+
+// authorize returns the code of the callback.
+func authorize(t *testing.T, issuer *oidctest.Issuer, p *oidc.Provider, nonce string) string {
+	authURL, err := p.AuthCodeURL(context.Background(), "state", nonce, oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	callback, err := issuer.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "state", callback.Query().Get("state"))
+	return callback.Query().Get("code")
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oidc.CodeChallenge(verifier))
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	issuer := oidctest.NewIssuer("client", "secret")
+	defer issuer.Close()
+
+	authURL, err := newProvider(issuer).AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, issuer.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, "challenge", u.Query().Get("code_challenge"))
+	assert.Equal(t, "nonce", u.Query().Get("nonce"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+	issuer := oidctest.NewIssuer("client", "s3cr:et")
+	defer issuer.Close()
+	issuer.Login(oidctest.User{Subject: "42", Email: "alice@test.com", EmailVerified: true, PreferredUsername: "alice"})
+
+	t.Run("no error", func(t *testing.T) {
+		p := newProvider(issuer)
+		claims, err := p.Exchange(ctx, authorize(t, issuer, p, "nonce"), verifier, "nonce")
+		require.NoError(t, err)
+		assert.Equal(t, &oidc.Claims{
+			Issuer:            issuer.URL,
+			Subject:           "42",
+			Email:             "alice@test.com",
+			EmailVerified:     true,
+			PreferredUsername: "alice",
+		}, claims)
+	})
+
+	t.Run("code used twice", func(t *testing.T) {
+		p := newProvider(issuer)
+		code := authorize(t, issuer, p, "nonce")
+		_, err := p.Exchange(ctx, code, verifier, "nonce")
+		require.NoError(t, err)
+		_, err = p.Exchange(ctx, code, verifier, "nonce")
+		assert.ErrorIs(t, err, oidc.ErrProviderError)
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		p := newProvider(issuer)
+		_, err := p.Exchange(ctx, authorize(t, issuer, p, "nonce"), "an-other-verifier-an-other-verifier-an-other", "nonce")
+		assert.ErrorIs(t, err, oidc.ErrProviderError)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		p := newProvider(issuer)
+		_, err := p.Exchange(ctx, authorize(t, issuer, p, "nonce"), verifier, "an other nonce")
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+
+	t.Run("wrong client secret", func(t *testing.T) {
+		p := oidc.NewProvider(&config.OIDCConfig{Issuer: issuer.URL, ClientID: "client", ClientSecret: "wrong", RedirectURL: "http://localhost/oidc/callback"}, nil)
+		_, err := p.Exchange(ctx, authorize(t, issuer, p, "nonce"), verifier, "nonce")
+		assert.ErrorIs(t, err, oidc.ErrProviderError)
+	})
+
+	t.Run("unknown issuer", func(t *testing.T) {
+		p := oidc.NewProvider(&config.OIDCConfig{Issuer: "http://127.0.0.1:1", ClientID: "client"}, nil)
+		_, err := p.AuthCodeURL(ctx, "state", "nonce", "challenge")
+		assert.ErrorIs(t, err, oidc.ErrProviderError)
+	})
+}
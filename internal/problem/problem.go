@@ -0,0 +1,93 @@
+// Package problem answers the errors of the handlers as RFC 7807 problem details. A handler adds its error
+// to the context with c.Error and returns, Middleware writes the answer.
+package problem
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"shop-aggregator/internal/model"
+)
+
+const ContentType = "application/problem+json"
+
+// Details is the problem details document. Type is always about:blank, Code tells the errors of a same
+// status apart.
+type Details struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+// New returns the details of err for the request path instance. An error that is not a domain error is
+// internal, its message may tell about the database and is not shown, nor is the cause of an internal domain
+// error.
+func New(err error, instance string) *Details {
+	e := model.AsError(err)
+	detail := err.Error()
+	if e == nil {
+		e = model.AsError(model.ErrInternalError)
+	}
+	if e.Kind == model.KindInternal {
+		detail = e.Message
+	}
+
+	status := Status(e.Kind)
+	return &Details{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     e.Code,
+	}
+}
+
+// Status returns the HTTP status of the errors of kind. The resources of an other user are not found
+// rather than forbidden, the usecases already return them as such.
+func Status(kind model.ErrorKind) int {
+	switch kind {
+	case model.KindInvalidRequest:
+		return http.StatusBadRequest
+	case model.KindInvalid:
+		return http.StatusUnprocessableEntity
+	case model.KindNotFound:
+		return http.StatusNotFound
+	case model.KindConflict:
+		return http.StatusConflict
+	case model.KindUnauthorized:
+		return http.StatusUnauthorized
+	case model.KindForbidden:
+		return http.StatusForbidden
+	case model.KindTooManyRequests:
+		return http.StatusTooManyRequests
+	case model.KindUnavailable:
+		return http.StatusServiceUnavailable
+	case model.KindUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Middleware answers the last error added to the context, unless the handler already answered.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+		if model.AsError(err) == nil {
+			log.Error().Caller().Err(err).Str("path", c.Request.URL.Path).Msg("Middleware.unexpected error")
+		}
+
+		details := New(err, c.Request.URL.Path)
+		c.Header("Content-Type", ContentType)
+		c.JSON(details.Status, details)
+	}
+}
@@ -0,0 +1,79 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/problem"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(problem.Middleware())
+	router.GET("/not-found", func(c *gin.Context) { c.Error(model.ErrBillNotFoundError) })
+	router.GET("/conflict", func(c *gin.Context) { c.Error(model.ErrEmailExistError) })
+	router.GET("/bill-state", func(c *gin.Context) { c.Error(model.ErrBillNotOpenError) })
+	router.GET("/invalid", func(c *gin.Context) {
+		c.Error(fmt.Errorf("%w: %q", model.ErrInvalidMoneyError, "abc"))
+	})
+	router.GET("/malformed", func(c *gin.Context) {
+		c.Error(fmt.Errorf("%w: invalid bill id", model.ErrInvalidRequestError))
+	})
+	router.GET("/internal", func(c *gin.Context) { c.Error(model.ErrBillError) })
+	router.GET("/unexpected", func(c *gin.Context) { c.Error(errors.New("pq: relation bill does not exist")) })
+	router.GET("/answered", func(c *gin.Context) {
+		c.Error(model.ErrBillError)
+		c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		code   string
+		detail string
+	}{
+		{"/not-found", http.StatusNotFound, "bill_not_found", "bill not found"},
+		{"/conflict", http.StatusConflict, "email_exists", "email already used"},
+		// the bill is in the wrong state, the user may act on it
+		{"/bill-state", http.StatusConflict, "bill_not_open", "bill not open"},
+		{"/invalid", http.StatusUnprocessableEntity, "invalid_money", `invalid money: "abc"`},
+		{"/malformed", http.StatusBadRequest, "invalid_request", "malformed request: invalid bill id"},
+		{"/internal", http.StatusInternalServerError, "bill_error", "bill error"},
+		// the cause of an unexpected error is not shown
+		{"/unexpected", http.StatusInternalServerError, "internal_error", "internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+			var details problem.Details
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+			assert.Equal(t, problem.Details{
+				Type:     "about:blank",
+				Title:    http.StatusText(tt.status),
+				Status:   tt.status,
+				Detail:   tt.detail,
+				Instance: tt.path,
+				Code:     tt.code,
+			}, details)
+		})
+	}
+
+	t.Run("answered", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/answered", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+}
@@ -0,0 +1,171 @@
+package receipt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	htmlHiddenRegexp = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)>`)
+	htmlLineRegexp   = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|table|h[1-6])>`)
+	htmlCellRegexp   = regexp.MustCompile(`(?i)</t[dh]>`)
+	htmlTagRegexp    = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankRegexp      = regexp.MustCompile(`[ \x{00A0}]+`)
+)
+
+// Text is the readable content of a receipt file, Date is the date of the e-mail, zero for other files.
+type Text struct {
+	Text string
+	Date time.Time
+}
+
+// Read accepts a plain text receipt, an HTML receipt or an e-mail (.eml) holding one of both.
+func Read(raw []byte) (*Text, error) {
+	if isEmail(raw) {
+		return ReadEmail(bytes.NewReader(raw))
+	}
+	text := string(raw)
+	if isHTML(text) {
+		text = HTMLToText(text)
+	}
+	return &Text{Text: text}, nil
+}
+
+// ReadEmail returns the text of the e-mail, the text/plain part is preferred over the text/html one.
+func ReadEmail(r io.Reader) (*Text, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, htmlText, err := readPart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Text{Text: plain}
+	if strings.TrimSpace(t.Text) == "" {
+		t.Text = HTMLToText(htmlText)
+	}
+	if strings.TrimSpace(t.Text) == "" {
+		return nil, errors.New("no text in the e-mail")
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		t.Date = date
+	}
+
+	return t, nil
+}
+
+// readPart walks the parts of the e-mail and returns the first text/plain and text/html bodies found.
+func readPart(contentType, encoding string, body io.Reader) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var plain, htmlText string
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return plain, htmlText, nil
+			}
+			if err != nil {
+				return "", "", err
+			}
+			p, h, err := readPart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", "", err
+			}
+			if plain == "" {
+				plain = p
+			}
+			if htmlText == "" {
+				htmlText = h
+			}
+		}
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	content, err := decode(body, encoding, params["charset"])
+	if err != nil {
+		return "", "", err
+	}
+	if mediaType == "text/html" {
+		return "", content, nil
+	}
+	return content, "", nil
+}
+
+func decode(body io.Reader, encoding, charset string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return string(content), nil
+	case "iso-8859-1", "iso-8859-15", "latin1", "windows-1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	default:
+		return "", fmt.Errorf("unsupported charset %q", charset)
+	}
+}
+
+// HTMLToText keeps one line per row or paragraph, the cells of a table row are separated by tabs.
+func HTMLToText(s string) string {
+	s = htmlHiddenRegexp.ReplaceAllString(s, "")
+	s = strings.NewReplacer("\r", "", "\n", " ").Replace(s)
+	s = htmlLineRegexp.ReplaceAllString(s, "\n")
+	s = htmlCellRegexp.ReplaceAllString(s, "\t")
+	s = html.UnescapeString(htmlTagRegexp.ReplaceAllString(s, ""))
+
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		cells := strings.Split(line, "\t")
+		for i, cell := range cells {
+			cells[i] = strings.TrimSpace(blankRegexp.ReplaceAllString(cell, " "))
+		}
+		line = strings.TrimRight(strings.Join(cells, "\t"), "\t")
+		if strings.Trim(line, "\t") != "" {
+			lines = append(lines, strings.TrimLeft(line, "\t"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isEmail(raw []byte) bool {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	return err == nil && msg.Header.Get("From") != ""
+}
+
+func isHTML(text string) bool {
+	lower := strings.ToLower(text)
+	return strings.Contains(lower, "<html") || strings.Contains(lower, "<table") || strings.Contains(lower, "<body")
+}
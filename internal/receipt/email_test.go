@@ -0,0 +1,36 @@
+package receipt_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/receipt"
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	html := `<html><head><style>td { color: red; }</style></head><body><p>Magasin&nbsp;: Laon</p>
+<table><tr><td>Nutella</td><td>2</td><td></td><td>6,98&nbsp;&euro;</td></tr></table></body></html>`
+	assert.Equal(t, "Magasin : Laon\nNutella\t2\t\t6,98 €", receipt.HTMLToText(html))
+}
+
+func TestRead(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		text, err := receipt.Read([]byte("INTERMARCHE\nNUTELLA 3,49\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "INTERMARCHE\nNUTELLA 3,49\n", text.Text)
+		assert.True(t, text.Date.IsZero())
+	})
+
+	t.Run("e-mail", func(t *testing.T) {
+		eml := "From: shop@example.com\r\nDate: Mon, 18 Mar 2024 09:15:00 +0100\r\nContent-Type: text/plain; charset=utf-8\r\n\r\nNUTELLA 3,49\r\n"
+		text, err := receipt.Read([]byte(eml))
+		require.NoError(t, err)
+		assert.Equal(t, "NUTELLA 3,49\r\n", text.Text)
+		assert.Equal(t, 2024, text.Date.Year())
+	})
+
+	t.Run("e-mail without text", func(t *testing.T) {
+		_, err := receipt.Read([]byte("From: shop@example.com\r\nContent-Type: image/png\r\n\r\nxxx"))
+		assert.Error(t, err)
+	})
+}
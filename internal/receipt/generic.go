@@ -0,0 +1,75 @@
+package receipt
+
+import (
+	"regexp"
+	"shop-aggregator/internal/model"
+	"strings"
+	"time"
+)
+
+// 12/03/2024 18:42, 12.03.24 or 12-03-2024 à 18h42
+var dateRegexp = regexp.MustCompile(`(\d{2})[/.-](\d{2})[/.-](\d{4}|\d{2})(?:\D{1,4}(\d{2})[:hH](\d{2}))?`)
+
+// Generic reads any printed receipt with ParseLines, it is used for the companies without their own parser.
+type Generic struct{}
+
+func (Generic) Parse(text, currency string) (*model.ParsedReceipt, error) {
+	lines, total := parseText(text, currency)
+	if len(lines) == 0 {
+		return nil, ErrNoLine
+	}
+
+	pr := &model.ParsedReceipt{
+		StoreName: firstLine(text),
+		Date:      parseDate(text),
+		Total:     model.ZeroMoney(currency),
+	}
+	for _, line := range lines {
+		if line.Total.IsNegative() {
+			pr.Discounts = append(pr.Discounts, line)
+			continue
+		}
+		pr.Lines = append(pr.Lines, line)
+	}
+	if total != nil {
+		pr.Total = *total
+		return pr, nil
+	}
+	sum, err := pr.Sum()
+	if err != nil {
+		return nil, err
+	}
+	pr.Total = sum
+
+	return pr, nil
+}
+
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// parseDate returns the first date printed on the receipt, zero without date.
+func parseDate(text string) time.Time {
+	matches := dateRegexp.FindStringSubmatch(text)
+	if matches == nil {
+		return time.Time{}
+	}
+	year := matches[3]
+	if len(year) == 2 {
+		year = "20" + year
+	}
+	value, layout := matches[1]+"/"+matches[2]+"/"+year, "02/01/2006"
+	if matches[4] != "" {
+		value, layout = value+" "+matches[4]+":"+matches[5], layout+" 15:04"
+	}
+	date, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
@@ -0,0 +1,133 @@
+package receipt
+
+import (
+	"github.com/shopspring/decimal"
+	"regexp"
+	"shop-aggregator/internal/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout parses the receipts of a retailer printing one item per line. Store and Date are searched in
+// the whole text, the other expressions are tried on each line in the order Total, Discount, Item.
+//
+// The expressions use named groups: "store", "date", "name", "quantity", "unit" and "total". Only
+// "name" and "total" are required for an item, the quantity defaults to 1 and the unit price to the
+// total divided by the quantity. Discounts are always negative whatever the sign printed.
+type Layout struct {
+	Store      *regexp.Regexp
+	Date       *regexp.Regexp
+	DateFormat string
+	Item       *regexp.Regexp
+	Discount   *regexp.Regexp
+	Total      *regexp.Regexp
+}
+
+func (l *Layout) Parse(text, currency string) (*model.ParsedReceipt, error) {
+	pr := &model.ParsedReceipt{
+		StoreName: firstLine(text),
+		Total:     model.ZeroMoney(currency),
+	}
+	if store := findGroup(l.Store, text, "store"); store != "" {
+		pr.StoreName = store
+	}
+	if date := findGroup(l.Date, text, "date"); date != "" {
+		if d, err := time.Parse(l.DateFormat, date); err == nil {
+			pr.Date = d
+		}
+	}
+
+	hasTotal := false
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if groups := matchGroups(l.Total, line); groups != nil {
+			if total, ok := parseAmount(groups["total"], currency); ok {
+				pr.Total, hasTotal = total, true
+			}
+			continue
+		}
+		if groups := matchGroups(l.Discount, line); groups != nil {
+			if total, ok := parseAmount(groups["total"], currency); ok {
+				total = model.NewMoney(total.Amount.Abs().Neg(), currency)
+				pr.Discounts = append(pr.Discounts, &model.ReceiptLine{Name: cleanName(groups["name"]), Quantity: 1, UnitPrice: total, Total: total})
+			}
+			continue
+		}
+		if groups := matchGroups(l.Item, line); groups != nil {
+			if item, ok := newLayoutLine(groups, currency); ok {
+				pr.Lines = append(pr.Lines, item)
+			}
+		}
+	}
+
+	if len(pr.Lines) == 0 {
+		return nil, ErrNoLine
+	}
+	if !hasTotal {
+		sum, err := pr.Sum()
+		if err != nil {
+			return nil, err
+		}
+		pr.Total = sum
+	}
+
+	return pr, nil
+}
+
+func newLayoutLine(groups map[string]string, currency string) (*model.ReceiptLine, bool) {
+	total, ok := parseAmount(groups["total"], currency)
+	if !ok {
+		return nil, false
+	}
+	line := &model.ReceiptLine{
+		Name:      cleanName(groups["name"]),
+		Quantity:  1,
+		UnitPrice: total,
+		Total:     total,
+	}
+	if quantity, err := strconv.ParseInt(groups["quantity"], 10, 64); err == nil && quantity > 0 {
+		line.Quantity = quantity
+		line.UnitPrice = model.NewMoney(total.Amount.DivRound(decimal.NewFromInt(quantity), 2), currency)
+	}
+	if unit, ok := parseAmount(groups["unit"], currency); ok {
+		line.UnitPrice = unit
+	}
+	return line, true
+}
+
+func parseAmount(value, currency string) (model.Money, bool) {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "€"))
+	if value == "" {
+		return model.Money{}, false
+	}
+	amount, err := model.ParseDecimal(value)
+	if err != nil {
+		return model.Money{}, false
+	}
+	return model.NewMoney(amount, currency), true
+}
+
+func matchGroups(re *regexp.Regexp, text string) map[string]string {
+	if re == nil {
+		return nil
+	}
+	matches := re.FindStringSubmatch(text)
+	if matches == nil {
+		return nil
+	}
+	groups := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = matches[i]
+		}
+	}
+	return groups
+}
+
+func findGroup(re *regexp.Regexp, text, name string) string {
+	return strings.TrimSpace(matchGroups(re, text)[name])
+}
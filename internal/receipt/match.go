@@ -0,0 +1,105 @@
+package receipt
+
+import (
+	"shop-aggregator/internal/model"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MatchThreshold is the lowest score for a known product to be proposed on a receipt line.
+	MatchThreshold = 0.35
+	// samePriceBonus favors a product last bought at the price printed on the receipt.
+	samePriceBonus = 0.15
+)
+
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "á", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i",
+	"ô", "o", "ö", "o", "ó", "o",
+	"ù", "u", "û", "u", "ü", "u", "ú", "u",
+	"ÿ", "y", "œ", "oe", "æ", "ae",
+)
+
+// Match returns the known product the closest to the receipt line with its score, nil under the MatchThreshold.
+func Match(line *model.ReceiptLine, products []*model.UserProduct) (*model.UserProduct, float64) {
+	var best *model.UserProduct
+	bestScore := 0.0
+	for _, product := range products {
+		score := Similarity(line.Name, product.ProductName)
+		if withBrand := Similarity(line.Name, product.BrandName+" "+product.ProductName); withBrand > score {
+			score = withBrand
+		}
+		if score >= MatchThreshold-samePriceBonus && product.Price.Cmp(line.UnitPrice) == 0 {
+			score += samePriceBonus
+		}
+		if score > 1 {
+			score = 1
+		}
+		if score > bestScore {
+			best, bestScore = product, score
+		}
+	}
+
+	if bestScore < MatchThreshold {
+		return nil, 0
+	}
+	return best, bestScore
+}
+
+// Similarity is the Dice coefficient of the trigrams of both normalized names, from 0 to 1.
+func Similarity(a, b string) float64 {
+	ta := trigrams(Normalize(a))
+	tb := trigrams(Normalize(b))
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram, count := range ta {
+		common += min(count, tb[trigram])
+	}
+
+	return 2 * float64(common) / float64(total(ta)+total(tb))
+}
+
+// Normalize lowers the case, removes the accents and keeps letters and digits separated by single spaces.
+func Normalize(s string) string {
+	s = accentReplacer.Replace(strings.ToLower(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// IsOfCompany tells if the store name printed on a receipt names the company, a word of at least three
+// letters of the company is enough: "E.LECLERC LAON" is a store of "E.Leclerc".
+func IsOfCompany(storeName, company string) bool {
+	name := " " + Normalize(storeName) + " "
+	for _, word := range strings.Fields(Normalize(company)) {
+		if len([]rune(word)) >= 3 && strings.Contains(name, " "+word+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func trigrams(s string) map[string]int {
+	result := map[string]int{}
+	for _, word := range strings.Fields(s) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])]++
+		}
+	}
+	return result
+}
+
+func total(trigrams map[string]int) int {
+	n := 0
+	for _, count := range trigrams {
+		n += count
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+package receipt_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/receipt"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "lait demi ecreme 1l", receipt.Normalize("  Lait demi-écrémé, 1L "))
+}
+
+func TestIsOfCompany(t *testing.T) {
+	assert.True(t, receipt.IsOfCompany("E.LECLERC LAON", "E.Leclerc"))
+	assert.True(t, receipt.IsOfCompany("INTERMARCHE VERVINS", "Intermarché Super"))
+	assert.False(t, receipt.IsOfCompany("Lidl Laon", "Carrefour Market"))
+	assert.False(t, receipt.IsOfCompany("", "Lidl"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, receipt.Similarity("NUTELLA 400G", "Nutella 400g"))
+	assert.Equal(t, 0.0, receipt.Similarity("", "Nutella 400g"))
+	assert.Greater(t, receipt.Similarity("LAIT DEMI ECR 1L", "Lait demi-écrémé 1L"), receipt.Similarity("LAIT DEMI ECR 1L", "Café moulu 250g"))
+}
+
+func TestMatch(t *testing.T) {
+	nutella := &model.UserProduct{ProductName: "Pâte à tartiner 400g", BrandName: "Nutella", Price: money(t, "3.49")}
+	milk := &model.UserProduct{ProductName: "Lait demi-écrémé 1L", BrandName: "Lactel", Price: money(t, "1.15")}
+	coffee := &model.UserProduct{ProductName: "Café moulu 250g", BrandName: "Carte Noire", Price: money(t, "2.10")}
+	products := []*model.UserProduct{nutella, milk, coffee}
+
+	t.Run("match with brand", func(t *testing.T) {
+		match, score := receipt.Match(&model.ReceiptLine{Name: "NUTELLA PATE A TARTINER", UnitPrice: money(t, "3.79")}, products)
+		assert.Equal(t, nutella, match)
+		assert.GreaterOrEqual(t, score, receipt.MatchThreshold)
+	})
+
+	t.Run("same price bonus", func(t *testing.T) {
+		_, withoutBonus := receipt.Match(&model.ReceiptLine{Name: "CAFE MOULU", UnitPrice: money(t, "2.50")}, products)
+		match, withBonus := receipt.Match(&model.ReceiptLine{Name: "CAFE MOULU", UnitPrice: money(t, "2.10")}, products)
+		assert.Equal(t, coffee, match)
+		assert.Greater(t, withBonus, withoutBonus)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		match, score := receipt.Match(&model.ReceiptLine{Name: "SAC CABAS", UnitPrice: money(t, "0.10")}, products)
+		assert.Nil(t, match)
+		assert.Equal(t, 0.0, score)
+	})
+}
+
+func money(t *testing.T, amount string) model.Money {
+	m, err := model.ParseMoney(amount)
+	assert.NoError(t, err)
+	return m
+}
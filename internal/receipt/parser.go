@@ -0,0 +1,144 @@
+package receipt
+
+import (
+	"regexp"
+	"shop-aggregator/internal/model"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// NUTELLA 400G   3,49 €  A
+	priceRegexp = regexp.MustCompile(`^(.*?)\s*(-?\d{1,5}[.,]\d{2})\s*(?:€|EUR|E)?(?:\s+[A-D0-9]{1,2})?$`)
+	// 2 x 1,25 €
+	quantityRegexp = regexp.MustCompile(`(?i)(?:^|\s)(\d{1,3})\s*[x*]\s*(\d{1,5}[.,]\d{2})\s*(?:€|EUR)?`)
+	// 0,532 kg x 2,99 €/kg
+	weightRegexp = regexp.MustCompile(`(?i)\d+[.,]\d+\s*kg\s*[x*]\s*\d+[.,]\d{2}\s*(?:€|EUR)?\s*(?:/\s*kg)?`)
+	// everything printed after the total is about the payment
+	totalRegexp = regexp.MustCompile(`(?i)^(total|a payer|à payer|net a payer|net à payer)\b`)
+	skipRegexp  = regexp.MustCompile(`(?i)^(sous[- ]?total|tva|taux|cb|carte|especes|espèces|rendu|monnaie|montant|paiement|nb articles|articles?)\b`)
+)
+
+type quantity struct {
+	count int64
+	unit  model.Money
+}
+
+// ParseLines extracts the items of an OCR'd receipt. A quantity printed alone on its line ("2 x 1,25")
+// goes with the item above it when the totals agree, otherwise with the item below it.
+func ParseLines(text, currency string) []*model.ReceiptLine {
+	lines, _ := parseText(text, currency)
+	return lines
+}
+
+// parseText returns the items of the receipt and the amount of its total line, nil without total.
+func parseText(text, currency string) ([]*model.ReceiptLine, *model.Money) {
+	var lines []*model.ReceiptLine
+	var pending *quantity
+	var total *model.Money
+
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.Join(strings.Fields(raw), " ")
+		if line == "" || skipRegexp.MatchString(line) {
+			continue
+		}
+		if totalRegexp.MatchString(line) {
+			if _, amount, ok := parsePrice(line, currency); ok {
+				total = &amount
+			}
+			break
+		}
+
+		line = weightRegexp.ReplaceAllString(line, "")
+		qty := parseQuantity(line, currency)
+		if qty != nil {
+			line = strings.TrimSpace(quantityRegexp.ReplaceAllString(line, " "))
+		}
+
+		name, total, ok := parsePrice(line, currency)
+		if !hasName(name) {
+			// detail line, only a quantity and maybe its total
+			if qty == nil {
+				continue
+			}
+			if previous := last(lines); previous != nil && previous.Quantity == 1 && previous.Total.Cmp(qty.unit.Mul(qty.count)) == 0 {
+				previous.Quantity = qty.count
+				previous.UnitPrice = qty.unit
+				continue
+			}
+			pending = qty
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		receiptLine := &model.ReceiptLine{
+			Name:      cleanName(name),
+			Quantity:  1,
+			UnitPrice: total,
+			Total:     total,
+		}
+		if qty == nil {
+			qty, pending = pending, nil
+		}
+		if qty != nil && qty.count > 0 {
+			receiptLine.Quantity = qty.count
+			receiptLine.UnitPrice = qty.unit
+		}
+		lines = append(lines, receiptLine)
+	}
+
+	return lines, total
+}
+
+func parseQuantity(line, currency string) *quantity {
+	matches := quantityRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return nil
+	}
+	count, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return nil
+	}
+	unit, err := model.ParseDecimal(matches[2])
+	if err != nil {
+		return nil
+	}
+	return &quantity{count: count, unit: model.NewMoney(unit, currency)}
+}
+
+func parsePrice(line, currency string) (string, model.Money, bool) {
+	matches := priceRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return line, model.Money{}, false
+	}
+	amount, err := model.ParseDecimal(matches[2])
+	if err != nil {
+		return line, model.Money{}, false
+	}
+	return matches[1], model.NewMoney(amount, currency), true
+}
+
+// hasName tells if the text holds a product name and not only numbers and units.
+func hasName(text string) bool {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+func cleanName(name string) string {
+	return strings.TrimSpace(strings.TrimLeft(name, "*>-# "))
+}
+
+func last(lines []*model.ReceiptLine) *model.ReceiptLine {
+	if len(lines) == 0 {
+		return nil
+	}
+	return lines[len(lines)-1]
+}
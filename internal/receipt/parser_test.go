@@ -0,0 +1,56 @@
+package receipt_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/receipt"
+	"testing"
+)
+
+func TestParseLines(t *testing.T) {
+	text := `INTERMARCHE VERVINS
+7 rue du labrador
+
+NUTELLA 400G          3,49 €  A
+LAIT DEMI ECR 1L      2,50 A
+      2 x 1,25 €
+   3 x 0,99
+YAOURT NATURE X4      2,97
+BANANES               1,59
+  0,532 kg x 2,99 €/kg
+*CAFE MOULU 250G 2 x 2,10 4,20
+REMISE FIDELITE      -0,50
+SOUS-TOTAL           14,34
+TOTAL                14,34
+CB                   14,34
+MERCI DE VOTRE VISITE 1,00`
+
+	lines := receipt.ParseLines(text, "EUR")
+	require.Len(t, lines, 6)
+
+	expected := []struct {
+		name     string
+		quantity int64
+		unit     string
+		total    string
+	}{
+		{"NUTELLA 400G", 1, "3.49 EUR", "3.49 EUR"},
+		{"LAIT DEMI ECR 1L", 2, "1.25 EUR", "2.50 EUR"},
+		{"YAOURT NATURE X4", 3, "0.99 EUR", "2.97 EUR"},
+		{"BANANES", 1, "1.59 EUR", "1.59 EUR"},
+		{"CAFE MOULU 250G", 2, "2.10 EUR", "4.20 EUR"},
+		{"REMISE FIDELITE", 1, "-0.50 EUR", "-0.50 EUR"},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, lines[i].Name)
+		assert.Equal(t, e.quantity, lines[i].Quantity, e.name)
+		assert.Equal(t, e.unit, lines[i].UnitPrice.String(), e.name)
+		assert.Equal(t, e.total, lines[i].Total.String(), e.name)
+	}
+}
+
+func TestParseLines_Empty(t *testing.T) {
+	assert.Empty(t, receipt.ParseLines("", model.DefaultCurrency))
+	assert.Empty(t, receipt.ParseLines("no price on this receipt\n\n", model.DefaultCurrency))
+}
@@ -0,0 +1,73 @@
+package receipt
+
+import (
+	"errors"
+	"shop-aggregator/internal/model"
+	"strings"
+)
+
+// ErrNoLine is returned by the parsers when nothing looking like an item was found.
+var ErrNoLine = errors.New("no line found on the receipt")
+
+// Parser turns the text of a receipt into a structured receipt, the amounts are in currency.
+type Parser interface {
+	Parse(text, currency string) (*model.ParsedReceipt, error)
+}
+
+// Registry holds the parser of each company. Companies are looked up by their normalized name, then by
+// a registered name contained in it ("carrefour market" uses the "carrefour" parser), then the fallback.
+type Registry struct {
+	parsers  map[string]Parser
+	fallback Parser
+}
+
+func NewRegistry(fallback Parser) *Registry {
+	return &Registry{
+		parsers:  map[string]Parser{},
+		fallback: fallback,
+	}
+}
+
+func (r *Registry) Register(company string, parser Parser) {
+	r.parsers[Normalize(company)] = parser
+}
+
+func (r *Registry) Parser(company string) Parser {
+	name := Normalize(company)
+	if parser, ok := r.parsers[name]; ok {
+		return parser
+	}
+
+	var best Parser
+	bestLength := 0
+	for key, parser := range r.parsers {
+		if len(key) > bestLength && strings.Contains(" "+name+" ", " "+key+" ") {
+			best, bestLength = parser, len(key)
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return r.fallback
+}
+
+func (r *Registry) Parse(company, text, currency string) (*model.ParsedReceipt, error) {
+	return r.Parser(company).Parse(text, currency)
+}
+
+// ParseReceipt reads a text, HTML or e-mail receipt of the company. The date of the e-mail is kept when
+// the receipt does not print its own.
+func (r *Registry) ParseReceipt(company string, raw []byte, currency string) (*model.ParsedReceipt, error) {
+	text, err := Read(raw)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := r.Parse(company, text.Text, currency)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Date.IsZero() {
+		pr.Date = text.Date
+	}
+	return pr, nil
+}
@@ -0,0 +1,18 @@
+package retailer
+
+import (
+	"regexp"
+	"shop-aggregator/internal/receipt"
+)
+
+// Carrefour reads the HTML e-mail receipts, one table row per item:
+//
+//	Nutella pâte à tartiner 400g | 2 | 3,49 € | 6,98 €
+var Carrefour = &receipt.Layout{
+	Store:      regexp.MustCompile(`(?m)^Magasin\s*:\s*(?P<store>.+)$`),
+	Date:       regexp.MustCompile(`(?m)^Date d'achat\s*:\s*(?P<date>\d{2}/\d{2}/\d{4} \d{2}:\d{2})`),
+	DateFormat: "02/01/2006 15:04",
+	Item:       regexp.MustCompile(`^(?P<name>[^\t]+)\t(?P<quantity>\d+)\t(?P<unit>\d+,\d{2})\s*€?\t(?P<total>\d+,\d{2})\s*€?$`),
+	Discount:   regexp.MustCompile(`(?i)^(?P<name>(?:remise|bon d'achat|coupon)[^\t]*)\t+(?P<total>-?\d+,\d{2})\s*€?$`),
+	Total:      regexp.MustCompile(`(?i)^total payé\t+(?P<total>\d+,\d{2})\s*€?$`),
+}
@@ -0,0 +1,19 @@
+package retailer
+
+import (
+	"regexp"
+	"shop-aggregator/internal/receipt"
+)
+
+// Leclerc reads the dematerialized receipts, printed in columns:
+//
+//	ARTICLE                 QTE   PU     MONTANT
+//	LAIT DEMI ECREME 1L       2   1,25    2,50
+var Leclerc = &receipt.Layout{
+	Store:      regexp.MustCompile(`(?m)^(?P<store>E\.LECLERC .+)$`),
+	Date:       regexp.MustCompile(`(?m)^Date\s*:\s*(?P<date>\d{2}/\d{2}/\d{4} \d{2}:\d{2})`),
+	DateFormat: "02/01/2006 15:04",
+	Item:       regexp.MustCompile(`^(?P<name>.+?)\s{2,}(?P<quantity>\d+)\s+(?P<unit>\d+,\d{2})\s+(?P<total>\d+,\d{2})$`),
+	Discount:   regexp.MustCompile(`^(?P<name>REMISE.*?)\s{2,}(?P<total>-\d+,\d{2})$`),
+	Total:      regexp.MustCompile(`^TOTAL A PAYER\s+(?P<total>\d+,\d{2})$`),
+}
@@ -0,0 +1,18 @@
+package retailer
+
+import (
+	"regexp"
+	"shop-aggregator/internal/receipt"
+)
+
+// Lidl reads the plain text e-mail receipts, the quantity is printed after the name:
+//
+//	Lait demi-écrémé 1L 1,25 x 2      2,50 A
+var Lidl = &receipt.Layout{
+	Store:      regexp.MustCompile(`(?m)^(?P<store>Lidl [^\d]+)$`),
+	Date:       regexp.MustCompile(`(?P<date>\d{2}\.\d{2}\.\d{2} \d{2}:\d{2})`),
+	DateFormat: "02.01.06 15:04",
+	Item:       regexp.MustCompile(`^(?P<name>.+?)\s+(?:(?P<unit>\d+,\d{2})\s*x\s*(?P<quantity>\d+)\s+)?(?P<total>\d+,\d{2})\s+[A-C]$`),
+	Discount:   regexp.MustCompile(`(?i)^(?P<name>(?:remise|lidl plus|coupon).*?)\s+(?P<total>-\d+,\d{2})$`),
+	Total:      regexp.MustCompile(`(?i)^a payer\s+(?P<total>\d+,\d{2})$`),
+}
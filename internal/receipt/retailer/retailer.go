@@ -0,0 +1,15 @@
+// Package retailer holds the receipt layouts of the retailers. To support a new one, add a file with its
+// parser, register it in NewRegistry and drop a receipt with its .golden.json file in testdata/<company>.
+package retailer
+
+import "shop-aggregator/internal/receipt"
+
+// NewRegistry returns the parsers of all the retailers, receipt.Generic reads the other companies.
+func NewRegistry() *receipt.Registry {
+	r := receipt.NewRegistry(receipt.Generic{})
+	r.Register("carrefour", Carrefour)
+	r.Register("lidl", Lidl)
+	r.Register("leclerc", Leclerc)
+	r.Register("e leclerc", Leclerc)
+	return r
+}
@@ -0,0 +1,58 @@
+package retailer_test
+
+import (
+	"encoding/json"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/receipt/retailer"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// TestGolden parses every receipt of testdata/<company> and compares it with its .golden.json file,
+// run go test -update to write the golden files of new receipts.
+func TestGolden(t *testing.T) {
+	registry := retailer.NewRegistry()
+	files, err := filepath.Glob(filepath.Join("testdata", "*", "*"))
+	require.NoError(t, err)
+
+	for _, file := range files {
+		if strings.HasSuffix(file, ".golden.json") {
+			continue
+		}
+		company := filepath.Base(filepath.Dir(file))
+		t.Run(company+"/"+filepath.Base(file), func(t *testing.T) {
+			raw, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			pr, err := registry.ParseReceipt(company, raw, model.DefaultCurrency)
+			require.NoError(t, err)
+			sum, err := pr.Sum()
+			require.NoError(t, err)
+			assert.Equal(t, pr.Total.String(), sum.String(), "lines and discounts must add up to the total")
+
+			got, err := json.MarshalIndent(pr, "", "  ")
+			require.NoError(t, err)
+			golden := strings.TrimSuffix(file, filepath.Ext(file)) + ".golden.json"
+			if *update {
+				require.NoError(t, os.WriteFile(golden, append(got, '\n'), 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry := retailer.NewRegistry()
+	assert.Equal(t, retailer.Carrefour, registry.Parser("Carrefour Market"))
+	assert.Equal(t, retailer.Leclerc, registry.Parser("E.Leclerc"))
+	assert.Equal(t, retailer.Lidl, registry.Parser("LIDL"))
+}
@@ -3,17 +3,27 @@ package router
 import (
 	"context"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"shop-aggregator/internal/auth"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/problem"
 )
 
 type AuthStorer interface {
-	EnsureValidToken(context.Context, string) (uuid.UUID, error)
+	EnsureValidToken(context.Context, string) (*model.Session, error)
+}
+
+type APIKeyStorer interface {
+	EnsureValidAPIKey(context.Context, string) (*model.APIKey, error)
 }
 
 type AuthHandler interface {
 	Login(c *gin.Context)
+	LoginTwoFactor(c *gin.Context)
+	Refresh(c *gin.Context)
 	Logout(c *gin.Context)
+	GetSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeOtherSessions(c *gin.Context)
 }
 
 type UserHandler interface {
@@ -21,6 +31,10 @@ type UserHandler interface {
 	UpdatePassword(c *gin.Context)
 	GetUser(c *gin.Context)
 	UpdateEmail(c *gin.Context)
+	ResendVerification(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
 }
 
 type BrandHandler interface {
@@ -37,7 +51,13 @@ type BillHandler interface {
 	Close(c *gin.Context)
 	GetBillsByUserID(c *gin.Context)
 	GetLastBill(c *gin.Context)
+	GetOpenBill(c *gin.Context)
+	GetOpenBills(c *gin.Context)
 	Cancel(c *gin.Context)
+	GetDiscrepancies(c *gin.Context)
+	Reopen(c *gin.Context)
+	Archive(c *gin.Context)
+	GetHistory(c *gin.Context)
 }
 
 type StoreHandler interface {
@@ -57,6 +77,85 @@ type UserProductHandler interface {
 	Delete(c *gin.Context)
 }
 
+type PriceHandler interface {
+	CompareByProductID(c *gin.Context)
+	CompareByEAN(c *gin.Context)
+	SelectStorePrices(c *gin.Context)
+	SelectUserStorePrices(c *gin.Context)
+}
+
+type PriceHistoryHandler interface {
+	GetPriceHistory(c *gin.Context)
+}
+
+type ShoppingListHandler interface {
+	Optimize(c *gin.Context)
+}
+
+type ReceiptHandler interface {
+	Scan(c *gin.Context)
+	Import(c *gin.Context)
+}
+
+type AdminHandler interface {
+	SetRole(c *gin.Context)
+	DisableUser(c *gin.Context)
+	EnableUser(c *gin.Context)
+	UpdateBrand(c *gin.Context)
+	MergeBrands(c *gin.Context)
+	UpdateCompany(c *gin.Context)
+	MergeCompanies(c *gin.Context)
+	UpdateStore(c *gin.Context)
+	MergeStores(c *gin.Context)
+	UpdateProduct(c *gin.Context)
+	MergeProducts(c *gin.Context)
+	GetStats(c *gin.Context)
+	GetLoginAttempts(c *gin.Context)
+}
+
+type OIDCHandler interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+}
+
+type APIKeyHandler interface {
+	Create(c *gin.Context)
+	GetAPIKeys(c *gin.Context)
+	Revoke(c *gin.Context)
+}
+
+type TwoFactorHandler interface {
+	Enroll(c *gin.Context)
+	Confirm(c *gin.Context)
+	Disable(c *gin.Context)
+}
+
+type HouseholdHandler interface {
+	Create(c *gin.Context)
+	GetHouseholds(c *gin.Context)
+	GetMembers(c *gin.Context)
+	Invite(c *gin.Context)
+	GetInvitations(c *gin.Context)
+	AcceptInvitation(c *gin.Context)
+	DeclineInvitation(c *gin.Context)
+	SetMemberRole(c *gin.Context)
+	RemoveMember(c *gin.Context)
+	GetSpending(c *gin.Context)
+}
+
+type BillSplitHandler interface {
+	SplitBill(c *gin.Context)
+	GetSplit(c *gin.Context)
+	GetBalances(c *gin.Context)
+	Settle(c *gin.Context)
+}
+
+type AccountHandler interface {
+	Export(c *gin.Context)
+	ScheduleDeletion(c *gin.Context)
+	CancelDeletion(c *gin.Context)
+}
+
 type InitialisationHandler interface {
 	AppInitialisation(c *gin.Context)
 }
@@ -65,6 +164,7 @@ type InitialisationHandler interface {
 func NewRouter(
 	router *gin.Engine,
 	as AuthStorer,
+	aks APIKeyStorer,
 	ah AuthHandler,
 	uh UserHandler,
 	bh BrandHandler,
@@ -74,56 +174,137 @@ func NewRouter(
 	ph ProductHandler,
 	uph UserProductHandler,
 	ih InitialisationHandler,
+	prh PriceHandler,
+	phh PriceHistoryHandler,
+	slh ShoppingListHandler,
+	rh ReceiptHandler,
+	adh AdminHandler,
+	oh OIDCHandler,
+	akh APIKeyHandler,
+	tfh TwoFactorHandler,
+	hh HouseholdHandler,
+	bsh BillSplitHandler,
+	ach AccountHandler,
 ) *gin.Engine {
+	// the handlers and the middlewares add their errors to the context, they are answered as problem details
+	router.Use(problem.Middleware())
+
 	router.GET("/init", ih.AppInitialisation)
 
 	router.POST("/create-user", uh.CreateUser)
 	router.POST("/login", ah.Login)
+	router.POST("/login/2fa", ah.LoginTwoFactor)
+	router.POST("/refresh", ah.Refresh)
+	router.POST("/verify-email", uh.VerifyEmail)
+	router.POST("/forgot-password", uh.ForgotPassword)
+	router.POST("/reset-password", uh.ResetPassword)
+	router.GET("/oidc/login", oh.Login)
+	router.GET("/oidc/callback", oh.Callback)
 
 	protected := router.Group("/")
-	protected.Use(auth.Middleware(as))
+	protected.Use(auth.Middleware(as, aks))
+
+	// the API keys reach the bills and the catalog within their scopes, never the account or the admin
+	bills := protected.Group("/")
+	bills.Use(auth.RequireScopes(model.ScopeBillsRead, model.ScopeBillsWrite))
+	catalog := protected.Group("/")
+	catalog.Use(auth.RequireScopes(model.ScopeCatalogRead, model.ScopeCatalogWrite))
+	// optimizing a list only reads the catalog
+	catalogRead := protected.Group("/")
+	catalogRead.Use(auth.RequireScopes(model.ScopeCatalogRead, model.ScopeCatalogRead))
 
 	user := protected.Group("/user")
+	user.Use(auth.RequireSession())
 	{
 		user.GET("/get", uh.GetUser)
 		user.POST("/logout", ah.Logout)
 		user.POST("/reset-password", uh.UpdatePassword)
 		user.POST("/update-email", uh.UpdateEmail)
+		user.POST("/resend-verification", uh.ResendVerification)
+		user.GET("/sessions", ah.GetSessions)
+		user.POST("/sessions/revoke-others", ah.RevokeOtherSessions)
+		user.DELETE("/sessions/:session_id", ah.RevokeSession)
+		user.POST("/api-keys", akh.Create)
+		user.GET("/api-keys", akh.GetAPIKeys)
+		user.DELETE("/api-keys/:api_key_id", akh.Revoke)
+		user.POST("/2fa/enroll", tfh.Enroll)
+		user.POST("/2fa/confirm", tfh.Confirm)
+		user.POST("/2fa/disable", tfh.Disable)
+		user.GET("/export", ach.Export)
+		user.POST("/deletion", ach.ScheduleDeletion)
+		user.DELETE("/deletion", ach.CancelDeletion)
+	}
+
+	// the members of a household are managed from a session, its spending is read like its bills
+	household := protected.Group("/household")
+	household.Use(auth.RequireSession())
+	{
+		household.POST("", hh.Create)
+		household.GET("", hh.GetHouseholds)
+		household.GET("/invitations", hh.GetInvitations)
+		household.POST("/invitations/:invitation_id/accept", hh.AcceptInvitation)
+		household.POST("/invitations/:invitation_id/decline", hh.DeclineInvitation)
+		household.GET("/:household_id/members", hh.GetMembers)
+		household.POST("/:household_id/invitations", hh.Invite)
+		household.PUT("/:household_id/members/:user_id/role", hh.SetMemberRole)
+		household.DELETE("/:household_id/members/:user_id", hh.RemoveMember)
 	}
 
-	brand := protected.Group("/brand")
+	householdBills := bills.Group("/household")
+	{
+		householdBills.GET("/:household_id/spending", hh.GetSpending)
+	}
+
+	brand := catalog.Group("/brand")
 	{
 		brand.GET("/get/:name", bh.GetByPartialName)
 		brand.POST("/create", bh.Create)
 	}
 
-	company := protected.Group("/company")
+	company := catalog.Group("/company")
 	{
 		company.GET("/get/:name", ch.GetByPartialName)
 	}
 
-	bill := protected.Group("/bill")
+	bill := bills.Group("/bill")
 	{
 		bill.GET("/get-all", bih.GetBillsByUserID)
 		bill.GET("/get-last", bih.GetLastBill)
+		bill.GET("/open", bih.GetOpenBills)
+		bill.GET("/open/:bill_id", bih.GetOpenBill)
 		bill.POST("/start", bih.Start)
 		bill.POST("/stop", bih.Close)
 		bill.POST("/cancel", bih.Cancel)
+		bill.GET("/discrepancies", bih.GetDiscrepancies)
+		bill.POST("/import", rh.Import)
+		bill.POST("/:bill_id/receipt", rh.Scan)
+		bill.POST("/:bill_id/reopen", bih.Reopen)
+		bill.POST("/:bill_id/archive", bih.Archive)
+		bill.GET("/:bill_id/history", bih.GetHistory)
+		bill.PUT("/:bill_id/split", bsh.SplitBill)
+		bill.GET("/:bill_id/split", bsh.GetSplit)
+	}
+
+	// what the users owe each other on the splits of their bills
+	balance := bills.Group("/balance")
+	{
+		balance.GET("", bsh.GetBalances)
+		balance.POST("/settle", bsh.Settle)
 	}
 
-	store := protected.Group("/store")
+	store := catalog.Group("/store")
 	{
 		store.GET("/get/:store_type/:search", sh.GetStoreByZipCodeOrName)
 		store.POST("/create-store", sh.CreateStore)
 	}
 
-	product := protected.Group("/product")
+	product := catalog.Group("/product")
 	{
 		product.GET("/get/:ean", ph.GetProductByEAN)
 		product.POST("/create-product", ph.Create)
 	}
 
-	userProduct := protected.Group("/user-product")
+	userProduct := bills.Group("/user-product")
 	{
 		userProduct.GET("/get-bill-id/:bill_id", uph.SelectProductsByBillID)
 		userProduct.POST("/create-user-product", uph.Create)
@@ -131,5 +312,43 @@ func NewRouter(
 		userProduct.DELETE("/delete/:user_product_id", uph.Delete)
 	}
 
+	price := catalog.Group("/price")
+	{
+		price.GET("/product/:product_id", prh.CompareByProductID)
+		price.GET("/ean/:ean", prh.CompareByEAN)
+		price.GET("/store/:store_id", prh.SelectStorePrices)
+		price.GET("/store/:store_id/mine", prh.SelectUserStorePrices)
+		price.GET("/history/:product_id", phh.GetPriceHistory)
+	}
+
+	shoppingList := catalogRead.Group("/shopping-list")
+	{
+		shoppingList.POST("/optimize", slh.Optimize)
+	}
+
+	// moderators fix the shared catalog, admins also manage the users
+	admin := protected.Group("/admin")
+	admin.Use(auth.RequireSession(), auth.RequireRole(model.RoleModerator))
+	{
+		admin.PUT("/brand/:brand_id", adh.UpdateBrand)
+		admin.POST("/brand/:brand_id/merge", adh.MergeBrands)
+		admin.PUT("/company/:company_id", adh.UpdateCompany)
+		admin.POST("/company/:company_id/merge", adh.MergeCompanies)
+		admin.PUT("/store/:store_id", adh.UpdateStore)
+		admin.POST("/store/:store_id/merge", adh.MergeStores)
+		admin.PUT("/product/:product_id", adh.UpdateProduct)
+		admin.POST("/product/:product_id/merge", adh.MergeProducts)
+	}
+
+	adminOnly := admin.Group("/")
+	adminOnly.Use(auth.RequireRole(model.RoleAdmin))
+	{
+		adminOnly.GET("/stats", adh.GetStats)
+		adminOnly.GET("/login-attempts", adh.GetLoginAttempts)
+		adminOnly.PUT("/users/:user_id/role", adh.SetRole)
+		adminOnly.POST("/users/:user_id/disable", adh.DisableUser)
+		adminOnly.POST("/users/:user_id/enable", adh.EnableUser)
+	}
+
 	return router
 }
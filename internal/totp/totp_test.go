@@ -0,0 +1,84 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"shop-aggregator/internal/totp"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 seed of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the RFC 6238 vectors, truncated to 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		code, err := totp.Code(rfcSecret, totp.Step(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, tt.unix)
+	}
+
+	_, err := totp.Code("not base32!", 1)
+	assert.ErrorIs(t, err, totp.ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := totp.Step(now)
+
+	for _, step := range []int64{current - 1, current, current + 1} {
+		code, err := totp.Code(rfcSecret, step)
+		require.NoError(t, err)
+		got, ok := totp.Validate(rfcSecret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, step, got)
+	}
+
+	old, err := totp.Code(rfcSecret, current-2)
+	require.NoError(t, err)
+	_, ok := totp.Validate(rfcSecret, old, now)
+	assert.False(t, ok)
+
+	_, ok = totp.Validate(rfcSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	other, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	_, ok := totp.Validate(secret, code, time.Now())
+	assert.True(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(totp.ProvisioningURI("Shop Aggregator", "alice", "SECRET"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Shop Aggregator:alice", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "Shop Aggregator", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
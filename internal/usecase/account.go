@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"time"
+)
+
+const defaultAccountDeletionGrace = 30 * 24 * time.Hour
+
+type AccountStorer interface {
+	SelectBills(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error
+	CancelDeletion(ctx context.Context, userID uuid.UUID) error
+	SelectDueDeletions(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	DeleteAccount(ctx context.Context, userID, pseudonymID uuid.UUID) error
+}
+
+type AccountUserStorer interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+}
+
+type AccountSessionStorer interface {
+	SelectSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Session, error)
+}
+
+type AccountUserProductStorer interface {
+	SelectProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserProduct, error)
+}
+
+// Account exports the personal data of the users and deletes their accounts. A deletion is scheduled
+// DeletionGrace ahead, the user can cancel it until then. The catalog is shared and records no author, it is
+// left as it is.
+type Account struct {
+	AccountStorer            AccountStorer
+	AccountUserStorer        AccountUserStorer
+	AccountSessionStorer     AccountSessionStorer
+	AccountUserProductStorer AccountUserProductStorer
+	DeletionGrace            time.Duration
+}
+
+func NewAccount(as AccountStorer, aus AccountUserStorer, ass AccountSessionStorer, aups AccountUserProductStorer, cfg *config.AuthConfig) *Account {
+	a := &Account{
+		AccountStorer:            as,
+		AccountUserStorer:        aus,
+		AccountSessionStorer:     ass,
+		AccountUserProductStorer: aups,
+		DeletionGrace:            time.Duration(cfg.AccountDeletionGraceDays) * 24 * time.Hour,
+	}
+	if a.DeletionGrace <= 0 {
+		a.DeletionGrace = defaultAccountDeletionGrace
+	}
+	return a
+}
+
+// Export returns the profile, the open sessions, the bills and the lines of the user.
+func (a *Account) Export(ctx context.Context, userID uuid.UUID) (*model.PersonalData, error) {
+	user, err := a.AccountUserStorer.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Export.GetUserByID")
+		return nil, model.ErrAccountError
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	data := &model.PersonalData{User: user, ExportedAt: time.Now().UTC()}
+	if data.Sessions, err = a.AccountSessionStorer.SelectSessionsByUserID(ctx, userID); err != nil {
+		log.Error().Caller().Err(err).Msg("Export.SelectSessionsByUserID")
+		return nil, model.ErrAccountError
+	}
+	if data.Bills, err = a.AccountStorer.SelectBills(ctx, userID); err != nil {
+		log.Error().Caller().Err(err).Msg("Export.SelectBills")
+		return nil, model.ErrAccountError
+	}
+	if data.Lines, err = a.AccountUserProductStorer.SelectProductsByUserID(ctx, userID); err != nil {
+		log.Error().Caller().Err(err).Msg("Export.SelectProductsByUserID")
+		return nil, model.ErrAccountError
+	}
+	return data, nil
+}
+
+// ScheduleDeletion returns when the account will be deleted.
+func (a *Account) ScheduleDeletion(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	at := time.Now().UTC().Add(a.DeletionGrace)
+	if err := a.AccountStorer.ScheduleDeletion(ctx, userID, at); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, model.ErrAccountDeletionScheduledError
+		}
+		log.Error().Caller().Err(err).Msg("ScheduleDeletion.ScheduleDeletion")
+		return time.Time{}, model.ErrAccountError
+	}
+	return at, nil
+}
+
+func (a *Account) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	if err := a.AccountStorer.CancelDeletion(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrAccountDeletionNotFoundError
+		}
+		log.Error().Caller().Err(err).Msg("CancelDeletion.CancelDeletion")
+		return model.ErrAccountError
+	}
+	return nil
+}
+
+// PurgeAccounts deletes the accounts whose deletion is due and returns how many were deleted. Each account
+// gets its own pseudonym, what stays of it is no longer linked to the others nor to the user. An account
+// failing to be deleted is tried again on the next purge.
+func (a *Account) PurgeAccounts(ctx context.Context) (int, error) {
+	userIDs, err := a.AccountStorer.SelectDueDeletions(ctx, time.Now().UTC())
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("PurgeAccounts.SelectDueDeletions")
+		return 0, model.ErrAccountError
+	}
+
+	deleted := 0
+	for _, userID := range userIDs {
+		if err = a.AccountStorer.DeleteAccount(ctx, userID, uuid.New()); err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Error().Caller().Err(err).Msg("PurgeAccounts.DeleteAccount")
+			}
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestAccount_Export(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewAccountStorer(t)
+	mockUserStorer := NewAccountUserStorer(t)
+	mockSessionStorer := NewAccountSessionStorer(t)
+	mockUserProductStorer := NewAccountUserProductStorer(t)
+	a := usecase.NewAccount(mockStorer, mockUserStorer, mockSessionStorer, mockUserProductStorer, &config.AuthConfig{})
+
+	userID := uuid.New()
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, userID).Return(nil, nil).Once()
+		_, err := a.Export(ctx, userID)
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+
+	t.Run("SelectBills error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, userID).Return(&model.User{ID: userID}, nil).Once()
+		mockSessionStorer.EXPECT().SelectSessionsByUserID(ctx, userID).Return([]*model.Session{}, nil).Once()
+		mockStorer.EXPECT().SelectBills(ctx, userID).Return(nil, errors.New("random error")).Once()
+		_, err := a.Export(ctx, userID)
+		assert.ErrorIs(t, err, model.ErrAccountError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		user := &model.User{ID: userID, Login: "alice"}
+		sessions := []*model.Session{{SessionID: uuid.New()}}
+		bills := []*model.Bill{{BillID: uuid.New()}}
+		lines := []*model.UserProduct{{UserProductID: uuid.New()}}
+		mockUserStorer.EXPECT().GetUserByID(ctx, userID).Return(user, nil).Once()
+		mockSessionStorer.EXPECT().SelectSessionsByUserID(ctx, userID).Return(sessions, nil).Once()
+		mockStorer.EXPECT().SelectBills(ctx, userID).Return(bills, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByUserID(ctx, userID).Return(lines, nil).Once()
+
+		data, err := a.Export(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, user, data.User)
+		assert.Equal(t, sessions, data.Sessions)
+		assert.Equal(t, bills, data.Bills)
+		assert.Equal(t, lines, data.Lines)
+	})
+}
+
+func TestAccount_ScheduleDeletion(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewAccountStorer(t)
+	a := usecase.NewAccount(mockStorer, nil, nil, nil, &config.AuthConfig{AccountDeletionGraceDays: 7})
+
+	userID := uuid.New()
+
+	t.Run("already scheduled", func(t *testing.T) {
+		mockStorer.EXPECT().ScheduleDeletion(ctx, userID, mock.Anything).Return(pgx.ErrNoRows).Once()
+		_, err := a.ScheduleDeletion(ctx, userID)
+		assert.ErrorIs(t, err, model.ErrAccountDeletionScheduledError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockStorer.EXPECT().ScheduleDeletion(ctx, userID, mock.Anything).Return(nil).Once()
+		at, err := a.ScheduleDeletion(ctx, userID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), at, time.Minute)
+	})
+
+	t.Run("cancel without deletion", func(t *testing.T) {
+		mockStorer.EXPECT().CancelDeletion(ctx, userID).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.CancelDeletion(ctx, userID), model.ErrAccountDeletionNotFoundError)
+	})
+}
+
+func TestAccount_PurgeAccounts(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewAccountStorer(t)
+	a := usecase.NewAccount(mockStorer, nil, nil, nil, &config.AuthConfig{})
+
+	canceled := uuid.New()
+	failing := uuid.New()
+	due := uuid.New()
+	pseudonyms := map[uuid.UUID]bool{}
+
+	mockStorer.EXPECT().SelectDueDeletions(ctx, mock.Anything).Return([]uuid.UUID{canceled, failing, due}, nil).Once()
+	mockStorer.EXPECT().DeleteAccount(ctx, canceled, mock.Anything).Return(pgx.ErrNoRows).Once()
+	mockStorer.EXPECT().DeleteAccount(ctx, failing, mock.Anything).Return(errors.New("random error")).Once()
+	mockStorer.EXPECT().DeleteAccount(ctx, due, mock.Anything).Run(func(_ context.Context, _, pseudonymID uuid.UUID) {
+		pseudonyms[pseudonymID] = true
+	}).Return(nil).Once()
+
+	deleted, err := a.PurgeAccounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Len(t, pseudonyms, 1)
+	assert.False(t, pseudonyms[due])
+}
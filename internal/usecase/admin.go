@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+)
+
+type AdminUserStorer interface {
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
+	Disable(ctx context.Context, id uuid.UUID) error
+	Enable(ctx context.Context, id uuid.UUID) error
+}
+
+type AdminBrandStorer interface {
+	SelectBrandByName(ctx context.Context, name string) (*model.Brand, error)
+	Update(ctx context.Context, brand *model.Brand) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+}
+
+type AdminCompanyStorer interface {
+	SelectCompanyByName(ctx context.Context, name string) (*model.Company, error)
+	Update(ctx context.Context, company *model.Company) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+}
+
+type AdminStoreStorer interface {
+	Update(ctx context.Context, store *model.Store) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+}
+
+type AdminProductStorer interface {
+	GetProductByEAN(ctx context.Context, ean string) (*model.Product, error)
+	Update(ctx context.Context, product *model.Product) error
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+}
+
+type AdminStatsStorer interface {
+	SelectStats(ctx context.Context) (*model.Stats, error)
+}
+
+type AdminPriceHistoryStorer interface {
+	Refresh(ctx context.Context) error
+}
+
+type AdminLoginAttemptStorer interface {
+	SelectLoginAttempts(ctx context.Context, login, ip string, limit int) ([]*model.LoginAttempt, error)
+}
+
+const (
+	defaultLoginAttemptsLimit = 100
+	maxLoginAttemptsLimit     = 1000
+)
+
+// Admin manages the users and fixes the shared catalog. A merge moves everything that points to the source
+// entity to the target entity then deletes the source entity.
+type Admin struct {
+	AdminUserStorer         AdminUserStorer
+	AdminBrandStorer        AdminBrandStorer
+	AdminCompanyStorer      AdminCompanyStorer
+	AdminStoreStorer        AdminStoreStorer
+	AdminProductStorer      AdminProductStorer
+	AdminStatsStorer        AdminStatsStorer
+	AdminPriceHistoryStorer AdminPriceHistoryStorer
+	AdminLoginAttemptStorer AdminLoginAttemptStorer
+}
+
+func NewAdmin(aus AdminUserStorer, abs AdminBrandStorer, acs AdminCompanyStorer, ass AdminStoreStorer, aps AdminProductStorer, asts AdminStatsStorer, aphs AdminPriceHistoryStorer, alas AdminLoginAttemptStorer) *Admin {
+	return &Admin{
+		AdminUserStorer:         aus,
+		AdminBrandStorer:        abs,
+		AdminCompanyStorer:      acs,
+		AdminStoreStorer:        ass,
+		AdminProductStorer:      aps,
+		AdminStatsStorer:        asts,
+		AdminPriceHistoryStorer: aphs,
+		AdminLoginAttemptStorer: alas,
+	}
+}
+
+// SetRole changes the role of an other user, the own role of the admin cannot be changed.
+func (a *Admin) SetRole(ctx context.Context, adminID, userID uuid.UUID, role string) error {
+	if !model.IsValidRole(role) {
+		return model.ErrInvalidRoleError
+	}
+	if adminID == userID {
+		return model.ErrOwnAccountError
+	}
+
+	return a.userError(a.AdminUserStorer.SetRole(ctx, userID, role), "SetRole.SetRole")
+}
+
+// DisableUser logs the user out of every device and refuses the next logins.
+func (a *Admin) DisableUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if adminID == userID {
+		return model.ErrOwnAccountError
+	}
+
+	return a.userError(a.AdminUserStorer.Disable(ctx, userID), "DisableUser.Disable")
+}
+
+func (a *Admin) EnableUser(ctx context.Context, userID uuid.UUID) error {
+	return a.userError(a.AdminUserStorer.Enable(ctx, userID), "EnableUser.Enable")
+}
+
+func (a *Admin) UpdateBrand(ctx context.Context, brand *model.Brand) error {
+	existing, err := a.AdminBrandStorer.SelectBrandByName(ctx, brand.BrandName)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("UpdateBrand.SelectBrandByName")
+		return model.ErrAdminError
+	}
+	if existing != nil && existing.BrandID != brand.BrandID {
+		return model.ErrCatalogConflictError
+	}
+
+	return a.catalogError(a.AdminBrandStorer.Update(ctx, brand), "UpdateBrand.Update")
+}
+
+func (a *Admin) MergeBrands(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if sourceID == targetID {
+		return model.ErrCatalogConflictError
+	}
+
+	return a.catalogError(a.AdminBrandStorer.Merge(ctx, sourceID, targetID), "MergeBrands.Merge")
+}
+
+func (a *Admin) UpdateCompany(ctx context.Context, company *model.Company) error {
+	existing, err := a.AdminCompanyStorer.SelectCompanyByName(ctx, company.CompanyName)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("UpdateCompany.SelectCompanyByName")
+		return model.ErrAdminError
+	}
+	if existing != nil && existing.CompanyID != company.CompanyID {
+		return model.ErrCatalogConflictError
+	}
+
+	return a.catalogError(a.AdminCompanyStorer.Update(ctx, company), "UpdateCompany.Update")
+}
+
+func (a *Admin) MergeCompanies(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if sourceID == targetID {
+		return model.ErrCatalogConflictError
+	}
+
+	if err := a.catalogError(a.AdminCompanyStorer.Merge(ctx, sourceID, targetID), "MergeCompanies.Merge"); err != nil {
+		return err
+	}
+	a.refreshPriceHistory(ctx, "MergeCompanies.Refresh")
+	return nil
+}
+
+func (a *Admin) UpdateStore(ctx context.Context, store *model.Store) error {
+	return a.catalogError(a.AdminStoreStorer.Update(ctx, store), "UpdateStore.Update")
+}
+
+func (a *Admin) MergeStores(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if sourceID == targetID {
+		return model.ErrCatalogConflictError
+	}
+
+	if err := a.catalogError(a.AdminStoreStorer.Merge(ctx, sourceID, targetID), "MergeStores.Merge"); err != nil {
+		return err
+	}
+	a.refreshPriceHistory(ctx, "MergeStores.Refresh")
+	return nil
+}
+
+func (a *Admin) UpdateProduct(ctx context.Context, product *model.Product) error {
+	existing, err := a.AdminProductStorer.GetProductByEAN(ctx, product.EAN)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("UpdateProduct.GetProductByEAN")
+		return model.ErrAdminError
+	}
+	if existing != nil && existing.ProductID != product.ProductID {
+		return model.ErrCatalogConflictError
+	}
+
+	return a.catalogError(a.AdminProductStorer.Update(ctx, product), "UpdateProduct.Update")
+}
+
+func (a *Admin) MergeProducts(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if sourceID == targetID {
+		return model.ErrCatalogConflictError
+	}
+
+	if err := a.catalogError(a.AdminProductStorer.Merge(ctx, sourceID, targetID), "MergeProducts.Merge"); err != nil {
+		return err
+	}
+	a.refreshPriceHistory(ctx, "MergeProducts.Refresh")
+	return nil
+}
+
+func (a *Admin) GetStats(ctx context.Context) (*model.Stats, error) {
+	stats, err := a.AdminStatsStorer.SelectStats(ctx)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetStats.SelectStats")
+		return nil, model.ErrAdminError
+	}
+	return stats, nil
+}
+
+// GetLoginAttempts returns the last login attempts of the login and of the ip, all of them when both are
+// empty.
+func (a *Admin) GetLoginAttempts(ctx context.Context, login, ip string, limit int) ([]*model.LoginAttempt, error) {
+	if limit <= 0 {
+		limit = defaultLoginAttemptsLimit
+	}
+	if limit > maxLoginAttemptsLimit {
+		limit = maxLoginAttemptsLimit
+	}
+
+	attempts, err := a.AdminLoginAttemptStorer.SelectLoginAttempts(ctx, login, ip, limit)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetLoginAttempts.SelectLoginAttempts")
+		return nil, model.ErrAdminError
+	}
+	return attempts, nil
+}
+
+func (a *Admin) userError(err error, msg string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrUserNotFound
+	}
+	if err != nil {
+		log.Error().Caller(1).Err(err).Msg(msg)
+		return model.ErrAdminError
+	}
+	return nil
+}
+
+func (a *Admin) catalogError(err error, msg string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrCatalogNotFoundError
+	}
+	if err != nil {
+		log.Error().Caller(1).Err(err).Msg(msg)
+		return model.ErrAdminError
+	}
+	return nil
+}
+
+// refreshPriceHistory rebuilds the price history on the merged entities, the merge is done at this point and
+// a stale price history must not fail the request.
+func (a *Admin) refreshPriceHistory(ctx context.Context, msg string) {
+	if err := a.AdminPriceHistoryStorer.Refresh(ctx); err != nil {
+		log.Error().Caller(1).Err(err).Msg(msg)
+	}
+}
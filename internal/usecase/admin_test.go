@@ -0,0 +1,188 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+)
+
+func TestAdmin_Users(t *testing.T) {
+	ctx := context.Background()
+	mockUserStorer := NewAdminUserStorer(t)
+	a := usecase.NewAdmin(mockUserStorer, nil, nil, nil, nil, nil, nil, nil)
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	expectedError := errors.New("random error")
+
+	t.Run("invalid role", func(t *testing.T) {
+		assert.ErrorIs(t, a.SetRole(ctx, adminID, userID, "root"), model.ErrInvalidRoleError)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		assert.ErrorIs(t, a.SetRole(ctx, adminID, adminID, model.RoleUser), model.ErrOwnAccountError)
+		assert.ErrorIs(t, a.DisableUser(ctx, adminID, adminID), model.ErrOwnAccountError)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserStorer.EXPECT().SetRole(ctx, userID, model.RoleModerator).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.SetRole(ctx, adminID, userID, model.RoleModerator), model.ErrUserNotFound)
+		mockUserStorer.EXPECT().Disable(ctx, userID).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.DisableUser(ctx, adminID, userID), model.ErrUserNotFound)
+		mockUserStorer.EXPECT().Enable(ctx, userID).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.EnableUser(ctx, userID), model.ErrUserNotFound)
+	})
+
+	t.Run("storer error", func(t *testing.T) {
+		mockUserStorer.EXPECT().SetRole(ctx, userID, model.RoleModerator).Return(expectedError).Once()
+		assert.ErrorIs(t, a.SetRole(ctx, adminID, userID, model.RoleModerator), model.ErrAdminError)
+		mockUserStorer.EXPECT().Disable(ctx, userID).Return(expectedError).Once()
+		assert.ErrorIs(t, a.DisableUser(ctx, adminID, userID), model.ErrAdminError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserStorer.EXPECT().SetRole(ctx, userID, model.RoleModerator).Return(nil).Once()
+		assert.NoError(t, a.SetRole(ctx, adminID, userID, model.RoleModerator))
+		mockUserStorer.EXPECT().Disable(ctx, userID).Return(nil).Once()
+		assert.NoError(t, a.DisableUser(ctx, adminID, userID))
+		mockUserStorer.EXPECT().Enable(ctx, userID).Return(nil).Once()
+		assert.NoError(t, a.EnableUser(ctx, userID))
+	})
+}
+
+func TestAdmin_Catalog(t *testing.T) {
+	ctx := context.Background()
+	mockBrandStorer := NewAdminBrandStorer(t)
+	mockCompanyStorer := NewAdminCompanyStorer(t)
+	mockStoreStorer := NewAdminStoreStorer(t)
+	mockProductStorer := NewAdminProductStorer(t)
+	mockPriceHistoryStorer := NewAdminPriceHistoryStorer(t)
+	a := usecase.NewAdmin(nil, mockBrandStorer, mockCompanyStorer, mockStoreStorer, mockProductStorer, nil, mockPriceHistoryStorer, nil)
+
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	expectedError := errors.New("random error")
+
+	t.Run("rename to the name of an other brand", func(t *testing.T) {
+		brand := &model.Brand{BrandID: sourceID, BrandName: "nutella"}
+		mockBrandStorer.EXPECT().SelectBrandByName(ctx, "nutella").Return(&model.Brand{BrandID: targetID}, nil).Once()
+		assert.ErrorIs(t, a.UpdateBrand(ctx, brand), model.ErrCatalogConflictError)
+	})
+
+	t.Run("UpdateBrand", func(t *testing.T) {
+		brand := &model.Brand{BrandID: sourceID, BrandName: "Nutella"}
+		mockBrandStorer.EXPECT().SelectBrandByName(ctx, "Nutella").Return(nil, nil).Once()
+		mockBrandStorer.EXPECT().Update(ctx, brand).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.UpdateBrand(ctx, brand), model.ErrCatalogNotFoundError)
+
+		mockBrandStorer.EXPECT().SelectBrandByName(ctx, "Nutella").Return(brand, nil).Once()
+		mockBrandStorer.EXPECT().Update(ctx, brand).Return(nil).Once()
+		assert.NoError(t, a.UpdateBrand(ctx, brand))
+	})
+
+	t.Run("merge into itself", func(t *testing.T) {
+		assert.ErrorIs(t, a.MergeBrands(ctx, sourceID, sourceID), model.ErrCatalogConflictError)
+		assert.ErrorIs(t, a.MergeStores(ctx, sourceID, sourceID), model.ErrCatalogConflictError)
+	})
+
+	t.Run("MergeBrands", func(t *testing.T) {
+		mockBrandStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, a.MergeBrands(ctx, sourceID, targetID), model.ErrCatalogNotFoundError)
+		mockBrandStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(nil).Once()
+		assert.NoError(t, a.MergeBrands(ctx, sourceID, targetID))
+	})
+
+	t.Run("UpdateCompany", func(t *testing.T) {
+		company := &model.Company{CompanyID: sourceID, CompanyName: "Carrefour"}
+		mockCompanyStorer.EXPECT().SelectCompanyByName(ctx, "Carrefour").Return(nil, expectedError).Once()
+		assert.ErrorIs(t, a.UpdateCompany(ctx, company), model.ErrAdminError)
+
+		mockCompanyStorer.EXPECT().SelectCompanyByName(ctx, "Carrefour").Return(nil, nil).Once()
+		mockCompanyStorer.EXPECT().Update(ctx, company).Return(nil).Once()
+		assert.NoError(t, a.UpdateCompany(ctx, company))
+	})
+
+	t.Run("MergeCompanies", func(t *testing.T) {
+		mockCompanyStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(expectedError).Once()
+		assert.ErrorIs(t, a.MergeCompanies(ctx, sourceID, targetID), model.ErrAdminError)
+
+		// a stale price history does not fail the merge
+		mockCompanyStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(expectedError).Once()
+		assert.NoError(t, a.MergeCompanies(ctx, sourceID, targetID))
+	})
+
+	t.Run("UpdateStore and MergeStores", func(t *testing.T) {
+		store := &model.Store{StoreID: sourceID, City: "Paris"}
+		mockStoreStorer.EXPECT().Update(ctx, store).Return(nil).Once()
+		assert.NoError(t, a.UpdateStore(ctx, store))
+
+		mockStoreStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(nil).Once()
+		assert.NoError(t, a.MergeStores(ctx, sourceID, targetID))
+	})
+
+	t.Run("UpdateProduct", func(t *testing.T) {
+		product := &model.Product{ProductID: sourceID, EAN: "3017620422003", ProductName: "Nutella"}
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, product.EAN).Return(&model.Product{ProductID: targetID}, nil).Once()
+		assert.ErrorIs(t, a.UpdateProduct(ctx, product), model.ErrCatalogConflictError)
+
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, product.EAN).Return(nil, nil).Once()
+		mockProductStorer.EXPECT().Update(ctx, product).Return(nil).Once()
+		assert.NoError(t, a.UpdateProduct(ctx, product))
+	})
+
+	t.Run("MergeProducts", func(t *testing.T) {
+		mockProductStorer.EXPECT().Merge(ctx, sourceID, targetID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(nil).Once()
+		assert.NoError(t, a.MergeProducts(ctx, sourceID, targetID))
+	})
+}
+
+func TestAdmin_GetStats(t *testing.T) {
+	ctx := context.Background()
+	mockStatsStorer := NewAdminStatsStorer(t)
+	a := usecase.NewAdmin(nil, nil, nil, nil, nil, mockStatsStorer, nil, nil)
+
+	t.Run("SelectStats error", func(t *testing.T) {
+		mockStatsStorer.EXPECT().SelectStats(ctx).Return(nil, errors.New("random error")).Once()
+		stats, err := a.GetStats(ctx)
+		assert.ErrorIs(t, err, model.ErrAdminError)
+		assert.Nil(t, stats)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := &model.Stats{Users: 3, Bills: 2}
+		mockStatsStorer.EXPECT().SelectStats(ctx).Return(expected, nil).Once()
+		stats, err := a.GetStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats)
+	})
+}
+
+func TestAdmin_GetLoginAttempts(t *testing.T) {
+	ctx := context.Background()
+	mockLoginAttemptStorer := NewAdminLoginAttemptStorer(t)
+	a := usecase.NewAdmin(nil, nil, nil, nil, nil, nil, nil, mockLoginAttemptStorer)
+
+	t.Run("SelectLoginAttempts error", func(t *testing.T) {
+		mockLoginAttemptStorer.EXPECT().SelectLoginAttempts(ctx, "login", "", 100).Return(nil, errors.New("random error")).Once()
+		attempts, err := a.GetLoginAttempts(ctx, "login", "", 0)
+		assert.ErrorIs(t, err, model.ErrAdminError)
+		assert.Nil(t, attempts)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		expected := []*model.LoginAttempt{{Login: "login", IP: "10.0.0.1"}}
+		mockLoginAttemptStorer.EXPECT().SelectLoginAttempts(ctx, "", "10.0.0.1", 1000).Return(expected, nil).Once()
+		attempts, err := a.GetLoginAttempts(ctx, "", "10.0.0.1", 5000)
+		require.NoError(t, err)
+		assert.Equal(t, expected, attempts)
+	})
+}
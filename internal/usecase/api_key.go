@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/utils"
+	"time"
+)
+
+const (
+	apiKeyLength = 48
+	// apiKeyShownLength is how much of a key is kept in clear to recognize it in a list.
+	apiKeyShownLength = len(model.APIKeyPrefix) + 8
+)
+
+type APIKeyStorer interface {
+	Insert(ctx context.Context, apiKey *model.APIKey) error
+	SelectAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, userID, apiKeyID uuid.UUID) error
+}
+
+type APIKey struct {
+	APIKeyStorer APIKeyStorer
+}
+
+func NewAPIKey(aks APIKeyStorer) *APIKey {
+	return &APIKey{
+		APIKeyStorer: aks,
+	}
+}
+
+// Create returns the new key of the user, the only time the key itself is known.
+func (ak *APIKey) Create(ctx context.Context, userID uuid.UUID, name string, scopes []string, expiresAt *time.Time) (*model.APIKey, error) {
+	if len(scopes) == 0 {
+		return nil, model.ErrInvalidScopeError
+	}
+	for _, scope := range scopes {
+		if !model.IsValidScope(scope) {
+			return nil, model.ErrInvalidScopeError
+		}
+	}
+	if expiresAt != nil {
+		utc := expiresAt.UTC()
+		if !utc.After(time.Now().UTC()) {
+			return nil, model.ErrInvalidExpiryError
+		}
+		expiresAt = &utc
+	}
+
+	token, err := utils.GenerateToken(apiKeyLength)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Create.GenerateToken")
+		return nil, model.ErrAPIKeyError
+	}
+	key := model.APIKeyPrefix + token
+
+	apiKey := &model.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Key:       key,
+		Prefix:    key[:apiKeyShownLength],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err = ak.APIKeyStorer.Insert(ctx, apiKey); err != nil {
+		log.Error().Caller().Err(err).Msg("Create.Insert")
+		return nil, model.ErrAPIKeyError
+	}
+	return apiKey, nil
+}
+
+func (ak *APIKey) GetAPIKeys(ctx context.Context, userID uuid.UUID) ([]*model.APIKey, error) {
+	apiKeys, err := ak.APIKeyStorer.SelectAPIKeysByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetAPIKeys.SelectAPIKeysByUserID")
+		return nil, model.ErrAPIKeyError
+	}
+	return apiKeys, nil
+}
+
+func (ak *APIKey) RevokeAPIKey(ctx context.Context, userID, apiKeyID uuid.UUID) error {
+	err := ak.APIKeyStorer.Revoke(ctx, userID, apiKeyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrAPIKeyNotFoundError
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("RevokeAPIKey.Revoke")
+		return model.ErrAPIKeyError
+	}
+	return nil
+}
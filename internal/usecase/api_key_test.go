@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_Create(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewAPIKeyStorer(t)
+	ak := usecase.NewAPIKey(mockStorer)
+	userID := uuid.New()
+
+	t.Run("no scope", func(t *testing.T) {
+		_, err := ak.Create(ctx, userID, "backup", nil, nil)
+		assert.ErrorIs(t, err, model.ErrInvalidScopeError)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := ak.Create(ctx, userID, "backup", []string{model.ScopeBillsRead, "admin"}, nil)
+		assert.ErrorIs(t, err, model.ErrInvalidScopeError)
+	})
+
+	t.Run("past expiry", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		_, err := ak.Create(ctx, userID, "backup", []string{model.ScopeBillsRead}, &past)
+		assert.ErrorIs(t, err, model.ErrInvalidExpiryError)
+	})
+
+	t.Run("Insert error", func(t *testing.T) {
+		mockStorer.EXPECT().Insert(ctx, mock.Anything).Return(errors.New("random error")).Once()
+		_, err := ak.Create(ctx, userID, "backup", []string{model.ScopeBillsRead}, nil)
+		assert.ErrorIs(t, err, model.ErrAPIKeyError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		mockStorer.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+
+		apiKey, err := ak.Create(ctx, userID, "backup", []string{model.ScopeCatalogWrite}, &expiresAt)
+		require.NoError(t, err)
+		assert.Equal(t, userID, apiKey.UserID)
+		assert.True(t, model.IsAPIKey(apiKey.Key))
+		assert.True(t, strings.HasPrefix(apiKey.Key, apiKey.Prefix))
+		assert.Less(t, len(apiKey.Prefix), len(apiKey.Key))
+		assert.Equal(t, []string{model.ScopeCatalogWrite}, apiKey.Scopes)
+		assert.Equal(t, expiresAt.UTC(), *apiKey.ExpiresAt)
+	})
+}
+
+func TestAPIKey_RevokeAPIKey(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewAPIKeyStorer(t)
+	ak := usecase.NewAPIKey(mockStorer)
+	userID, apiKeyID := uuid.New(), uuid.New()
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockStorer.EXPECT().Revoke(ctx, userID, apiKeyID).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, ak.RevokeAPIKey(ctx, userID, apiKeyID), model.ErrAPIKeyNotFoundError)
+	})
+
+	t.Run("Revoke error", func(t *testing.T) {
+		mockStorer.EXPECT().Revoke(ctx, userID, apiKeyID).Return(errors.New("random error")).Once()
+		assert.ErrorIs(t, ak.RevokeAPIKey(ctx, userID, apiKeyID), model.ErrAPIKeyError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockStorer.EXPECT().Revoke(ctx, userID, apiKeyID).Return(nil).Once()
+		assert.NoError(t, ak.RevokeAPIKey(ctx, userID, apiKeyID))
+	})
+}
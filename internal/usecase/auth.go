@@ -2,68 +2,508 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/config"
 	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
 	"shop-aggregator/internal/utils"
+	"time"
+)
+
+const (
+	defaultAccessTokenTTL     = 15 * time.Minute
+	defaultRefreshTokenTTL    = 30 * 24 * time.Hour
+	defaultMaxLoginFailures   = 5
+	defaultMaxIPLoginFailures = 20
+	defaultLoginBackoff       = time.Second
+	defaultLockout            = 15 * time.Minute
+	// loginFailureWindow is how long the failures of a login or an ip are remembered.
+	loginFailureWindow = 24 * time.Hour
+	// loginChallengeTTL is how long the second factor of a login is waited for.
+	loginChallengeTTL = 5 * time.Minute
+	// maxChallengeFailures is how many wrong codes a login challenge takes, the password is needed again after.
+	maxChallengeFailures = 5
 )
 
 type AuthStorer interface {
-	Upsert(context.Context, uuid.UUID, string) error
-	Logout(context.Context, uuid.UUID) error
+	Insert(ctx context.Context, session *model.Session) error
+	SelectSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOthers(ctx context.Context, userID, sessionID uuid.UUID) error
+	SelectRefreshToken(ctx context.Context, token string) (*model.RefreshToken, error)
+	Rotate(ctx context.Context, refreshTokenID uuid.UUID, session *model.Session) error
 }
 
 type AuthUserStorer interface {
 	GetUserByLogin(context.Context, string) (*model.User, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	UpdatePassword(context.Context, uuid.UUID, string) error
 }
 
+type AuthLoginAttemptStorer interface {
+	Insert(ctx context.Context, attempt *model.LoginAttempt) error
+	SelectLockedUntil(ctx context.Context, login, ip string) (*time.Time, error)
+	RecordFailure(ctx context.Context, scope, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, scope, key string, until time.Time) error
+	Reset(ctx context.Context, scope, key string) error
+}
+
+type AuthTwoFactorStorer interface {
+	SelectTOTP(ctx context.Context, userID uuid.UUID) (*model.TOTP, error)
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error
+	InsertChallenge(ctx context.Context, challenge *model.LoginChallenge) error
+	SelectChallenge(ctx context.Context, challenge string) (*model.LoginChallenge, error)
+	FailChallenge(ctx context.Context, challengeID uuid.UUID, maxFailures int) error
+	UseChallenge(ctx context.Context, challengeID uuid.UUID) error
+}
+
+// Auth issues short-lived access tokens, the refresh token of a session gets new tokens until
+// RefreshTokenTTL passes without a refresh.
+//
+// A login or an ip is locked after MaxLoginFailures or MaxIPLoginFailures failed attempts, for LoginBackoff,
+// then twice as long after each new failure, up to Lockout. A successful login resets the failures of the
+// login only, an attacker can not reset the ip with an account of their own.
+//
+// The password of a user with 2FA only gets a login challenge, the session is opened once a code of the app
+// or a recovery code answers it. The wrong codes count as failed attempts too.
+//
+// A password hash of an outdated algorithm or cost is hashed again with PasswordHasher once the password is
+// known to be right.
 type Auth struct {
-	AuthStorer     AuthStorer
-	AuthUserStorer AuthUserStorer
+	AuthStorer             AuthStorer
+	AuthUserStorer         AuthUserStorer
+	AuthLoginAttemptStorer AuthLoginAttemptStorer
+	AuthTwoFactorStorer    AuthTwoFactorStorer
+	PasswordHasher         utils.PasswordHasher
+	AccessTokenTTL         time.Duration
+	RefreshTokenTTL        time.Duration
+	MaxLoginFailures       int
+	MaxIPLoginFailures     int
+	LoginBackoff           time.Duration
+	Lockout                time.Duration
+	// dummyPasswordHash is checked for the unknown logins, they answer as slowly as a wrong password.
+	dummyPasswordHash string
 }
 
-func NewAuth(a AuthStorer, au AuthUserStorer) *Auth {
-	return &Auth{
-		AuthStorer:     a,
-		AuthUserStorer: au,
+func NewAuth(a AuthStorer, au AuthUserStorer, ala AuthLoginAttemptStorer, atf AuthTwoFactorStorer, ph utils.PasswordHasher, cfg *config.AuthConfig) *Auth {
+	auth := &Auth{
+		AuthStorer:             a,
+		AuthUserStorer:         au,
+		AuthLoginAttemptStorer: ala,
+		AuthTwoFactorStorer:    atf,
+		PasswordHasher:         ph,
+		AccessTokenTTL:         time.Duration(cfg.AccessTokenTTLMinutes) * time.Minute,
+		RefreshTokenTTL:        time.Duration(cfg.RefreshTokenTTLHours) * time.Hour,
+		MaxLoginFailures:       cfg.MaxLoginFailures,
+		MaxIPLoginFailures:     cfg.MaxIPLoginFailures,
+		LoginBackoff:           time.Duration(cfg.LoginBackoffSeconds) * time.Second,
+		Lockout:                time.Duration(cfg.LockoutMinutes) * time.Minute,
+	}
+	if auth.AccessTokenTTL <= 0 {
+		auth.AccessTokenTTL = defaultAccessTokenTTL
+	}
+	if auth.RefreshTokenTTL <= 0 {
+		auth.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
+	if auth.MaxLoginFailures <= 0 {
+		auth.MaxLoginFailures = defaultMaxLoginFailures
 	}
+	if auth.MaxIPLoginFailures <= 0 {
+		auth.MaxIPLoginFailures = defaultMaxIPLoginFailures
+	}
+	if auth.LoginBackoff <= 0 {
+		auth.LoginBackoff = defaultLoginBackoff
+	}
+	if auth.Lockout <= 0 {
+		auth.Lockout = defaultLockout
+	}
+	if ph != nil {
+		// a failure only makes the unknown logins answer faster
+		auth.dummyPasswordHash, _ = ph.Hash(uuid.NewString())
+	}
+
+	return auth
 }
 
-func (a *Auth) Login(ctx context.Context, login, password string) (string, error) {
+// Login opens a new session for the device described by session and returns its tokens, the sessions
+// of the other devices stay open. A user with 2FA gets a login challenge instead, LoginTwoFactor opens the
+// session. An unknown login and a wrong password both return ErrInvalidCredentialsError, the logins can not
+// be enumerated.
+func (a *Auth) Login(ctx context.Context, login, password string, session *model.Session) (*response.Login, *model.LoginChallenge, error) {
+	attempt := &model.LoginAttempt{Login: login, IP: session.IP}
+
+	lockedUntil, err := a.AuthLoginAttemptStorer.SelectLockedUntil(ctx, login, session.IP)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Login.SelectLockedUntil")
+		return nil, nil, model.ErrUserError
+	}
+	if lockedUntil != nil {
+		attempt.Reason = model.LoginAttemptLocked
+		a.recordAttempt(ctx, attempt)
+		return nil, nil, model.ErrTooManyAttemptsError
+	}
+
 	user, err := a.AuthUserStorer.GetUserByLogin(ctx, login)
 	if err != nil {
-		log.Error().Caller().Err(err)
-		return "", model.ErrUserError
+		log.Error().Caller().Err(err).Msg("Login.GetUserByLogin")
+		return nil, nil, model.ErrUserError
 	}
 
 	if user == nil {
-		return "", model.ErrUserNotFound
+		a.PasswordHasher.Verify(password, a.dummyPasswordHash)
+		return nil, nil, a.loginFailed(ctx, attempt)
 	}
+	attempt.UserID = &user.ID
 
-	if !utils.CheckPasswordHash(password, user.HashPassword) {
-		return "", model.ErrPasswordError
+	ok, rehash := a.PasswordHasher.Verify(password, user.HashPassword)
+	if !ok {
+		return nil, nil, a.loginFailed(ctx, attempt)
+	}
+	if rehash {
+		a.rehashPassword(ctx, user.ID, password)
 	}
 
-	token, err := utils.GenerateToken(128)
+	if user.Disabled {
+		attempt.Reason = model.LoginAttemptDisabled
+		a.recordAttempt(ctx, attempt)
+		return nil, nil, model.ErrUserDisabledError
+	}
+
+	challenge, err := a.TwoFactorChallenge(ctx, user, session)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		// the failures of the login are only reset once the second factor is right
+		attempt.Reason = model.LoginAttemptTwoFactorRequired
+		a.recordAttempt(ctx, attempt)
+		return nil, challenge, nil
+	}
+
+	tokens, err := a.completeLogin(ctx, user, session, attempt)
+	return tokens, nil, err
+}
+
+// LoginTwoFactor answers the challenge of Login with a code of the app or a recovery code and opens the
+// session. A challenge is used once and dies after maxChallengeFailures wrong codes.
+func (a *Auth) LoginTwoFactor(ctx context.Context, challenge, code string, session *model.Session) (*response.Login, error) {
+	c, err := a.AuthTwoFactorStorer.SelectChallenge(ctx, challenge)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.SelectChallenge")
+		return nil, model.ErrUserError
+	}
+	if c == nil {
+		return nil, model.ErrInvalidLoginChallengeError
+	}
+	attempt := &model.LoginAttempt{Login: c.Login, IP: session.IP, UserID: &c.UserID}
+	if session.Device == "" {
+		session.Device = c.Device
+	}
+
+	lockedUntil, err := a.AuthLoginAttemptStorer.SelectLockedUntil(ctx, c.Login, session.IP)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.SelectLockedUntil")
+		return nil, model.ErrUserError
+	}
+	if lockedUntil != nil {
+		attempt.Reason = model.LoginAttemptLocked
+		a.recordAttempt(ctx, attempt)
+		return nil, model.ErrTooManyAttemptsError
+	}
+
+	t, err := a.AuthTwoFactorStorer.SelectTOTP(ctx, c.UserID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.SelectTOTP")
+		return nil, model.ErrUserError
+	}
+	if t == nil || !t.IsEnabled() {
+		// the 2FA was turned off since the password was checked
+		return nil, model.ErrInvalidLoginChallengeError
+	}
+
+	ok, err := checkTwoFactorCode(ctx, a.AuthTwoFactorStorer, t, code)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.checkTwoFactorCode")
+		return nil, model.ErrUserError
+	}
+	if !ok {
+		if err = a.AuthTwoFactorStorer.FailChallenge(ctx, c.LoginChallengeID, maxChallengeFailures); err != nil {
+			log.Error().Caller().Err(err).Msg("LoginTwoFactor.FailChallenge")
+		}
+		attempt.Reason = model.LoginAttemptInvalidTwoFactorCode
+		a.failAttempt(ctx, attempt)
+		return nil, model.ErrInvalidTwoFactorCodeError
+	}
+
+	err = a.AuthTwoFactorStorer.UseChallenge(ctx, c.LoginChallengeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrInvalidLoginChallengeError
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.UseChallenge")
+		return nil, model.ErrUserError
+	}
+
+	user, err := a.AuthUserStorer.GetUserByID(ctx, c.UserID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("LoginTwoFactor.GetUserByID")
+		return nil, model.ErrUserError
+	}
+	if user == nil {
+		return nil, model.ErrInvalidLoginChallengeError
+	}
+	if user.Disabled {
+		attempt.Reason = model.LoginAttemptDisabled
+		a.recordAttempt(ctx, attempt)
+		return nil, model.ErrUserDisabledError
+	}
+
+	return a.completeLogin(ctx, user, session, attempt)
+}
+
+// TwoFactorChallenge returns the login challenge of the user who enabled two-factor, nil for the others. A
+// login proving only the first factor, with a password or an identity provider, goes through it.
+func (a *Auth) TwoFactorChallenge(ctx context.Context, user *model.User, session *model.Session) (*model.LoginChallenge, error) {
+	t, err := a.AuthTwoFactorStorer.SelectTOTP(ctx, user.ID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("TwoFactorChallenge.SelectTOTP")
+		return nil, model.ErrUserError
+	}
+	if t == nil || !t.IsEnabled() {
+		return nil, nil
+	}
+	return a.challenge(ctx, user, session)
+}
+
+// challenge issues the login challenge of the user, the device of the session is kept for the session opened
+// by LoginTwoFactor.
+func (a *Auth) challenge(ctx context.Context, user *model.User, session *model.Session) (*model.LoginChallenge, error) {
+	token, err := utils.GenerateToken(64)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("challenge.GenerateToken")
+		return nil, model.ErrUserError
+	}
+
+	challenge := &model.LoginChallenge{
+		UserID:    user.ID,
+		Login:     user.Login,
+		Challenge: token,
+		Device:    session.Device,
+		ExpiresAt: time.Now().UTC().Add(loginChallengeTTL),
+	}
+	if err = a.AuthTwoFactorStorer.InsertChallenge(ctx, challenge); err != nil {
+		log.Error().Caller().Err(err).Msg("challenge.InsertChallenge")
+		return nil, model.ErrUserError
+	}
+	return challenge, nil
+}
+
+// completeLogin resets the failures of the login and opens the session of the authenticated user.
+func (a *Auth) completeLogin(ctx context.Context, user *model.User, session *model.Session, attempt *model.LoginAttempt) (*response.Login, error) {
+	if err := a.AuthLoginAttemptStorer.Reset(ctx, model.LoginThrottleLogin, attempt.Login); err != nil {
+		log.Error().Caller().Err(err).Msg("completeLogin.Reset")
+	}
+
+	tokens, err := a.OpenSession(ctx, user, session)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt.Success = true
+	attempt.Reason = ""
+	a.recordAttempt(ctx, attempt)
+	return tokens, nil
+}
+
+// OpenSession opens a new session of the authenticated user for the device described by session and returns
+// its tokens.
+func (a *Auth) OpenSession(ctx context.Context, user *model.User, session *model.Session) (*response.Login, error) {
+	if err := a.issueTokens(session); err != nil {
+		log.Error().Caller().Err(err).Msg("OpenSession.issueTokens")
+		return nil, model.ErrUserError
+	}
+
+	session.UserID = user.ID
+	if err := a.AuthStorer.Insert(ctx, session); err != nil {
+		log.Error().Caller().Err(err).Msg("OpenSession.Insert")
+		return nil, model.ErrUserError
+	}
+
+	return response.NewLoginFromModel(session), nil
+}
+
+func (a *Auth) loginFailed(ctx context.Context, attempt *model.LoginAttempt) error {
+	attempt.Reason = model.LoginAttemptInvalidCredentials
+	a.failAttempt(ctx, attempt)
+	return model.ErrInvalidCredentialsError
+}
+
+// failAttempt counts the failure for the login and for the ip and locks the ones above their free failures.
+func (a *Auth) failAttempt(ctx context.Context, attempt *model.LoginAttempt) {
+	a.recordAttempt(ctx, attempt)
+
+	a.throttle(ctx, model.LoginThrottleLogin, attempt.Login, a.MaxLoginFailures)
+	if attempt.IP != "" {
+		a.throttle(ctx, model.LoginThrottleIP, attempt.IP, a.MaxIPLoginFailures)
+	}
+}
+
+func (a *Auth) throttle(ctx context.Context, scope, key string, maxFailures int) {
+	failures, err := a.AuthLoginAttemptStorer.RecordFailure(ctx, scope, key, loginFailureWindow)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("throttle.RecordFailure")
+		return
+	}
+	if failures < maxFailures {
+		return
+	}
+
+	lockout := a.lockout(failures - maxFailures)
+	log.Warn().Str("scope", scope).Str("key", key).Int("failures", failures).Dur("lockout", lockout).Msg("login locked")
+	if err = a.AuthLoginAttemptStorer.Lock(ctx, scope, key, time.Now().UTC().Add(lockout)); err != nil {
+		log.Error().Caller().Err(err).Msg("throttle.Lock")
+	}
+}
+
+// lockout returns LoginBackoff doubled for each failure above the free ones, up to Lockout.
+func (a *Auth) lockout(extraFailures int) time.Duration {
+	lockout := a.LoginBackoff
+	for i := 0; i < extraFailures && lockout < a.Lockout; i++ {
+		lockout *= 2
+	}
+	if lockout > a.Lockout {
+		return a.Lockout
+	}
+	return lockout
+}
+
+// recordAttempt keeps the attempt for the admins, a failure is only logged.
+func (a *Auth) recordAttempt(ctx context.Context, attempt *model.LoginAttempt) {
+	if err := a.AuthLoginAttemptStorer.Insert(ctx, attempt); err != nil {
+		log.Error().Caller().Err(err).Msg("recordAttempt.Insert")
+	}
+}
+
+// Refresh exchanges a refresh token for new tokens, the refresh token can be used once. A used refresh
+// token presented again was stolen or replayed, the whole session is revoked.
+func (a *Auth) Refresh(ctx context.Context, token string) (*response.Login, error) {
+	refreshToken, err := a.AuthStorer.SelectRefreshToken(ctx, token)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Refresh.SelectRefreshToken")
+		return nil, model.ErrSessionError
+	}
+	if refreshToken == nil || refreshToken.SessionRevoked {
+		return nil, model.ErrInvalidRefreshTokenError
+	}
+	if refreshToken.UsedAt != nil {
+		return nil, a.revokeFamily(ctx, refreshToken)
+	}
+	if !time.Now().UTC().Before(refreshToken.ExpiresAt) {
+		return nil, model.ErrTokenExpiredError
+	}
+
+	session := &model.Session{}
+	if err = a.issueTokens(session); err != nil {
+		log.Error().Caller().Err(err).Msg("Refresh.issueTokens")
+		return nil, model.ErrSessionError
+	}
+
+	err = a.AuthStorer.Rotate(ctx, refreshToken.RefreshTokenID, session)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// an other request used the refresh token first
+		return nil, a.revokeFamily(ctx, refreshToken)
+	}
 	if err != nil {
-		log.Error().Caller().Err(err)
-		return "", model.ErrUserError
+		log.Error().Caller().Err(err).Msg("Refresh.Rotate")
+		return nil, model.ErrSessionError
+	}
+
+	return response.NewLoginFromModel(session), nil
+}
+
+func (a *Auth) revokeFamily(ctx context.Context, refreshToken *model.RefreshToken) error {
+	log.Warn().Str("session_id", refreshToken.SessionID.String()).Msg("refresh token reused, session revoked")
+	err := a.AuthStorer.Revoke(ctx, refreshToken.UserID, refreshToken.SessionID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		log.Error().Caller().Err(err).Msg("revokeFamily.Revoke")
+		return model.ErrSessionError
 	}
+	return model.ErrRefreshTokenReusedError
+}
 
-	err = a.AuthStorer.Upsert(ctx, user.ID, token)
+// issueTokens sets new access and refresh tokens on the session.
+func (a *Auth) issueTokens(session *model.Session) error {
+	token, err := utils.GenerateToken(128)
 	if err != nil {
-		log.Error().Caller().Err(err)
-		return "", model.ErrUserError
+		return err
+	}
+	refreshToken, err := utils.GenerateToken(128)
+	if err != nil {
+		return err
 	}
 
-	return token, nil
+	now := time.Now().UTC()
+	session.Token = token
+	session.ExpiresAt = now.Add(a.AccessTokenTTL)
+	session.RefreshToken = refreshToken
+	session.RefreshExpiresAt = now.Add(a.RefreshTokenTTL)
+	return nil
 }
 
-func (a *Auth) Logout(ctx context.Context, id uuid.UUID) error {
-	err := a.AuthStorer.Logout(ctx, id)
+// Logout revokes the current session only.
+func (a *Auth) Logout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	err := a.AuthStorer.Revoke(ctx, userID, sessionID)
 	if err != nil {
-		log.Error().Caller().Err(err)
+		log.Error().Caller().Err(err).Msg("Logout.Revoke")
 		return model.ErrUserNotFound
 	}
 	return nil
 }
+
+// GetSessions returns the active sessions of the user, the most recently seen first.
+func (a *Auth) GetSessions(ctx context.Context, userID uuid.UUID) ([]*model.Session, error) {
+	sessions, err := a.AuthStorer.SelectSessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetSessions.SelectSessionsByUserID")
+		return nil, model.ErrSessionError
+	}
+	return sessions, nil
+}
+
+func (a *Auth) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	err := a.AuthStorer.Revoke(ctx, userID, sessionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrSessionNotFoundError
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("RevokeSession.Revoke")
+		return model.ErrSessionError
+	}
+	return nil
+}
+
+// RevokeOtherSessions logs the user out of every device but the current one.
+func (a *Auth) RevokeOtherSessions(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := a.AuthStorer.RevokeOthers(ctx, userID, sessionID); err != nil {
+		log.Error().Caller().Err(err).Msg("RevokeOtherSessions.RevokeOthers")
+		return model.ErrSessionError
+	}
+	return nil
+}
+
+// rehashPassword upgrades the hash of the password, the login goes on whatever happens, the hash is upgraded
+// on a next login otherwise.
+func (a *Auth) rehashPassword(ctx context.Context, userID uuid.UUID, password string) {
+	hash, err := a.PasswordHasher.Hash(password)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("rehashPassword.Hash")
+		return
+	}
+	if err = a.AuthUserStorer.UpdatePassword(ctx, userID, hash); err != nil {
+		log.Error().Caller().Err(err).Msg("rehashPassword.UpdatePassword")
+	}
+}
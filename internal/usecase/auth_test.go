@@ -4,82 +4,442 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
 	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/totp"
 	"shop-aggregator/internal/usecase"
 	"shop-aggregator/internal/utils"
 	"testing"
+	"time"
 )
 
 func TestAuth_Login(t *testing.T) {
 	ctx := context.Background()
 	mockUserStore := NewAuthUserStorer(t)
 	mockAuthStore := NewAuthStorer(t)
+	mockLoginAttemptStore := NewAuthLoginAttemptStorer(t)
+	mockTwoFactorStore := NewAuthTwoFactorStorer(t)
 
-	au := usecase.NewAuth(mockAuthStore, mockUserStore)
+	au := usecase.NewAuth(mockAuthStore, mockUserStore, mockLoginAttemptStore, mockTwoFactorStore, passwordHasher, &config.AuthConfig{})
 	expectedLogin := "login"
 	expectedPassword := "password"
-	expectedHashPassword, errHash := utils.HashPassword(expectedPassword)
+	expectedIP := "10.0.0.1"
+	expectedHashPassword, errHash := passwordHasher.Hash(expectedPassword)
 	require.NoError(t, errHash)
 	expectedUser := model.User{
+		ID:           uuid.New(),
 		HashPassword: expectedHashPassword,
 	}
 
 	expectedError := errors.New("random error")
 
+	attemptWith := func(success bool, reason string) interface{} {
+		return mock.MatchedBy(func(a *model.LoginAttempt) bool {
+			return a.Login == expectedLogin && a.IP == expectedIP && a.Success == success && a.Reason == reason
+		})
+	}
+	expectFailure := func(loginFailures, ipFailures int) {
+		mockLoginAttemptStore.EXPECT().Insert(ctx, attemptWith(false, model.LoginAttemptInvalidCredentials)).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().RecordFailure(ctx, model.LoginThrottleLogin, expectedLogin, mock.Anything).Return(loginFailures, nil).Once()
+		mockLoginAttemptStore.EXPECT().RecordFailure(ctx, model.LoginThrottleIP, expectedIP, mock.Anything).Return(ipFailures, nil).Once()
+	}
+
+	t.Run("SelectLockedUntil error", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, expectedError).Once()
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrUserError)
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		lockedUntil := time.Now().Add(time.Minute)
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(&lockedUntil, nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, attemptWith(false, model.LoginAttemptLocked)).Return(nil).Once()
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrTooManyAttemptsError)
+	})
+
 	t.Run("GetUserByLogin error", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
 		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(nil, expectedError).Once()
-		_, err := au.Login(ctx, expectedLogin, expectedPassword)
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
 		require.ErrorIs(t, model.ErrUserError, err)
 	})
 
 	t.Run("GetUserByLogin, user not found", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
 		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(nil, nil).Once()
-		_, err := au.Login(ctx, expectedLogin, expectedPassword)
-		require.ErrorIs(t, model.ErrUserNotFound, err)
+		expectFailure(1, 1)
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidCredentialsError)
 	})
 
 	t.Run("not same old password", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
 		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
-		_, err := au.Login(ctx, expectedLogin, "")
-		require.ErrorIs(t, model.ErrPasswordError, err)
+		expectFailure(2, 2)
+		_, _, err := au.Login(ctx, expectedLogin, "", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidCredentialsError)
+	})
+
+	t.Run("too many failures lock the login", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
+		expectFailure(7, 7)
+		mockLoginAttemptStore.EXPECT().Lock(ctx, model.LoginThrottleLogin, expectedLogin, mock.Anything).Run(func(_ context.Context, _ string, _ string, until time.Time) {
+			// 5 free failures, then 1s doubled twice
+			require.WithinDuration(t, time.Now().Add(4*time.Second), until, time.Second)
+		}).Return(nil).Once()
+		_, _, err := au.Login(ctx, expectedLogin, "", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidCredentialsError)
+	})
+
+	t.Run("the lockout is capped", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
+		expectFailure(2, 60)
+		mockLoginAttemptStore.EXPECT().Lock(ctx, model.LoginThrottleIP, expectedIP, mock.Anything).Run(func(_ context.Context, _ string, _ string, until time.Time) {
+			require.WithinDuration(t, time.Now().Add(15*time.Minute), until, time.Second)
+		}).Return(nil).Once()
+		_, _, err := au.Login(ctx, expectedLogin, "", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidCredentialsError)
+	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		disabledUser := expectedUser
+		disabledUser.Disabled = true
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&disabledUser, nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, attemptWith(false, model.LoginAttemptDisabled)).Return(nil).Once()
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrUserDisabledError)
+	})
+
+	t.Run("SelectTOTP error", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, expectedUser.ID).Return(nil, expectedError).Once()
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrUserError)
+	})
+
+	t.Run("2fa returns a challenge", func(t *testing.T) {
+		enabledAt := time.Now()
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, expectedUser.ID).Return(&model.TOTP{UserID: expectedUser.ID, EnabledAt: &enabledAt}, nil).Once()
+		mockTwoFactorStore.EXPECT().InsertChallenge(ctx, mock.Anything).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, attemptWith(false, model.LoginAttemptTwoFactorRequired)).Return(nil).Once()
+
+		tokens, challenge, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{Device: "phone", IP: expectedIP})
+		require.NoError(t, err)
+		require.Nil(t, tokens)
+		require.NotNil(t, challenge)
+		require.NotEmpty(t, challenge.Challenge)
+		require.Equal(t, expectedUser.ID, challenge.UserID)
+		require.Equal(t, "phone", challenge.Device)
+		require.WithinDuration(t, time.Now().Add(5*time.Minute), challenge.ExpiresAt, time.Minute)
 	})
 
-	t.Run("Upsert error", func(t *testing.T) {
+	t.Run("outdated hash is upgraded", func(t *testing.T) {
+		bcryptHash, err := utils.NewBcryptHasher(4).Hash(expectedPassword)
+		require.NoError(t, err)
+		bcryptUser := expectedUser
+		bcryptUser.HashPassword = bcryptHash
+		enabledAt := time.Now()
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
+		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&bcryptUser, nil).Once()
+		mockUserStore.EXPECT().UpdatePassword(ctx, expectedUser.ID, mock.Anything).Run(func(_ context.Context, _ uuid.UUID, hash string) {
+			ok, rehash := passwordHasher.Verify(expectedPassword, hash)
+			require.True(t, ok)
+			require.False(t, rehash)
+		}).Return(expectedError).Once()
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, expectedUser.ID).Return(&model.TOTP{UserID: expectedUser.ID, EnabledAt: &enabledAt}, nil).Once()
+		mockTwoFactorStore.EXPECT().InsertChallenge(ctx, mock.Anything).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, attemptWith(false, model.LoginAttemptTwoFactorRequired)).Return(nil).Once()
+
+		// a failed upgrade does not fail the login
+		_, challenge, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{Device: "phone", IP: expectedIP})
+		require.NoError(t, err)
+		require.NotNil(t, challenge)
+	})
+
+	t.Run("Insert error", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
 		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
-		mockAuthStore.EXPECT().Upsert(ctx, expectedUser.ID, mock.Anything).Run(func(_a0 context.Context, _a1 uuid.UUID, _a2 string) {
-			require.NotEmpty(t, _a2)
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, expectedUser.ID).Return(nil, nil).Once()
+		mockLoginAttemptStore.EXPECT().Reset(ctx, model.LoginThrottleLogin, expectedLogin).Return(nil).Once()
+		mockAuthStore.EXPECT().Insert(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *model.Session) {
+			require.NotEmpty(t, _a1.Token)
 		}).Return(expectedError).Once()
-		_, err := au.Login(ctx, expectedLogin, expectedPassword)
+		_, _, err := au.Login(ctx, expectedLogin, expectedPassword, &model.Session{IP: expectedIP})
 		require.ErrorIs(t, model.ErrUserError, err)
 	})
 
 	t.Run("no error", func(t *testing.T) {
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, expectedLogin, expectedIP).Return(nil, nil).Once()
 		mockUserStore.EXPECT().GetUserByLogin(ctx, expectedLogin).Return(&expectedUser, nil).Once()
-		mockAuthStore.EXPECT().Upsert(ctx, expectedUser.ID, mock.Anything).Run(func(_a0 context.Context, _a1 uuid.UUID, _a2 string) {
-			require.NotEmpty(t, _a2)
-		}).Return(nil).Once()
-		token, err := au.Login(ctx, expectedLogin, expectedPassword)
+		// an enrolment that is not confirmed does not ask for a code
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, expectedUser.ID).Return(&model.TOTP{UserID: expectedUser.ID}, nil).Once()
+		mockLoginAttemptStore.EXPECT().Reset(ctx, model.LoginThrottleLogin, expectedLogin).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, mock.MatchedBy(func(a *model.LoginAttempt) bool {
+			return a.Success && a.UserID != nil && *a.UserID == expectedUser.ID
+		})).Return(nil).Once()
+		session := &model.Session{Device: "phone", IP: expectedIP, UserAgent: "Mozilla/5.0"}
+		mockAuthStore.EXPECT().Insert(ctx, session).Return(nil).Once()
+		tokens, challenge, err := au.Login(ctx, expectedLogin, expectedPassword, session)
+		require.NoError(t, err)
+		require.Nil(t, challenge)
+		require.NotEmpty(t, tokens.Token)
+		require.NotEmpty(t, tokens.RefreshToken)
+		require.NotEqual(t, tokens.Token, tokens.RefreshToken)
+		require.Equal(t, tokens.Token, session.Token)
+		require.Equal(t, expectedUser.ID, session.UserID)
+		require.Equal(t, "phone", session.Device)
+		require.WithinDuration(t, time.Now().Add(15*time.Minute), tokens.ExpiresAt, time.Minute)
+		require.WithinDuration(t, time.Now().Add(30*24*time.Hour), tokens.RefreshExpiresAt, time.Minute)
+	})
+}
+
+func TestAuth_LoginTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	mockUserStore := NewAuthUserStorer(t)
+	mockAuthStore := NewAuthStorer(t)
+	mockLoginAttemptStore := NewAuthLoginAttemptStorer(t)
+	mockTwoFactorStore := NewAuthTwoFactorStorer(t)
+	au := usecase.NewAuth(mockAuthStore, mockUserStore, mockLoginAttemptStore, mockTwoFactorStore, passwordHasher, &config.AuthConfig{})
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	enabledAt := time.Now()
+	user := &model.User{ID: uuid.New(), Login: "login"}
+	challenge := &model.LoginChallenge{LoginChallengeID: uuid.New(), UserID: user.ID, Login: "login", Device: "phone"}
+	enabled := &model.TOTP{UserID: user.ID, Secret: secret, EnabledAt: &enabledAt}
+	expectedIP := "10.0.0.1"
+	expectChallenge := func() {
+		mockTwoFactorStore.EXPECT().SelectChallenge(ctx, "challenge").Return(challenge, nil).Once()
+		mockLoginAttemptStore.EXPECT().SelectLockedUntil(ctx, "login", expectedIP).Return(nil, nil).Once()
+		mockTwoFactorStore.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+	}
+
+	t.Run("unknown challenge", func(t *testing.T) {
+		mockTwoFactorStore.EXPECT().SelectChallenge(ctx, "challenge").Return(nil, nil).Once()
+		_, err := au.LoginTwoFactor(ctx, "challenge", "123456", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidLoginChallengeError)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		expectChallenge()
+		mockTwoFactorStore.EXPECT().UseRecoveryCode(ctx, user.ID, "wrongcode").Return(pgx.ErrNoRows).Once()
+		mockTwoFactorStore.EXPECT().FailChallenge(ctx, challenge.LoginChallengeID, 5).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, mock.MatchedBy(func(a *model.LoginAttempt) bool {
+			return !a.Success && a.Reason == model.LoginAttemptInvalidTwoFactorCode
+		})).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().RecordFailure(ctx, model.LoginThrottleLogin, "login", mock.Anything).Return(1, nil).Once()
+		mockLoginAttemptStore.EXPECT().RecordFailure(ctx, model.LoginThrottleIP, expectedIP, mock.Anything).Return(1, nil).Once()
+
+		_, err := au.LoginTwoFactor(ctx, "challenge", "wrong-code", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidTwoFactorCodeError)
+	})
+
+	t.Run("code of the app", func(t *testing.T) {
+		expectChallenge()
+		code, err := totp.Code(secret, totp.Step(time.Now()))
+		require.NoError(t, err)
+		mockTwoFactorStore.EXPECT().UseStep(ctx, user.ID, mock.Anything).Return(nil).Once()
+		mockTwoFactorStore.EXPECT().UseChallenge(ctx, challenge.LoginChallengeID).Return(nil).Once()
+		mockUserStore.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		mockLoginAttemptStore.EXPECT().Reset(ctx, model.LoginThrottleLogin, "login").Return(nil).Once()
+		session := &model.Session{IP: expectedIP}
+		mockAuthStore.EXPECT().Insert(ctx, session).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, mock.MatchedBy(func(a *model.LoginAttempt) bool {
+			return a.Success && a.Reason == ""
+		})).Return(nil).Once()
+
+		tokens, err := au.LoginTwoFactor(ctx, "challenge", code, session)
+		require.NoError(t, err)
+		require.NotEmpty(t, tokens.Token)
+		require.Equal(t, "phone", session.Device)
+	})
+
+	t.Run("a code of the app is used once", func(t *testing.T) {
+		expectChallenge()
+		code, err := totp.Code(secret, totp.Step(time.Now()))
 		require.NoError(t, err)
-		require.NotEmpty(t, token)
+		mockTwoFactorStore.EXPECT().UseStep(ctx, user.ID, mock.Anything).Return(pgx.ErrNoRows).Once()
+		mockTwoFactorStore.EXPECT().FailChallenge(ctx, challenge.LoginChallengeID, 5).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+		mockLoginAttemptStore.EXPECT().RecordFailure(ctx, mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Twice()
+
+		_, err = au.LoginTwoFactor(ctx, "challenge", code, &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrInvalidTwoFactorCodeError)
+	})
+
+	t.Run("recovery code of a disabled user", func(t *testing.T) {
+		expectChallenge()
+		mockTwoFactorStore.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(nil).Once()
+		mockTwoFactorStore.EXPECT().UseChallenge(ctx, challenge.LoginChallengeID).Return(nil).Once()
+		mockUserStore.EXPECT().GetUserByID(ctx, user.ID).Return(&model.User{ID: user.ID, Disabled: true}, nil).Once()
+		mockLoginAttemptStore.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+
+		_, err := au.LoginTwoFactor(ctx, "challenge", "ABCDE-FGHIJ", &model.Session{IP: expectedIP})
+		require.ErrorIs(t, err, model.ErrUserDisabledError)
 	})
 }
 
 func TestAuth_Logout(t *testing.T) {
 	ctx := context.Background()
 	mockAuthStore := NewAuthStorer(t)
-	au := usecase.NewAuth(mockAuthStore, nil)
+	au := usecase.NewAuth(mockAuthStore, nil, nil, nil, passwordHasher, &config.AuthConfig{})
 	expectedID := uuid.New()
+	expectedSessionID := uuid.New()
+	expectedError := errors.New("random error")
+
+	t.Run("Revoke error", func(t *testing.T) {
+		mockAuthStore.EXPECT().Revoke(ctx, expectedID, expectedSessionID).Return(expectedError).Once()
+		require.ErrorIs(t, model.ErrUserNotFound, au.Logout(ctx, expectedID, expectedSessionID))
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockAuthStore.EXPECT().Revoke(ctx, expectedID, expectedSessionID).Return(nil).Once()
+		require.Nil(t, au.Logout(ctx, expectedID, expectedSessionID))
+	})
+}
+
+func TestAuth_Sessions(t *testing.T) {
+	ctx := context.Background()
+	mockAuthStore := NewAuthStorer(t)
+	au := usecase.NewAuth(mockAuthStore, nil, nil, nil, passwordHasher, &config.AuthConfig{})
+	userID := uuid.New()
+	sessionID := uuid.New()
+	expectedError := errors.New("random error")
+
+	t.Run("SelectSessionsByUserID error", func(t *testing.T) {
+		mockAuthStore.EXPECT().SelectSessionsByUserID(ctx, userID).Return(nil, expectedError).Once()
+		sessions, err := au.GetSessions(ctx, userID)
+		require.ErrorIs(t, err, model.ErrSessionError)
+		require.Nil(t, sessions)
+	})
+
+	t.Run("GetSessions", func(t *testing.T) {
+		expected := []*model.Session{{SessionID: sessionID, UserID: userID}, {SessionID: uuid.New(), UserID: userID}}
+		mockAuthStore.EXPECT().SelectSessionsByUserID(ctx, userID).Return(expected, nil).Once()
+		sessions, err := au.GetSessions(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, expected, sessions)
+	})
+
+	t.Run("revoke a session of an other user", func(t *testing.T) {
+		otherID := uuid.New()
+		mockAuthStore.EXPECT().Revoke(ctx, userID, otherID).Return(pgx.ErrNoRows).Once()
+		require.ErrorIs(t, au.RevokeSession(ctx, userID, otherID), model.ErrSessionNotFoundError)
+	})
+
+	t.Run("Revoke error", func(t *testing.T) {
+		mockAuthStore.EXPECT().Revoke(ctx, userID, sessionID).Return(expectedError).Once()
+		require.ErrorIs(t, au.RevokeSession(ctx, userID, sessionID), model.ErrSessionError)
+	})
+
+	t.Run("RevokeSession", func(t *testing.T) {
+		mockAuthStore.EXPECT().Revoke(ctx, userID, sessionID).Return(nil).Once()
+		require.NoError(t, au.RevokeSession(ctx, userID, sessionID))
+	})
+
+	t.Run("RevokeOthers error", func(t *testing.T) {
+		mockAuthStore.EXPECT().RevokeOthers(ctx, userID, sessionID).Return(expectedError).Once()
+		require.ErrorIs(t, au.RevokeOtherSessions(ctx, userID, sessionID), model.ErrSessionError)
+	})
+
+	t.Run("RevokeOtherSessions", func(t *testing.T) {
+		mockAuthStore.EXPECT().RevokeOthers(ctx, userID, sessionID).Return(nil).Once()
+		require.NoError(t, au.RevokeOtherSessions(ctx, userID, sessionID))
+	})
+}
+
+func TestAuth_Refresh(t *testing.T) {
+	ctx := context.Background()
+	mockAuthStore := NewAuthStorer(t)
+	au := usecase.NewAuth(mockAuthStore, nil, nil, nil, passwordHasher, &config.AuthConfig{AccessTokenTTLMinutes: 5, RefreshTokenTTLHours: 24})
+	userID := uuid.New()
 	expectedError := errors.New("random error")
+	newRefreshToken := func() *model.RefreshToken {
+		return &model.RefreshToken{RefreshTokenID: uuid.New(), SessionID: uuid.New(), UserID: userID, ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	}
 
-	t.Run("Logout error", func(t *testing.T) {
-		mockAuthStore.EXPECT().Logout(ctx, expectedID).Return(expectedError).Once()
-		require.ErrorIs(t, model.ErrUserNotFound, au.Logout(ctx, expectedID))
+	t.Run("SelectRefreshToken error", func(t *testing.T) {
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(nil, expectedError).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrSessionError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(nil, nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrInvalidRefreshTokenError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		refreshToken := newRefreshToken()
+		refreshToken.SessionRevoked = true
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrInvalidRefreshTokenError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		refreshToken := newRefreshToken()
+		refreshToken.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrTokenExpiredError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("reused token revokes the session", func(t *testing.T) {
+		refreshToken := newRefreshToken()
+		usedAt := time.Now().UTC().Add(-time.Minute)
+		refreshToken.UsedAt = &usedAt
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		mockAuthStore.EXPECT().Revoke(ctx, userID, refreshToken.SessionID).Return(nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrRefreshTokenReusedError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("token used in between", func(t *testing.T) {
+		refreshToken := newRefreshToken()
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		mockAuthStore.EXPECT().Rotate(ctx, refreshToken.RefreshTokenID, mock.Anything).Return(pgx.ErrNoRows).Once()
+		mockAuthStore.EXPECT().Revoke(ctx, userID, refreshToken.SessionID).Return(nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrRefreshTokenReusedError)
+		require.Nil(t, tokens)
+	})
+
+	t.Run("Rotate error", func(t *testing.T) {
+		refreshToken := newRefreshToken()
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		mockAuthStore.EXPECT().Rotate(ctx, refreshToken.RefreshTokenID, mock.Anything).Return(expectedError).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.ErrorIs(t, err, model.ErrSessionError)
+		require.Nil(t, tokens)
 	})
 
 	t.Run("no error", func(t *testing.T) {
-		mockAuthStore.EXPECT().Logout(ctx, expectedID).Return(nil).Once()
-		require.Nil(t, au.Logout(ctx, expectedID))
+		refreshToken := newRefreshToken()
+		mockAuthStore.EXPECT().SelectRefreshToken(ctx, "token").Return(refreshToken, nil).Once()
+		mockAuthStore.EXPECT().Rotate(ctx, refreshToken.RefreshTokenID, mock.MatchedBy(func(session *model.Session) bool {
+			return session.Token != "" && session.RefreshToken != "" && session.RefreshToken != "token"
+		})).Return(nil).Once()
+		tokens, err := au.Refresh(ctx, "token")
+		require.NoError(t, err)
+		require.NotEmpty(t, tokens.Token)
+		require.WithinDuration(t, time.Now().Add(5*time.Minute), tokens.ExpiresAt, time.Minute)
+		require.WithinDuration(t, time.Now().Add(24*time.Hour), tokens.RefreshExpiresAt, time.Minute)
 	})
 }
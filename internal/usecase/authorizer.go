@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+)
+
+type AuthorizerBillStorer interface {
+	SelectBillByID(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+}
+
+type AuthorizerUserProductStorer interface {
+	SelectProductByID(ctx context.Context, id uuid.UUID) (*model.UserProduct, error)
+}
+
+type AuthorizerHouseholdStorer interface {
+	SelectMember(ctx context.Context, householdID, userID uuid.UUID) (*model.HouseholdMember, error)
+}
+
+// Authorizer checks the authenticated user owns the bills and lines a use case acts on, or shares them as a
+// member of their household. The bills, lines and households of the others are not found, so their
+// existence is not leaked.
+type Authorizer struct {
+	AuthorizerBillStorer        AuthorizerBillStorer
+	AuthorizerUserProductStorer AuthorizerUserProductStorer
+	AuthorizerHouseholdStorer   AuthorizerHouseholdStorer
+}
+
+func NewAuthorizer(abs AuthorizerBillStorer, aups AuthorizerUserProductStorer, ahs AuthorizerHouseholdStorer) *Authorizer {
+	return &Authorizer{
+		AuthorizerBillStorer:        abs,
+		AuthorizerUserProductStorer: aups,
+		AuthorizerHouseholdStorer:   ahs,
+	}
+}
+
+// Household returns the membership of the user, ErrHouseholdNotFoundError when the user is not a member.
+func (a *Authorizer) Household(ctx context.Context, userID, householdID uuid.UUID) (*model.HouseholdMember, error) {
+	member, err := a.AuthorizerHouseholdStorer.SelectMember(ctx, householdID, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Household.SelectMember")
+		return nil, model.ErrHouseholdError
+	}
+	if member == nil {
+		return nil, model.ErrHouseholdNotFoundError
+	}
+	return member, nil
+}
+
+// Bill returns the personal bill of the user or the bill of a household the user is still a member of whatever
+// its state, ErrBillNotFoundError for a missing bill or the bill of an other user.
+func (a *Authorizer) Bill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error) {
+	bill, err := a.AuthorizerBillStorer.SelectBillByID(ctx, userID, billID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Bill.SelectBillByID")
+		return nil, model.ErrBillError
+	}
+	if bill == nil {
+		return nil, model.ErrBillNotFoundError
+	}
+	return bill, nil
+}
+
+// OpenBill returns the bill if it can still be changed, ErrBillNotOpenError otherwise.
+func (a *Authorizer) OpenBill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error) {
+	bill, err := a.Bill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+	if bill.State != model.BillStateCreate {
+		return nil, model.ErrBillNotOpenError
+	}
+	return bill, nil
+}
+
+// UserProduct returns the line of the open bill, whoever of the household added it, ErrUserProductNotFoundError when the line is
+// not on this bill.
+func (a *Authorizer) UserProduct(ctx context.Context, userID, billID, userProductID uuid.UUID) (*model.UserProduct, error) {
+	if _, err := a.OpenBill(ctx, userID, billID); err != nil {
+		return nil, err
+	}
+
+	userProduct, err := a.AuthorizerUserProductStorer.SelectProductByID(ctx, userProductID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("UserProduct.SelectProductByID")
+		return nil, model.ErrUserProductError
+	}
+	if userProduct == nil || userProduct.BillID != billID {
+		return nil, model.ErrUserProductNotFoundError
+	}
+	return userProduct, nil
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+)
+
+func TestAuthorizer_OpenBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewAuthorizerBillStorer(t)
+	a := usecase.NewAuthorizer(mockBillStorer, nil, nil)
+
+	userID := uuid.New()
+
+	t.Run("SelectBillByID error", func(t *testing.T) {
+		billID := uuid.New()
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, billID).Return(nil, errors.New("random error")).Once()
+		bill, err := a.OpenBill(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		billID := uuid.New()
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, billID).Return(nil, nil).Once()
+		bill, err := a.OpenBill(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("closed bill", func(t *testing.T) {
+		closed := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCompleted}
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, closed.BillID).Return(closed, nil).Twice()
+		bill, err := a.OpenBill(ctx, userID, closed.BillID)
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, bill)
+
+		// reading a closed bill is allowed
+		bill, err = a.Bill(ctx, userID, closed.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, closed, bill)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		open := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCreate}
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, open.BillID).Return(open, nil).Once()
+		bill, err := a.OpenBill(ctx, userID, open.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, open, bill)
+	})
+}
+
+func TestAuthorizer_UserProduct(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewAuthorizerBillStorer(t)
+	mockUserProductStorer := NewAuthorizerUserProductStorer(t)
+	a := usecase.NewAuthorizer(mockBillStorer, mockUserProductStorer, nil)
+
+	userID := uuid.New()
+	open := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCreate}
+
+	t.Run("closed bill", func(t *testing.T) {
+		closed := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCanceled}
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, closed.BillID).Return(closed, nil).Once()
+		line, err := a.UserProduct(ctx, userID, closed.BillID, uuid.New())
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, line)
+	})
+
+	t.Run("SelectProductByID error", func(t *testing.T) {
+		userProductID := uuid.New()
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductByID(ctx, userProductID).Return(nil, errors.New("random error")).Once()
+		line, err := a.UserProduct(ctx, userID, open.BillID, userProductID)
+		assert.ErrorIs(t, err, model.ErrUserProductError)
+		assert.Nil(t, line)
+	})
+
+	t.Run("line of an other bill", func(t *testing.T) {
+		other := &model.UserProduct{UserProductID: uuid.New(), BillID: uuid.New()}
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductByID(ctx, other.UserProductID).Return(other, nil).Once()
+		line, err := a.UserProduct(ctx, userID, open.BillID, other.UserProductID)
+		assert.ErrorIs(t, err, model.ErrUserProductNotFoundError)
+		assert.Nil(t, line)
+	})
+
+	t.Run("missing line", func(t *testing.T) {
+		userProductID := uuid.New()
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductByID(ctx, userProductID).Return(nil, nil).Once()
+		line, err := a.UserProduct(ctx, userID, open.BillID, userProductID)
+		assert.ErrorIs(t, err, model.ErrUserProductNotFoundError)
+		assert.Nil(t, line)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := &model.UserProduct{UserProductID: uuid.New(), BillID: open.BillID}
+		mockBillStorer.EXPECT().SelectBillByID(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductByID(ctx, expected.UserProductID).Return(expected, nil).Once()
+		line, err := a.UserProduct(ctx, userID, open.BillID, expected.UserProductID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, line)
+	})
+}
+
+func TestAuthorizer_Household(t *testing.T) {
+	ctx := context.Background()
+	mockHouseholdStorer := NewAuthorizerHouseholdStorer(t)
+	a := usecase.NewAuthorizer(nil, nil, mockHouseholdStorer)
+
+	userID := uuid.New()
+	householdID := uuid.New()
+
+	t.Run("SelectMember error", func(t *testing.T) {
+		mockHouseholdStorer.EXPECT().SelectMember(ctx, householdID, userID).Return(nil, errors.New("random error")).Once()
+		_, err := a.Household(ctx, userID, householdID)
+		assert.ErrorIs(t, err, model.ErrHouseholdError)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mockHouseholdStorer.EXPECT().SelectMember(ctx, householdID, userID).Return(nil, nil).Once()
+		_, err := a.Household(ctx, userID, householdID)
+		assert.ErrorIs(t, err, model.ErrHouseholdNotFoundError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		member := &model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: model.HouseholdRoleMember}
+		mockHouseholdStorer.EXPECT().SelectMember(ctx, householdID, userID).Return(member, nil).Once()
+		got, err := a.Household(ctx, userID, householdID)
+		require.NoError(t, err)
+		assert.Equal(t, member, got)
+	})
+}
@@ -6,13 +6,16 @@ import (
 	"github.com/rs/zerolog/log"
 	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/model/response"
+	"time"
 )
 
 type BillStorer interface {
 	Insert(ctx context.Context, bill *model.Bill) error
-	Update(ctx context.Context, bill *model.Bill) error
-	GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
-	ExistsUnclosedBill(ctx context.Context, userID uuid.UUID) (*model.Bill, error)
+	Transition(ctx context.Context, bill *model.Bill, from, event string, actorID uuid.UUID) error
+	GetBillsByUserID(ctx context.Context, userID uuid.UUID, filter *model.BillFilter) ([]*model.Bill, error)
+	SelectOpenBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
+	SelectOpenBillByStoreID(ctx context.Context, userID, householdID, storeID uuid.UUID) (*model.Bill, error)
+	SelectDiscrepanciesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error)
 }
 
 type BillStoreStorer interface {
@@ -27,11 +30,28 @@ type BillUserProductsStorer interface {
 	SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error)
 }
 
+type BillPriceHistoryStorer interface {
+	Refresh(ctx context.Context) error
+}
+
+type BillEventStorer interface {
+	SelectByBillID(ctx context.Context, billID uuid.UUID) ([]*model.BillEvent, error)
+}
+
+type BillAuthorizer interface {
+	Bill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+	OpenBill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+	Household(ctx context.Context, userID, householdID uuid.UUID) (*model.HouseholdMember, error)
+}
+
 type Bill struct {
 	BillStorer             BillStorer
 	BillStoreStorer        BillStoreStorer
 	BillCompanyStorer      BillCompanyStorer
 	BillUserProductsStorer BillUserProductsStorer
+	BillPriceHistoryStorer BillPriceHistoryStorer
+	BillEventStorer        BillEventStorer
+	BillAuthorizer         BillAuthorizer
 }
 
 func NewBill(
@@ -39,28 +59,46 @@ func NewBill(
 	bss BillStoreStorer,
 	bcs BillCompanyStorer,
 	bups BillUserProductsStorer,
+	bphs BillPriceHistoryStorer,
+	bes BillEventStorer,
+	ba BillAuthorizer,
 ) *Bill {
 	return &Bill{
 		BillStorer:             bs,
 		BillStoreStorer:        bss,
 		BillCompanyStorer:      bcs,
 		BillUserProductsStorer: bups,
+		BillPriceHistoryStorer: bphs,
+		BillEventStorer:        bes,
+		BillAuthorizer:         ba,
 	}
 }
 
-func (b *Bill) StartBill(ctx context.Context, userID, storeID uuid.UUID) (*response.Bill, error) {
-	bill, err := b.BillStorer.ExistsUnclosedBill(ctx, userID)
+// StartBill returns the open bill of the user in the store, a new one when there is none. With a
+// householdID the bill is the one of the household, shared with its members. The open bills of the other
+// stores are left as they are.
+func (b *Bill) StartBill(ctx context.Context, userID, householdID, storeID uuid.UUID) (*response.Bill, error) {
+	if householdID != uuid.Nil {
+		if _, err := b.BillAuthorizer.Household(ctx, userID, householdID); err != nil {
+			return nil, err
+		}
+	}
+
+	bill, err := b.BillStorer.SelectOpenBillByStoreID(ctx, userID, householdID, storeID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("StartBill.ExistsUnclosedBill")
+		log.Error().Caller().Err(err).Msg("StartBill.SelectOpenBillByStoreID")
 		return nil, model.ErrBillError
 	}
 	if bill == nil {
 		bill = &model.Bill{
 			UserID:  userID,
 			StoreID: storeID,
-			Amount:  "0.0",
+			Amount:  model.ZeroMoney(model.DefaultCurrency),
 			State:   model.BillStateCreate,
 		}
+		if householdID != uuid.Nil {
+			bill.HouseholdID = &householdID
+		}
 		if err = b.BillStorer.Insert(ctx, bill); err != nil {
 			log.Error().Caller().Err(err).Msg("StartBill.Insert")
 			return nil, model.ErrBillError
@@ -70,73 +108,228 @@ func (b *Bill) StartBill(ctx context.Context, userID, storeID uuid.UUID) (*respo
 	return b.prepareBillResponse(ctx, bill)
 }
 
-func (b *Bill) CloseBill(ctx context.Context, userID, billID uuid.UUID, amount string) error {
-	bill, err := b.BillStorer.ExistsUnclosedBill(ctx, userID)
+// StartDatedBill opens a new personal bill of the user in the store for a purchase made at date in the
+// currency, such as an imported receipt. Unlike StartBill it never returns the bill already open in the store.
+func (b *Bill) StartDatedBill(ctx context.Context, userID, storeID uuid.UUID, currency string, date time.Time) (*response.Bill, error) {
+	bill := &model.Bill{
+		UserID:    userID,
+		StoreID:   storeID,
+		Amount:    model.ZeroMoney(currency),
+		State:     model.BillStateCreate,
+		CreatedAt: date,
+	}
+	if err := b.BillStorer.Insert(ctx, bill); err != nil {
+		log.Error().Caller().Err(err).Msg("StartDatedBill.Insert")
+		return nil, model.ErrBillError
+	}
+
+	return b.prepareBillResponse(ctx, bill)
+}
+
+// CloseBill stores the declared amount next to the sum of the lines, the returned bill carries the
+// discrepancy between both. The amount is in the currency of the bill, it is not converted.
+func (b *Bill) CloseBill(ctx context.Context, userID, billID uuid.UUID, amount model.Money) (*response.Bill, error) {
+	if amount.IsNegative() {
+		return nil, model.ErrInvalidMoneyError
+	}
+
+	bill, err := b.BillAuthorizer.OpenBill(ctx, userID, billID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("CloseBill.ExistsUnclosedBill")
-		return model.ErrBillError
+		return nil, err
 	}
-	if bill == nil {
-		return model.ErrBillError
+	if amount.Currency != bill.Amount.Currency {
+		return nil, model.ErrInvalidMoneyError
 	}
-	if bill.BillID != billID {
-		return model.ErrBillError
+
+	products, err := b.BillUserProductsStorer.SelectProductsByBillID(ctx, bill.BillID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("CloseBill.SelectProductsByBillID")
+		return nil, model.ErrBillError
 	}
 
-	bill.State = model.BillStateCompleted
 	bill.Amount = amount
-	err = b.BillStorer.Update(ctx, bill)
-	if err != nil {
-		log.Error().Caller().Err(err).Msg("CloseBill.Update")
-		return model.ErrBillError
+	if bill.ComputedAmount, err = model.SumUserProducts(products, bill.Amount.Currency); err != nil {
+		return nil, err
+	}
+	if err = b.transition(ctx, bill, model.BillEventClose, userID); err != nil {
+		return nil, err
+	}
+
+	// the bill is closed at this point, a stale price history must not fail the request
+	if err = b.BillPriceHistoryStorer.Refresh(ctx); err != nil {
+		log.Error().Caller().Err(err).Msg("CloseBill.Refresh")
 	}
 
-	return err
+	return b.newBillResponse(ctx, bill, products)
 }
 
 func (b *Bill) CancelBill(ctx context.Context, userID, billID uuid.UUID) error {
-	bill, err := b.BillStorer.ExistsUnclosedBill(ctx, userID)
+	bill, err := b.BillAuthorizer.OpenBill(ctx, userID, billID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("CancelBill.ExistsUnclosedBill")
-		return model.ErrBillError
+		return err
 	}
-	if bill == nil {
-		return nil
+
+	bill.Amount = model.ZeroMoney(bill.Amount.Currency)
+	return b.transition(ctx, bill, model.BillEventCancel, userID)
+}
+
+// ReopenBill puts a closed bill back in the create state so its lines can be corrected, it is closed
+// again with CloseBill. Its prices leave the price history until then.
+func (b *Bill) ReopenBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error) {
+	bill, err := b.BillAuthorizer.Bill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
 	}
-	if bill.BillID != billID {
-		return model.ErrBillError
+
+	if err = b.transition(ctx, bill, model.BillEventReopen, userID); err != nil {
+		return nil, err
 	}
 
-	bill.State = model.BillStateCanceled
-	bill.Amount = "0"
-	err = b.BillStorer.Update(ctx, bill)
+	if err = b.BillPriceHistoryStorer.Refresh(ctx); err != nil {
+		log.Error().Caller().Err(err).Msg("ReopenBill.Refresh")
+	}
+
+	return b.prepareBillResponse(ctx, bill)
+}
+
+// ArchiveBill puts away a closed bill, an archived bill keeps its prices in the history.
+func (b *Bill) ArchiveBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error) {
+	bill, err := b.BillAuthorizer.Bill(ctx, userID, billID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("CancelBill.Update")
-		return model.ErrBillError
+		return nil, err
+	}
+
+	if err = b.transition(ctx, bill, model.BillEventArchive, userID); err != nil {
+		return nil, err
 	}
 
-	return err
+	return b.prepareBillResponse(ctx, bill)
+}
+
+// GetBillHistory returns the transitions of the bill of the user, the oldest first.
+func (b *Bill) GetBillHistory(ctx context.Context, userID, billID uuid.UUID) ([]*model.BillEvent, error) {
+	bill, err := b.BillAuthorizer.Bill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := b.BillEventStorer.SelectByBillID(ctx, bill.BillID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetBillHistory.SelectByBillID")
+		return nil, model.ErrBillError
+	}
+
+	return events, nil
 }
 
-func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
-	bills, err := b.BillStorer.GetBillsByUserID(ctx, userID)
+// transition moves the bill to the state reached by the event and saves it with the event. The storer
+// fails when an other request changed the state in between.
+func (b *Bill) transition(ctx context.Context, bill *model.Bill, event string, actorID uuid.UUID) error {
+	from := bill.State
+	next, err := model.NextBillState(from, event)
+	if err != nil {
+		return err
+	}
+
+	bill.State = next
+	if err = b.BillStorer.Transition(ctx, bill, from, event, actorID); err != nil {
+		bill.State = from
+		log.Error().Caller().Err(err).Msg("transition.Transition")
+		return model.ErrBillError
+	}
+	return nil
+}
+
+// GetBillsByUserID returns a page of the bills of the user and of its households, the most recent first by
+// default.
+func (b *Bill) GetBillsByUserID(ctx context.Context, userID uuid.UUID, filter *model.BillFilter) (*model.BillPage, error) {
+	if filter.Sort == "" {
+		filter.Sort = model.BillSortDate
+	}
+	if filter.Order == "" {
+		filter.Order = model.BillOrderDesc
+	}
+	if filter.Limit <= 0 || filter.Limit > model.MaxBillLimit {
+		filter.Limit = model.DefaultBillLimit
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, model.ErrBillRangeError
+	}
+	if filter.Cursor != nil && filter.Cursor.Sort != filter.Sort {
+		return nil, model.ErrInvalidCursorError
+	}
+
+	// one more bill tells if there is a next page
+	query := *filter
+	query.Limit++
+	bills, err := b.BillStorer.GetBillsByUserID(ctx, userID, &query)
 	if err != nil {
 		log.Error().Caller().Err(err).Msg("GetBillsByUserID.GetBillsByUserID")
 		return nil, model.ErrBillError
 	}
+
+	page := &model.BillPage{Bills: bills}
+	if len(bills) > filter.Limit {
+		page.Bills = bills[:filter.Limit]
+		page.NextCursor = model.NewBillCursor(filter.Sort, page.Bills[filter.Limit-1]).Encode()
+	}
+
+	return page, nil
+}
+
+func (b *Bill) GetDiscrepancies(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	bills, err := b.BillStorer.SelectDiscrepanciesByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetDiscrepancies.SelectDiscrepanciesByUserID")
+		return nil, model.ErrBillError
+	}
 	return bills, nil
 }
 
+// GetLastBill returns the most recently started open bill, nil without open bill.
 func (b *Bill) GetLastBill(ctx context.Context, userID uuid.UUID) (*response.Bill, error) {
-	bill, err := b.BillStorer.ExistsUnclosedBill(ctx, userID)
+	bills, err := b.BillStorer.SelectOpenBillsByUserID(ctx, userID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("GetLastBill.ExistsUnclosedBill")
+		log.Error().Caller().Err(err).Msg("GetLastBill.SelectOpenBillsByUserID")
 		return nil, model.ErrBillError
 	}
+	if len(bills) == 0 {
+		return nil, nil
+	}
+
+	return b.prepareBillResponse(ctx, bills[0])
+}
+
+// GetOpenBill returns the open bill of the user with its lines.
+func (b *Bill) GetOpenBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error) {
+	bill, err := b.BillAuthorizer.OpenBill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
 
 	return b.prepareBillResponse(ctx, bill)
 }
 
+// GetOpenBills returns the open bills of the user with their lines, the most recent first.
+func (b *Bill) GetOpenBills(ctx context.Context, userID uuid.UUID) ([]*response.Bill, error) {
+	bills, err := b.BillStorer.SelectOpenBillsByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetOpenBills.SelectOpenBillsByUserID")
+		return nil, model.ErrBillError
+	}
+
+	result := []*response.Bill{}
+	for _, bill := range bills {
+		br, err := b.prepareBillResponse(ctx, bill)
+		if err != nil {
+			return nil, model.ErrBillError
+		}
+		result = append(result, br)
+	}
+
+	return result, nil
+}
+
 func (b *Bill) prepareBillResponse(ctx context.Context, bill *model.Bill) (*response.Bill, error) {
 	if bill == nil {
 		return nil, nil
@@ -146,16 +339,26 @@ func (b *Bill) prepareBillResponse(ctx context.Context, bill *model.Bill) (*resp
 		log.Error().Caller().Err(err).Msg("prepareBillResponse.SelectProductsByBillID")
 		return nil, err
 	}
+	// an open bill shows the running total of its lines
+	if bill.State == model.BillStateCreate {
+		if bill.ComputedAmount, err = model.SumUserProducts(products, bill.Amount.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.newBillResponse(ctx, bill, products)
+}
 
+func (b *Bill) newBillResponse(ctx context.Context, bill *model.Bill, products []*model.UserProduct) (*response.Bill, error) {
 	store, err := b.BillStoreStorer.SelectStoreByID(ctx, bill.StoreID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("prepareBillResponse.SelectStoreByID")
+		log.Error().Caller().Err(err).Msg("newBillResponse.SelectStoreByID")
 		return nil, model.ErrStoreError
 	}
 
 	company, err := b.BillCompanyStorer.SelectCompanyByID(ctx, store.CompanyID)
 	if err != nil {
-		log.Error().Caller().Err(err).Msg("prepareBillResponse.SelectCompanyByID")
+		log.Error().Caller().Err(err).Msg("newBillResponse.SelectCompanyByID")
 		return nil, model.ErrStoreError
 	}
 
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+)
+
+type BillSplitStorer interface {
+	ReplaceSplit(ctx context.Context, split *model.BillSplit) error
+	SelectSplit(ctx context.Context, billID uuid.UUID) (*model.BillSplit, error)
+	InsertSettlement(ctx context.Context, settlement *model.Settlement) error
+	SelectBalances(ctx context.Context, userID uuid.UUID) ([]*model.Balance, error)
+}
+
+type BillSplitUserProductStorer interface {
+	SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error)
+}
+
+type BillSplitHouseholdStorer interface {
+	SelectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error)
+}
+
+type BillSplitUserStorer interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+}
+
+type BillSplitAuthorizer interface {
+	Bill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+	Household(ctx context.Context, userID, householdID uuid.UUID) (*model.HouseholdMember, error)
+}
+
+// BillSplit splits the lines of the closed bills between users and keeps what they owe each other. The user
+// who started a bill paid it, the others owe their share to this user until they settle it.
+type BillSplit struct {
+	BillSplitStorer            BillSplitStorer
+	BillSplitUserProductStorer BillSplitUserProductStorer
+	BillSplitHouseholdStorer   BillSplitHouseholdStorer
+	BillSplitUserStorer        BillSplitUserStorer
+	BillSplitAuthorizer        BillSplitAuthorizer
+}
+
+func NewBillSplit(
+	bss BillSplitStorer,
+	bsups BillSplitUserProductStorer,
+	bshs BillSplitHouseholdStorer,
+	bsus BillSplitUserStorer,
+	bsa BillSplitAuthorizer,
+) *BillSplit {
+	return &BillSplit{
+		BillSplitStorer:            bss,
+		BillSplitUserProductStorer: bsups,
+		BillSplitHouseholdStorer:   bshs,
+		BillSplitUserStorer:        bsus,
+		BillSplitAuthorizer:        bsa,
+	}
+}
+
+// SplitBill replaces the split of the closed bill by the allocations of its lines. A household is only given
+// a part by one of its members. Reopening the bill drops its split.
+func (bs *BillSplit) SplitBill(ctx context.Context, userID, billID uuid.UUID, allocations []*model.SplitAllocation) (*model.BillSplit, error) {
+	bill, err := bs.BillSplitAuthorizer.Bill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+	if !bill.IsClosed() {
+		return nil, model.ErrBillNotClosedError
+	}
+
+	households, err := bs.participants(ctx, userID, allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := bs.BillSplitUserProductStorer.SelectProductsByBillID(ctx, billID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("SplitBill.SelectProductsByBillID")
+		return nil, model.ErrBillSplitError
+	}
+	shares, err := model.ComputeSplit(bill.UserID, lines, allocations, households, bill.Amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	split := &model.BillSplit{
+		BillID:      billID,
+		PayerID:     bill.UserID,
+		CreatedBy:   userID,
+		Currency:    bill.Amount.Currency,
+		Allocations: allocations,
+		Shares:      shares,
+	}
+	if err = bs.BillSplitStorer.ReplaceSplit(ctx, split); err != nil {
+		log.Error().Caller().Err(err).Msg("SplitBill.ReplaceSplit")
+		return nil, model.ErrBillSplitError
+	}
+	return bs.selectSplit(ctx, billID)
+}
+
+// GetSplit returns the split of the bill, ErrBillSplitNotFoundError when it is not split.
+func (bs *BillSplit) GetSplit(ctx context.Context, userID, billID uuid.UUID) (*model.BillSplit, error) {
+	if _, err := bs.BillSplitAuthorizer.Bill(ctx, userID, billID); err != nil {
+		return nil, err
+	}
+	return bs.selectSplit(ctx, billID)
+}
+
+// GetBalances returns what each other user owes to the user, negative when the user owes it.
+func (bs *BillSplit) GetBalances(ctx context.Context, userID uuid.UUID) ([]*model.Balance, error) {
+	balances, err := bs.BillSplitStorer.SelectBalances(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetBalances.SelectBalances")
+		return nil, model.ErrBillSplitError
+	}
+	return balances, nil
+}
+
+// Settle records a payment of the user to an other one, up to what the user owes it in the currency.
+func (bs *BillSplit) Settle(ctx context.Context, userID, toUserID uuid.UUID, amount model.Money) (*model.Settlement, error) {
+	if toUserID == userID || !amount.Amount.IsPositive() {
+		return nil, model.ErrInvalidSettlementError
+	}
+	if err := bs.userExists(ctx, toUserID); err != nil {
+		return nil, err
+	}
+
+	settlement := &model.Settlement{
+		FromUserID: userID,
+		ToUserID:   toUserID,
+		Amount:     amount,
+	}
+	if err := bs.BillSplitStorer.InsertSettlement(ctx, settlement); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrInvalidSettlementError
+		}
+		log.Error().Caller().Err(err).Msg("Settle.InsertSettlement")
+		return nil, model.ErrBillSplitError
+	}
+	return settlement, nil
+}
+
+// participants checks the users of the allocations exist and the user is a member of their households, it
+// returns the members of these households.
+func (bs *BillSplit) participants(ctx context.Context, userID uuid.UUID, allocations []*model.SplitAllocation) (map[uuid.UUID][]uuid.UUID, error) {
+	households := make(map[uuid.UUID][]uuid.UUID)
+	users := make(map[uuid.UUID]bool)
+	for _, allocation := range allocations {
+		switch allocation.ParticipantType {
+		case model.SplitParticipantUser:
+			if users[allocation.ParticipantID] {
+				continue
+			}
+			if err := bs.userExists(ctx, allocation.ParticipantID); err != nil {
+				return nil, err
+			}
+			users[allocation.ParticipantID] = true
+		case model.SplitParticipantHousehold:
+			if _, ok := households[allocation.ParticipantID]; ok {
+				continue
+			}
+			if _, err := bs.BillSplitAuthorizer.Household(ctx, userID, allocation.ParticipantID); err != nil {
+				return nil, err
+			}
+			members, err := bs.BillSplitHouseholdStorer.SelectMembers(ctx, allocation.ParticipantID)
+			if err != nil {
+				log.Error().Caller().Err(err).Msg("participants.SelectMembers")
+				return nil, model.ErrBillSplitError
+			}
+			memberIDs := make([]uuid.UUID, 0, len(members))
+			for _, member := range members {
+				memberIDs = append(memberIDs, member.UserID)
+			}
+			households[allocation.ParticipantID] = memberIDs
+		default:
+			return nil, model.ErrInvalidSplitError
+		}
+	}
+	return households, nil
+}
+
+func (bs *BillSplit) userExists(ctx context.Context, userID uuid.UUID) error {
+	user, err := bs.BillSplitUserStorer.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("userExists.GetUserByID")
+		return model.ErrBillSplitError
+	}
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+	return nil
+}
+
+func (bs *BillSplit) selectSplit(ctx context.Context, billID uuid.UUID) (*model.BillSplit, error) {
+	split, err := bs.BillSplitStorer.SelectSplit(ctx, billID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("selectSplit.SelectSplit")
+		return nil, model.ErrBillSplitError
+	}
+	if split == nil {
+		return nil, model.ErrBillSplitNotFoundError
+	}
+	return split, nil
+}
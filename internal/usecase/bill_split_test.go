@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+)
+
+func TestBillSplit_SplitBill(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewBillSplitStorer(t)
+	mockUserProductStorer := NewBillSplitUserProductStorer(t)
+	mockHouseholdStorer := NewBillSplitHouseholdStorer(t)
+	mockUserStorer := NewBillSplitUserStorer(t)
+	mockAuthorizer := NewBillSplitAuthorizer(t)
+	bs := usecase.NewBillSplit(mockStorer, mockUserProductStorer, mockHouseholdStorer, mockUserStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	friendID := uuid.New()
+	householdID := uuid.New()
+	// a personal bill is split with any user, with the households of the user
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, Amount: model.ZeroMoney("EUR"), State: model.BillStateCompleted}
+	line := &model.UserProduct{UserProductID: uuid.New(), BillID: bill.BillID, Price: model.NewMoney(decimal.NewFromInt(9), "EUR"), Quantity: 1}
+	toFriend := &model.SplitAllocation{UserProductID: line.UserProductID, ParticipantType: model.SplitParticipantUser, ParticipantID: friendID, Method: model.SplitMethodShare, Value: decimal.NewFromInt(2)}
+	toHousehold := &model.SplitAllocation{UserProductID: line.UserProductID, ParticipantType: model.SplitParticipantHousehold, ParticipantID: householdID, Method: model.SplitMethodShare, Value: decimal.NewFromInt(1)}
+
+	t.Run("bill not closed", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(&model.Bill{State: model.BillStateCreate}, nil).Once()
+		_, err := bs.SplitBill(ctx, userID, bill.BillID, []*model.SplitAllocation{toFriend})
+		assert.ErrorIs(t, err, model.ErrBillNotClosedError)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(nil, nil).Once()
+		_, err := bs.SplitBill(ctx, userID, bill.BillID, []*model.SplitAllocation{toFriend})
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+
+	t.Run("household of an other user", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(nil, model.ErrHouseholdNotFoundError).Once()
+		_, err := bs.SplitBill(ctx, userID, bill.BillID, []*model.SplitAllocation{toHousehold})
+		assert.ErrorIs(t, err, model.ErrHouseholdNotFoundError)
+	})
+
+	t.Run("ReplaceSplit error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(&model.User{ID: friendID}, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByBillID(ctx, bill.BillID).Return([]*model.UserProduct{line}, nil).Once()
+		mockStorer.EXPECT().ReplaceSplit(ctx, mock.Anything).Return(errors.New("random error")).Once()
+		_, err := bs.SplitBill(ctx, userID, bill.BillID, []*model.SplitAllocation{toFriend})
+		assert.ErrorIs(t, err, model.ErrBillSplitError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(&model.User{ID: friendID}, nil).Once()
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(&model.HouseholdMember{}, nil).Once()
+		mockHouseholdStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{{UserID: userID}, {UserID: friendID}}, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByBillID(ctx, bill.BillID).Return([]*model.UserProduct{line}, nil).Once()
+		mockStorer.EXPECT().ReplaceSplit(ctx, mock.MatchedBy(func(split *model.BillSplit) bool {
+			// two thirds to the friend, the household third shared between the user and the friend
+			return split.PayerID == userID && len(split.Shares) == 2 &&
+				split.Shares[0].Amount.String() == "1.50 EUR" && split.Shares[1].Amount.String() == "7.50 EUR"
+		})).Return(nil).Once()
+		expected := &model.BillSplit{BillID: bill.BillID}
+		mockStorer.EXPECT().SelectSplit(ctx, bill.BillID).Return(expected, nil).Once()
+
+		split, err := bs.SplitBill(ctx, userID, bill.BillID, []*model.SplitAllocation{toFriend, toHousehold})
+		require.NoError(t, err)
+		assert.Equal(t, expected, split)
+	})
+}
+
+func TestBillSplit_GetSplit(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewBillSplitStorer(t)
+	mockAuthorizer := NewBillSplitAuthorizer(t)
+	bs := usecase.NewBillSplit(mockStorer, nil, nil, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	billID := uuid.New()
+
+	t.Run("not split", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, billID).Return(&model.Bill{}, nil).Once()
+		mockStorer.EXPECT().SelectSplit(ctx, billID).Return(nil, nil).Once()
+		_, err := bs.GetSplit(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillSplitNotFoundError)
+	})
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, billID).Return(nil, model.ErrBillNotFoundError).Once()
+		_, err := bs.GetSplit(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+	})
+}
+
+func TestBillSplit_Settle(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewBillSplitStorer(t)
+	mockUserStorer := NewBillSplitUserStorer(t)
+	bs := usecase.NewBillSplit(mockStorer, nil, nil, mockUserStorer, nil)
+
+	userID := uuid.New()
+	friendID := uuid.New()
+	amount := model.NewMoney(decimal.NewFromInt(5), "EUR")
+
+	t.Run("invalid settlements", func(t *testing.T) {
+		_, err := bs.Settle(ctx, userID, userID, amount)
+		assert.ErrorIs(t, err, model.ErrInvalidSettlementError)
+		_, err = bs.Settle(ctx, userID, friendID, model.ZeroMoney("EUR"))
+		assert.ErrorIs(t, err, model.ErrInvalidSettlementError)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(nil, nil).Once()
+		_, err := bs.Settle(ctx, userID, friendID, amount)
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+
+	t.Run("more than owed", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(&model.User{ID: friendID}, nil).Once()
+		mockStorer.EXPECT().InsertSettlement(ctx, mock.Anything).Return(pgx.ErrNoRows).Once()
+		_, err := bs.Settle(ctx, userID, friendID, amount)
+		assert.ErrorIs(t, err, model.ErrInvalidSettlementError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, friendID).Return(&model.User{ID: friendID}, nil).Once()
+		mockStorer.EXPECT().InsertSettlement(ctx, mock.MatchedBy(func(settlement *model.Settlement) bool {
+			return settlement.FromUserID == userID && settlement.ToUserID == friendID && settlement.Amount == amount
+		})).Return(nil).Once()
+		settlement, err := bs.Settle(ctx, userID, friendID, amount)
+		require.NoError(t, err)
+		assert.Equal(t, friendID, settlement.ToUserID)
+	})
+}
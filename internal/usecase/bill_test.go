@@ -0,0 +1,497 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestBill_CloseBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	mockPriceHistoryStorer := NewBillPriceHistoryStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, mockPriceHistoryStorer, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	company := &model.Company{CompanyID: store.CompanyID, CompanyName: "intermarché"}
+	products := []*model.UserProduct{
+		{Price: money("2,49"), Quantity: 2},
+		{Price: money("1.10"), Quantity: 1},
+	}
+	expectedError := errors.New("random error")
+	newOpenBill := func() *model.Bill {
+		return &model.Bill{BillID: uuid.New(), UserID: userID, StoreID: store.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCreate}
+	}
+
+	t.Run("negative amount", func(t *testing.T) {
+		bill, err := b.CloseBill(ctx, userID, uuid.New(), money("-1"))
+		assert.ErrorIs(t, err, model.ErrInvalidMoneyError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, billID).Return(nil, model.ErrBillNotFoundError).Once()
+		bill, err := b.CloseBill(ctx, userID, billID, money("6.08"))
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("bill not open", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, billID).Return(nil, model.ErrBillNotOpenError).Once()
+		bill, err := b.CloseBill(ctx, userID, billID, money("6.08"))
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("amount in an other currency", func(t *testing.T) {
+		open := newOpenBill()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+		bill, err := b.CloseBill(ctx, userID, open.BillID, money("6.08 USD"))
+		assert.ErrorIs(t, err, model.ErrInvalidMoneyError)
+		assert.Nil(t, bill)
+		assert.Equal(t, model.DefaultCurrency, open.Amount.Currency)
+	})
+
+	t.Run("SelectProductsByBillID error", func(t *testing.T) {
+		open := newOpenBill()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, open.BillID).Return(nil, expectedError).Once()
+		bill, err := b.CloseBill(ctx, userID, open.BillID, money("6.08"))
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("Transition error", func(t *testing.T) {
+		open := newOpenBill()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, open.BillID).Return(products, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, open, model.BillStateCreate, model.BillEventClose, userID).Return(expectedError).Once()
+		bill, err := b.CloseBill(ctx, userID, open.BillID, money("6.08"))
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("totals match", func(t *testing.T) {
+		open := newOpenBill()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, open.BillID).Return(products, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, mock.MatchedBy(func(bill *model.Bill) bool {
+			return bill.State == model.BillStateCompleted && bill.ComputedAmount.String() == "6.08 EUR"
+		}), model.BillStateCreate, model.BillEventClose, userID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.CloseBill(ctx, userID, open.BillID, money("6,08 €"))
+		require.NoError(t, err)
+		assert.Equal(t, "6.08 EUR", bill.ComputedAmount.String())
+		require.NotNil(t, bill.Discrepancy)
+		assert.True(t, bill.Discrepancy.IsZero())
+	})
+
+	t.Run("discrepancy flagged", func(t *testing.T) {
+		open := newOpenBill()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, open.BillID).Return(products, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, open, model.BillStateCreate, model.BillEventClose, userID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(expectedError).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.CloseBill(ctx, userID, open.BillID, money("7.58"))
+		require.NoError(t, err)
+		require.NotNil(t, bill.Discrepancy)
+		assert.Equal(t, "1.50 EUR", bill.Discrepancy.String())
+		assert.True(t, open.HasDiscrepancy())
+	})
+}
+
+func TestBill_ReopenBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	mockPriceHistoryStorer := NewBillPriceHistoryStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, mockPriceHistoryStorer, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	expectedError := errors.New("random error")
+
+	t.Run("Bill error", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().Bill(ctx, userID, billID).Return(nil, model.ErrBillError).Once()
+		bill, err := b.ReopenBill(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("bill not found", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().Bill(ctx, userID, billID).Return(nil, model.ErrBillNotFoundError).Once()
+		bill, err := b.ReopenBill(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("canceled bill", func(t *testing.T) {
+		canceled := &model.Bill{BillID: uuid.New(), State: model.BillStateCanceled}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, canceled.BillID).Return(canceled, nil).Once()
+		bill, err := b.ReopenBill(ctx, userID, canceled.BillID)
+		assert.ErrorIs(t, err, model.ErrBillTransitionError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("Transition error", func(t *testing.T) {
+		closed := &model.Bill{BillID: uuid.New(), State: model.BillStateCompleted}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, closed.BillID).Return(closed, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, closed, model.BillStateCompleted, model.BillEventReopen, userID).Return(expectedError).Once()
+		bill, err := b.ReopenBill(ctx, userID, closed.BillID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+		assert.Equal(t, model.BillStateCompleted, closed.State)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		closed := &model.Bill{BillID: uuid.New(), StoreID: store.StoreID, Amount: money("10"), ComputedAmount: money("8"), State: model.BillStateCompleted}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, closed.BillID).Return(closed, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, closed, model.BillStateCompleted, model.BillEventReopen, userID).Return(nil).Once()
+		mockPriceHistoryStorer.EXPECT().Refresh(ctx).Return(nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, closed.BillID).Return([]*model.UserProduct{{Price: money("5"), Quantity: 2}}, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(&model.Company{}, nil).Once()
+		bill, err := b.ReopenBill(ctx, userID, closed.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, model.BillStateCreate, bill.State)
+		assert.Equal(t, "10.00 EUR", bill.ComputedAmount.String())
+		assert.Nil(t, bill.Discrepancy)
+	})
+}
+
+func TestBill_ArchiveBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, nil, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+
+	t.Run("open bill", func(t *testing.T) {
+		open := &model.Bill{BillID: uuid.New(), State: model.BillStateCreate}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, open.BillID).Return(open, nil).Once()
+		bill, err := b.ArchiveBill(ctx, userID, open.BillID)
+		assert.ErrorIs(t, err, model.ErrBillTransitionError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("canceled bill", func(t *testing.T) {
+		canceled := &model.Bill{BillID: uuid.New(), State: model.BillStateCanceled}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, canceled.BillID).Return(canceled, nil).Once()
+		bill, err := b.ArchiveBill(ctx, userID, canceled.BillID)
+		assert.ErrorIs(t, err, model.ErrBillTransitionError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("closed bill", func(t *testing.T) {
+		closed := &model.Bill{BillID: uuid.New(), StoreID: store.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCompleted}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, closed.BillID).Return(closed, nil).Once()
+		mockBillStorer.EXPECT().Transition(ctx, closed, model.BillStateCompleted, model.BillEventArchive, userID).Return(nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, closed.BillID).Return(nil, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(&model.Company{}, nil).Once()
+		bill, err := b.ArchiveBill(ctx, userID, closed.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, model.BillStateArchived, bill.State)
+	})
+}
+
+func TestBill_GetBillHistory(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockEventStorer := NewBillEventStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, nil, nil, nil, nil, mockEventStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	bill := &model.Bill{BillID: uuid.New(), State: model.BillStateCompleted}
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(nil, model.ErrBillNotFoundError).Once()
+		events, err := b.GetBillHistory(ctx, userID, bill.BillID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, events)
+	})
+
+	t.Run("SelectByBillID error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockEventStorer.EXPECT().SelectByBillID(ctx, bill.BillID).Return(nil, errors.New("random error")).Once()
+		events, err := b.GetBillHistory(ctx, userID, bill.BillID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, events)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := []*model.BillEvent{
+			{BillID: bill.BillID, Event: model.BillEventStart, ToState: model.BillStateCreate},
+			{BillID: bill.BillID, Event: model.BillEventClose, FromState: model.BillStateCreate, ToState: model.BillStateCompleted},
+		}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockEventStorer.EXPECT().SelectByBillID(ctx, bill.BillID).Return(expected, nil).Once()
+		events, err := b.GetBillHistory(ctx, userID, bill.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, events)
+	})
+}
+
+func TestBill_StartBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, nil, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	company := &model.Company{CompanyID: store.CompanyID, CompanyName: "intermarché"}
+
+	t.Run("SelectOpenBillByStoreID error", func(t *testing.T) {
+		mockBillStorer.EXPECT().SelectOpenBillByStoreID(ctx, userID, uuid.Nil, store.StoreID).Return(nil, errors.New("random error")).Once()
+		bill, err := b.StartBill(ctx, userID, uuid.Nil, store.StoreID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("open bill of the store", func(t *testing.T) {
+		open := &model.Bill{BillID: uuid.New(), UserID: userID, StoreID: store.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCreate}
+		mockBillStorer.EXPECT().SelectOpenBillByStoreID(ctx, userID, uuid.Nil, store.StoreID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, open.BillID).Return(nil, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.StartBill(ctx, userID, uuid.Nil, store.StoreID)
+		require.NoError(t, err)
+		assert.Equal(t, open.BillID, bill.BillID)
+	})
+
+	t.Run("new bill", func(t *testing.T) {
+		billID := uuid.New()
+		mockBillStorer.EXPECT().SelectOpenBillByStoreID(ctx, userID, uuid.Nil, store.StoreID).Return(nil, nil).Once()
+		mockBillStorer.EXPECT().Insert(ctx, mock.MatchedBy(func(bill *model.Bill) bool {
+			return bill.UserID == userID && bill.HouseholdID == nil && bill.StoreID == store.StoreID && bill.State == model.BillStateCreate
+		})).Run(func(ctx context.Context, bill *model.Bill) {
+			bill.BillID = billID
+		}).Return(nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, billID).Return(nil, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.StartBill(ctx, userID, uuid.Nil, store.StoreID)
+		require.NoError(t, err)
+		assert.Equal(t, billID, bill.BillID)
+	})
+
+	t.Run("household of an other user", func(t *testing.T) {
+		householdID := uuid.New()
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(nil, model.ErrHouseholdNotFoundError).Once()
+		bill, err := b.StartBill(ctx, userID, householdID, store.StoreID)
+		assert.ErrorIs(t, err, model.ErrHouseholdNotFoundError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("new bill of the household", func(t *testing.T) {
+		billID := uuid.New()
+		householdID := uuid.New()
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(&model.HouseholdMember{HouseholdID: householdID, UserID: userID}, nil).Once()
+		mockBillStorer.EXPECT().SelectOpenBillByStoreID(ctx, userID, householdID, store.StoreID).Return(nil, nil).Once()
+		mockBillStorer.EXPECT().Insert(ctx, mock.MatchedBy(func(bill *model.Bill) bool {
+			return bill.UserID == userID && bill.HouseholdID != nil && *bill.HouseholdID == householdID
+		})).Run(func(ctx context.Context, bill *model.Bill) {
+			bill.BillID = billID
+		}).Return(nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, billID).Return(nil, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.StartBill(ctx, userID, householdID, store.StoreID)
+		require.NoError(t, err)
+		assert.Equal(t, billID, bill.BillID)
+		assert.Equal(t, &householdID, bill.HouseholdID)
+	})
+}
+
+func TestBill_StartDatedBill(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, nil, nil, nil)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	company := &model.Company{CompanyID: store.CompanyID, CompanyName: "lidl"}
+	date := time.Date(2024, 3, 14, 10, 5, 0, 0, time.UTC)
+
+	t.Run("Insert error", func(t *testing.T) {
+		mockBillStorer.EXPECT().Insert(ctx, mock.Anything).Return(errors.New("random error")).Once()
+		bill, err := b.StartDatedBill(ctx, userID, store.StoreID, "CHF", date)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bill)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		billID := uuid.New()
+		// a new bill even when one is open in the store, dated at the purchase
+		mockBillStorer.EXPECT().Insert(ctx, mock.MatchedBy(func(bill *model.Bill) bool {
+			return bill.UserID == userID && bill.HouseholdID == nil && bill.StoreID == store.StoreID &&
+				bill.Amount.Currency == "CHF" && bill.CreatedAt.Equal(date)
+		})).Run(func(ctx context.Context, bill *model.Bill) {
+			bill.BillID = billID
+		}).Return(nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, billID).Return(nil, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		bill, err := b.StartDatedBill(ctx, userID, store.StoreID, "CHF", date)
+		require.NoError(t, err)
+		assert.Equal(t, billID, bill.BillID)
+	})
+}
+
+func TestBill_GetOpenBills(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	mockStoreStorer := NewBillStoreStorer(t)
+	mockCompanyStorer := NewBillCompanyStorer(t)
+	mockUserProductsStorer := NewBillUserProductsStorer(t)
+	mockAuthorizer := NewBillAuthorizer(t)
+	b := usecase.NewBill(mockBillStorer, mockStoreStorer, mockCompanyStorer, mockUserProductsStorer, nil, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	online := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	open := []*model.Bill{
+		{BillID: uuid.New(), StoreID: online.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCreate},
+		{BillID: uuid.New(), StoreID: store.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCreate},
+	}
+
+	t.Run("SelectOpenBillsByUserID error", func(t *testing.T) {
+		mockBillStorer.EXPECT().SelectOpenBillsByUserID(ctx, userID).Return(nil, errors.New("random error")).Once()
+		bills, err := b.GetOpenBills(ctx, userID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bills)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockBillStorer.EXPECT().SelectOpenBillsByUserID(ctx, userID).Return(open, nil).Once()
+		mockUserProductsStorer.EXPECT().SelectProductsByBillID(ctx, mock.Anything).Return([]*model.UserProduct{{Price: money("1.50"), Quantity: 2}}, nil).Twice()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, online.StoreID).Return(online, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, mock.Anything).Return(&model.Company{}, nil).Twice()
+		bills, err := b.GetOpenBills(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, bills, 2)
+		assert.Equal(t, open[0].BillID, bills[0].BillID)
+		assert.Equal(t, open[1].BillID, bills[1].BillID)
+		assert.Equal(t, "3.00 EUR", bills[1].ComputedAmount.String())
+	})
+
+	t.Run("GetOpenBill not open", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, billID).Return(nil, model.ErrBillNotOpenError).Once()
+		bill, err := b.GetOpenBill(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, bill)
+	})
+}
+
+func TestBill_GetDiscrepancies(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	b := usecase.NewBill(mockBillStorer, nil, nil, nil, nil, nil, nil)
+
+	userID := uuid.New()
+
+	t.Run("SelectDiscrepanciesByUserID error", func(t *testing.T) {
+		mockBillStorer.EXPECT().SelectDiscrepanciesByUserID(ctx, userID).Return(nil, errors.New("random error")).Once()
+		bills, err := b.GetDiscrepancies(ctx, userID)
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, bills)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := []*model.Bill{{BillID: uuid.New(), Amount: money("10"), ComputedAmount: money("8")}}
+		mockBillStorer.EXPECT().SelectDiscrepanciesByUserID(ctx, userID).Return(expected, nil).Once()
+		bills, err := b.GetDiscrepancies(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, bills)
+	})
+}
+
+func TestBill_GetBillsByUserID(t *testing.T) {
+	ctx := context.Background()
+	mockBillStorer := NewBillStorer(t)
+	b := usecase.NewBill(mockBillStorer, nil, nil, nil, nil, nil, nil)
+
+	userID := uuid.New()
+	now := time.Now().UTC()
+	bills := []*model.Bill{
+		{BillID: uuid.New(), CreatedAt: now},
+		{BillID: uuid.New(), CreatedAt: now.Add(-time.Hour)},
+		{BillID: uuid.New(), CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	t.Run("invalid range", func(t *testing.T) {
+		page, err := b.GetBillsByUserID(ctx, userID, &model.BillFilter{From: now, To: now.AddDate(0, 0, -1)})
+		assert.ErrorIs(t, err, model.ErrBillRangeError)
+		assert.Nil(t, page)
+	})
+
+	t.Run("cursor of an other sort", func(t *testing.T) {
+		filter := &model.BillFilter{Sort: model.BillSortAmount, Cursor: model.NewBillCursor(model.BillSortDate, bills[0])}
+		page, err := b.GetBillsByUserID(ctx, userID, filter)
+		assert.ErrorIs(t, err, model.ErrInvalidCursorError)
+		assert.Nil(t, page)
+	})
+
+	t.Run("GetBillsByUserID error", func(t *testing.T) {
+		mockBillStorer.EXPECT().GetBillsByUserID(ctx, userID, mock.Anything).Return(nil, errors.New("random error")).Once()
+		page, err := b.GetBillsByUserID(ctx, userID, &model.BillFilter{})
+		assert.ErrorIs(t, err, model.ErrBillError)
+		assert.Nil(t, page)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		mockBillStorer.EXPECT().GetBillsByUserID(ctx, userID, &model.BillFilter{Sort: model.BillSortDate, Order: model.BillOrderDesc, Limit: 3}).Return(bills, nil).Once()
+		page, err := b.GetBillsByUserID(ctx, userID, &model.BillFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, bills[:2], page.Bills)
+		cursor, err := model.DecodeBillCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, model.NewBillCursor(model.BillSortDate, bills[1]), cursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockBillStorer.EXPECT().GetBillsByUserID(ctx, userID, &model.BillFilter{Sort: model.BillSortDate, Order: model.BillOrderDesc, Limit: model.DefaultBillLimit + 1}).Return(bills, nil).Once()
+		page, err := b.GetBillsByUserID(ctx, userID, &model.BillFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, bills, page.Bills)
+		assert.Empty(t, page.NextCursor)
+	})
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+	"strings"
+	"time"
+)
+
+const householdInvitationTTL = 7 * 24 * time.Hour
+
+type HouseholdStorer interface {
+	Insert(ctx context.Context, household *model.Household) error
+	SelectHouseholdsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Household, error)
+	SelectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error)
+	UpdateMemberRole(ctx context.Context, householdID, userID uuid.UUID, role string) error
+	DeleteMember(ctx context.Context, householdID, userID uuid.UUID) error
+	InsertInvitation(ctx context.Context, invitation *model.HouseholdInvitation) error
+	SelectInvitationsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.HouseholdInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*model.HouseholdMember, error)
+	DeleteInvitation(ctx context.Context, userID, invitationID uuid.UUID) error
+	SelectSpending(ctx context.Context, householdID uuid.UUID, from, to time.Time) ([]*model.HouseholdSpending, error)
+}
+
+type HouseholdUserStorer interface {
+	GetUserByLogin(ctx context.Context, login string) (*model.User, error)
+}
+
+type HouseholdAuthorizer interface {
+	Household(ctx context.Context, userID, householdID uuid.UUID) (*model.HouseholdMember, error)
+}
+
+// Household manages the households and their members. Every member reads the household, only its owners
+// invite, change the roles and remove the other members. A household always keeps an owner while it has
+// other members.
+type Household struct {
+	HouseholdStorer     HouseholdStorer
+	HouseholdUserStorer HouseholdUserStorer
+	HouseholdAuthorizer HouseholdAuthorizer
+}
+
+func NewHousehold(hs HouseholdStorer, hus HouseholdUserStorer, ha HouseholdAuthorizer) *Household {
+	return &Household{
+		HouseholdStorer:     hs,
+		HouseholdUserStorer: hus,
+		HouseholdAuthorizer: ha,
+	}
+}
+
+// Create returns the new household, the user is its owner.
+func (h *Household) Create(ctx context.Context, userID uuid.UUID, name string) (*model.Household, error) {
+	household := &model.Household{
+		Name:      strings.TrimSpace(name),
+		CreatedBy: userID,
+		Role:      model.HouseholdRoleOwner,
+	}
+	if household.Name == "" {
+		return nil, model.ErrInvalidHouseholdNameError
+	}
+
+	if err := h.HouseholdStorer.Insert(ctx, household); err != nil {
+		log.Error().Caller().Err(err).Msg("Create.Insert")
+		return nil, model.ErrHouseholdError
+	}
+	return household, nil
+}
+
+func (h *Household) GetHouseholds(ctx context.Context, userID uuid.UUID) ([]*model.Household, error) {
+	households, err := h.HouseholdStorer.SelectHouseholdsByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetHouseholds.SelectHouseholdsByUserID")
+		return nil, model.ErrHouseholdError
+	}
+	return households, nil
+}
+
+func (h *Household) GetMembers(ctx context.Context, userID, householdID uuid.UUID) ([]*model.HouseholdMember, error) {
+	if _, err := h.HouseholdAuthorizer.Household(ctx, userID, householdID); err != nil {
+		return nil, err
+	}
+	return h.selectMembers(ctx, householdID)
+}
+
+// Invite invites the user of the login with the role, an invitation still pending is renewed.
+func (h *Household) Invite(ctx context.Context, userID, householdID uuid.UUID, login, role string) (*model.HouseholdInvitation, error) {
+	if role == "" {
+		role = model.HouseholdRoleMember
+	}
+	if !model.IsValidHouseholdRole(role) {
+		return nil, model.ErrInvalidHouseholdRoleError
+	}
+	if _, err := h.owner(ctx, userID, householdID); err != nil {
+		return nil, err
+	}
+
+	invited, err := h.HouseholdUserStorer.GetUserByLogin(ctx, login)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Invite.GetUserByLogin")
+		return nil, model.ErrHouseholdError
+	}
+	if invited == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	members, err := h.selectMembers(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	if findHouseholdMember(members, invited.ID) != nil {
+		return nil, model.ErrHouseholdMemberExistsError
+	}
+
+	invitation := &model.HouseholdInvitation{
+		HouseholdID: householdID,
+		UserID:      invited.ID,
+		InvitedBy:   userID,
+		Role:        role,
+		ExpiresAt:   time.Now().UTC().Add(householdInvitationTTL),
+	}
+	if err = h.HouseholdStorer.InsertInvitation(ctx, invitation); err != nil {
+		log.Error().Caller().Err(err).Msg("Invite.InsertInvitation")
+		return nil, model.ErrHouseholdError
+	}
+	return invitation, nil
+}
+
+// GetInvitations returns the pending invitations of the user.
+func (h *Household) GetInvitations(ctx context.Context, userID uuid.UUID) ([]*model.HouseholdInvitation, error) {
+	invitations, err := h.HouseholdStorer.SelectInvitationsByUserID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetInvitations.SelectInvitationsByUserID")
+		return nil, model.ErrHouseholdError
+	}
+	return invitations, nil
+}
+
+func (h *Household) AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*model.HouseholdMember, error) {
+	member, err := h.HouseholdStorer.AcceptInvitation(ctx, userID, invitationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrHouseholdInvitationNotFoundError
+		}
+		log.Error().Caller().Err(err).Msg("AcceptInvitation.AcceptInvitation")
+		return nil, model.ErrHouseholdError
+	}
+	return member, nil
+}
+
+func (h *Household) DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) error {
+	if err := h.HouseholdStorer.DeleteInvitation(ctx, userID, invitationID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrHouseholdInvitationNotFoundError
+		}
+		log.Error().Caller().Err(err).Msg("DeclineInvitation.DeleteInvitation")
+		return model.ErrHouseholdError
+	}
+	return nil
+}
+
+// SetMemberRole changes the role of a member, the last owner is not demoted.
+func (h *Household) SetMemberRole(ctx context.Context, userID, householdID, memberID uuid.UUID, role string) error {
+	if !model.IsValidHouseholdRole(role) {
+		return model.ErrInvalidHouseholdRoleError
+	}
+	if _, err := h.owner(ctx, userID, householdID); err != nil {
+		return err
+	}
+
+	members, err := h.selectMembers(ctx, householdID)
+	if err != nil {
+		return err
+	}
+	member := findHouseholdMember(members, memberID)
+	if member == nil {
+		return model.ErrHouseholdMemberNotFoundError
+	}
+	if member.IsOwner() && role != model.HouseholdRoleOwner && countHouseholdOwners(members) == 1 {
+		return model.ErrHouseholdLastOwnerError
+	}
+
+	if err = h.HouseholdStorer.UpdateMemberRole(ctx, householdID, memberID, role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrHouseholdMemberNotFoundError
+		}
+		log.Error().Caller().Err(err).Msg("SetMemberRole.UpdateMemberRole")
+		return model.ErrHouseholdError
+	}
+	return nil
+}
+
+// RemoveMember removes a member, a member removing itself leaves the household. The last owner only leaves
+// a household without other members. The bills the member started for the household stay in it.
+func (h *Household) RemoveMember(ctx context.Context, userID, householdID, memberID uuid.UUID) error {
+	current, err := h.HouseholdAuthorizer.Household(ctx, userID, householdID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !current.IsOwner() {
+		return model.ErrHouseholdOwnerRequiredError
+	}
+
+	members, err := h.selectMembers(ctx, householdID)
+	if err != nil {
+		return err
+	}
+	member := findHouseholdMember(members, memberID)
+	if member == nil {
+		return model.ErrHouseholdMemberNotFoundError
+	}
+	if member.IsOwner() && countHouseholdOwners(members) == 1 && len(members) > 1 {
+		return model.ErrHouseholdLastOwnerError
+	}
+
+	if err = h.HouseholdStorer.DeleteMember(ctx, householdID, memberID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrHouseholdMemberNotFoundError
+		}
+		log.Error().Caller().Err(err).Msg("RemoveMember.DeleteMember")
+		return model.ErrHouseholdError
+	}
+	return nil
+}
+
+// GetSpending returns what each member spent on the closed bills of the household between the days from
+// and to, both included and optional.
+func (h *Household) GetSpending(ctx context.Context, userID, householdID uuid.UUID, from, to time.Time) ([]*model.HouseholdSpending, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, model.ErrBillRangeError
+	}
+	if _, err := h.HouseholdAuthorizer.Household(ctx, userID, householdID); err != nil {
+		return nil, err
+	}
+
+	spending, err := h.HouseholdStorer.SelectSpending(ctx, householdID, from, to)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("GetSpending.SelectSpending")
+		return nil, model.ErrHouseholdError
+	}
+	return spending, nil
+}
+
+// owner returns the membership of the user, ErrHouseholdOwnerRequiredError when it is not an owner.
+func (h *Household) owner(ctx context.Context, userID, householdID uuid.UUID) (*model.HouseholdMember, error) {
+	member, err := h.HouseholdAuthorizer.Household(ctx, userID, householdID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsOwner() {
+		return nil, model.ErrHouseholdOwnerRequiredError
+	}
+	return member, nil
+}
+
+func (h *Household) selectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error) {
+	members, err := h.HouseholdStorer.SelectMembers(ctx, householdID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("selectMembers.SelectMembers")
+		return nil, model.ErrHouseholdError
+	}
+	return members, nil
+}
+
+func findHouseholdMember(members []*model.HouseholdMember, userID uuid.UUID) *model.HouseholdMember {
+	for _, member := range members {
+		if member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+func countHouseholdOwners(members []*model.HouseholdMember) int {
+	owners := 0
+	for _, member := range members {
+		if member.IsOwner() {
+			owners++
+		}
+	}
+	return owners
+}
@@ -0,0 +1,220 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestHousehold_Create(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	h := usecase.NewHousehold(mockStorer, nil, nil)
+
+	userID := uuid.New()
+
+	t.Run("empty name", func(t *testing.T) {
+		_, err := h.Create(ctx, userID, "  ")
+		assert.ErrorIs(t, err, model.ErrInvalidHouseholdNameError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockStorer.EXPECT().Insert(ctx, mock.MatchedBy(func(household *model.Household) bool {
+			return household.Name == "home" && household.CreatedBy == userID
+		})).Return(nil).Once()
+		household, err := h.Create(ctx, userID, " home ")
+		require.NoError(t, err)
+		assert.Equal(t, model.HouseholdRoleOwner, household.Role)
+	})
+}
+
+func TestHousehold_Invite(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	mockUserStorer := NewHouseholdUserStorer(t)
+	mockAuthorizer := NewHouseholdAuthorizer(t)
+	h := usecase.NewHousehold(mockStorer, mockUserStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	householdID := uuid.New()
+	owner := &model.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: model.HouseholdRoleOwner}
+	bob := &model.User{ID: uuid.New(), Login: "bob"}
+
+	t.Run("invalid role", func(t *testing.T) {
+		_, err := h.Invite(ctx, userID, householdID, "bob", "admin")
+		assert.ErrorIs(t, err, model.ErrInvalidHouseholdRoleError)
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(&model.HouseholdMember{Role: model.HouseholdRoleMember}, nil).Once()
+		_, err := h.Invite(ctx, userID, householdID, "bob", "")
+		assert.ErrorIs(t, err, model.ErrHouseholdOwnerRequiredError)
+	})
+
+	t.Run("unknown login", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(owner, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, "nobody").Return(nil, nil).Once()
+		_, err := h.Invite(ctx, userID, householdID, "nobody", "")
+		assert.ErrorIs(t, err, model.ErrUserNotFound)
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(owner, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, "bob").Return(bob, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, {UserID: bob.ID}}, nil).Once()
+		_, err := h.Invite(ctx, userID, householdID, "bob", "")
+		assert.ErrorIs(t, err, model.ErrHouseholdMemberExistsError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(owner, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, "bob").Return(bob, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner}, nil).Once()
+		mockStorer.EXPECT().InsertInvitation(ctx, mock.Anything).Return(nil).Once()
+
+		invitation, err := h.Invite(ctx, userID, householdID, "bob", "")
+		require.NoError(t, err)
+		assert.Equal(t, bob.ID, invitation.UserID)
+		assert.Equal(t, model.HouseholdRoleMember, invitation.Role)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), invitation.ExpiresAt, time.Minute)
+	})
+}
+
+func TestHousehold_AcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	h := usecase.NewHousehold(mockStorer, nil, nil)
+
+	userID := uuid.New()
+	invitationID := uuid.New()
+
+	t.Run("unknown invitation", func(t *testing.T) {
+		mockStorer.EXPECT().AcceptInvitation(ctx, userID, invitationID).Return(nil, pgx.ErrNoRows).Once()
+		_, err := h.AcceptInvitation(ctx, userID, invitationID)
+		assert.ErrorIs(t, err, model.ErrHouseholdInvitationNotFoundError)
+	})
+
+	t.Run("AcceptInvitation error", func(t *testing.T) {
+		mockStorer.EXPECT().AcceptInvitation(ctx, userID, invitationID).Return(nil, errors.New("random error")).Once()
+		_, err := h.AcceptInvitation(ctx, userID, invitationID)
+		assert.ErrorIs(t, err, model.ErrHouseholdError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		member := &model.HouseholdMember{UserID: userID, Role: model.HouseholdRoleMember}
+		mockStorer.EXPECT().AcceptInvitation(ctx, userID, invitationID).Return(member, nil).Once()
+		got, err := h.AcceptInvitation(ctx, userID, invitationID)
+		require.NoError(t, err)
+		assert.Equal(t, member, got)
+	})
+}
+
+func TestHousehold_SetMemberRole(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	mockAuthorizer := NewHouseholdAuthorizer(t)
+	h := usecase.NewHousehold(mockStorer, nil, mockAuthorizer)
+
+	householdID := uuid.New()
+	owner := &model.HouseholdMember{HouseholdID: householdID, UserID: uuid.New(), Role: model.HouseholdRoleOwner}
+	member := &model.HouseholdMember{HouseholdID: householdID, UserID: uuid.New(), Role: model.HouseholdRoleMember}
+
+	t.Run("last owner", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, owner.UserID, householdID).Return(owner, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, member}, nil).Once()
+		err := h.SetMemberRole(ctx, owner.UserID, householdID, owner.UserID, model.HouseholdRoleMember)
+		assert.ErrorIs(t, err, model.ErrHouseholdLastOwnerError)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, owner.UserID, householdID).Return(owner, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, member}, nil).Once()
+		err := h.SetMemberRole(ctx, owner.UserID, householdID, uuid.New(), model.HouseholdRoleOwner)
+		assert.ErrorIs(t, err, model.ErrHouseholdMemberNotFoundError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, owner.UserID, householdID).Return(owner, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, member}, nil).Once()
+		mockStorer.EXPECT().UpdateMemberRole(ctx, householdID, member.UserID, model.HouseholdRoleOwner).Return(nil).Once()
+		require.NoError(t, h.SetMemberRole(ctx, owner.UserID, householdID, member.UserID, model.HouseholdRoleOwner))
+	})
+}
+
+func TestHousehold_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	mockAuthorizer := NewHouseholdAuthorizer(t)
+	h := usecase.NewHousehold(mockStorer, nil, mockAuthorizer)
+
+	householdID := uuid.New()
+	owner := &model.HouseholdMember{HouseholdID: householdID, UserID: uuid.New(), Role: model.HouseholdRoleOwner}
+	member := &model.HouseholdMember{HouseholdID: householdID, UserID: uuid.New(), Role: model.HouseholdRoleMember}
+
+	t.Run("member removes an other member", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, member.UserID, householdID).Return(member, nil).Once()
+		err := h.RemoveMember(ctx, member.UserID, householdID, owner.UserID)
+		assert.ErrorIs(t, err, model.ErrHouseholdOwnerRequiredError)
+	})
+
+	t.Run("member leaves", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, member.UserID, householdID).Return(member, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, member}, nil).Once()
+		mockStorer.EXPECT().DeleteMember(ctx, householdID, member.UserID).Return(nil).Once()
+		require.NoError(t, h.RemoveMember(ctx, member.UserID, householdID, member.UserID))
+	})
+
+	t.Run("last owner leaves with other members", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, owner.UserID, householdID).Return(owner, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner, member}, nil).Once()
+		err := h.RemoveMember(ctx, owner.UserID, householdID, owner.UserID)
+		assert.ErrorIs(t, err, model.ErrHouseholdLastOwnerError)
+	})
+
+	t.Run("last member leaves", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, owner.UserID, householdID).Return(owner, nil).Once()
+		mockStorer.EXPECT().SelectMembers(ctx, householdID).Return([]*model.HouseholdMember{owner}, nil).Once()
+		mockStorer.EXPECT().DeleteMember(ctx, householdID, owner.UserID).Return(nil).Once()
+		require.NoError(t, h.RemoveMember(ctx, owner.UserID, householdID, owner.UserID))
+	})
+}
+
+func TestHousehold_GetSpending(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewHouseholdStorer(t)
+	mockAuthorizer := NewHouseholdAuthorizer(t)
+	h := usecase.NewHousehold(mockStorer, nil, mockAuthorizer)
+
+	userID := uuid.New()
+	householdID := uuid.New()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := h.GetSpending(ctx, userID, householdID, to, from)
+		assert.ErrorIs(t, err, model.ErrBillRangeError)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(nil, model.ErrHouseholdNotFoundError).Once()
+		_, err := h.GetSpending(ctx, userID, householdID, from, to)
+		assert.ErrorIs(t, err, model.ErrHouseholdNotFoundError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := []*model.HouseholdSpending{{UserID: userID, Login: "alice", Amount: model.ZeroMoney(model.DefaultCurrency), Bills: 2}}
+		mockAuthorizer.EXPECT().Household(ctx, userID, householdID).Return(&model.HouseholdMember{}, nil).Once()
+		mockStorer.EXPECT().SelectSpending(ctx, householdID, from, to).Return(expected, nil).Once()
+		spending, err := h.GetSpending(ctx, userID, householdID, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, expected, spending)
+	})
+}
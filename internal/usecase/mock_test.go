@@ -4,33 +4,39 @@ import (
 	context "context"
 	uuid "github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
+	io "io"
+	mailer "shop-aggregator/internal/mailer"
 	model "shop-aggregator/internal/model"
+	response "shop-aggregator/internal/model/response"
+	oidc "shop-aggregator/internal/oidc"
+	time "time"
 )
 
-// AuthStorer is an autogenerated mock type for the AuthStorer type
-type AuthStorer struct {
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+// APIKeyStorer is an autogenerated mock type for the APIKeyStorer type
+type APIKeyStorer struct {
 	mock.Mock
 }
 
-type AuthStorer_Expecter struct {
+type APIKeyStorer_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *AuthStorer) EXPECT() *AuthStorer_Expecter {
-	return &AuthStorer_Expecter{mock: &_m.Mock}
+func (_m *APIKeyStorer) EXPECT() *APIKeyStorer_Expecter {
+	return &APIKeyStorer_Expecter{mock: &_m.Mock}
 }
 
-// Logout provides a mock function with given fields: _a0, _a1
-func (_m *AuthStorer) Logout(_a0 context.Context, _a1 uuid.UUID) error {
-	ret := _m.Called(_a0, _a1)
+// Insert provides a mock function with given fields: ctx, apiKey
+func (_m *APIKeyStorer) Insert(ctx context.Context, apiKey *model.APIKey) error {
+	ret := _m.Called(ctx, apiKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Logout")
+		panic("no return value specified for Insert")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.APIKey) error); ok {
+		r0 = rf(ctx, apiKey)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -38,46 +44,46 @@ func (_m *AuthStorer) Logout(_a0 context.Context, _a1 uuid.UUID) error {
 	return r0
 }
 
-// AuthStorer_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
-type AuthStorer_Logout_Call struct {
+// APIKeyStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type APIKeyStorer_Insert_Call struct {
 	*mock.Call
 }
 
-// Logout is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 uuid.UUID
-func (_e *AuthStorer_Expecter) Logout(_a0 interface{}, _a1 interface{}) *AuthStorer_Logout_Call {
-	return &AuthStorer_Logout_Call{Call: _e.mock.On("Logout", _a0, _a1)}
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey *model.APIKey
+func (_e *APIKeyStorer_Expecter) Insert(ctx interface{}, apiKey interface{}) *APIKeyStorer_Insert_Call {
+	return &APIKeyStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, apiKey)}
 }
 
-func (_c *AuthStorer_Logout_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID)) *AuthStorer_Logout_Call {
+func (_c *APIKeyStorer_Insert_Call) Run(run func(ctx context.Context, apiKey *model.APIKey)) *APIKeyStorer_Insert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*model.APIKey))
 	})
 	return _c
 }
 
-func (_c *AuthStorer_Logout_Call) Return(_a0 error) *AuthStorer_Logout_Call {
+func (_c *APIKeyStorer_Insert_Call) Return(_a0 error) *APIKeyStorer_Insert_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AuthStorer_Logout_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AuthStorer_Logout_Call {
+func (_c *APIKeyStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.APIKey) error) *APIKeyStorer_Insert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Upsert provides a mock function with given fields: _a0, _a1, _a2
-func (_m *AuthStorer) Upsert(_a0 context.Context, _a1 uuid.UUID, _a2 string) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// Revoke provides a mock function with given fields: ctx, userID, apiKeyID
+func (_m *APIKeyStorer) Revoke(ctx context.Context, userID uuid.UUID, apiKeyID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, apiKeyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Upsert")
+		panic("no return value specified for Revoke")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, apiKeyID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,43 +91,102 @@ func (_m *AuthStorer) Upsert(_a0 context.Context, _a1 uuid.UUID, _a2 string) err
 	return r0
 }
 
-// AuthStorer_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
-type AuthStorer_Upsert_Call struct {
+// APIKeyStorer_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type APIKeyStorer_Revoke_Call struct {
 	*mock.Call
 }
 
-// Upsert is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 uuid.UUID
-//   - _a2 string
-func (_e *AuthStorer_Expecter) Upsert(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthStorer_Upsert_Call {
-	return &AuthStorer_Upsert_Call{Call: _e.mock.On("Upsert", _a0, _a1, _a2)}
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - apiKeyID uuid.UUID
+func (_e *APIKeyStorer_Expecter) Revoke(ctx interface{}, userID interface{}, apiKeyID interface{}) *APIKeyStorer_Revoke_Call {
+	return &APIKeyStorer_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, apiKeyID)}
 }
 
-func (_c *AuthStorer_Upsert_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID, _a2 string)) *AuthStorer_Upsert_Call {
+func (_c *APIKeyStorer_Revoke_Call) Run(run func(ctx context.Context, userID uuid.UUID, apiKeyID uuid.UUID)) *APIKeyStorer_Revoke_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *AuthStorer_Upsert_Call) Return(_a0 error) *AuthStorer_Upsert_Call {
+func (_c *APIKeyStorer_Revoke_Call) Return(_a0 error) *APIKeyStorer_Revoke_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AuthStorer_Upsert_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *AuthStorer_Upsert_Call {
+func (_c *APIKeyStorer_Revoke_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *APIKeyStorer_Revoke_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewAuthStorer creates a new instance of AuthStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// SelectAPIKeysByUserID provides a mock function with given fields: ctx, userID
+func (_m *APIKeyStorer) SelectAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]*model.APIKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectAPIKeysByUserID")
+	}
+
+	var r0 []*model.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.APIKey, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.APIKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyStorer_SelectAPIKeysByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectAPIKeysByUserID'
+type APIKeyStorer_SelectAPIKeysByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectAPIKeysByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *APIKeyStorer_Expecter) SelectAPIKeysByUserID(ctx interface{}, userID interface{}) *APIKeyStorer_SelectAPIKeysByUserID_Call {
+	return &APIKeyStorer_SelectAPIKeysByUserID_Call{Call: _e.mock.On("SelectAPIKeysByUserID", ctx, userID)}
+}
+
+func (_c *APIKeyStorer_SelectAPIKeysByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *APIKeyStorer_SelectAPIKeysByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *APIKeyStorer_SelectAPIKeysByUserID_Call) Return(_a0 []*model.APIKey, _a1 error) *APIKeyStorer_SelectAPIKeysByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyStorer_SelectAPIKeysByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.APIKey, error)) *APIKeyStorer_SelectAPIKeysByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyStorer creates a new instance of APIKeyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewAuthStorer(t interface {
+func NewAPIKeyStorer(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *AuthStorer {
-	mock := &AuthStorer{}
+}) *APIKeyStorer {
+	mock := &APIKeyStorer{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -129,44 +194,8755 @@ func NewAuthStorer(t interface {
 	return mock
 }
 
-// Code generated by mockery v2.42.2. DO NOT EDIT.
+// AccountSessionStorer is an autogenerated mock type for the AccountSessionStorer type
+type AccountSessionStorer struct {
+	mock.Mock
+}
 
-// AuthUserStorer is an autogenerated mock type for the AuthUserStorer type
-type AuthUserStorer struct {
+type AccountSessionStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountSessionStorer) EXPECT() *AccountSessionStorer_Expecter {
+	return &AccountSessionStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectSessionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *AccountSessionStorer) SelectSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSessionsByUserID")
+	}
+
+	var r0 []*model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountSessionStorer_SelectSessionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSessionsByUserID'
+type AccountSessionStorer_SelectSessionsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectSessionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AccountSessionStorer_Expecter) SelectSessionsByUserID(ctx interface{}, userID interface{}) *AccountSessionStorer_SelectSessionsByUserID_Call {
+	return &AccountSessionStorer_SelectSessionsByUserID_Call{Call: _e.mock.On("SelectSessionsByUserID", ctx, userID)}
+}
+
+func (_c *AccountSessionStorer_SelectSessionsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AccountSessionStorer_SelectSessionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountSessionStorer_SelectSessionsByUserID_Call) Return(_a0 []*model.Session, _a1 error) *AccountSessionStorer_SelectSessionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountSessionStorer_SelectSessionsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Session, error)) *AccountSessionStorer_SelectSessionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountSessionStorer creates a new instance of AccountSessionStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountSessionStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountSessionStorer {
+	mock := &AccountSessionStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AccountStorer is an autogenerated mock type for the AccountStorer type
+type AccountStorer struct {
 	mock.Mock
 }
 
-type AuthUserStorer_Expecter struct {
+type AccountStorer_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *AuthUserStorer) EXPECT() *AuthUserStorer_Expecter {
-	return &AuthUserStorer_Expecter{mock: &_m.Mock}
+func (_m *AccountStorer) EXPECT() *AccountStorer_Expecter {
+	return &AccountStorer_Expecter{mock: &_m.Mock}
 }
 
-// GetUserByLogin provides a mock function with given fields: _a0, _a1
-func (_m *AuthUserStorer) GetUserByLogin(_a0 context.Context, _a1 string) (*model.User, error) {
-	ret := _m.Called(_a0, _a1)
+// CancelDeletion provides a mock function with given fields: ctx, userID
+func (_m *AccountStorer) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccountStorer_CancelDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletion'
+type AccountStorer_CancelDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AccountStorer_Expecter) CancelDeletion(ctx interface{}, userID interface{}) *AccountStorer_CancelDeletion_Call {
+	return &AccountStorer_CancelDeletion_Call{Call: _e.mock.On("CancelDeletion", ctx, userID)}
+}
+
+func (_c *AccountStorer_CancelDeletion_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AccountStorer_CancelDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountStorer_CancelDeletion_Call) Return(_a0 error) *AccountStorer_CancelDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountStorer_CancelDeletion_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AccountStorer_CancelDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, userID, pseudonymID
+func (_m *AccountStorer) DeleteAccount(ctx context.Context, userID uuid.UUID, pseudonymID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, pseudonymID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, pseudonymID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccountStorer_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type AccountStorer_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - pseudonymID uuid.UUID
+func (_e *AccountStorer_Expecter) DeleteAccount(ctx interface{}, userID interface{}, pseudonymID interface{}) *AccountStorer_DeleteAccount_Call {
+	return &AccountStorer_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, userID, pseudonymID)}
+}
+
+func (_c *AccountStorer_DeleteAccount_Call) Run(run func(ctx context.Context, userID uuid.UUID, pseudonymID uuid.UUID)) *AccountStorer_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountStorer_DeleteAccount_Call) Return(_a0 error) *AccountStorer_DeleteAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountStorer_DeleteAccount_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AccountStorer_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleDeletion provides a mock function with given fields: ctx, userID, at
+func (_m *AccountStorer) ScheduleDeletion(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	ret := _m.Called(ctx, userID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = rf(ctx, userID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccountStorer_ScheduleDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleDeletion'
+type AccountStorer_ScheduleDeletion_Call struct {
+	*mock.Call
+}
+
+// ScheduleDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - at time.Time
+func (_e *AccountStorer_Expecter) ScheduleDeletion(ctx interface{}, userID interface{}, at interface{}) *AccountStorer_ScheduleDeletion_Call {
+	return &AccountStorer_ScheduleDeletion_Call{Call: _e.mock.On("ScheduleDeletion", ctx, userID, at)}
+}
+
+func (_c *AccountStorer_ScheduleDeletion_Call) Run(run func(ctx context.Context, userID uuid.UUID, at time.Time)) *AccountStorer_ScheduleDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AccountStorer_ScheduleDeletion_Call) Return(_a0 error) *AccountStorer_ScheduleDeletion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AccountStorer_ScheduleDeletion_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) error) *AccountStorer_ScheduleDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectBills provides a mock function with given fields: ctx, userID
+func (_m *AccountStorer) SelectBills(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectBills")
+	}
+
+	var r0 []*model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Bill, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Bill); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountStorer_SelectBills_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectBills'
+type AccountStorer_SelectBills_Call struct {
+	*mock.Call
+}
+
+// SelectBills is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AccountStorer_Expecter) SelectBills(ctx interface{}, userID interface{}) *AccountStorer_SelectBills_Call {
+	return &AccountStorer_SelectBills_Call{Call: _e.mock.On("SelectBills", ctx, userID)}
+}
+
+func (_c *AccountStorer_SelectBills_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AccountStorer_SelectBills_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountStorer_SelectBills_Call) Return(_a0 []*model.Bill, _a1 error) *AccountStorer_SelectBills_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountStorer_SelectBills_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Bill, error)) *AccountStorer_SelectBills_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectDueDeletions provides a mock function with given fields: ctx, now
+func (_m *AccountStorer) SelectDueDeletions(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectDueDeletions")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountStorer_SelectDueDeletions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectDueDeletions'
+type AccountStorer_SelectDueDeletions_Call struct {
+	*mock.Call
+}
+
+// SelectDueDeletions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *AccountStorer_Expecter) SelectDueDeletions(ctx interface{}, now interface{}) *AccountStorer_SelectDueDeletions_Call {
+	return &AccountStorer_SelectDueDeletions_Call{Call: _e.mock.On("SelectDueDeletions", ctx, now)}
+}
+
+func (_c *AccountStorer_SelectDueDeletions_Call) Run(run func(ctx context.Context, now time.Time)) *AccountStorer_SelectDueDeletions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AccountStorer_SelectDueDeletions_Call) Return(_a0 []uuid.UUID, _a1 error) *AccountStorer_SelectDueDeletions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountStorer_SelectDueDeletions_Call) RunAndReturn(run func(context.Context, time.Time) ([]uuid.UUID, error)) *AccountStorer_SelectDueDeletions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountStorer creates a new instance of AccountStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountStorer {
+	mock := &AccountStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AccountUserProductStorer is an autogenerated mock type for the AccountUserProductStorer type
+type AccountUserProductStorer struct {
+	mock.Mock
+}
+
+type AccountUserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountUserProductStorer) EXPECT() *AccountUserProductStorer_Expecter {
+	return &AccountUserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectProductsByUserID provides a mock function with given fields: ctx, userID
+func (_m *AccountUserProductStorer) SelectProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByUserID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountUserProductStorer_SelectProductsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByUserID'
+type AccountUserProductStorer_SelectProductsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AccountUserProductStorer_Expecter) SelectProductsByUserID(ctx interface{}, userID interface{}) *AccountUserProductStorer_SelectProductsByUserID_Call {
+	return &AccountUserProductStorer_SelectProductsByUserID_Call{Call: _e.mock.On("SelectProductsByUserID", ctx, userID)}
+}
+
+func (_c *AccountUserProductStorer_SelectProductsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AccountUserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountUserProductStorer_SelectProductsByUserID_Call) Return(_a0 []*model.UserProduct, _a1 error) *AccountUserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountUserProductStorer_SelectProductsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *AccountUserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountUserProductStorer creates a new instance of AccountUserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountUserProductStorer {
+	mock := &AccountUserProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AccountUserStorer is an autogenerated mock type for the AccountUserStorer type
+type AccountUserStorer struct {
+	mock.Mock
+}
+
+type AccountUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AccountUserStorer) EXPECT() *AccountUserStorer_Expecter {
+	return &AccountUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *AccountUserStorer) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccountUserStorer_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type AccountUserStorer_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *AccountUserStorer_Expecter) GetUserByID(ctx interface{}, id interface{}) *AccountUserStorer_GetUserByID_Call {
+	return &AccountUserStorer_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *AccountUserStorer_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *AccountUserStorer_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AccountUserStorer_GetUserByID_Call) Return(_a0 *model.User, _a1 error) *AccountUserStorer_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AccountUserStorer_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *AccountUserStorer_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAccountUserStorer creates a new instance of AccountUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAccountUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AccountUserStorer {
+	mock := &AccountUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminBrandStorer is an autogenerated mock type for the AdminBrandStorer type
+type AdminBrandStorer struct {
+	mock.Mock
+}
+
+type AdminBrandStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminBrandStorer) EXPECT() *AdminBrandStorer_Expecter {
+	return &AdminBrandStorer_Expecter{mock: &_m.Mock}
+}
+
+// Merge provides a mock function with given fields: ctx, sourceID, targetID
+func (_m *AdminBrandStorer) Merge(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, sourceID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminBrandStorer_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type AdminBrandStorer_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID uuid.UUID
+//   - targetID uuid.UUID
+func (_e *AdminBrandStorer_Expecter) Merge(ctx interface{}, sourceID interface{}, targetID interface{}) *AdminBrandStorer_Merge_Call {
+	return &AdminBrandStorer_Merge_Call{Call: _e.mock.On("Merge", ctx, sourceID, targetID)}
+}
+
+func (_c *AdminBrandStorer_Merge_Call) Run(run func(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID)) *AdminBrandStorer_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminBrandStorer_Merge_Call) Return(_a0 error) *AdminBrandStorer_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminBrandStorer_Merge_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AdminBrandStorer_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectBrandByName provides a mock function with given fields: ctx, name
+func (_m *AdminBrandStorer) SelectBrandByName(ctx context.Context, name string) (*model.Brand, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectBrandByName")
+	}
+
+	var r0 *model.Brand
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Brand, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Brand); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Brand)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminBrandStorer_SelectBrandByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectBrandByName'
+type AdminBrandStorer_SelectBrandByName_Call struct {
+	*mock.Call
+}
+
+// SelectBrandByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *AdminBrandStorer_Expecter) SelectBrandByName(ctx interface{}, name interface{}) *AdminBrandStorer_SelectBrandByName_Call {
+	return &AdminBrandStorer_SelectBrandByName_Call{Call: _e.mock.On("SelectBrandByName", ctx, name)}
+}
+
+func (_c *AdminBrandStorer_SelectBrandByName_Call) Run(run func(ctx context.Context, name string)) *AdminBrandStorer_SelectBrandByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminBrandStorer_SelectBrandByName_Call) Return(_a0 *model.Brand, _a1 error) *AdminBrandStorer_SelectBrandByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminBrandStorer_SelectBrandByName_Call) RunAndReturn(run func(context.Context, string) (*model.Brand, error)) *AdminBrandStorer_SelectBrandByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, brand
+func (_m *AdminBrandStorer) Update(ctx context.Context, brand *model.Brand) error {
+	ret := _m.Called(ctx, brand)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Brand) error); ok {
+		r0 = rf(ctx, brand)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminBrandStorer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AdminBrandStorer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - brand *model.Brand
+func (_e *AdminBrandStorer_Expecter) Update(ctx interface{}, brand interface{}) *AdminBrandStorer_Update_Call {
+	return &AdminBrandStorer_Update_Call{Call: _e.mock.On("Update", ctx, brand)}
+}
+
+func (_c *AdminBrandStorer_Update_Call) Run(run func(ctx context.Context, brand *model.Brand)) *AdminBrandStorer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Brand))
+	})
+	return _c
+}
+
+func (_c *AdminBrandStorer_Update_Call) Return(_a0 error) *AdminBrandStorer_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminBrandStorer_Update_Call) RunAndReturn(run func(context.Context, *model.Brand) error) *AdminBrandStorer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminBrandStorer creates a new instance of AdminBrandStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminBrandStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminBrandStorer {
+	mock := &AdminBrandStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminCompanyStorer is an autogenerated mock type for the AdminCompanyStorer type
+type AdminCompanyStorer struct {
+	mock.Mock
+}
+
+type AdminCompanyStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminCompanyStorer) EXPECT() *AdminCompanyStorer_Expecter {
+	return &AdminCompanyStorer_Expecter{mock: &_m.Mock}
+}
+
+// Merge provides a mock function with given fields: ctx, sourceID, targetID
+func (_m *AdminCompanyStorer) Merge(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, sourceID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminCompanyStorer_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type AdminCompanyStorer_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID uuid.UUID
+//   - targetID uuid.UUID
+func (_e *AdminCompanyStorer_Expecter) Merge(ctx interface{}, sourceID interface{}, targetID interface{}) *AdminCompanyStorer_Merge_Call {
+	return &AdminCompanyStorer_Merge_Call{Call: _e.mock.On("Merge", ctx, sourceID, targetID)}
+}
+
+func (_c *AdminCompanyStorer_Merge_Call) Run(run func(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID)) *AdminCompanyStorer_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminCompanyStorer_Merge_Call) Return(_a0 error) *AdminCompanyStorer_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminCompanyStorer_Merge_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AdminCompanyStorer_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectCompanyByName provides a mock function with given fields: ctx, name
+func (_m *AdminCompanyStorer) SelectCompanyByName(ctx context.Context, name string) (*model.Company, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectCompanyByName")
+	}
+
+	var r0 *model.Company
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Company, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Company); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Company)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminCompanyStorer_SelectCompanyByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCompanyByName'
+type AdminCompanyStorer_SelectCompanyByName_Call struct {
+	*mock.Call
+}
+
+// SelectCompanyByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *AdminCompanyStorer_Expecter) SelectCompanyByName(ctx interface{}, name interface{}) *AdminCompanyStorer_SelectCompanyByName_Call {
+	return &AdminCompanyStorer_SelectCompanyByName_Call{Call: _e.mock.On("SelectCompanyByName", ctx, name)}
+}
+
+func (_c *AdminCompanyStorer_SelectCompanyByName_Call) Run(run func(ctx context.Context, name string)) *AdminCompanyStorer_SelectCompanyByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminCompanyStorer_SelectCompanyByName_Call) Return(_a0 *model.Company, _a1 error) *AdminCompanyStorer_SelectCompanyByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminCompanyStorer_SelectCompanyByName_Call) RunAndReturn(run func(context.Context, string) (*model.Company, error)) *AdminCompanyStorer_SelectCompanyByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, company
+func (_m *AdminCompanyStorer) Update(ctx context.Context, company *model.Company) error {
+	ret := _m.Called(ctx, company)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Company) error); ok {
+		r0 = rf(ctx, company)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminCompanyStorer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AdminCompanyStorer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - company *model.Company
+func (_e *AdminCompanyStorer_Expecter) Update(ctx interface{}, company interface{}) *AdminCompanyStorer_Update_Call {
+	return &AdminCompanyStorer_Update_Call{Call: _e.mock.On("Update", ctx, company)}
+}
+
+func (_c *AdminCompanyStorer_Update_Call) Run(run func(ctx context.Context, company *model.Company)) *AdminCompanyStorer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Company))
+	})
+	return _c
+}
+
+func (_c *AdminCompanyStorer_Update_Call) Return(_a0 error) *AdminCompanyStorer_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminCompanyStorer_Update_Call) RunAndReturn(run func(context.Context, *model.Company) error) *AdminCompanyStorer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminCompanyStorer creates a new instance of AdminCompanyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminCompanyStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminCompanyStorer {
+	mock := &AdminCompanyStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminLoginAttemptStorer is an autogenerated mock type for the AdminLoginAttemptStorer type
+type AdminLoginAttemptStorer struct {
+	mock.Mock
+}
+
+type AdminLoginAttemptStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminLoginAttemptStorer) EXPECT() *AdminLoginAttemptStorer_Expecter {
+	return &AdminLoginAttemptStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectLoginAttempts provides a mock function with given fields: ctx, login, ip, limit
+func (_m *AdminLoginAttemptStorer) SelectLoginAttempts(ctx context.Context, login string, ip string, limit int) ([]*model.LoginAttempt, error) {
+	ret := _m.Called(ctx, login, ip, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectLoginAttempts")
+	}
+
+	var r0 []*model.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*model.LoginAttempt, error)); ok {
+		return rf(ctx, login, ip, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []*model.LoginAttempt); ok {
+		r0 = rf(ctx, login, ip, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, login, ip, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminLoginAttemptStorer_SelectLoginAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectLoginAttempts'
+type AdminLoginAttemptStorer_SelectLoginAttempts_Call struct {
+	*mock.Call
+}
+
+// SelectLoginAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - ip string
+//   - limit int
+func (_e *AdminLoginAttemptStorer_Expecter) SelectLoginAttempts(ctx interface{}, login interface{}, ip interface{}, limit interface{}) *AdminLoginAttemptStorer_SelectLoginAttempts_Call {
+	return &AdminLoginAttemptStorer_SelectLoginAttempts_Call{Call: _e.mock.On("SelectLoginAttempts", ctx, login, ip, limit)}
+}
+
+func (_c *AdminLoginAttemptStorer_SelectLoginAttempts_Call) Run(run func(ctx context.Context, login string, ip string, limit int)) *AdminLoginAttemptStorer_SelectLoginAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AdminLoginAttemptStorer_SelectLoginAttempts_Call) Return(_a0 []*model.LoginAttempt, _a1 error) *AdminLoginAttemptStorer_SelectLoginAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminLoginAttemptStorer_SelectLoginAttempts_Call) RunAndReturn(run func(context.Context, string, string, int) ([]*model.LoginAttempt, error)) *AdminLoginAttemptStorer_SelectLoginAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminLoginAttemptStorer creates a new instance of AdminLoginAttemptStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminLoginAttemptStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminLoginAttemptStorer {
+	mock := &AdminLoginAttemptStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminPriceHistoryStorer is an autogenerated mock type for the AdminPriceHistoryStorer type
+type AdminPriceHistoryStorer struct {
+	mock.Mock
+}
+
+type AdminPriceHistoryStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminPriceHistoryStorer) EXPECT() *AdminPriceHistoryStorer_Expecter {
+	return &AdminPriceHistoryStorer_Expecter{mock: &_m.Mock}
+}
+
+// Refresh provides a mock function with given fields: ctx
+func (_m *AdminPriceHistoryStorer) Refresh(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminPriceHistoryStorer_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type AdminPriceHistoryStorer_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AdminPriceHistoryStorer_Expecter) Refresh(ctx interface{}) *AdminPriceHistoryStorer_Refresh_Call {
+	return &AdminPriceHistoryStorer_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *AdminPriceHistoryStorer_Refresh_Call) Run(run func(ctx context.Context)) *AdminPriceHistoryStorer_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AdminPriceHistoryStorer_Refresh_Call) Return(_a0 error) *AdminPriceHistoryStorer_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminPriceHistoryStorer_Refresh_Call) RunAndReturn(run func(context.Context) error) *AdminPriceHistoryStorer_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminPriceHistoryStorer creates a new instance of AdminPriceHistoryStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminPriceHistoryStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminPriceHistoryStorer {
+	mock := &AdminPriceHistoryStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminProductStorer is an autogenerated mock type for the AdminProductStorer type
+type AdminProductStorer struct {
+	mock.Mock
+}
+
+type AdminProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminProductStorer) EXPECT() *AdminProductStorer_Expecter {
+	return &AdminProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetProductByEAN provides a mock function with given fields: ctx, ean
+func (_m *AdminProductStorer) GetProductByEAN(ctx context.Context, ean string) (*model.Product, error) {
+	ret := _m.Called(ctx, ean)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByEAN")
+	}
+
+	var r0 *model.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Product, error)); ok {
+		return rf(ctx, ean)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Product); ok {
+		r0 = rf(ctx, ean)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ean)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminProductStorer_GetProductByEAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByEAN'
+type AdminProductStorer_GetProductByEAN_Call struct {
+	*mock.Call
+}
+
+// GetProductByEAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ean string
+func (_e *AdminProductStorer_Expecter) GetProductByEAN(ctx interface{}, ean interface{}) *AdminProductStorer_GetProductByEAN_Call {
+	return &AdminProductStorer_GetProductByEAN_Call{Call: _e.mock.On("GetProductByEAN", ctx, ean)}
+}
+
+func (_c *AdminProductStorer_GetProductByEAN_Call) Run(run func(ctx context.Context, ean string)) *AdminProductStorer_GetProductByEAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminProductStorer_GetProductByEAN_Call) Return(_a0 *model.Product, _a1 error) *AdminProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminProductStorer_GetProductByEAN_Call) RunAndReturn(run func(context.Context, string) (*model.Product, error)) *AdminProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function with given fields: ctx, sourceID, targetID
+func (_m *AdminProductStorer) Merge(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, sourceID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminProductStorer_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type AdminProductStorer_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID uuid.UUID
+//   - targetID uuid.UUID
+func (_e *AdminProductStorer_Expecter) Merge(ctx interface{}, sourceID interface{}, targetID interface{}) *AdminProductStorer_Merge_Call {
+	return &AdminProductStorer_Merge_Call{Call: _e.mock.On("Merge", ctx, sourceID, targetID)}
+}
+
+func (_c *AdminProductStorer_Merge_Call) Run(run func(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID)) *AdminProductStorer_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminProductStorer_Merge_Call) Return(_a0 error) *AdminProductStorer_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminProductStorer_Merge_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AdminProductStorer_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, product
+func (_m *AdminProductStorer) Update(ctx context.Context, product *model.Product) error {
+	ret := _m.Called(ctx, product)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Product) error); ok {
+		r0 = rf(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminProductStorer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AdminProductStorer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *model.Product
+func (_e *AdminProductStorer_Expecter) Update(ctx interface{}, product interface{}) *AdminProductStorer_Update_Call {
+	return &AdminProductStorer_Update_Call{Call: _e.mock.On("Update", ctx, product)}
+}
+
+func (_c *AdminProductStorer_Update_Call) Run(run func(ctx context.Context, product *model.Product)) *AdminProductStorer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Product))
+	})
+	return _c
+}
+
+func (_c *AdminProductStorer_Update_Call) Return(_a0 error) *AdminProductStorer_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminProductStorer_Update_Call) RunAndReturn(run func(context.Context, *model.Product) error) *AdminProductStorer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminProductStorer creates a new instance of AdminProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminProductStorer {
+	mock := &AdminProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminStatsStorer is an autogenerated mock type for the AdminStatsStorer type
+type AdminStatsStorer struct {
+	mock.Mock
+}
+
+type AdminStatsStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminStatsStorer) EXPECT() *AdminStatsStorer_Expecter {
+	return &AdminStatsStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectStats provides a mock function with given fields: ctx
+func (_m *AdminStatsStorer) SelectStats(ctx context.Context) (*model.Stats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectStats")
+	}
+
+	var r0 *model.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*model.Stats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *model.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminStatsStorer_SelectStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectStats'
+type AdminStatsStorer_SelectStats_Call struct {
+	*mock.Call
+}
+
+// SelectStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AdminStatsStorer_Expecter) SelectStats(ctx interface{}) *AdminStatsStorer_SelectStats_Call {
+	return &AdminStatsStorer_SelectStats_Call{Call: _e.mock.On("SelectStats", ctx)}
+}
+
+func (_c *AdminStatsStorer_SelectStats_Call) Run(run func(ctx context.Context)) *AdminStatsStorer_SelectStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AdminStatsStorer_SelectStats_Call) Return(_a0 *model.Stats, _a1 error) *AdminStatsStorer_SelectStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminStatsStorer_SelectStats_Call) RunAndReturn(run func(context.Context) (*model.Stats, error)) *AdminStatsStorer_SelectStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminStatsStorer creates a new instance of AdminStatsStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminStatsStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminStatsStorer {
+	mock := &AdminStatsStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminStoreStorer is an autogenerated mock type for the AdminStoreStorer type
+type AdminStoreStorer struct {
+	mock.Mock
+}
+
+type AdminStoreStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminStoreStorer) EXPECT() *AdminStoreStorer_Expecter {
+	return &AdminStoreStorer_Expecter{mock: &_m.Mock}
+}
+
+// Merge provides a mock function with given fields: ctx, sourceID, targetID
+func (_m *AdminStoreStorer) Merge(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) error {
+	ret := _m.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, sourceID, targetID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminStoreStorer_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type AdminStoreStorer_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID uuid.UUID
+//   - targetID uuid.UUID
+func (_e *AdminStoreStorer_Expecter) Merge(ctx interface{}, sourceID interface{}, targetID interface{}) *AdminStoreStorer_Merge_Call {
+	return &AdminStoreStorer_Merge_Call{Call: _e.mock.On("Merge", ctx, sourceID, targetID)}
+}
+
+func (_c *AdminStoreStorer_Merge_Call) Run(run func(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID)) *AdminStoreStorer_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminStoreStorer_Merge_Call) Return(_a0 error) *AdminStoreStorer_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminStoreStorer_Merge_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AdminStoreStorer_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, store
+func (_m *AdminStoreStorer) Update(ctx context.Context, store *model.Store) error {
+	ret := _m.Called(ctx, store)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Store) error); ok {
+		r0 = rf(ctx, store)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminStoreStorer_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AdminStoreStorer_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - store *model.Store
+func (_e *AdminStoreStorer_Expecter) Update(ctx interface{}, store interface{}) *AdminStoreStorer_Update_Call {
+	return &AdminStoreStorer_Update_Call{Call: _e.mock.On("Update", ctx, store)}
+}
+
+func (_c *AdminStoreStorer_Update_Call) Run(run func(ctx context.Context, store *model.Store)) *AdminStoreStorer_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Store))
+	})
+	return _c
+}
+
+func (_c *AdminStoreStorer_Update_Call) Return(_a0 error) *AdminStoreStorer_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminStoreStorer_Update_Call) RunAndReturn(run func(context.Context, *model.Store) error) *AdminStoreStorer_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminStoreStorer creates a new instance of AdminStoreStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminStoreStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminStoreStorer {
+	mock := &AdminStoreStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AdminUserStorer is an autogenerated mock type for the AdminUserStorer type
+type AdminUserStorer struct {
+	mock.Mock
+}
+
+type AdminUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminUserStorer) EXPECT() *AdminUserStorer_Expecter {
+	return &AdminUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// Disable provides a mock function with given fields: ctx, id
+func (_m *AdminUserStorer) Disable(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Disable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminUserStorer_Disable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Disable'
+type AdminUserStorer_Disable_Call struct {
+	*mock.Call
+}
+
+// Disable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *AdminUserStorer_Expecter) Disable(ctx interface{}, id interface{}) *AdminUserStorer_Disable_Call {
+	return &AdminUserStorer_Disable_Call{Call: _e.mock.On("Disable", ctx, id)}
+}
+
+func (_c *AdminUserStorer_Disable_Call) Run(run func(ctx context.Context, id uuid.UUID)) *AdminUserStorer_Disable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminUserStorer_Disable_Call) Return(_a0 error) *AdminUserStorer_Disable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminUserStorer_Disable_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AdminUserStorer_Disable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enable provides a mock function with given fields: ctx, id
+func (_m *AdminUserStorer) Enable(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminUserStorer_Enable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enable'
+type AdminUserStorer_Enable_Call struct {
+	*mock.Call
+}
+
+// Enable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *AdminUserStorer_Expecter) Enable(ctx interface{}, id interface{}) *AdminUserStorer_Enable_Call {
+	return &AdminUserStorer_Enable_Call{Call: _e.mock.On("Enable", ctx, id)}
+}
+
+func (_c *AdminUserStorer_Enable_Call) Run(run func(ctx context.Context, id uuid.UUID)) *AdminUserStorer_Enable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AdminUserStorer_Enable_Call) Return(_a0 error) *AdminUserStorer_Enable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminUserStorer_Enable_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AdminUserStorer_Enable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRole provides a mock function with given fields: ctx, id, role
+func (_m *AdminUserStorer) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AdminUserStorer_SetRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRole'
+type AdminUserStorer_SetRole_Call struct {
+	*mock.Call
+}
+
+// SetRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - role string
+func (_e *AdminUserStorer_Expecter) SetRole(ctx interface{}, id interface{}, role interface{}) *AdminUserStorer_SetRole_Call {
+	return &AdminUserStorer_SetRole_Call{Call: _e.mock.On("SetRole", ctx, id, role)}
+}
+
+func (_c *AdminUserStorer_SetRole_Call) Run(run func(ctx context.Context, id uuid.UUID, role string)) *AdminUserStorer_SetRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AdminUserStorer_SetRole_Call) Return(_a0 error) *AdminUserStorer_SetRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AdminUserStorer_SetRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *AdminUserStorer_SetRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminUserStorer creates a new instance of AdminUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminUserStorer {
+	mock := &AdminUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthLoginAttemptStorer is an autogenerated mock type for the AuthLoginAttemptStorer type
+type AuthLoginAttemptStorer struct {
+	mock.Mock
+}
+
+type AuthLoginAttemptStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthLoginAttemptStorer) EXPECT() *AuthLoginAttemptStorer_Expecter {
+	return &AuthLoginAttemptStorer_Expecter{mock: &_m.Mock}
+}
+
+// Insert provides a mock function with given fields: ctx, attempt
+func (_m *AuthLoginAttemptStorer) Insert(ctx context.Context, attempt *model.LoginAttempt) error {
+	ret := _m.Called(ctx, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoginAttempt) error); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthLoginAttemptStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type AuthLoginAttemptStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attempt *model.LoginAttempt
+func (_e *AuthLoginAttemptStorer_Expecter) Insert(ctx interface{}, attempt interface{}) *AuthLoginAttemptStorer_Insert_Call {
+	return &AuthLoginAttemptStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, attempt)}
+}
+
+func (_c *AuthLoginAttemptStorer_Insert_Call) Run(run func(ctx context.Context, attempt *model.LoginAttempt)) *AuthLoginAttemptStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.LoginAttempt))
+	})
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Insert_Call) Return(_a0 error) *AuthLoginAttemptStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.LoginAttempt) error) *AuthLoginAttemptStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, scope, key, until
+func (_m *AuthLoginAttemptStorer) Lock(ctx context.Context, scope string, key string, until time.Time) error {
+	ret := _m.Called(ctx, scope, key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, scope, key, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthLoginAttemptStorer_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type AuthLoginAttemptStorer_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+//   - until time.Time
+func (_e *AuthLoginAttemptStorer_Expecter) Lock(ctx interface{}, scope interface{}, key interface{}, until interface{}) *AuthLoginAttemptStorer_Lock_Call {
+	return &AuthLoginAttemptStorer_Lock_Call{Call: _e.mock.On("Lock", ctx, scope, key, until)}
+}
+
+func (_c *AuthLoginAttemptStorer_Lock_Call) Run(run func(ctx context.Context, scope string, key string, until time.Time)) *AuthLoginAttemptStorer_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Lock_Call) Return(_a0 error) *AuthLoginAttemptStorer_Lock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Lock_Call) RunAndReturn(run func(context.Context, string, string, time.Time) error) *AuthLoginAttemptStorer_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailure provides a mock function with given fields: ctx, scope, key, window
+func (_m *AuthLoginAttemptStorer) RecordFailure(ctx context.Context, scope string, key string, window time.Duration) (int, error) {
+	ret := _m.Called(ctx, scope, key, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (int, error)); ok {
+		return rf(ctx, scope, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) int); ok {
+		r0 = rf(ctx, scope, key, window)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, scope, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthLoginAttemptStorer_RecordFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailure'
+type AuthLoginAttemptStorer_RecordFailure_Call struct {
+	*mock.Call
+}
+
+// RecordFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+//   - window time.Duration
+func (_e *AuthLoginAttemptStorer_Expecter) RecordFailure(ctx interface{}, scope interface{}, key interface{}, window interface{}) *AuthLoginAttemptStorer_RecordFailure_Call {
+	return &AuthLoginAttemptStorer_RecordFailure_Call{Call: _e.mock.On("RecordFailure", ctx, scope, key, window)}
+}
+
+func (_c *AuthLoginAttemptStorer_RecordFailure_Call) Run(run func(ctx context.Context, scope string, key string, window time.Duration)) *AuthLoginAttemptStorer_RecordFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_RecordFailure_Call) Return(_a0 int, _a1 error) *AuthLoginAttemptStorer_RecordFailure_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_RecordFailure_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (int, error)) *AuthLoginAttemptStorer_RecordFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: ctx, scope, key
+func (_m *AuthLoginAttemptStorer) Reset(ctx context.Context, scope string, key string) error {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthLoginAttemptStorer_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type AuthLoginAttemptStorer_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+func (_e *AuthLoginAttemptStorer_Expecter) Reset(ctx interface{}, scope interface{}, key interface{}) *AuthLoginAttemptStorer_Reset_Call {
+	return &AuthLoginAttemptStorer_Reset_Call{Call: _e.mock.On("Reset", ctx, scope, key)}
+}
+
+func (_c *AuthLoginAttemptStorer_Reset_Call) Run(run func(ctx context.Context, scope string, key string)) *AuthLoginAttemptStorer_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Reset_Call) Return(_a0 error) *AuthLoginAttemptStorer_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_Reset_Call) RunAndReturn(run func(context.Context, string, string) error) *AuthLoginAttemptStorer_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectLockedUntil provides a mock function with given fields: ctx, login, ip
+func (_m *AuthLoginAttemptStorer) SelectLockedUntil(ctx context.Context, login string, ip string) (*time.Time, error) {
+	ret := _m.Called(ctx, login, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectLockedUntil")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*time.Time, error)); ok {
+		return rf(ctx, login, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *time.Time); ok {
+		r0 = rf(ctx, login, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, login, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthLoginAttemptStorer_SelectLockedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectLockedUntil'
+type AuthLoginAttemptStorer_SelectLockedUntil_Call struct {
+	*mock.Call
+}
+
+// SelectLockedUntil is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+//   - ip string
+func (_e *AuthLoginAttemptStorer_Expecter) SelectLockedUntil(ctx interface{}, login interface{}, ip interface{}) *AuthLoginAttemptStorer_SelectLockedUntil_Call {
+	return &AuthLoginAttemptStorer_SelectLockedUntil_Call{Call: _e.mock.On("SelectLockedUntil", ctx, login, ip)}
+}
+
+func (_c *AuthLoginAttemptStorer_SelectLockedUntil_Call) Run(run func(ctx context.Context, login string, ip string)) *AuthLoginAttemptStorer_SelectLockedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_SelectLockedUntil_Call) Return(_a0 *time.Time, _a1 error) *AuthLoginAttemptStorer_SelectLockedUntil_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthLoginAttemptStorer_SelectLockedUntil_Call) RunAndReturn(run func(context.Context, string, string) (*time.Time, error)) *AuthLoginAttemptStorer_SelectLockedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthLoginAttemptStorer creates a new instance of AuthLoginAttemptStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthLoginAttemptStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthLoginAttemptStorer {
+	mock := &AuthLoginAttemptStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthStorer is an autogenerated mock type for the AuthStorer type
+type AuthStorer struct {
+	mock.Mock
+}
+
+type AuthStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthStorer) EXPECT() *AuthStorer_Expecter {
+	return &AuthStorer_Expecter{mock: &_m.Mock}
+}
+
+// Insert provides a mock function with given fields: ctx, session
+func (_m *AuthStorer) Insert(ctx context.Context, session *model.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type AuthStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session *model.Session
+func (_e *AuthStorer_Expecter) Insert(ctx interface{}, session interface{}) *AuthStorer_Insert_Call {
+	return &AuthStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, session)}
+}
+
+func (_c *AuthStorer_Insert_Call) Run(run func(ctx context.Context, session *model.Session)) *AuthStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Session))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_Insert_Call) Return(_a0 error) *AuthStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.Session) error) *AuthStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, sessionID
+func (_m *AuthStorer) Revoke(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthStorer_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type AuthStorer_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - sessionID uuid.UUID
+func (_e *AuthStorer_Expecter) Revoke(ctx interface{}, userID interface{}, sessionID interface{}) *AuthStorer_Revoke_Call {
+	return &AuthStorer_Revoke_Call{Call: _e.mock.On("Revoke", ctx, userID, sessionID)}
+}
+
+func (_c *AuthStorer_Revoke_Call) Run(run func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID)) *AuthStorer_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_Revoke_Call) Return(_a0 error) *AuthStorer_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthStorer_Revoke_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AuthStorer_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOthers provides a mock function with given fields: ctx, userID, sessionID
+func (_m *AuthStorer) RevokeOthers(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOthers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthStorer_RevokeOthers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOthers'
+type AuthStorer_RevokeOthers_Call struct {
+	*mock.Call
+}
+
+// RevokeOthers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - sessionID uuid.UUID
+func (_e *AuthStorer_Expecter) RevokeOthers(ctx interface{}, userID interface{}, sessionID interface{}) *AuthStorer_RevokeOthers_Call {
+	return &AuthStorer_RevokeOthers_Call{Call: _e.mock.On("RevokeOthers", ctx, userID, sessionID)}
+}
+
+func (_c *AuthStorer_RevokeOthers_Call) Run(run func(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID)) *AuthStorer_RevokeOthers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_RevokeOthers_Call) Return(_a0 error) *AuthStorer_RevokeOthers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthStorer_RevokeOthers_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *AuthStorer_RevokeOthers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rotate provides a mock function with given fields: ctx, refreshTokenID, session
+func (_m *AuthStorer) Rotate(ctx context.Context, refreshTokenID uuid.UUID, session *model.Session) error {
+	ret := _m.Called(ctx, refreshTokenID, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.Session) error); ok {
+		r0 = rf(ctx, refreshTokenID, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthStorer_Rotate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rotate'
+type AuthStorer_Rotate_Call struct {
+	*mock.Call
+}
+
+// Rotate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshTokenID uuid.UUID
+//   - session *model.Session
+func (_e *AuthStorer_Expecter) Rotate(ctx interface{}, refreshTokenID interface{}, session interface{}) *AuthStorer_Rotate_Call {
+	return &AuthStorer_Rotate_Call{Call: _e.mock.On("Rotate", ctx, refreshTokenID, session)}
+}
+
+func (_c *AuthStorer_Rotate_Call) Run(run func(ctx context.Context, refreshTokenID uuid.UUID, session *model.Session)) *AuthStorer_Rotate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.Session))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_Rotate_Call) Return(_a0 error) *AuthStorer_Rotate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthStorer_Rotate_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.Session) error) *AuthStorer_Rotate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectRefreshToken provides a mock function with given fields: ctx, token
+func (_m *AuthStorer) SelectRefreshToken(ctx context.Context, token string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectRefreshToken")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.RefreshToken, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthStorer_SelectRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectRefreshToken'
+type AuthStorer_SelectRefreshToken_Call struct {
+	*mock.Call
+}
+
+// SelectRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *AuthStorer_Expecter) SelectRefreshToken(ctx interface{}, token interface{}) *AuthStorer_SelectRefreshToken_Call {
+	return &AuthStorer_SelectRefreshToken_Call{Call: _e.mock.On("SelectRefreshToken", ctx, token)}
+}
+
+func (_c *AuthStorer_SelectRefreshToken_Call) Run(run func(ctx context.Context, token string)) *AuthStorer_SelectRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_SelectRefreshToken_Call) Return(_a0 *model.RefreshToken, _a1 error) *AuthStorer_SelectRefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthStorer_SelectRefreshToken_Call) RunAndReturn(run func(context.Context, string) (*model.RefreshToken, error)) *AuthStorer_SelectRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectSessionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *AuthStorer) SelectSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSessionsByUserID")
+	}
+
+	var r0 []*model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthStorer_SelectSessionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSessionsByUserID'
+type AuthStorer_SelectSessionsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectSessionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AuthStorer_Expecter) SelectSessionsByUserID(ctx interface{}, userID interface{}) *AuthStorer_SelectSessionsByUserID_Call {
+	return &AuthStorer_SelectSessionsByUserID_Call{Call: _e.mock.On("SelectSessionsByUserID", ctx, userID)}
+}
+
+func (_c *AuthStorer_SelectSessionsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AuthStorer_SelectSessionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthStorer_SelectSessionsByUserID_Call) Return(_a0 []*model.Session, _a1 error) *AuthStorer_SelectSessionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthStorer_SelectSessionsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Session, error)) *AuthStorer_SelectSessionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthStorer creates a new instance of AuthStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthStorer {
+	mock := &AuthStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// AuthTwoFactorStorer is an autogenerated mock type for the AuthTwoFactorStorer type
+type AuthTwoFactorStorer struct {
+	mock.Mock
+}
+
+type AuthTwoFactorStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthTwoFactorStorer) EXPECT() *AuthTwoFactorStorer_Expecter {
+	return &AuthTwoFactorStorer_Expecter{mock: &_m.Mock}
+}
+
+// FailChallenge provides a mock function with given fields: ctx, challengeID, maxFailures
+func (_m *AuthTwoFactorStorer) FailChallenge(ctx context.Context, challengeID uuid.UUID, maxFailures int) error {
+	ret := _m.Called(ctx, challengeID, maxFailures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, challengeID, maxFailures)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthTwoFactorStorer_FailChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailChallenge'
+type AuthTwoFactorStorer_FailChallenge_Call struct {
+	*mock.Call
+}
+
+// FailChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeID uuid.UUID
+//   - maxFailures int
+func (_e *AuthTwoFactorStorer_Expecter) FailChallenge(ctx interface{}, challengeID interface{}, maxFailures interface{}) *AuthTwoFactorStorer_FailChallenge_Call {
+	return &AuthTwoFactorStorer_FailChallenge_Call{Call: _e.mock.On("FailChallenge", ctx, challengeID, maxFailures)}
+}
+
+func (_c *AuthTwoFactorStorer_FailChallenge_Call) Run(run func(ctx context.Context, challengeID uuid.UUID, maxFailures int)) *AuthTwoFactorStorer_FailChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_FailChallenge_Call) Return(_a0 error) *AuthTwoFactorStorer_FailChallenge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_FailChallenge_Call) RunAndReturn(run func(context.Context, uuid.UUID, int) error) *AuthTwoFactorStorer_FailChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertChallenge provides a mock function with given fields: ctx, challenge
+func (_m *AuthTwoFactorStorer) InsertChallenge(ctx context.Context, challenge *model.LoginChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoginChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthTwoFactorStorer_InsertChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertChallenge'
+type AuthTwoFactorStorer_InsertChallenge_Call struct {
+	*mock.Call
+}
+
+// InsertChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge *model.LoginChallenge
+func (_e *AuthTwoFactorStorer_Expecter) InsertChallenge(ctx interface{}, challenge interface{}) *AuthTwoFactorStorer_InsertChallenge_Call {
+	return &AuthTwoFactorStorer_InsertChallenge_Call{Call: _e.mock.On("InsertChallenge", ctx, challenge)}
+}
+
+func (_c *AuthTwoFactorStorer_InsertChallenge_Call) Run(run func(ctx context.Context, challenge *model.LoginChallenge)) *AuthTwoFactorStorer_InsertChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.LoginChallenge))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_InsertChallenge_Call) Return(_a0 error) *AuthTwoFactorStorer_InsertChallenge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_InsertChallenge_Call) RunAndReturn(run func(context.Context, *model.LoginChallenge) error) *AuthTwoFactorStorer_InsertChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectChallenge provides a mock function with given fields: ctx, challenge
+func (_m *AuthTwoFactorStorer) SelectChallenge(ctx context.Context, challenge string) (*model.LoginChallenge, error) {
+	ret := _m.Called(ctx, challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectChallenge")
+	}
+
+	var r0 *model.LoginChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.LoginChallenge, error)); ok {
+		return rf(ctx, challenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.LoginChallenge); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, challenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthTwoFactorStorer_SelectChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectChallenge'
+type AuthTwoFactorStorer_SelectChallenge_Call struct {
+	*mock.Call
+}
+
+// SelectChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challenge string
+func (_e *AuthTwoFactorStorer_Expecter) SelectChallenge(ctx interface{}, challenge interface{}) *AuthTwoFactorStorer_SelectChallenge_Call {
+	return &AuthTwoFactorStorer_SelectChallenge_Call{Call: _e.mock.On("SelectChallenge", ctx, challenge)}
+}
+
+func (_c *AuthTwoFactorStorer_SelectChallenge_Call) Run(run func(ctx context.Context, challenge string)) *AuthTwoFactorStorer_SelectChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_SelectChallenge_Call) Return(_a0 *model.LoginChallenge, _a1 error) *AuthTwoFactorStorer_SelectChallenge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_SelectChallenge_Call) RunAndReturn(run func(context.Context, string) (*model.LoginChallenge, error)) *AuthTwoFactorStorer_SelectChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectTOTP provides a mock function with given fields: ctx, userID
+func (_m *AuthTwoFactorStorer) SelectTOTP(ctx context.Context, userID uuid.UUID) (*model.TOTP, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectTOTP")
+	}
+
+	var r0 *model.TOTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.TOTP, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.TOTP); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TOTP)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthTwoFactorStorer_SelectTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectTOTP'
+type AuthTwoFactorStorer_SelectTOTP_Call struct {
+	*mock.Call
+}
+
+// SelectTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *AuthTwoFactorStorer_Expecter) SelectTOTP(ctx interface{}, userID interface{}) *AuthTwoFactorStorer_SelectTOTP_Call {
+	return &AuthTwoFactorStorer_SelectTOTP_Call{Call: _e.mock.On("SelectTOTP", ctx, userID)}
+}
+
+func (_c *AuthTwoFactorStorer_SelectTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *AuthTwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_SelectTOTP_Call) Return(_a0 *model.TOTP, _a1 error) *AuthTwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_SelectTOTP_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.TOTP, error)) *AuthTwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseChallenge provides a mock function with given fields: ctx, challengeID
+func (_m *AuthTwoFactorStorer) UseChallenge(ctx context.Context, challengeID uuid.UUID) error {
+	ret := _m.Called(ctx, challengeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, challengeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthTwoFactorStorer_UseChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseChallenge'
+type AuthTwoFactorStorer_UseChallenge_Call struct {
+	*mock.Call
+}
+
+// UseChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - challengeID uuid.UUID
+func (_e *AuthTwoFactorStorer_Expecter) UseChallenge(ctx interface{}, challengeID interface{}) *AuthTwoFactorStorer_UseChallenge_Call {
+	return &AuthTwoFactorStorer_UseChallenge_Call{Call: _e.mock.On("UseChallenge", ctx, challengeID)}
+}
+
+func (_c *AuthTwoFactorStorer_UseChallenge_Call) Run(run func(ctx context.Context, challengeID uuid.UUID)) *AuthTwoFactorStorer_UseChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseChallenge_Call) Return(_a0 error) *AuthTwoFactorStorer_UseChallenge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseChallenge_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *AuthTwoFactorStorer_UseChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, code
+func (_m *AuthTwoFactorStorer) UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthTwoFactorStorer_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type AuthTwoFactorStorer_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - code string
+func (_e *AuthTwoFactorStorer_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, code interface{}) *AuthTwoFactorStorer_UseRecoveryCode_Call {
+	return &AuthTwoFactorStorer_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, code)}
+}
+
+func (_c *AuthTwoFactorStorer_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID uuid.UUID, code string)) *AuthTwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseRecoveryCode_Call) Return(_a0 error) *AuthTwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *AuthTwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseStep provides a mock function with given fields: ctx, userID, step
+func (_m *AuthTwoFactorStorer) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthTwoFactorStorer_UseStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseStep'
+type AuthTwoFactorStorer_UseStep_Call struct {
+	*mock.Call
+}
+
+// UseStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - step int64
+func (_e *AuthTwoFactorStorer_Expecter) UseStep(ctx interface{}, userID interface{}, step interface{}) *AuthTwoFactorStorer_UseStep_Call {
+	return &AuthTwoFactorStorer_UseStep_Call{Call: _e.mock.On("UseStep", ctx, userID, step)}
+}
+
+func (_c *AuthTwoFactorStorer_UseStep_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64)) *AuthTwoFactorStorer_UseStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseStep_Call) Return(_a0 error) *AuthTwoFactorStorer_UseStep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthTwoFactorStorer_UseStep_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *AuthTwoFactorStorer_UseStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthTwoFactorStorer creates a new instance of AuthTwoFactorStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthTwoFactorStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthTwoFactorStorer {
+	mock := &AuthTwoFactorStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthUserStorer is an autogenerated mock type for the AuthUserStorer type
+type AuthUserStorer struct {
+	mock.Mock
+}
+
+type AuthUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthUserStorer) EXPECT() *AuthUserStorer_Expecter {
+	return &AuthUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *AuthUserStorer) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUserStorer_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type AuthUserStorer_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *AuthUserStorer_Expecter) GetUserByID(ctx interface{}, id interface{}) *AuthUserStorer_GetUserByID_Call {
+	return &AuthUserStorer_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *AuthUserStorer_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *AuthUserStorer_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthUserStorer_GetUserByID_Call) Return(_a0 *model.User, _a1 error) *AuthUserStorer_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUserStorer_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *AuthUserStorer_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByLogin provides a mock function with given fields: _a0, _a1
+func (_m *AuthUserStorer) GetUserByLogin(_a0 context.Context, _a1 string) (*model.User, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByLogin")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUserStorer_GetUserByLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByLogin'
+type AuthUserStorer_GetUserByLogin_Call struct {
+	*mock.Call
+}
+
+// GetUserByLogin is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *AuthUserStorer_Expecter) GetUserByLogin(_a0 interface{}, _a1 interface{}) *AuthUserStorer_GetUserByLogin_Call {
+	return &AuthUserStorer_GetUserByLogin_Call{Call: _e.mock.On("GetUserByLogin", _a0, _a1)}
+}
+
+func (_c *AuthUserStorer_GetUserByLogin_Call) Run(run func(_a0 context.Context, _a1 string)) *AuthUserStorer_GetUserByLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AuthUserStorer_GetUserByLogin_Call) Return(_a0 *model.User, _a1 error) *AuthUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthUserStorer_GetUserByLogin_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *AuthUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePassword provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AuthUserStorer) UpdatePassword(_a0 context.Context, _a1 uuid.UUID, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUserStorer_UpdatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePassword'
+type AuthUserStorer_UpdatePassword_Call struct {
+	*mock.Call
+}
+
+// UpdatePassword is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 uuid.UUID
+//   - _a2 string
+func (_e *AuthUserStorer_Expecter) UpdatePassword(_a0 interface{}, _a1 interface{}, _a2 interface{}) *AuthUserStorer_UpdatePassword_Call {
+	return &AuthUserStorer_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", _a0, _a1, _a2)}
+}
+
+func (_c *AuthUserStorer_UpdatePassword_Call) Run(run func(_a0 context.Context, _a1 uuid.UUID, _a2 string)) *AuthUserStorer_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AuthUserStorer_UpdatePassword_Call) Return(_a0 error) *AuthUserStorer_UpdatePassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthUserStorer_UpdatePassword_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *AuthUserStorer_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthUserStorer creates a new instance of AuthUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthUserStorer {
+	mock := &AuthUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthorizerBillStorer is an autogenerated mock type for the AuthorizerBillStorer type
+type AuthorizerBillStorer struct {
+	mock.Mock
+}
+
+type AuthorizerBillStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthorizerBillStorer) EXPECT() *AuthorizerBillStorer_Expecter {
+	return &AuthorizerBillStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectBillByID provides a mock function with given fields: ctx, userID, billID
+func (_m *AuthorizerBillStorer) SelectBillByID(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectBillByID")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizerBillStorer_SelectBillByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectBillByID'
+type AuthorizerBillStorer_SelectBillByID_Call struct {
+	*mock.Call
+}
+
+// SelectBillByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *AuthorizerBillStorer_Expecter) SelectBillByID(ctx interface{}, userID interface{}, billID interface{}) *AuthorizerBillStorer_SelectBillByID_Call {
+	return &AuthorizerBillStorer_SelectBillByID_Call{Call: _e.mock.On("SelectBillByID", ctx, userID, billID)}
+}
+
+func (_c *AuthorizerBillStorer_SelectBillByID_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *AuthorizerBillStorer_SelectBillByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthorizerBillStorer_SelectBillByID_Call) Return(_a0 *model.Bill, _a1 error) *AuthorizerBillStorer_SelectBillByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthorizerBillStorer_SelectBillByID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *AuthorizerBillStorer_SelectBillByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthorizerBillStorer creates a new instance of AuthorizerBillStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizerBillStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthorizerBillStorer {
+	mock := &AuthorizerBillStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthorizerHouseholdStorer is an autogenerated mock type for the AuthorizerHouseholdStorer type
+type AuthorizerHouseholdStorer struct {
+	mock.Mock
+}
+
+type AuthorizerHouseholdStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthorizerHouseholdStorer) EXPECT() *AuthorizerHouseholdStorer_Expecter {
+	return &AuthorizerHouseholdStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMember provides a mock function with given fields: ctx, householdID, userID
+func (_m *AuthorizerHouseholdStorer) SelectMember(ctx context.Context, householdID uuid.UUID, userID uuid.UUID) (*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, householdID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMember")
+	}
+
+	var r0 *model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)); ok {
+		return rf(ctx, householdID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.HouseholdMember); ok {
+		r0 = rf(ctx, householdID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, householdID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizerHouseholdStorer_SelectMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMember'
+type AuthorizerHouseholdStorer_SelectMember_Call struct {
+	*mock.Call
+}
+
+// SelectMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+//   - userID uuid.UUID
+func (_e *AuthorizerHouseholdStorer_Expecter) SelectMember(ctx interface{}, householdID interface{}, userID interface{}) *AuthorizerHouseholdStorer_SelectMember_Call {
+	return &AuthorizerHouseholdStorer_SelectMember_Call{Call: _e.mock.On("SelectMember", ctx, householdID, userID)}
+}
+
+func (_c *AuthorizerHouseholdStorer_SelectMember_Call) Run(run func(ctx context.Context, householdID uuid.UUID, userID uuid.UUID)) *AuthorizerHouseholdStorer_SelectMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthorizerHouseholdStorer_SelectMember_Call) Return(_a0 *model.HouseholdMember, _a1 error) *AuthorizerHouseholdStorer_SelectMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthorizerHouseholdStorer_SelectMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)) *AuthorizerHouseholdStorer_SelectMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthorizerHouseholdStorer creates a new instance of AuthorizerHouseholdStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizerHouseholdStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthorizerHouseholdStorer {
+	mock := &AuthorizerHouseholdStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// AuthorizerUserProductStorer is an autogenerated mock type for the AuthorizerUserProductStorer type
+type AuthorizerUserProductStorer struct {
+	mock.Mock
+}
+
+type AuthorizerUserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthorizerUserProductStorer) EXPECT() *AuthorizerUserProductStorer_Expecter {
+	return &AuthorizerUserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectProductByID provides a mock function with given fields: ctx, id
+func (_m *AuthorizerUserProductStorer) SelectProductByID(ctx context.Context, id uuid.UUID) (*model.UserProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductByID")
+	}
+
+	var r0 *model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.UserProduct, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.UserProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizerUserProductStorer_SelectProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductByID'
+type AuthorizerUserProductStorer_SelectProductByID_Call struct {
+	*mock.Call
+}
+
+// SelectProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *AuthorizerUserProductStorer_Expecter) SelectProductByID(ctx interface{}, id interface{}) *AuthorizerUserProductStorer_SelectProductByID_Call {
+	return &AuthorizerUserProductStorer_SelectProductByID_Call{Call: _e.mock.On("SelectProductByID", ctx, id)}
+}
+
+func (_c *AuthorizerUserProductStorer_SelectProductByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *AuthorizerUserProductStorer_SelectProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *AuthorizerUserProductStorer_SelectProductByID_Call) Return(_a0 *model.UserProduct, _a1 error) *AuthorizerUserProductStorer_SelectProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthorizerUserProductStorer_SelectProductByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.UserProduct, error)) *AuthorizerUserProductStorer_SelectProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthorizerUserProductStorer creates a new instance of AuthorizerUserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizerUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthorizerUserProductStorer {
+	mock := &AuthorizerUserProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillAuthorizer is an autogenerated mock type for the BillAuthorizer type
+type BillAuthorizer struct {
+	mock.Mock
+}
+
+type BillAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillAuthorizer) EXPECT() *BillAuthorizer_Expecter {
+	return &BillAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// Bill provides a mock function with given fields: ctx, userID, billID
+func (_m *BillAuthorizer) Bill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillAuthorizer_Bill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bill'
+type BillAuthorizer_Bill_Call struct {
+	*mock.Call
+}
+
+// Bill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *BillAuthorizer_Expecter) Bill(ctx interface{}, userID interface{}, billID interface{}) *BillAuthorizer_Bill_Call {
+	return &BillAuthorizer_Bill_Call{Call: _e.mock.On("Bill", ctx, userID, billID)}
+}
+
+func (_c *BillAuthorizer_Bill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *BillAuthorizer_Bill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillAuthorizer_Bill_Call) Return(_a0 *model.Bill, _a1 error) *BillAuthorizer_Bill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillAuthorizer_Bill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *BillAuthorizer_Bill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Household provides a mock function with given fields: ctx, userID, householdID
+func (_m *BillAuthorizer) Household(ctx context.Context, userID uuid.UUID, householdID uuid.UUID) (*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, userID, householdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Household")
+	}
+
+	var r0 *model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)); ok {
+		return rf(ctx, userID, householdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.HouseholdMember); ok {
+		r0 = rf(ctx, userID, householdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, householdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillAuthorizer_Household_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Household'
+type BillAuthorizer_Household_Call struct {
+	*mock.Call
+}
+
+// Household is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - householdID uuid.UUID
+func (_e *BillAuthorizer_Expecter) Household(ctx interface{}, userID interface{}, householdID interface{}) *BillAuthorizer_Household_Call {
+	return &BillAuthorizer_Household_Call{Call: _e.mock.On("Household", ctx, userID, householdID)}
+}
+
+func (_c *BillAuthorizer_Household_Call) Run(run func(ctx context.Context, userID uuid.UUID, householdID uuid.UUID)) *BillAuthorizer_Household_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillAuthorizer_Household_Call) Return(_a0 *model.HouseholdMember, _a1 error) *BillAuthorizer_Household_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillAuthorizer_Household_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)) *BillAuthorizer_Household_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenBill provides a mock function with given fields: ctx, userID, billID
+func (_m *BillAuthorizer) OpenBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillAuthorizer_OpenBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBill'
+type BillAuthorizer_OpenBill_Call struct {
+	*mock.Call
+}
+
+// OpenBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *BillAuthorizer_Expecter) OpenBill(ctx interface{}, userID interface{}, billID interface{}) *BillAuthorizer_OpenBill_Call {
+	return &BillAuthorizer_OpenBill_Call{Call: _e.mock.On("OpenBill", ctx, userID, billID)}
+}
+
+func (_c *BillAuthorizer_OpenBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *BillAuthorizer_OpenBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillAuthorizer_OpenBill_Call) Return(_a0 *model.Bill, _a1 error) *BillAuthorizer_OpenBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillAuthorizer_OpenBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *BillAuthorizer_OpenBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillAuthorizer creates a new instance of BillAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillAuthorizer {
+	mock := &BillAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillCompanyStorer is an autogenerated mock type for the BillCompanyStorer type
+type BillCompanyStorer struct {
+	mock.Mock
+}
+
+type BillCompanyStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillCompanyStorer) EXPECT() *BillCompanyStorer_Expecter {
+	return &BillCompanyStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectCompanyByID provides a mock function with given fields: ctx, companyID
+func (_m *BillCompanyStorer) SelectCompanyByID(ctx context.Context, companyID uuid.UUID) (*model.Company, error) {
+	ret := _m.Called(ctx, companyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectCompanyByID")
+	}
+
+	var r0 *model.Company
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Company, error)); ok {
+		return rf(ctx, companyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Company); ok {
+		r0 = rf(ctx, companyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Company)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, companyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillCompanyStorer_SelectCompanyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCompanyByID'
+type BillCompanyStorer_SelectCompanyByID_Call struct {
+	*mock.Call
+}
+
+// SelectCompanyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - companyID uuid.UUID
+func (_e *BillCompanyStorer_Expecter) SelectCompanyByID(ctx interface{}, companyID interface{}) *BillCompanyStorer_SelectCompanyByID_Call {
+	return &BillCompanyStorer_SelectCompanyByID_Call{Call: _e.mock.On("SelectCompanyByID", ctx, companyID)}
+}
+
+func (_c *BillCompanyStorer_SelectCompanyByID_Call) Run(run func(ctx context.Context, companyID uuid.UUID)) *BillCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillCompanyStorer_SelectCompanyByID_Call) Return(_a0 *model.Company, _a1 error) *BillCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillCompanyStorer_SelectCompanyByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Company, error)) *BillCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillCompanyStorer creates a new instance of BillCompanyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillCompanyStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillCompanyStorer {
+	mock := &BillCompanyStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillEventStorer is an autogenerated mock type for the BillEventStorer type
+type BillEventStorer struct {
+	mock.Mock
+}
+
+type BillEventStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillEventStorer) EXPECT() *BillEventStorer_Expecter {
+	return &BillEventStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectByBillID provides a mock function with given fields: ctx, billID
+func (_m *BillEventStorer) SelectByBillID(ctx context.Context, billID uuid.UUID) ([]*model.BillEvent, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectByBillID")
+	}
+
+	var r0 []*model.BillEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.BillEvent, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.BillEvent); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BillEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillEventStorer_SelectByBillID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectByBillID'
+type BillEventStorer_SelectByBillID_Call struct {
+	*mock.Call
+}
+
+// SelectByBillID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+func (_e *BillEventStorer_Expecter) SelectByBillID(ctx interface{}, billID interface{}) *BillEventStorer_SelectByBillID_Call {
+	return &BillEventStorer_SelectByBillID_Call{Call: _e.mock.On("SelectByBillID", ctx, billID)}
+}
+
+func (_c *BillEventStorer_SelectByBillID_Call) Run(run func(ctx context.Context, billID uuid.UUID)) *BillEventStorer_SelectByBillID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillEventStorer_SelectByBillID_Call) Return(_a0 []*model.BillEvent, _a1 error) *BillEventStorer_SelectByBillID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillEventStorer_SelectByBillID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.BillEvent, error)) *BillEventStorer_SelectByBillID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillEventStorer creates a new instance of BillEventStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillEventStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillEventStorer {
+	mock := &BillEventStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillPriceHistoryStorer is an autogenerated mock type for the BillPriceHistoryStorer type
+type BillPriceHistoryStorer struct {
+	mock.Mock
+}
+
+type BillPriceHistoryStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillPriceHistoryStorer) EXPECT() *BillPriceHistoryStorer_Expecter {
+	return &BillPriceHistoryStorer_Expecter{mock: &_m.Mock}
+}
+
+// Refresh provides a mock function with given fields: ctx
+func (_m *BillPriceHistoryStorer) Refresh(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BillPriceHistoryStorer_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type BillPriceHistoryStorer_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BillPriceHistoryStorer_Expecter) Refresh(ctx interface{}) *BillPriceHistoryStorer_Refresh_Call {
+	return &BillPriceHistoryStorer_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *BillPriceHistoryStorer_Refresh_Call) Run(run func(ctx context.Context)) *BillPriceHistoryStorer_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BillPriceHistoryStorer_Refresh_Call) Return(_a0 error) *BillPriceHistoryStorer_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BillPriceHistoryStorer_Refresh_Call) RunAndReturn(run func(context.Context) error) *BillPriceHistoryStorer_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillPriceHistoryStorer creates a new instance of BillPriceHistoryStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillPriceHistoryStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillPriceHistoryStorer {
+	mock := &BillPriceHistoryStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillSplitAuthorizer is an autogenerated mock type for the BillSplitAuthorizer type
+type BillSplitAuthorizer struct {
+	mock.Mock
+}
+
+type BillSplitAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillSplitAuthorizer) EXPECT() *BillSplitAuthorizer_Expecter {
+	return &BillSplitAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// Bill provides a mock function with given fields: ctx, userID, billID
+func (_m *BillSplitAuthorizer) Bill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitAuthorizer_Bill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bill'
+type BillSplitAuthorizer_Bill_Call struct {
+	*mock.Call
+}
+
+// Bill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *BillSplitAuthorizer_Expecter) Bill(ctx interface{}, userID interface{}, billID interface{}) *BillSplitAuthorizer_Bill_Call {
+	return &BillSplitAuthorizer_Bill_Call{Call: _e.mock.On("Bill", ctx, userID, billID)}
+}
+
+func (_c *BillSplitAuthorizer_Bill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *BillSplitAuthorizer_Bill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitAuthorizer_Bill_Call) Return(_a0 *model.Bill, _a1 error) *BillSplitAuthorizer_Bill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitAuthorizer_Bill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *BillSplitAuthorizer_Bill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Household provides a mock function with given fields: ctx, userID, householdID
+func (_m *BillSplitAuthorizer) Household(ctx context.Context, userID uuid.UUID, householdID uuid.UUID) (*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, userID, householdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Household")
+	}
+
+	var r0 *model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)); ok {
+		return rf(ctx, userID, householdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.HouseholdMember); ok {
+		r0 = rf(ctx, userID, householdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, householdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitAuthorizer_Household_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Household'
+type BillSplitAuthorizer_Household_Call struct {
+	*mock.Call
+}
+
+// Household is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - householdID uuid.UUID
+func (_e *BillSplitAuthorizer_Expecter) Household(ctx interface{}, userID interface{}, householdID interface{}) *BillSplitAuthorizer_Household_Call {
+	return &BillSplitAuthorizer_Household_Call{Call: _e.mock.On("Household", ctx, userID, householdID)}
+}
+
+func (_c *BillSplitAuthorizer_Household_Call) Run(run func(ctx context.Context, userID uuid.UUID, householdID uuid.UUID)) *BillSplitAuthorizer_Household_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitAuthorizer_Household_Call) Return(_a0 *model.HouseholdMember, _a1 error) *BillSplitAuthorizer_Household_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitAuthorizer_Household_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)) *BillSplitAuthorizer_Household_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillSplitAuthorizer creates a new instance of BillSplitAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillSplitAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillSplitAuthorizer {
+	mock := &BillSplitAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillSplitHouseholdStorer is an autogenerated mock type for the BillSplitHouseholdStorer type
+type BillSplitHouseholdStorer struct {
+	mock.Mock
+}
+
+type BillSplitHouseholdStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillSplitHouseholdStorer) EXPECT() *BillSplitHouseholdStorer_Expecter {
+	return &BillSplitHouseholdStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMembers provides a mock function with given fields: ctx, householdID
+func (_m *BillSplitHouseholdStorer) SelectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, householdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMembers")
+	}
+
+	var r0 []*model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.HouseholdMember, error)); ok {
+		return rf(ctx, householdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.HouseholdMember); ok {
+		r0 = rf(ctx, householdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, householdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitHouseholdStorer_SelectMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMembers'
+type BillSplitHouseholdStorer_SelectMembers_Call struct {
+	*mock.Call
+}
+
+// SelectMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+func (_e *BillSplitHouseholdStorer_Expecter) SelectMembers(ctx interface{}, householdID interface{}) *BillSplitHouseholdStorer_SelectMembers_Call {
+	return &BillSplitHouseholdStorer_SelectMembers_Call{Call: _e.mock.On("SelectMembers", ctx, householdID)}
+}
+
+func (_c *BillSplitHouseholdStorer_SelectMembers_Call) Run(run func(ctx context.Context, householdID uuid.UUID)) *BillSplitHouseholdStorer_SelectMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitHouseholdStorer_SelectMembers_Call) Return(_a0 []*model.HouseholdMember, _a1 error) *BillSplitHouseholdStorer_SelectMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitHouseholdStorer_SelectMembers_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.HouseholdMember, error)) *BillSplitHouseholdStorer_SelectMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillSplitHouseholdStorer creates a new instance of BillSplitHouseholdStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillSplitHouseholdStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillSplitHouseholdStorer {
+	mock := &BillSplitHouseholdStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillSplitStorer is an autogenerated mock type for the BillSplitStorer type
+type BillSplitStorer struct {
+	mock.Mock
+}
+
+type BillSplitStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillSplitStorer) EXPECT() *BillSplitStorer_Expecter {
+	return &BillSplitStorer_Expecter{mock: &_m.Mock}
+}
+
+// InsertSettlement provides a mock function with given fields: ctx, settlement
+func (_m *BillSplitStorer) InsertSettlement(ctx context.Context, settlement *model.Settlement) error {
+	ret := _m.Called(ctx, settlement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSettlement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Settlement) error); ok {
+		r0 = rf(ctx, settlement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BillSplitStorer_InsertSettlement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSettlement'
+type BillSplitStorer_InsertSettlement_Call struct {
+	*mock.Call
+}
+
+// InsertSettlement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - settlement *model.Settlement
+func (_e *BillSplitStorer_Expecter) InsertSettlement(ctx interface{}, settlement interface{}) *BillSplitStorer_InsertSettlement_Call {
+	return &BillSplitStorer_InsertSettlement_Call{Call: _e.mock.On("InsertSettlement", ctx, settlement)}
+}
+
+func (_c *BillSplitStorer_InsertSettlement_Call) Run(run func(ctx context.Context, settlement *model.Settlement)) *BillSplitStorer_InsertSettlement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Settlement))
+	})
+	return _c
+}
+
+func (_c *BillSplitStorer_InsertSettlement_Call) Return(_a0 error) *BillSplitStorer_InsertSettlement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BillSplitStorer_InsertSettlement_Call) RunAndReturn(run func(context.Context, *model.Settlement) error) *BillSplitStorer_InsertSettlement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceSplit provides a mock function with given fields: ctx, split
+func (_m *BillSplitStorer) ReplaceSplit(ctx context.Context, split *model.BillSplit) error {
+	ret := _m.Called(ctx, split)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSplit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.BillSplit) error); ok {
+		r0 = rf(ctx, split)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BillSplitStorer_ReplaceSplit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSplit'
+type BillSplitStorer_ReplaceSplit_Call struct {
+	*mock.Call
+}
+
+// ReplaceSplit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - split *model.BillSplit
+func (_e *BillSplitStorer_Expecter) ReplaceSplit(ctx interface{}, split interface{}) *BillSplitStorer_ReplaceSplit_Call {
+	return &BillSplitStorer_ReplaceSplit_Call{Call: _e.mock.On("ReplaceSplit", ctx, split)}
+}
+
+func (_c *BillSplitStorer_ReplaceSplit_Call) Run(run func(ctx context.Context, split *model.BillSplit)) *BillSplitStorer_ReplaceSplit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.BillSplit))
+	})
+	return _c
+}
+
+func (_c *BillSplitStorer_ReplaceSplit_Call) Return(_a0 error) *BillSplitStorer_ReplaceSplit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BillSplitStorer_ReplaceSplit_Call) RunAndReturn(run func(context.Context, *model.BillSplit) error) *BillSplitStorer_ReplaceSplit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectBalances provides a mock function with given fields: ctx, userID
+func (_m *BillSplitStorer) SelectBalances(ctx context.Context, userID uuid.UUID) ([]*model.Balance, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectBalances")
+	}
+
+	var r0 []*model.Balance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Balance, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Balance); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Balance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitStorer_SelectBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectBalances'
+type BillSplitStorer_SelectBalances_Call struct {
+	*mock.Call
+}
+
+// SelectBalances is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *BillSplitStorer_Expecter) SelectBalances(ctx interface{}, userID interface{}) *BillSplitStorer_SelectBalances_Call {
+	return &BillSplitStorer_SelectBalances_Call{Call: _e.mock.On("SelectBalances", ctx, userID)}
+}
+
+func (_c *BillSplitStorer_SelectBalances_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *BillSplitStorer_SelectBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitStorer_SelectBalances_Call) Return(_a0 []*model.Balance, _a1 error) *BillSplitStorer_SelectBalances_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitStorer_SelectBalances_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Balance, error)) *BillSplitStorer_SelectBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectSplit provides a mock function with given fields: ctx, billID
+func (_m *BillSplitStorer) SelectSplit(ctx context.Context, billID uuid.UUID) (*model.BillSplit, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSplit")
+	}
+
+	var r0 *model.BillSplit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.BillSplit, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.BillSplit); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BillSplit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitStorer_SelectSplit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSplit'
+type BillSplitStorer_SelectSplit_Call struct {
+	*mock.Call
+}
+
+// SelectSplit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+func (_e *BillSplitStorer_Expecter) SelectSplit(ctx interface{}, billID interface{}) *BillSplitStorer_SelectSplit_Call {
+	return &BillSplitStorer_SelectSplit_Call{Call: _e.mock.On("SelectSplit", ctx, billID)}
+}
+
+func (_c *BillSplitStorer_SelectSplit_Call) Run(run func(ctx context.Context, billID uuid.UUID)) *BillSplitStorer_SelectSplit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitStorer_SelectSplit_Call) Return(_a0 *model.BillSplit, _a1 error) *BillSplitStorer_SelectSplit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitStorer_SelectSplit_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.BillSplit, error)) *BillSplitStorer_SelectSplit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillSplitStorer creates a new instance of BillSplitStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillSplitStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillSplitStorer {
+	mock := &BillSplitStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillSplitUserProductStorer is an autogenerated mock type for the BillSplitUserProductStorer type
+type BillSplitUserProductStorer struct {
+	mock.Mock
+}
+
+type BillSplitUserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillSplitUserProductStorer) EXPECT() *BillSplitUserProductStorer_Expecter {
+	return &BillSplitUserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectProductsByBillID provides a mock function with given fields: ctx, billID
+func (_m *BillSplitUserProductStorer) SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByBillID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitUserProductStorer_SelectProductsByBillID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByBillID'
+type BillSplitUserProductStorer_SelectProductsByBillID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByBillID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+func (_e *BillSplitUserProductStorer_Expecter) SelectProductsByBillID(ctx interface{}, billID interface{}) *BillSplitUserProductStorer_SelectProductsByBillID_Call {
+	return &BillSplitUserProductStorer_SelectProductsByBillID_Call{Call: _e.mock.On("SelectProductsByBillID", ctx, billID)}
+}
+
+func (_c *BillSplitUserProductStorer_SelectProductsByBillID_Call) Run(run func(ctx context.Context, billID uuid.UUID)) *BillSplitUserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitUserProductStorer_SelectProductsByBillID_Call) Return(_a0 []*model.UserProduct, _a1 error) *BillSplitUserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitUserProductStorer_SelectProductsByBillID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *BillSplitUserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillSplitUserProductStorer creates a new instance of BillSplitUserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillSplitUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillSplitUserProductStorer {
+	mock := &BillSplitUserProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillSplitUserStorer is an autogenerated mock type for the BillSplitUserStorer type
+type BillSplitUserStorer struct {
+	mock.Mock
+}
+
+type BillSplitUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillSplitUserStorer) EXPECT() *BillSplitUserStorer_Expecter {
+	return &BillSplitUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *BillSplitUserStorer) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillSplitUserStorer_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type BillSplitUserStorer_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *BillSplitUserStorer_Expecter) GetUserByID(ctx interface{}, id interface{}) *BillSplitUserStorer_GetUserByID_Call {
+	return &BillSplitUserStorer_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *BillSplitUserStorer_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *BillSplitUserStorer_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillSplitUserStorer_GetUserByID_Call) Return(_a0 *model.User, _a1 error) *BillSplitUserStorer_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillSplitUserStorer_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *BillSplitUserStorer_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillSplitUserStorer creates a new instance of BillSplitUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillSplitUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillSplitUserStorer {
+	mock := &BillSplitUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillStoreStorer is an autogenerated mock type for the BillStoreStorer type
+type BillStoreStorer struct {
+	mock.Mock
+}
+
+type BillStoreStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillStoreStorer) EXPECT() *BillStoreStorer_Expecter {
+	return &BillStoreStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectStoreByID provides a mock function with given fields: ctx, storeID
+func (_m *BillStoreStorer) SelectStoreByID(ctx context.Context, storeID uuid.UUID) (*model.Store, error) {
+	ret := _m.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectStoreByID")
+	}
+
+	var r0 *model.Store
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Store, error)); ok {
+		return rf(ctx, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Store); ok {
+		r0 = rf(ctx, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Store)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillStoreStorer_SelectStoreByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectStoreByID'
+type BillStoreStorer_SelectStoreByID_Call struct {
+	*mock.Call
+}
+
+// SelectStoreByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID uuid.UUID
+func (_e *BillStoreStorer_Expecter) SelectStoreByID(ctx interface{}, storeID interface{}) *BillStoreStorer_SelectStoreByID_Call {
+	return &BillStoreStorer_SelectStoreByID_Call{Call: _e.mock.On("SelectStoreByID", ctx, storeID)}
+}
+
+func (_c *BillStoreStorer_SelectStoreByID_Call) Run(run func(ctx context.Context, storeID uuid.UUID)) *BillStoreStorer_SelectStoreByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillStoreStorer_SelectStoreByID_Call) Return(_a0 *model.Store, _a1 error) *BillStoreStorer_SelectStoreByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillStoreStorer_SelectStoreByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Store, error)) *BillStoreStorer_SelectStoreByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillStoreStorer creates a new instance of BillStoreStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillStoreStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillStoreStorer {
+	mock := &BillStoreStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillStorer is an autogenerated mock type for the BillStorer type
+type BillStorer struct {
+	mock.Mock
+}
+
+type BillStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillStorer) EXPECT() *BillStorer_Expecter {
+	return &BillStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetBillsByUserID provides a mock function with given fields: ctx, userID, filter
+func (_m *BillStorer) GetBillsByUserID(ctx context.Context, userID uuid.UUID, filter *model.BillFilter) ([]*model.Bill, error) {
+	ret := _m.Called(ctx, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBillsByUserID")
+	}
+
+	var r0 []*model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BillFilter) ([]*model.Bill, error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.BillFilter) []*model.Bill); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.BillFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillStorer_GetBillsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBillsByUserID'
+type BillStorer_GetBillsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetBillsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - filter *model.BillFilter
+func (_e *BillStorer_Expecter) GetBillsByUserID(ctx interface{}, userID interface{}, filter interface{}) *BillStorer_GetBillsByUserID_Call {
+	return &BillStorer_GetBillsByUserID_Call{Call: _e.mock.On("GetBillsByUserID", ctx, userID, filter)}
+}
+
+func (_c *BillStorer_GetBillsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID, filter *model.BillFilter)) *BillStorer_GetBillsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.BillFilter))
+	})
+	return _c
+}
+
+func (_c *BillStorer_GetBillsByUserID_Call) Return(_a0 []*model.Bill, _a1 error) *BillStorer_GetBillsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillStorer_GetBillsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.BillFilter) ([]*model.Bill, error)) *BillStorer_GetBillsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, bill
+func (_m *BillStorer) Insert(ctx context.Context, bill *model.Bill) error {
+	ret := _m.Called(ctx, bill)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Bill) error); ok {
+		r0 = rf(ctx, bill)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BillStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type BillStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bill *model.Bill
+func (_e *BillStorer_Expecter) Insert(ctx interface{}, bill interface{}) *BillStorer_Insert_Call {
+	return &BillStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, bill)}
+}
+
+func (_c *BillStorer_Insert_Call) Run(run func(ctx context.Context, bill *model.Bill)) *BillStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Bill))
+	})
+	return _c
+}
+
+func (_c *BillStorer_Insert_Call) Return(_a0 error) *BillStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BillStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.Bill) error) *BillStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectDiscrepanciesByUserID provides a mock function with given fields: ctx, userID
+func (_m *BillStorer) SelectDiscrepanciesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectDiscrepanciesByUserID")
+	}
+
+	var r0 []*model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Bill, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Bill); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillStorer_SelectDiscrepanciesByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectDiscrepanciesByUserID'
+type BillStorer_SelectDiscrepanciesByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectDiscrepanciesByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *BillStorer_Expecter) SelectDiscrepanciesByUserID(ctx interface{}, userID interface{}) *BillStorer_SelectDiscrepanciesByUserID_Call {
+	return &BillStorer_SelectDiscrepanciesByUserID_Call{Call: _e.mock.On("SelectDiscrepanciesByUserID", ctx, userID)}
+}
+
+func (_c *BillStorer_SelectDiscrepanciesByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *BillStorer_SelectDiscrepanciesByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillStorer_SelectDiscrepanciesByUserID_Call) Return(_a0 []*model.Bill, _a1 error) *BillStorer_SelectDiscrepanciesByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillStorer_SelectDiscrepanciesByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Bill, error)) *BillStorer_SelectDiscrepanciesByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectOpenBillByStoreID provides a mock function with given fields: ctx, userID, householdID, storeID
+func (_m *BillStorer) SelectOpenBillByStoreID(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, storeID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, householdID, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectOpenBillByStoreID")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, householdID, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, householdID, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, householdID, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillStorer_SelectOpenBillByStoreID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectOpenBillByStoreID'
+type BillStorer_SelectOpenBillByStoreID_Call struct {
+	*mock.Call
+}
+
+// SelectOpenBillByStoreID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - householdID uuid.UUID
+//   - storeID uuid.UUID
+func (_e *BillStorer_Expecter) SelectOpenBillByStoreID(ctx interface{}, userID interface{}, householdID interface{}, storeID interface{}) *BillStorer_SelectOpenBillByStoreID_Call {
+	return &BillStorer_SelectOpenBillByStoreID_Call{Call: _e.mock.On("SelectOpenBillByStoreID", ctx, userID, householdID, storeID)}
+}
+
+func (_c *BillStorer_SelectOpenBillByStoreID_Call) Run(run func(ctx context.Context, userID uuid.UUID, householdID uuid.UUID, storeID uuid.UUID)) *BillStorer_SelectOpenBillByStoreID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillStorer_SelectOpenBillByStoreID_Call) Return(_a0 *model.Bill, _a1 error) *BillStorer_SelectOpenBillByStoreID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillStorer_SelectOpenBillByStoreID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*model.Bill, error)) *BillStorer_SelectOpenBillByStoreID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectOpenBillsByUserID provides a mock function with given fields: ctx, userID
+func (_m *BillStorer) SelectOpenBillsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Bill, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectOpenBillsByUserID")
+	}
+
+	var r0 []*model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Bill, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Bill); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillStorer_SelectOpenBillsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectOpenBillsByUserID'
+type BillStorer_SelectOpenBillsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectOpenBillsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *BillStorer_Expecter) SelectOpenBillsByUserID(ctx interface{}, userID interface{}) *BillStorer_SelectOpenBillsByUserID_Call {
+	return &BillStorer_SelectOpenBillsByUserID_Call{Call: _e.mock.On("SelectOpenBillsByUserID", ctx, userID)}
+}
+
+func (_c *BillStorer_SelectOpenBillsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *BillStorer_SelectOpenBillsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillStorer_SelectOpenBillsByUserID_Call) Return(_a0 []*model.Bill, _a1 error) *BillStorer_SelectOpenBillsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillStorer_SelectOpenBillsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Bill, error)) *BillStorer_SelectOpenBillsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transition provides a mock function with given fields: ctx, bill, from, event, actorID
+func (_m *BillStorer) Transition(ctx context.Context, bill *model.Bill, from string, event string, actorID uuid.UUID) error {
+	ret := _m.Called(ctx, bill, from, event, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Bill, string, string, uuid.UUID) error); ok {
+		r0 = rf(ctx, bill, from, event, actorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BillStorer_Transition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transition'
+type BillStorer_Transition_Call struct {
+	*mock.Call
+}
+
+// Transition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bill *model.Bill
+//   - from string
+//   - event string
+//   - actorID uuid.UUID
+func (_e *BillStorer_Expecter) Transition(ctx interface{}, bill interface{}, from interface{}, event interface{}, actorID interface{}) *BillStorer_Transition_Call {
+	return &BillStorer_Transition_Call{Call: _e.mock.On("Transition", ctx, bill, from, event, actorID)}
+}
+
+func (_c *BillStorer_Transition_Call) Run(run func(ctx context.Context, bill *model.Bill, from string, event string, actorID uuid.UUID)) *BillStorer_Transition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Bill), args[2].(string), args[3].(string), args[4].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillStorer_Transition_Call) Return(_a0 error) *BillStorer_Transition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BillStorer_Transition_Call) RunAndReturn(run func(context.Context, *model.Bill, string, string, uuid.UUID) error) *BillStorer_Transition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillStorer creates a new instance of BillStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillStorer {
+	mock := &BillStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BillUserProductsStorer is an autogenerated mock type for the BillUserProductsStorer type
+type BillUserProductsStorer struct {
+	mock.Mock
+}
+
+type BillUserProductsStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BillUserProductsStorer) EXPECT() *BillUserProductsStorer_Expecter {
+	return &BillUserProductsStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectProductsByBillID provides a mock function with given fields: ctx, billID
+func (_m *BillUserProductsStorer) SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByBillID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BillUserProductsStorer_SelectProductsByBillID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByBillID'
+type BillUserProductsStorer_SelectProductsByBillID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByBillID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+func (_e *BillUserProductsStorer_Expecter) SelectProductsByBillID(ctx interface{}, billID interface{}) *BillUserProductsStorer_SelectProductsByBillID_Call {
+	return &BillUserProductsStorer_SelectProductsByBillID_Call{Call: _e.mock.On("SelectProductsByBillID", ctx, billID)}
+}
+
+func (_c *BillUserProductsStorer_SelectProductsByBillID_Call) Run(run func(ctx context.Context, billID uuid.UUID)) *BillUserProductsStorer_SelectProductsByBillID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *BillUserProductsStorer_SelectProductsByBillID_Call) Return(_a0 []*model.UserProduct, _a1 error) *BillUserProductsStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BillUserProductsStorer_SelectProductsByBillID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *BillUserProductsStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBillUserProductsStorer creates a new instance of BillUserProductsStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillUserProductsStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillUserProductsStorer {
+	mock := &BillUserProductsStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// CompanyStorer is an autogenerated mock type for the CompanyStorer type
+type CompanyStorer struct {
+	mock.Mock
+}
+
+type CompanyStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CompanyStorer) EXPECT() *CompanyStorer_Expecter {
+	return &CompanyStorer_Expecter{mock: &_m.Mock}
+}
+
+// Insert provides a mock function with given fields: ctx, company
+func (_m *CompanyStorer) Insert(ctx context.Context, company *model.Company) error {
+	ret := _m.Called(ctx, company)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Company) error); ok {
+		r0 = rf(ctx, company)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompanyStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type CompanyStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - company *model.Company
+func (_e *CompanyStorer_Expecter) Insert(ctx interface{}, company interface{}) *CompanyStorer_Insert_Call {
+	return &CompanyStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, company)}
+}
+
+func (_c *CompanyStorer_Insert_Call) Run(run func(ctx context.Context, company *model.Company)) *CompanyStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Company))
+	})
+	return _c
+}
+
+func (_c *CompanyStorer_Insert_Call) Return(_a0 error) *CompanyStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CompanyStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.Company) error) *CompanyStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectCompanies provides a mock function with given fields: ctx, name
+func (_m *CompanyStorer) SelectCompanies(ctx context.Context, name string) ([]*model.Company, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectCompanies")
+	}
+
+	var r0 []*model.Company
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Company, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Company); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Company)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompanyStorer_SelectCompanies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCompanies'
+type CompanyStorer_SelectCompanies_Call struct {
+	*mock.Call
+}
+
+// SelectCompanies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *CompanyStorer_Expecter) SelectCompanies(ctx interface{}, name interface{}) *CompanyStorer_SelectCompanies_Call {
+	return &CompanyStorer_SelectCompanies_Call{Call: _e.mock.On("SelectCompanies", ctx, name)}
+}
+
+func (_c *CompanyStorer_SelectCompanies_Call) Run(run func(ctx context.Context, name string)) *CompanyStorer_SelectCompanies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *CompanyStorer_SelectCompanies_Call) Return(_a0 []*model.Company, _a1 error) *CompanyStorer_SelectCompanies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CompanyStorer_SelectCompanies_Call) RunAndReturn(run func(context.Context, string) ([]*model.Company, error)) *CompanyStorer_SelectCompanies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCompanyStorer creates a new instance of CompanyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCompanyStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CompanyStorer {
+	mock := &CompanyStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Code generated by mockery v2.42.2. DO NOT EDIT.
+
+// HouseholdAuthorizer is an autogenerated mock type for the HouseholdAuthorizer type
+type HouseholdAuthorizer struct {
+	mock.Mock
+}
+
+type HouseholdAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HouseholdAuthorizer) EXPECT() *HouseholdAuthorizer_Expecter {
+	return &HouseholdAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// Household provides a mock function with given fields: ctx, userID, householdID
+func (_m *HouseholdAuthorizer) Household(ctx context.Context, userID uuid.UUID, householdID uuid.UUID) (*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, userID, householdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Household")
+	}
+
+	var r0 *model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)); ok {
+		return rf(ctx, userID, householdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.HouseholdMember); ok {
+		r0 = rf(ctx, userID, householdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, householdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdAuthorizer_Household_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Household'
+type HouseholdAuthorizer_Household_Call struct {
+	*mock.Call
+}
+
+// Household is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - householdID uuid.UUID
+func (_e *HouseholdAuthorizer_Expecter) Household(ctx interface{}, userID interface{}, householdID interface{}) *HouseholdAuthorizer_Household_Call {
+	return &HouseholdAuthorizer_Household_Call{Call: _e.mock.On("Household", ctx, userID, householdID)}
+}
+
+func (_c *HouseholdAuthorizer_Household_Call) Run(run func(ctx context.Context, userID uuid.UUID, householdID uuid.UUID)) *HouseholdAuthorizer_Household_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdAuthorizer_Household_Call) Return(_a0 *model.HouseholdMember, _a1 error) *HouseholdAuthorizer_Household_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdAuthorizer_Household_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)) *HouseholdAuthorizer_Household_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHouseholdAuthorizer creates a new instance of HouseholdAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHouseholdAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HouseholdAuthorizer {
+	mock := &HouseholdAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// HouseholdStorer is an autogenerated mock type for the HouseholdStorer type
+type HouseholdStorer struct {
+	mock.Mock
+}
+
+type HouseholdStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HouseholdStorer) EXPECT() *HouseholdStorer_Expecter {
+	return &HouseholdStorer_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, userID, invitationID
+func (_m *HouseholdStorer) AcceptInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) (*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, userID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 *model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)); ok {
+		return rf(ctx, userID, invitationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.HouseholdMember); ok {
+		r0 = rf(ctx, userID, invitationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, invitationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdStorer_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type HouseholdStorer_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - invitationID uuid.UUID
+func (_e *HouseholdStorer_Expecter) AcceptInvitation(ctx interface{}, userID interface{}, invitationID interface{}) *HouseholdStorer_AcceptInvitation_Call {
+	return &HouseholdStorer_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, userID, invitationID)}
+}
+
+func (_c *HouseholdStorer_AcceptInvitation_Call) Run(run func(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID)) *HouseholdStorer_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_AcceptInvitation_Call) Return(_a0 *model.HouseholdMember, _a1 error) *HouseholdStorer_AcceptInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdStorer_AcceptInvitation_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.HouseholdMember, error)) *HouseholdStorer_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvitation provides a mock function with given fields: ctx, userID, invitationID
+func (_m *HouseholdStorer) DeleteInvitation(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, invitationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, invitationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HouseholdStorer_DeleteInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvitation'
+type HouseholdStorer_DeleteInvitation_Call struct {
+	*mock.Call
+}
+
+// DeleteInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - invitationID uuid.UUID
+func (_e *HouseholdStorer_Expecter) DeleteInvitation(ctx interface{}, userID interface{}, invitationID interface{}) *HouseholdStorer_DeleteInvitation_Call {
+	return &HouseholdStorer_DeleteInvitation_Call{Call: _e.mock.On("DeleteInvitation", ctx, userID, invitationID)}
+}
+
+func (_c *HouseholdStorer_DeleteInvitation_Call) Run(run func(ctx context.Context, userID uuid.UUID, invitationID uuid.UUID)) *HouseholdStorer_DeleteInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_DeleteInvitation_Call) Return(_a0 error) *HouseholdStorer_DeleteInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HouseholdStorer_DeleteInvitation_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *HouseholdStorer_DeleteInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMember provides a mock function with given fields: ctx, householdID, userID
+func (_m *HouseholdStorer) DeleteMember(ctx context.Context, householdID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, householdID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, householdID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HouseholdStorer_DeleteMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMember'
+type HouseholdStorer_DeleteMember_Call struct {
+	*mock.Call
+}
+
+// DeleteMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+//   - userID uuid.UUID
+func (_e *HouseholdStorer_Expecter) DeleteMember(ctx interface{}, householdID interface{}, userID interface{}) *HouseholdStorer_DeleteMember_Call {
+	return &HouseholdStorer_DeleteMember_Call{Call: _e.mock.On("DeleteMember", ctx, householdID, userID)}
+}
+
+func (_c *HouseholdStorer_DeleteMember_Call) Run(run func(ctx context.Context, householdID uuid.UUID, userID uuid.UUID)) *HouseholdStorer_DeleteMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_DeleteMember_Call) Return(_a0 error) *HouseholdStorer_DeleteMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HouseholdStorer_DeleteMember_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *HouseholdStorer_DeleteMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, household
+func (_m *HouseholdStorer) Insert(ctx context.Context, household *model.Household) error {
+	ret := _m.Called(ctx, household)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Household) error); ok {
+		r0 = rf(ctx, household)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HouseholdStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type HouseholdStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - household *model.Household
+func (_e *HouseholdStorer_Expecter) Insert(ctx interface{}, household interface{}) *HouseholdStorer_Insert_Call {
+	return &HouseholdStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, household)}
+}
+
+func (_c *HouseholdStorer_Insert_Call) Run(run func(ctx context.Context, household *model.Household)) *HouseholdStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.Household))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_Insert_Call) Return(_a0 error) *HouseholdStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HouseholdStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.Household) error) *HouseholdStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertInvitation provides a mock function with given fields: ctx, invitation
+func (_m *HouseholdStorer) InsertInvitation(ctx context.Context, invitation *model.HouseholdInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.HouseholdInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HouseholdStorer_InsertInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertInvitation'
+type HouseholdStorer_InsertInvitation_Call struct {
+	*mock.Call
+}
+
+// InsertInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitation *model.HouseholdInvitation
+func (_e *HouseholdStorer_Expecter) InsertInvitation(ctx interface{}, invitation interface{}) *HouseholdStorer_InsertInvitation_Call {
+	return &HouseholdStorer_InsertInvitation_Call{Call: _e.mock.On("InsertInvitation", ctx, invitation)}
+}
+
+func (_c *HouseholdStorer_InsertInvitation_Call) Run(run func(ctx context.Context, invitation *model.HouseholdInvitation)) *HouseholdStorer_InsertInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.HouseholdInvitation))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_InsertInvitation_Call) Return(_a0 error) *HouseholdStorer_InsertInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HouseholdStorer_InsertInvitation_Call) RunAndReturn(run func(context.Context, *model.HouseholdInvitation) error) *HouseholdStorer_InsertInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectHouseholdsByUserID provides a mock function with given fields: ctx, userID
+func (_m *HouseholdStorer) SelectHouseholdsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Household, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectHouseholdsByUserID")
+	}
+
+	var r0 []*model.Household
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.Household, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.Household); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Household)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdStorer_SelectHouseholdsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectHouseholdsByUserID'
+type HouseholdStorer_SelectHouseholdsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectHouseholdsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *HouseholdStorer_Expecter) SelectHouseholdsByUserID(ctx interface{}, userID interface{}) *HouseholdStorer_SelectHouseholdsByUserID_Call {
+	return &HouseholdStorer_SelectHouseholdsByUserID_Call{Call: _e.mock.On("SelectHouseholdsByUserID", ctx, userID)}
+}
+
+func (_c *HouseholdStorer_SelectHouseholdsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *HouseholdStorer_SelectHouseholdsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectHouseholdsByUserID_Call) Return(_a0 []*model.Household, _a1 error) *HouseholdStorer_SelectHouseholdsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectHouseholdsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.Household, error)) *HouseholdStorer_SelectHouseholdsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectInvitationsByUserID provides a mock function with given fields: ctx, userID
+func (_m *HouseholdStorer) SelectInvitationsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.HouseholdInvitation, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectInvitationsByUserID")
+	}
+
+	var r0 []*model.HouseholdInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.HouseholdInvitation, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.HouseholdInvitation); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HouseholdInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdStorer_SelectInvitationsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectInvitationsByUserID'
+type HouseholdStorer_SelectInvitationsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectInvitationsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *HouseholdStorer_Expecter) SelectInvitationsByUserID(ctx interface{}, userID interface{}) *HouseholdStorer_SelectInvitationsByUserID_Call {
+	return &HouseholdStorer_SelectInvitationsByUserID_Call{Call: _e.mock.On("SelectInvitationsByUserID", ctx, userID)}
+}
+
+func (_c *HouseholdStorer_SelectInvitationsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *HouseholdStorer_SelectInvitationsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectInvitationsByUserID_Call) Return(_a0 []*model.HouseholdInvitation, _a1 error) *HouseholdStorer_SelectInvitationsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectInvitationsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.HouseholdInvitation, error)) *HouseholdStorer_SelectInvitationsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectMembers provides a mock function with given fields: ctx, householdID
+func (_m *HouseholdStorer) SelectMembers(ctx context.Context, householdID uuid.UUID) ([]*model.HouseholdMember, error) {
+	ret := _m.Called(ctx, householdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMembers")
+	}
+
+	var r0 []*model.HouseholdMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.HouseholdMember, error)); ok {
+		return rf(ctx, householdID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.HouseholdMember); ok {
+		r0 = rf(ctx, householdID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HouseholdMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, householdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdStorer_SelectMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMembers'
+type HouseholdStorer_SelectMembers_Call struct {
+	*mock.Call
+}
+
+// SelectMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+func (_e *HouseholdStorer_Expecter) SelectMembers(ctx interface{}, householdID interface{}) *HouseholdStorer_SelectMembers_Call {
+	return &HouseholdStorer_SelectMembers_Call{Call: _e.mock.On("SelectMembers", ctx, householdID)}
+}
+
+func (_c *HouseholdStorer_SelectMembers_Call) Run(run func(ctx context.Context, householdID uuid.UUID)) *HouseholdStorer_SelectMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectMembers_Call) Return(_a0 []*model.HouseholdMember, _a1 error) *HouseholdStorer_SelectMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectMembers_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.HouseholdMember, error)) *HouseholdStorer_SelectMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectSpending provides a mock function with given fields: ctx, householdID, from, to
+func (_m *HouseholdStorer) SelectSpending(ctx context.Context, householdID uuid.UUID, from time.Time, to time.Time) ([]*model.HouseholdSpending, error) {
+	ret := _m.Called(ctx, householdID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSpending")
+	}
+
+	var r0 []*model.HouseholdSpending
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) ([]*model.HouseholdSpending, error)); ok {
+		return rf(ctx, householdID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, time.Time) []*model.HouseholdSpending); ok {
+		r0 = rf(ctx, householdID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.HouseholdSpending)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, householdID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdStorer_SelectSpending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSpending'
+type HouseholdStorer_SelectSpending_Call struct {
+	*mock.Call
+}
+
+// SelectSpending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+//   - from time.Time
+//   - to time.Time
+func (_e *HouseholdStorer_Expecter) SelectSpending(ctx interface{}, householdID interface{}, from interface{}, to interface{}) *HouseholdStorer_SelectSpending_Call {
+	return &HouseholdStorer_SelectSpending_Call{Call: _e.mock.On("SelectSpending", ctx, householdID, from, to)}
+}
+
+func (_c *HouseholdStorer_SelectSpending_Call) Run(run func(ctx context.Context, householdID uuid.UUID, from time.Time, to time.Time)) *HouseholdStorer_SelectSpending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectSpending_Call) Return(_a0 []*model.HouseholdSpending, _a1 error) *HouseholdStorer_SelectSpending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdStorer_SelectSpending_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, time.Time) ([]*model.HouseholdSpending, error)) *HouseholdStorer_SelectSpending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, householdID, userID, role
+func (_m *HouseholdStorer) UpdateMemberRole(ctx context.Context, householdID uuid.UUID, userID uuid.UUID, role string) error {
+	ret := _m.Called(ctx, householdID, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, householdID, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HouseholdStorer_UpdateMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMemberRole'
+type HouseholdStorer_UpdateMemberRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - householdID uuid.UUID
+//   - userID uuid.UUID
+//   - role string
+func (_e *HouseholdStorer_Expecter) UpdateMemberRole(ctx interface{}, householdID interface{}, userID interface{}, role interface{}) *HouseholdStorer_UpdateMemberRole_Call {
+	return &HouseholdStorer_UpdateMemberRole_Call{Call: _e.mock.On("UpdateMemberRole", ctx, householdID, userID, role)}
+}
+
+func (_c *HouseholdStorer_UpdateMemberRole_Call) Run(run func(ctx context.Context, householdID uuid.UUID, userID uuid.UUID, role string)) *HouseholdStorer_UpdateMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *HouseholdStorer_UpdateMemberRole_Call) Return(_a0 error) *HouseholdStorer_UpdateMemberRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HouseholdStorer_UpdateMemberRole_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string) error) *HouseholdStorer_UpdateMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHouseholdStorer creates a new instance of HouseholdStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHouseholdStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HouseholdStorer {
+	mock := &HouseholdStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// HouseholdUserStorer is an autogenerated mock type for the HouseholdUserStorer type
+type HouseholdUserStorer struct {
+	mock.Mock
+}
+
+type HouseholdUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HouseholdUserStorer) EXPECT() *HouseholdUserStorer_Expecter {
+	return &HouseholdUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByLogin provides a mock function with given fields: ctx, login
+func (_m *HouseholdUserStorer) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByLogin")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HouseholdUserStorer_GetUserByLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByLogin'
+type HouseholdUserStorer_GetUserByLogin_Call struct {
+	*mock.Call
+}
+
+// GetUserByLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *HouseholdUserStorer_Expecter) GetUserByLogin(ctx interface{}, login interface{}) *HouseholdUserStorer_GetUserByLogin_Call {
+	return &HouseholdUserStorer_GetUserByLogin_Call{Call: _e.mock.On("GetUserByLogin", ctx, login)}
+}
+
+func (_c *HouseholdUserStorer_GetUserByLogin_Call) Run(run func(ctx context.Context, login string)) *HouseholdUserStorer_GetUserByLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *HouseholdUserStorer_GetUserByLogin_Call) Return(_a0 *model.User, _a1 error) *HouseholdUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HouseholdUserStorer_GetUserByLogin_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *HouseholdUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHouseholdUserStorer creates a new instance of HouseholdUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHouseholdUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HouseholdUserStorer {
+	mock := &HouseholdUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// OIDCProvider is an autogenerated mock type for the OIDCProvider type
+type OIDCProvider struct {
+	mock.Mock
+}
+
+type OIDCProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCProvider) EXPECT() *OIDCProvider_Expecter {
+	return &OIDCProvider_Expecter{mock: &_m.Mock}
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce, codeChallenge
+func (_m *OIDCProvider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, codeChallenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, state, nonce, codeChallenge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, codeChallenge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCProvider_AuthCodeURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCodeURL'
+type OIDCProvider_AuthCodeURL_Call struct {
+	*mock.Call
+}
+
+// AuthCodeURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state string
+//   - nonce string
+//   - codeChallenge string
+func (_e *OIDCProvider_Expecter) AuthCodeURL(ctx interface{}, state interface{}, nonce interface{}, codeChallenge interface{}) *OIDCProvider_AuthCodeURL_Call {
+	return &OIDCProvider_AuthCodeURL_Call{Call: _e.mock.On("AuthCodeURL", ctx, state, nonce, codeChallenge)}
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) Run(run func(ctx context.Context, state string, nonce string, codeChallenge string)) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) Return(_a0 string, _a1 error) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCProvider_AuthCodeURL_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *OIDCProvider_AuthCodeURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exchange provides a mock function with given fields: ctx, code, codeVerifier, nonce
+func (_m *OIDCProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*oidc.Claims, error) {
+	ret := _m.Called(ctx, code, codeVerifier, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 *oidc.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*oidc.Claims, error)); ok {
+		return rf(ctx, code, codeVerifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *oidc.Claims); ok {
+		r0 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCProvider_Exchange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exchange'
+type OIDCProvider_Exchange_Call struct {
+	*mock.Call
+}
+
+// Exchange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+//   - codeVerifier string
+//   - nonce string
+func (_e *OIDCProvider_Expecter) Exchange(ctx interface{}, code interface{}, codeVerifier interface{}, nonce interface{}) *OIDCProvider_Exchange_Call {
+	return &OIDCProvider_Exchange_Call{Call: _e.mock.On("Exchange", ctx, code, codeVerifier, nonce)}
+}
+
+func (_c *OIDCProvider_Exchange_Call) Run(run func(ctx context.Context, code string, codeVerifier string, nonce string)) *OIDCProvider_Exchange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCProvider_Exchange_Call) Return(_a0 *oidc.Claims, _a1 error) *OIDCProvider_Exchange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCProvider_Exchange_Call) RunAndReturn(run func(context.Context, string, string, string) (*oidc.Claims, error)) *OIDCProvider_Exchange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCProvider creates a new instance of OIDCProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCProvider {
+	mock := &OIDCProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// OIDCSessionOpener is an autogenerated mock type for the OIDCSessionOpener type
+type OIDCSessionOpener struct {
+	mock.Mock
+}
+
+type OIDCSessionOpener_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCSessionOpener) EXPECT() *OIDCSessionOpener_Expecter {
+	return &OIDCSessionOpener_Expecter{mock: &_m.Mock}
+}
+
+// OpenSession provides a mock function with given fields: ctx, user, session
+func (_m *OIDCSessionOpener) OpenSession(ctx context.Context, user *model.User, session *model.Session) (*response.Login, error) {
+	ret := _m.Called(ctx, user, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenSession")
+	}
+
+	var r0 *response.Login
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Session) (*response.Login, error)); ok {
+		return rf(ctx, user, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Session) *response.Login); ok {
+		r0 = rf(ctx, user, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Login)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Session) error); ok {
+		r1 = rf(ctx, user, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCSessionOpener_OpenSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenSession'
+type OIDCSessionOpener_OpenSession_Call struct {
+	*mock.Call
+}
+
+// OpenSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+//   - session *model.Session
+func (_e *OIDCSessionOpener_Expecter) OpenSession(ctx interface{}, user interface{}, session interface{}) *OIDCSessionOpener_OpenSession_Call {
+	return &OIDCSessionOpener_OpenSession_Call{Call: _e.mock.On("OpenSession", ctx, user, session)}
+}
+
+func (_c *OIDCSessionOpener_OpenSession_Call) Run(run func(ctx context.Context, user *model.User, session *model.Session)) *OIDCSessionOpener_OpenSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(*model.Session))
+	})
+	return _c
+}
+
+func (_c *OIDCSessionOpener_OpenSession_Call) Return(_a0 *response.Login, _a1 error) *OIDCSessionOpener_OpenSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCSessionOpener_OpenSession_Call) RunAndReturn(run func(context.Context, *model.User, *model.Session) (*response.Login, error)) *OIDCSessionOpener_OpenSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TwoFactorChallenge provides a mock function with given fields: ctx, user, session
+func (_m *OIDCSessionOpener) TwoFactorChallenge(ctx context.Context, user *model.User, session *model.Session) (*model.LoginChallenge, error) {
+	ret := _m.Called(ctx, user, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TwoFactorChallenge")
+	}
+
+	var r0 *model.LoginChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Session) (*model.LoginChallenge, error)); ok {
+		return rf(ctx, user, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.Session) *model.LoginChallenge); ok {
+		r0 = rf(ctx, user, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, *model.Session) error); ok {
+		r1 = rf(ctx, user, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCSessionOpener_TwoFactorChallenge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TwoFactorChallenge'
+type OIDCSessionOpener_TwoFactorChallenge_Call struct {
+	*mock.Call
+}
+
+// TwoFactorChallenge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+//   - session *model.Session
+func (_e *OIDCSessionOpener_Expecter) TwoFactorChallenge(ctx interface{}, user interface{}, session interface{}) *OIDCSessionOpener_TwoFactorChallenge_Call {
+	return &OIDCSessionOpener_TwoFactorChallenge_Call{Call: _e.mock.On("TwoFactorChallenge", ctx, user, session)}
+}
+
+func (_c *OIDCSessionOpener_TwoFactorChallenge_Call) Run(run func(ctx context.Context, user *model.User, session *model.Session)) *OIDCSessionOpener_TwoFactorChallenge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(*model.Session))
+	})
+	return _c
+}
+
+func (_c *OIDCSessionOpener_TwoFactorChallenge_Call) Return(_a0 *model.LoginChallenge, _a1 error) *OIDCSessionOpener_TwoFactorChallenge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCSessionOpener_TwoFactorChallenge_Call) RunAndReturn(run func(context.Context, *model.User, *model.Session) (*model.LoginChallenge, error)) *OIDCSessionOpener_TwoFactorChallenge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCSessionOpener creates a new instance of OIDCSessionOpener. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCSessionOpener(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCSessionOpener {
+	mock := &OIDCSessionOpener{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// OIDCStorer is an autogenerated mock type for the OIDCStorer type
+type OIDCStorer struct {
+	mock.Mock
+}
+
+type OIDCStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCStorer) EXPECT() *OIDCStorer_Expecter {
+	return &OIDCStorer_Expecter{mock: &_m.Mock}
+}
+
+// InsertIdentity provides a mock function with given fields: ctx, identity
+func (_m *OIDCStorer) InsertIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCStorer_InsertIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertIdentity'
+type OIDCStorer_InsertIdentity_Call struct {
+	*mock.Call
+}
+
+// InsertIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identity *model.UserIdentity
+func (_e *OIDCStorer_Expecter) InsertIdentity(ctx interface{}, identity interface{}) *OIDCStorer_InsertIdentity_Call {
+	return &OIDCStorer_InsertIdentity_Call{Call: _e.mock.On("InsertIdentity", ctx, identity)}
+}
+
+func (_c *OIDCStorer_InsertIdentity_Call) Run(run func(ctx context.Context, identity *model.UserIdentity)) *OIDCStorer_InsertIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserIdentity))
+	})
+	return _c
+}
+
+func (_c *OIDCStorer_InsertIdentity_Call) Return(_a0 error) *OIDCStorer_InsertIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCStorer_InsertIdentity_Call) RunAndReturn(run func(context.Context, *model.UserIdentity) error) *OIDCStorer_InsertIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertState provides a mock function with given fields: ctx, state
+func (_m *OIDCStorer) InsertState(ctx context.Context, state *model.OIDCState) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OIDCState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCStorer_InsertState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertState'
+type OIDCStorer_InsertState_Call struct {
+	*mock.Call
+}
+
+// InsertState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state *model.OIDCState
+func (_e *OIDCStorer_Expecter) InsertState(ctx interface{}, state interface{}) *OIDCStorer_InsertState_Call {
+	return &OIDCStorer_InsertState_Call{Call: _e.mock.On("InsertState", ctx, state)}
+}
+
+func (_c *OIDCStorer_InsertState_Call) Run(run func(ctx context.Context, state *model.OIDCState)) *OIDCStorer_InsertState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.OIDCState))
+	})
+	return _c
+}
+
+func (_c *OIDCStorer_InsertState_Call) Return(_a0 error) *OIDCStorer_InsertState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCStorer_InsertState_Call) RunAndReturn(run func(context.Context, *model.OIDCState) error) *OIDCStorer_InsertState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertUserWithIdentity provides a mock function with given fields: ctx, user, identity
+func (_m *OIDCStorer) InsertUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) error {
+	ret := _m.Called(ctx, user, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertUserWithIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, *model.UserIdentity) error); ok {
+		r0 = rf(ctx, user, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCStorer_InsertUserWithIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertUserWithIdentity'
+type OIDCStorer_InsertUserWithIdentity_Call struct {
+	*mock.Call
+}
+
+// InsertUserWithIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *model.User
+//   - identity *model.UserIdentity
+func (_e *OIDCStorer_Expecter) InsertUserWithIdentity(ctx interface{}, user interface{}, identity interface{}) *OIDCStorer_InsertUserWithIdentity_Call {
+	return &OIDCStorer_InsertUserWithIdentity_Call{Call: _e.mock.On("InsertUserWithIdentity", ctx, user, identity)}
+}
+
+func (_c *OIDCStorer_InsertUserWithIdentity_Call) Run(run func(ctx context.Context, user *model.User, identity *model.UserIdentity)) *OIDCStorer_InsertUserWithIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User), args[2].(*model.UserIdentity))
+	})
+	return _c
+}
+
+func (_c *OIDCStorer_InsertUserWithIdentity_Call) Return(_a0 error) *OIDCStorer_InsertUserWithIdentity_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCStorer_InsertUserWithIdentity_Call) RunAndReturn(run func(context.Context, *model.User, *model.UserIdentity) error) *OIDCStorer_InsertUserWithIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *OIDCStorer) LoginIdentity(ctx context.Context, issuer string, subject string) (*model.User, error) {
+	ret := _m.Called(ctx, issuer, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginIdentity")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.User, error)); ok {
+		return rf(ctx, issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.User); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCStorer_LoginIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginIdentity'
+type OIDCStorer_LoginIdentity_Call struct {
+	*mock.Call
+}
+
+// LoginIdentity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+//   - subject string
+func (_e *OIDCStorer_Expecter) LoginIdentity(ctx interface{}, issuer interface{}, subject interface{}) *OIDCStorer_LoginIdentity_Call {
+	return &OIDCStorer_LoginIdentity_Call{Call: _e.mock.On("LoginIdentity", ctx, issuer, subject)}
+}
+
+func (_c *OIDCStorer_LoginIdentity_Call) Run(run func(ctx context.Context, issuer string, subject string)) *OIDCStorer_LoginIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCStorer_LoginIdentity_Call) Return(_a0 *model.User, _a1 error) *OIDCStorer_LoginIdentity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCStorer_LoginIdentity_Call) RunAndReturn(run func(context.Context, string, string) (*model.User, error)) *OIDCStorer_LoginIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseState provides a mock function with given fields: ctx, state
+func (_m *OIDCStorer) UseState(ctx context.Context, state string) (*model.OIDCState, error) {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseState")
+	}
+
+	var r0 *model.OIDCState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.OIDCState, error)); ok {
+		return rf(ctx, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.OIDCState); ok {
+		r0 = rf(ctx, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OIDCState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCStorer_UseState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseState'
+type OIDCStorer_UseState_Call struct {
+	*mock.Call
+}
+
+// UseState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - state string
+func (_e *OIDCStorer_Expecter) UseState(ctx interface{}, state interface{}) *OIDCStorer_UseState_Call {
+	return &OIDCStorer_UseState_Call{Call: _e.mock.On("UseState", ctx, state)}
+}
+
+func (_c *OIDCStorer_UseState_Call) Run(run func(ctx context.Context, state string)) *OIDCStorer_UseState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCStorer_UseState_Call) Return(_a0 *model.OIDCState, _a1 error) *OIDCStorer_UseState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCStorer_UseState_Call) RunAndReturn(run func(context.Context, string) (*model.OIDCState, error)) *OIDCStorer_UseState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCStorer creates a new instance of OIDCStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCStorer {
+	mock := &OIDCStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// OIDCUserStorer is an autogenerated mock type for the OIDCUserStorer type
+type OIDCUserStorer struct {
+	mock.Mock
+}
+
+type OIDCUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCUserStorer) EXPECT() *OIDCUserStorer_Expecter {
+	return &OIDCUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *OIDCUserStorer) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCUserStorer_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type OIDCUserStorer_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *OIDCUserStorer_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *OIDCUserStorer_GetUserByEmail_Call {
+	return &OIDCUserStorer_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *OIDCUserStorer_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *OIDCUserStorer_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCUserStorer_GetUserByEmail_Call) Return(_a0 *model.User, _a1 error) *OIDCUserStorer_GetUserByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCUserStorer_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *OIDCUserStorer_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByLogin provides a mock function with given fields: ctx, login
+func (_m *OIDCUserStorer) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByLogin")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCUserStorer_GetUserByLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByLogin'
+type OIDCUserStorer_GetUserByLogin_Call struct {
+	*mock.Call
+}
+
+// GetUserByLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login string
+func (_e *OIDCUserStorer_Expecter) GetUserByLogin(ctx interface{}, login interface{}) *OIDCUserStorer_GetUserByLogin_Call {
+	return &OIDCUserStorer_GetUserByLogin_Call{Call: _e.mock.On("GetUserByLogin", ctx, login)}
+}
+
+func (_c *OIDCUserStorer_GetUserByLogin_Call) Run(run func(ctx context.Context, login string)) *OIDCUserStorer_GetUserByLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCUserStorer_GetUserByLogin_Call) Return(_a0 *model.User, _a1 error) *OIDCUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCUserStorer_GetUserByLogin_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *OIDCUserStorer_GetUserByLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCUserStorer creates a new instance of OIDCUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCUserStorer {
+	mock := &OIDCUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// PriceHistoryStorer is an autogenerated mock type for the PriceHistoryStorer type
+type PriceHistoryStorer struct {
+	mock.Mock
+}
+
+type PriceHistoryStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PriceHistoryStorer) EXPECT() *PriceHistoryStorer_Expecter {
+	return &PriceHistoryStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectPriceHistory provides a mock function with given fields: ctx, productID, filter
+func (_m *PriceHistoryStorer) SelectPriceHistory(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter) ([]*model.PricePoint, error) {
+	ret := _m.Called(ctx, productID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectPriceHistory")
+	}
+
+	var r0 []*model.PricePoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.PriceHistoryFilter) ([]*model.PricePoint, error)); ok {
+		return rf(ctx, productID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.PriceHistoryFilter) []*model.PricePoint); ok {
+		r0 = rf(ctx, productID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PricePoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.PriceHistoryFilter) error); ok {
+		r1 = rf(ctx, productID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PriceHistoryStorer_SelectPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectPriceHistory'
+type PriceHistoryStorer_SelectPriceHistory_Call struct {
+	*mock.Call
+}
+
+// SelectPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID uuid.UUID
+//   - filter *model.PriceHistoryFilter
+func (_e *PriceHistoryStorer_Expecter) SelectPriceHistory(ctx interface{}, productID interface{}, filter interface{}) *PriceHistoryStorer_SelectPriceHistory_Call {
+	return &PriceHistoryStorer_SelectPriceHistory_Call{Call: _e.mock.On("SelectPriceHistory", ctx, productID, filter)}
+}
+
+func (_c *PriceHistoryStorer_SelectPriceHistory_Call) Run(run func(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter)) *PriceHistoryStorer_SelectPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.PriceHistoryFilter))
+	})
+	return _c
+}
+
+func (_c *PriceHistoryStorer_SelectPriceHistory_Call) Return(_a0 []*model.PricePoint, _a1 error) *PriceHistoryStorer_SelectPriceHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PriceHistoryStorer_SelectPriceHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.PriceHistoryFilter) ([]*model.PricePoint, error)) *PriceHistoryStorer_SelectPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPriceHistoryStorer creates a new instance of PriceHistoryStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPriceHistoryStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PriceHistoryStorer {
+	mock := &PriceHistoryStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// PriceProductStorer is an autogenerated mock type for the PriceProductStorer type
+type PriceProductStorer struct {
+	mock.Mock
+}
+
+type PriceProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PriceProductStorer) EXPECT() *PriceProductStorer_Expecter {
+	return &PriceProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetProductByEAN provides a mock function with given fields: ctx, ean
+func (_m *PriceProductStorer) GetProductByEAN(ctx context.Context, ean string) (*model.Product, error) {
+	ret := _m.Called(ctx, ean)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByEAN")
+	}
+
+	var r0 *model.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Product, error)); ok {
+		return rf(ctx, ean)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Product); ok {
+		r0 = rf(ctx, ean)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ean)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PriceProductStorer_GetProductByEAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByEAN'
+type PriceProductStorer_GetProductByEAN_Call struct {
+	*mock.Call
+}
+
+// GetProductByEAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ean string
+func (_e *PriceProductStorer_Expecter) GetProductByEAN(ctx interface{}, ean interface{}) *PriceProductStorer_GetProductByEAN_Call {
+	return &PriceProductStorer_GetProductByEAN_Call{Call: _e.mock.On("GetProductByEAN", ctx, ean)}
+}
+
+func (_c *PriceProductStorer_GetProductByEAN_Call) Run(run func(ctx context.Context, ean string)) *PriceProductStorer_GetProductByEAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PriceProductStorer_GetProductByEAN_Call) Return(_a0 *model.Product, _a1 error) *PriceProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PriceProductStorer_GetProductByEAN_Call) RunAndReturn(run func(context.Context, string) (*model.Product, error)) *PriceProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPriceProductStorer creates a new instance of PriceProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPriceProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PriceProductStorer {
+	mock := &PriceProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// PriceStorer is an autogenerated mock type for the PriceStorer type
+type PriceStorer struct {
+	mock.Mock
+}
+
+type PriceStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PriceStorer) EXPECT() *PriceStorer_Expecter {
+	return &PriceStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMostRecentPricesByProductID provides a mock function with given fields: ctx, productID, filter
+func (_m *PriceStorer) SelectMostRecentPricesByProductID(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	ret := _m.Called(ctx, productID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMostRecentPricesByProductID")
+	}
+
+	var r0 []*model.StorePrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.PriceFilter) ([]*model.StorePrice, error)); ok {
+		return rf(ctx, productID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *model.PriceFilter) []*model.StorePrice); ok {
+		r0 = rf(ctx, productID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StorePrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *model.PriceFilter) error); ok {
+		r1 = rf(ctx, productID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PriceStorer_SelectMostRecentPricesByProductID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMostRecentPricesByProductID'
+type PriceStorer_SelectMostRecentPricesByProductID_Call struct {
+	*mock.Call
+}
+
+// SelectMostRecentPricesByProductID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID uuid.UUID
+//   - filter *model.PriceFilter
+func (_e *PriceStorer_Expecter) SelectMostRecentPricesByProductID(ctx interface{}, productID interface{}, filter interface{}) *PriceStorer_SelectMostRecentPricesByProductID_Call {
+	return &PriceStorer_SelectMostRecentPricesByProductID_Call{Call: _e.mock.On("SelectMostRecentPricesByProductID", ctx, productID, filter)}
+}
+
+func (_c *PriceStorer_SelectMostRecentPricesByProductID_Call) Run(run func(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter)) *PriceStorer_SelectMostRecentPricesByProductID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*model.PriceFilter))
+	})
+	return _c
+}
+
+func (_c *PriceStorer_SelectMostRecentPricesByProductID_Call) Return(_a0 []*model.StorePrice, _a1 error) *PriceStorer_SelectMostRecentPricesByProductID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PriceStorer_SelectMostRecentPricesByProductID_Call) RunAndReturn(run func(context.Context, uuid.UUID, *model.PriceFilter) ([]*model.StorePrice, error)) *PriceStorer_SelectMostRecentPricesByProductID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPriceStorer creates a new instance of PriceStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPriceStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PriceStorer {
+	mock := &PriceStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// PriceUserProductStorer is an autogenerated mock type for the PriceUserProductStorer type
+type PriceUserProductStorer struct {
+	mock.Mock
+}
+
+type PriceUserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PriceUserProductStorer) EXPECT() *PriceUserProductStorer_Expecter {
+	return &PriceUserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMostRecentUserProductByStoreID provides a mock function with given fields: ctx, storeID
+func (_m *PriceUserProductStorer) SelectMostRecentUserProductByStoreID(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMostRecentUserProductByStoreID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMostRecentUserProductByStoreID'
+type PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call struct {
+	*mock.Call
+}
+
+// SelectMostRecentUserProductByStoreID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID uuid.UUID
+func (_e *PriceUserProductStorer_Expecter) SelectMostRecentUserProductByStoreID(ctx interface{}, storeID interface{}) *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	return &PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call{Call: _e.mock.On("SelectMostRecentUserProductByStoreID", ctx, storeID)}
+}
+
+func (_c *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call) Run(run func(ctx context.Context, storeID uuid.UUID)) *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call) Return(_a0 []*model.UserProduct, _a1 error) *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *PriceUserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectProductsByUserIDAndStoreID provides a mock function with given fields: ctx, userID, storeID
+func (_m *PriceUserProductStorer) SelectProductsByUserIDAndStoreID(ctx context.Context, userID uuid.UUID, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByUserIDAndStoreID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, userID, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, userID, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByUserIDAndStoreID'
+type PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByUserIDAndStoreID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - storeID uuid.UUID
+func (_e *PriceUserProductStorer_Expecter) SelectProductsByUserIDAndStoreID(ctx interface{}, userID interface{}, storeID interface{}) *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call {
+	return &PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call{Call: _e.mock.On("SelectProductsByUserIDAndStoreID", ctx, userID, storeID)}
+}
+
+func (_c *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call) Run(run func(ctx context.Context, userID uuid.UUID, storeID uuid.UUID)) *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call) Return(_a0 []*model.UserProduct, _a1 error) *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)) *PriceUserProductStorer_SelectProductsByUserIDAndStoreID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPriceUserProductStorer creates a new instance of PriceUserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPriceUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PriceUserProductStorer {
+	mock := &PriceUserProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptAuthorizer is an autogenerated mock type for the ReceiptAuthorizer type
+type ReceiptAuthorizer struct {
+	mock.Mock
+}
+
+type ReceiptAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptAuthorizer) EXPECT() *ReceiptAuthorizer_Expecter {
+	return &ReceiptAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// OpenBill provides a mock function with given fields: ctx, userID, billID
+func (_m *ReceiptAuthorizer) OpenBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptAuthorizer_OpenBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBill'
+type ReceiptAuthorizer_OpenBill_Call struct {
+	*mock.Call
+}
+
+// OpenBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *ReceiptAuthorizer_Expecter) OpenBill(ctx interface{}, userID interface{}, billID interface{}) *ReceiptAuthorizer_OpenBill_Call {
+	return &ReceiptAuthorizer_OpenBill_Call{Call: _e.mock.On("OpenBill", ctx, userID, billID)}
+}
+
+func (_c *ReceiptAuthorizer_OpenBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *ReceiptAuthorizer_OpenBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptAuthorizer_OpenBill_Call) Return(_a0 *model.Bill, _a1 error) *ReceiptAuthorizer_OpenBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptAuthorizer_OpenBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *ReceiptAuthorizer_OpenBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptAuthorizer creates a new instance of ReceiptAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptAuthorizer {
+	mock := &ReceiptAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptImportBillUseCase is an autogenerated mock type for the ReceiptImportBillUseCase type
+type ReceiptImportBillUseCase struct {
+	mock.Mock
+}
+
+type ReceiptImportBillUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptImportBillUseCase) EXPECT() *ReceiptImportBillUseCase_Expecter {
+	return &ReceiptImportBillUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CancelBill provides a mock function with given fields: ctx, userID, billID
+func (_m *ReceiptImportBillUseCase) CancelBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) error {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelBill")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReceiptImportBillUseCase_CancelBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelBill'
+type ReceiptImportBillUseCase_CancelBill_Call struct {
+	*mock.Call
+}
+
+// CancelBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *ReceiptImportBillUseCase_Expecter) CancelBill(ctx interface{}, userID interface{}, billID interface{}) *ReceiptImportBillUseCase_CancelBill_Call {
+	return &ReceiptImportBillUseCase_CancelBill_Call{Call: _e.mock.On("CancelBill", ctx, userID, billID)}
+}
+
+func (_c *ReceiptImportBillUseCase_CancelBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *ReceiptImportBillUseCase_CancelBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_CancelBill_Call) Return(_a0 error) *ReceiptImportBillUseCase_CancelBill_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_CancelBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *ReceiptImportBillUseCase_CancelBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseBill provides a mock function with given fields: ctx, userID, billID, amount
+func (_m *ReceiptImportBillUseCase) CloseBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID, amount model.Money) (*response.Bill, error) {
+	ret := _m.Called(ctx, userID, billID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseBill")
+	}
+
+	var r0 *response.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, model.Money) (*response.Bill, error)); ok {
+		return rf(ctx, userID, billID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, model.Money) *response.Bill); ok {
+		r0 = rf(ctx, userID, billID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, model.Money) error); ok {
+		r1 = rf(ctx, userID, billID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportBillUseCase_CloseBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseBill'
+type ReceiptImportBillUseCase_CloseBill_Call struct {
+	*mock.Call
+}
+
+// CloseBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+//   - amount model.Money
+func (_e *ReceiptImportBillUseCase_Expecter) CloseBill(ctx interface{}, userID interface{}, billID interface{}, amount interface{}) *ReceiptImportBillUseCase_CloseBill_Call {
+	return &ReceiptImportBillUseCase_CloseBill_Call{Call: _e.mock.On("CloseBill", ctx, userID, billID, amount)}
+}
+
+func (_c *ReceiptImportBillUseCase_CloseBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID, amount model.Money)) *ReceiptImportBillUseCase_CloseBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(model.Money))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_CloseBill_Call) Return(_a0 *response.Bill, _a1 error) *ReceiptImportBillUseCase_CloseBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_CloseBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, model.Money) (*response.Bill, error)) *ReceiptImportBillUseCase_CloseBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenBill provides a mock function with given fields: ctx, userID, billID
+func (_m *ReceiptImportBillUseCase) GetOpenBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*response.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenBill")
+	}
+
+	var r0 *response.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*response.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *response.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportBillUseCase_GetOpenBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenBill'
+type ReceiptImportBillUseCase_GetOpenBill_Call struct {
+	*mock.Call
+}
+
+// GetOpenBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *ReceiptImportBillUseCase_Expecter) GetOpenBill(ctx interface{}, userID interface{}, billID interface{}) *ReceiptImportBillUseCase_GetOpenBill_Call {
+	return &ReceiptImportBillUseCase_GetOpenBill_Call{Call: _e.mock.On("GetOpenBill", ctx, userID, billID)}
+}
+
+func (_c *ReceiptImportBillUseCase_GetOpenBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *ReceiptImportBillUseCase_GetOpenBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_GetOpenBill_Call) Return(_a0 *response.Bill, _a1 error) *ReceiptImportBillUseCase_GetOpenBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_GetOpenBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*response.Bill, error)) *ReceiptImportBillUseCase_GetOpenBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartDatedBill provides a mock function with given fields: ctx, userID, storeID, currency, date
+func (_m *ReceiptImportBillUseCase) StartDatedBill(ctx context.Context, userID uuid.UUID, storeID uuid.UUID, currency string, date time.Time) (*response.Bill, error) {
+	ret := _m.Called(ctx, userID, storeID, currency, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartDatedBill")
+	}
+
+	var r0 *response.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) (*response.Bill, error)); ok {
+		return rf(ctx, userID, storeID, currency, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) *response.Bill); ok {
+		r0 = rf(ctx, userID, storeID, currency, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, storeID, currency, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportBillUseCase_StartDatedBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartDatedBill'
+type ReceiptImportBillUseCase_StartDatedBill_Call struct {
+	*mock.Call
+}
+
+// StartDatedBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - storeID uuid.UUID
+//   - currency string
+//   - date time.Time
+func (_e *ReceiptImportBillUseCase_Expecter) StartDatedBill(ctx interface{}, userID interface{}, storeID interface{}, currency interface{}, date interface{}) *ReceiptImportBillUseCase_StartDatedBill_Call {
+	return &ReceiptImportBillUseCase_StartDatedBill_Call{Call: _e.mock.On("StartDatedBill", ctx, userID, storeID, currency, date)}
+}
+
+func (_c *ReceiptImportBillUseCase_StartDatedBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, storeID uuid.UUID, currency string, date time.Time)) *ReceiptImportBillUseCase_StartDatedBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_StartDatedBill_Call) Return(_a0 *response.Bill, _a1 error) *ReceiptImportBillUseCase_StartDatedBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportBillUseCase_StartDatedBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, string, time.Time) (*response.Bill, error)) *ReceiptImportBillUseCase_StartDatedBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptImportBillUseCase creates a new instance of ReceiptImportBillUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptImportBillUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptImportBillUseCase {
+	mock := &ReceiptImportBillUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptImportCompanyStorer is an autogenerated mock type for the ReceiptImportCompanyStorer type
+type ReceiptImportCompanyStorer struct {
+	mock.Mock
+}
+
+type ReceiptImportCompanyStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptImportCompanyStorer) EXPECT() *ReceiptImportCompanyStorer_Expecter {
+	return &ReceiptImportCompanyStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectCompanyByID provides a mock function with given fields: ctx, companyID
+func (_m *ReceiptImportCompanyStorer) SelectCompanyByID(ctx context.Context, companyID uuid.UUID) (*model.Company, error) {
+	ret := _m.Called(ctx, companyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectCompanyByID")
+	}
+
+	var r0 *model.Company
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Company, error)); ok {
+		return rf(ctx, companyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Company); ok {
+		r0 = rf(ctx, companyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Company)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, companyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportCompanyStorer_SelectCompanyByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCompanyByID'
+type ReceiptImportCompanyStorer_SelectCompanyByID_Call struct {
+	*mock.Call
+}
+
+// SelectCompanyByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - companyID uuid.UUID
+func (_e *ReceiptImportCompanyStorer_Expecter) SelectCompanyByID(ctx interface{}, companyID interface{}) *ReceiptImportCompanyStorer_SelectCompanyByID_Call {
+	return &ReceiptImportCompanyStorer_SelectCompanyByID_Call{Call: _e.mock.On("SelectCompanyByID", ctx, companyID)}
+}
+
+func (_c *ReceiptImportCompanyStorer_SelectCompanyByID_Call) Run(run func(ctx context.Context, companyID uuid.UUID)) *ReceiptImportCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportCompanyStorer_SelectCompanyByID_Call) Return(_a0 *model.Company, _a1 error) *ReceiptImportCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportCompanyStorer_SelectCompanyByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Company, error)) *ReceiptImportCompanyStorer_SelectCompanyByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptImportCompanyStorer creates a new instance of ReceiptImportCompanyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptImportCompanyStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptImportCompanyStorer {
+	mock := &ReceiptImportCompanyStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptImportParser is an autogenerated mock type for the ReceiptImportParser type
+type ReceiptImportParser struct {
+	mock.Mock
+}
+
+type ReceiptImportParser_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptImportParser) EXPECT() *ReceiptImportParser_Expecter {
+	return &ReceiptImportParser_Expecter{mock: &_m.Mock}
+}
+
+// ParseReceipt provides a mock function with given fields: company, raw, currency
+func (_m *ReceiptImportParser) ParseReceipt(company string, raw []byte, currency string) (*model.ParsedReceipt, error) {
+	ret := _m.Called(company, raw, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseReceipt")
+	}
+
+	var r0 *model.ParsedReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []byte, string) (*model.ParsedReceipt, error)); ok {
+		return rf(company, raw, currency)
+	}
+	if rf, ok := ret.Get(0).(func(string, []byte, string) *model.ParsedReceipt); ok {
+		r0 = rf(company, raw, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ParsedReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []byte, string) error); ok {
+		r1 = rf(company, raw, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportParser_ParseReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParseReceipt'
+type ReceiptImportParser_ParseReceipt_Call struct {
+	*mock.Call
+}
+
+// ParseReceipt is a helper method to define mock.On call
+//   - company string
+//   - raw []byte
+//   - currency string
+func (_e *ReceiptImportParser_Expecter) ParseReceipt(company interface{}, raw interface{}, currency interface{}) *ReceiptImportParser_ParseReceipt_Call {
+	return &ReceiptImportParser_ParseReceipt_Call{Call: _e.mock.On("ParseReceipt", company, raw, currency)}
+}
+
+func (_c *ReceiptImportParser_ParseReceipt_Call) Run(run func(company string, raw []byte, currency string)) *ReceiptImportParser_ParseReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]byte), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportParser_ParseReceipt_Call) Return(_a0 *model.ParsedReceipt, _a1 error) *ReceiptImportParser_ParseReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportParser_ParseReceipt_Call) RunAndReturn(run func(string, []byte, string) (*model.ParsedReceipt, error)) *ReceiptImportParser_ParseReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptImportParser creates a new instance of ReceiptImportParser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptImportParser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptImportParser {
+	mock := &ReceiptImportParser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptImportUserProductUseCase is an autogenerated mock type for the ReceiptImportUserProductUseCase type
+type ReceiptImportUserProductUseCase struct {
+	mock.Mock
+}
+
+type ReceiptImportUserProductUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptImportUserProductUseCase) EXPECT() *ReceiptImportUserProductUseCase_Expecter {
+	return &ReceiptImportUserProductUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, um, userID
+func (_m *ReceiptImportUserProductUseCase) Create(ctx context.Context, um *model.UserProduct, userID uuid.UUID) (*model.UserProduct, error) {
+	ret := _m.Called(ctx, um, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserProduct, uuid.UUID) (*model.UserProduct, error)); ok {
+		return rf(ctx, um, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserProduct, uuid.UUID) *model.UserProduct); ok {
+		r0 = rf(ctx, um, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UserProduct, uuid.UUID) error); ok {
+		r1 = rf(ctx, um, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptImportUserProductUseCase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ReceiptImportUserProductUseCase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - um *model.UserProduct
+//   - userID uuid.UUID
+func (_e *ReceiptImportUserProductUseCase_Expecter) Create(ctx interface{}, um interface{}, userID interface{}) *ReceiptImportUserProductUseCase_Create_Call {
+	return &ReceiptImportUserProductUseCase_Create_Call{Call: _e.mock.On("Create", ctx, um, userID)}
+}
+
+func (_c *ReceiptImportUserProductUseCase_Create_Call) Run(run func(ctx context.Context, um *model.UserProduct, userID uuid.UUID)) *ReceiptImportUserProductUseCase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserProduct), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptImportUserProductUseCase_Create_Call) Return(_a0 *model.UserProduct, _a1 error) *ReceiptImportUserProductUseCase_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptImportUserProductUseCase_Create_Call) RunAndReturn(run func(context.Context, *model.UserProduct, uuid.UUID) (*model.UserProduct, error)) *ReceiptImportUserProductUseCase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptImportUserProductUseCase creates a new instance of ReceiptImportUserProductUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptImportUserProductUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptImportUserProductUseCase {
+	mock := &ReceiptImportUserProductUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptRecognizer is an autogenerated mock type for the ReceiptRecognizer type
+type ReceiptRecognizer struct {
+	mock.Mock
+}
+
+type ReceiptRecognizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptRecognizer) EXPECT() *ReceiptRecognizer_Expecter {
+	return &ReceiptRecognizer_Expecter{mock: &_m.Mock}
+}
+
+// Recognize provides a mock function with given fields: ctx, image
+func (_m *ReceiptRecognizer) Recognize(ctx context.Context, image io.Reader) (string, error) {
+	ret := _m.Called(ctx, image)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recognize")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) (string, error)); ok {
+		return rf(ctx, image)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader) string); ok {
+		r0 = rf(ctx, image)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = rf(ctx, image)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptRecognizer_Recognize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recognize'
+type ReceiptRecognizer_Recognize_Call struct {
+	*mock.Call
+}
+
+// Recognize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - image io.Reader
+func (_e *ReceiptRecognizer_Expecter) Recognize(ctx interface{}, image interface{}) *ReceiptRecognizer_Recognize_Call {
+	return &ReceiptRecognizer_Recognize_Call{Call: _e.mock.On("Recognize", ctx, image)}
+}
+
+func (_c *ReceiptRecognizer_Recognize_Call) Run(run func(ctx context.Context, image io.Reader)) *ReceiptRecognizer_Recognize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *ReceiptRecognizer_Recognize_Call) Return(_a0 string, _a1 error) *ReceiptRecognizer_Recognize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptRecognizer_Recognize_Call) RunAndReturn(run func(context.Context, io.Reader) (string, error)) *ReceiptRecognizer_Recognize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptRecognizer creates a new instance of ReceiptRecognizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptRecognizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptRecognizer {
+	mock := &ReceiptRecognizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptStoreStorer is an autogenerated mock type for the ReceiptStoreStorer type
+type ReceiptStoreStorer struct {
+	mock.Mock
+}
+
+type ReceiptStoreStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptStoreStorer) EXPECT() *ReceiptStoreStorer_Expecter {
+	return &ReceiptStoreStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectStoreByID provides a mock function with given fields: ctx, storeID
+func (_m *ReceiptStoreStorer) SelectStoreByID(ctx context.Context, storeID uuid.UUID) (*model.Store, error) {
+	ret := _m.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectStoreByID")
+	}
+
+	var r0 *model.Store
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.Store, error)); ok {
+		return rf(ctx, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.Store); ok {
+		r0 = rf(ctx, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Store)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptStoreStorer_SelectStoreByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectStoreByID'
+type ReceiptStoreStorer_SelectStoreByID_Call struct {
+	*mock.Call
+}
+
+// SelectStoreByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID uuid.UUID
+func (_e *ReceiptStoreStorer_Expecter) SelectStoreByID(ctx interface{}, storeID interface{}) *ReceiptStoreStorer_SelectStoreByID_Call {
+	return &ReceiptStoreStorer_SelectStoreByID_Call{Call: _e.mock.On("SelectStoreByID", ctx, storeID)}
+}
+
+func (_c *ReceiptStoreStorer_SelectStoreByID_Call) Run(run func(ctx context.Context, storeID uuid.UUID)) *ReceiptStoreStorer_SelectStoreByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptStoreStorer_SelectStoreByID_Call) Return(_a0 *model.Store, _a1 error) *ReceiptStoreStorer_SelectStoreByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptStoreStorer_SelectStoreByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.Store, error)) *ReceiptStoreStorer_SelectStoreByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptStoreStorer creates a new instance of ReceiptStoreStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptStoreStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptStoreStorer {
+	mock := &ReceiptStoreStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ReceiptUserProductStorer is an autogenerated mock type for the ReceiptUserProductStorer type
+type ReceiptUserProductStorer struct {
+	mock.Mock
+}
+
+type ReceiptUserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReceiptUserProductStorer) EXPECT() *ReceiptUserProductStorer_Expecter {
+	return &ReceiptUserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMostRecentProductsByUserIDAndCompanyID provides a mock function with given fields: ctx, userID, companyID
+func (_m *ReceiptUserProductStorer) SelectMostRecentProductsByUserIDAndCompanyID(ctx context.Context, userID uuid.UUID, companyID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID, companyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMostRecentProductsByUserIDAndCompanyID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, userID, companyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, userID, companyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, companyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMostRecentProductsByUserIDAndCompanyID'
+type ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call struct {
+	*mock.Call
+}
+
+// SelectMostRecentProductsByUserIDAndCompanyID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - companyID uuid.UUID
+func (_e *ReceiptUserProductStorer_Expecter) SelectMostRecentProductsByUserIDAndCompanyID(ctx interface{}, userID interface{}, companyID interface{}) *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call {
+	return &ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call{Call: _e.mock.On("SelectMostRecentProductsByUserIDAndCompanyID", ctx, userID, companyID)}
+}
+
+func (_c *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call) Run(run func(ctx context.Context, userID uuid.UUID, companyID uuid.UUID)) *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call) Return(_a0 []*model.UserProduct, _a1 error) *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)) *ReceiptUserProductStorer_SelectMostRecentProductsByUserIDAndCompanyID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReceiptUserProductStorer creates a new instance of ReceiptUserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceiptUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceiptUserProductStorer {
+	mock := &ReceiptUserProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ShoppingListPriceStorer is an autogenerated mock type for the ShoppingListPriceStorer type
+type ShoppingListPriceStorer struct {
+	mock.Mock
+}
+
+type ShoppingListPriceStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ShoppingListPriceStorer) EXPECT() *ShoppingListPriceStorer_Expecter {
+	return &ShoppingListPriceStorer_Expecter{mock: &_m.Mock}
+}
+
+// SelectMostRecentPricesByProductIDs provides a mock function with given fields: ctx, productIDs, filter
+func (_m *ShoppingListPriceStorer) SelectMostRecentPricesByProductIDs(ctx context.Context, productIDs []uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	ret := _m.Called(ctx, productIDs, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMostRecentPricesByProductIDs")
+	}
+
+	var r0 []*model.StorePrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *model.PriceFilter) ([]*model.StorePrice, error)); ok {
+		return rf(ctx, productIDs, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *model.PriceFilter) []*model.StorePrice); ok {
+		r0 = rf(ctx, productIDs, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.StorePrice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *model.PriceFilter) error); ok {
+		r1 = rf(ctx, productIDs, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMostRecentPricesByProductIDs'
+type ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call struct {
+	*mock.Call
+}
+
+// SelectMostRecentPricesByProductIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productIDs []uuid.UUID
+//   - filter *model.PriceFilter
+func (_e *ShoppingListPriceStorer_Expecter) SelectMostRecentPricesByProductIDs(ctx interface{}, productIDs interface{}, filter interface{}) *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call {
+	return &ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call{Call: _e.mock.On("SelectMostRecentPricesByProductIDs", ctx, productIDs, filter)}
+}
+
+func (_c *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call) Run(run func(ctx context.Context, productIDs []uuid.UUID, filter *model.PriceFilter)) *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(*model.PriceFilter))
+	})
+	return _c
+}
+
+func (_c *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call) Return(_a0 []*model.StorePrice, _a1 error) *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID, *model.PriceFilter) ([]*model.StorePrice, error)) *ShoppingListPriceStorer_SelectMostRecentPricesByProductIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewShoppingListPriceStorer creates a new instance of ShoppingListPriceStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShoppingListPriceStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShoppingListPriceStorer {
+	mock := &ShoppingListPriceStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// ShoppingListProductStorer is an autogenerated mock type for the ShoppingListProductStorer type
+type ShoppingListProductStorer struct {
+	mock.Mock
+}
+
+type ShoppingListProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ShoppingListProductStorer) EXPECT() *ShoppingListProductStorer_Expecter {
+	return &ShoppingListProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetProductByEAN provides a mock function with given fields: ctx, ean
+func (_m *ShoppingListProductStorer) GetProductByEAN(ctx context.Context, ean string) (*model.Product, error) {
+	ret := _m.Called(ctx, ean)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByEAN")
+	}
+
+	var r0 *model.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Product, error)); ok {
+		return rf(ctx, ean)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Product); ok {
+		r0 = rf(ctx, ean)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ean)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShoppingListProductStorer_GetProductByEAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByEAN'
+type ShoppingListProductStorer_GetProductByEAN_Call struct {
+	*mock.Call
+}
+
+// GetProductByEAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ean string
+func (_e *ShoppingListProductStorer_Expecter) GetProductByEAN(ctx interface{}, ean interface{}) *ShoppingListProductStorer_GetProductByEAN_Call {
+	return &ShoppingListProductStorer_GetProductByEAN_Call{Call: _e.mock.On("GetProductByEAN", ctx, ean)}
+}
+
+func (_c *ShoppingListProductStorer_GetProductByEAN_Call) Run(run func(ctx context.Context, ean string)) *ShoppingListProductStorer_GetProductByEAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ShoppingListProductStorer_GetProductByEAN_Call) Return(_a0 *model.Product, _a1 error) *ShoppingListProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ShoppingListProductStorer_GetProductByEAN_Call) RunAndReturn(run func(context.Context, string) (*model.Product, error)) *ShoppingListProductStorer_GetProductByEAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewShoppingListProductStorer creates a new instance of ShoppingListProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewShoppingListProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ShoppingListProductStorer {
+	mock := &ShoppingListProductStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TwoFactorStorer is an autogenerated mock type for the TwoFactorStorer type
+type TwoFactorStorer struct {
+	mock.Mock
+}
+
+type TwoFactorStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TwoFactorStorer) EXPECT() *TwoFactorStorer_Expecter {
+	return &TwoFactorStorer_Expecter{mock: &_m.Mock}
+}
+
+// DeleteTOTP provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorStorer) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TwoFactorStorer_DeleteTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTOTP'
+type TwoFactorStorer_DeleteTOTP_Call struct {
+	*mock.Call
+}
+
+// DeleteTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TwoFactorStorer_Expecter) DeleteTOTP(ctx interface{}, userID interface{}) *TwoFactorStorer_DeleteTOTP_Call {
+	return &TwoFactorStorer_DeleteTOTP_Call{Call: _e.mock.On("DeleteTOTP", ctx, userID)}
+}
+
+func (_c *TwoFactorStorer_DeleteTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TwoFactorStorer_DeleteTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_DeleteTOTP_Call) Return(_a0 error) *TwoFactorStorer_DeleteTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TwoFactorStorer_DeleteTOTP_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *TwoFactorStorer_DeleteTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableTOTP provides a mock function with given fields: ctx, userID, step, recoveryCodes
+func (_m *TwoFactorStorer) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodes []string) error {
+	ret := _m.Called(ctx, userID, step, recoveryCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, []string) error); ok {
+		r0 = rf(ctx, userID, step, recoveryCodes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TwoFactorStorer_EnableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableTOTP'
+type TwoFactorStorer_EnableTOTP_Call struct {
+	*mock.Call
+}
+
+// EnableTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - step int64
+//   - recoveryCodes []string
+func (_e *TwoFactorStorer_Expecter) EnableTOTP(ctx interface{}, userID interface{}, step interface{}, recoveryCodes interface{}) *TwoFactorStorer_EnableTOTP_Call {
+	return &TwoFactorStorer_EnableTOTP_Call{Call: _e.mock.On("EnableTOTP", ctx, userID, step, recoveryCodes)}
+}
+
+func (_c *TwoFactorStorer_EnableTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64, recoveryCodes []string)) *TwoFactorStorer_EnableTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_EnableTOTP_Call) Return(_a0 error) *TwoFactorStorer_EnableTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TwoFactorStorer_EnableTOTP_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, []string) error) *TwoFactorStorer_EnableTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectTOTP provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorStorer) SelectTOTP(ctx context.Context, userID uuid.UUID) (*model.TOTP, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectTOTP")
+	}
+
+	var r0 *model.TOTP
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.TOTP, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.TOTP); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TOTP)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TwoFactorStorer_SelectTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectTOTP'
+type TwoFactorStorer_SelectTOTP_Call struct {
+	*mock.Call
+}
+
+// SelectTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *TwoFactorStorer_Expecter) SelectTOTP(ctx interface{}, userID interface{}) *TwoFactorStorer_SelectTOTP_Call {
+	return &TwoFactorStorer_SelectTOTP_Call{Call: _e.mock.On("SelectTOTP", ctx, userID)}
+}
+
+func (_c *TwoFactorStorer_SelectTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *TwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_SelectTOTP_Call) Return(_a0 *model.TOTP, _a1 error) *TwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TwoFactorStorer_SelectTOTP_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.TOTP, error)) *TwoFactorStorer_SelectTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertTOTP provides a mock function with given fields: ctx, userID, secret
+func (_m *TwoFactorStorer) UpsertTOTP(ctx context.Context, userID uuid.UUID, secret string) error {
+	ret := _m.Called(ctx, userID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTOTP")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TwoFactorStorer_UpsertTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertTOTP'
+type TwoFactorStorer_UpsertTOTP_Call struct {
+	*mock.Call
+}
+
+// UpsertTOTP is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - secret string
+func (_e *TwoFactorStorer_Expecter) UpsertTOTP(ctx interface{}, userID interface{}, secret interface{}) *TwoFactorStorer_UpsertTOTP_Call {
+	return &TwoFactorStorer_UpsertTOTP_Call{Call: _e.mock.On("UpsertTOTP", ctx, userID, secret)}
+}
+
+func (_c *TwoFactorStorer_UpsertTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID, secret string)) *TwoFactorStorer_UpsertTOTP_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_UpsertTOTP_Call) Return(_a0 error) *TwoFactorStorer_UpsertTOTP_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TwoFactorStorer_UpsertTOTP_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *TwoFactorStorer_UpsertTOTP_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userID, code
+func (_m *TwoFactorStorer) UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TwoFactorStorer_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type TwoFactorStorer_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - code string
+func (_e *TwoFactorStorer_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, code interface{}) *TwoFactorStorer_UseRecoveryCode_Call {
+	return &TwoFactorStorer_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, code)}
+}
+
+func (_c *TwoFactorStorer_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID uuid.UUID, code string)) *TwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_UseRecoveryCode_Call) Return(_a0 error) *TwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TwoFactorStorer_UseRecoveryCode_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *TwoFactorStorer_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseStep provides a mock function with given fields: ctx, userID, step
+func (_m *TwoFactorStorer) UseStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TwoFactorStorer_UseStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseStep'
+type TwoFactorStorer_UseStep_Call struct {
+	*mock.Call
+}
+
+// UseStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - step int64
+func (_e *TwoFactorStorer_Expecter) UseStep(ctx interface{}, userID interface{}, step interface{}) *TwoFactorStorer_UseStep_Call {
+	return &TwoFactorStorer_UseStep_Call{Call: _e.mock.On("UseStep", ctx, userID, step)}
+}
+
+func (_c *TwoFactorStorer_UseStep_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64)) *TwoFactorStorer_UseStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *TwoFactorStorer_UseStep_Call) Return(_a0 error) *TwoFactorStorer_UseStep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TwoFactorStorer_UseStep_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *TwoFactorStorer_UseStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTwoFactorStorer creates a new instance of TwoFactorStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorStorer {
+	mock := &TwoFactorStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// TwoFactorUserStorer is an autogenerated mock type for the TwoFactorUserStorer type
+type TwoFactorUserStorer struct {
+	mock.Mock
+}
+
+type TwoFactorUserStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TwoFactorUserStorer) EXPECT() *TwoFactorUserStorer_Expecter {
+	return &TwoFactorUserStorer_Expecter{mock: &_m.Mock}
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *TwoFactorUserStorer) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TwoFactorUserStorer_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type TwoFactorUserStorer_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *TwoFactorUserStorer_Expecter) GetUserByID(ctx interface{}, id interface{}) *TwoFactorUserStorer_GetUserByID_Call {
+	return &TwoFactorUserStorer_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *TwoFactorUserStorer_GetUserByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *TwoFactorUserStorer_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *TwoFactorUserStorer_GetUserByID_Call) Return(_a0 *model.User, _a1 error) *TwoFactorUserStorer_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TwoFactorUserStorer_GetUserByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.User, error)) *TwoFactorUserStorer_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTwoFactorUserStorer creates a new instance of TwoFactorUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTwoFactorUserStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TwoFactorUserStorer {
+	mock := &TwoFactorUserStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// UserProductAuthorizer is an autogenerated mock type for the UserProductAuthorizer type
+type UserProductAuthorizer struct {
+	mock.Mock
+}
+
+type UserProductAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserProductAuthorizer) EXPECT() *UserProductAuthorizer_Expecter {
+	return &UserProductAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// Bill provides a mock function with given fields: ctx, userID, billID
+func (_m *UserProductAuthorizer) Bill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductAuthorizer_Bill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bill'
+type UserProductAuthorizer_Bill_Call struct {
+	*mock.Call
+}
+
+// Bill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *UserProductAuthorizer_Expecter) Bill(ctx interface{}, userID interface{}, billID interface{}) *UserProductAuthorizer_Bill_Call {
+	return &UserProductAuthorizer_Bill_Call{Call: _e.mock.On("Bill", ctx, userID, billID)}
+}
+
+func (_c *UserProductAuthorizer_Bill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *UserProductAuthorizer_Bill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductAuthorizer_Bill_Call) Return(_a0 *model.Bill, _a1 error) *UserProductAuthorizer_Bill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductAuthorizer_Bill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *UserProductAuthorizer_Bill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenBill provides a mock function with given fields: ctx, userID, billID
+func (_m *UserProductAuthorizer) OpenBill(ctx context.Context, userID uuid.UUID, billID uuid.UUID) (*model.Bill, error) {
+	ret := _m.Called(ctx, userID, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBill")
+	}
+
+	var r0 *model.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)); ok {
+		return rf(ctx, userID, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *model.Bill); ok {
+		r0 = rf(ctx, userID, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductAuthorizer_OpenBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBill'
+type UserProductAuthorizer_OpenBill_Call struct {
+	*mock.Call
+}
+
+// OpenBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+func (_e *UserProductAuthorizer_Expecter) OpenBill(ctx interface{}, userID interface{}, billID interface{}) *UserProductAuthorizer_OpenBill_Call {
+	return &UserProductAuthorizer_OpenBill_Call{Call: _e.mock.On("OpenBill", ctx, userID, billID)}
+}
+
+func (_c *UserProductAuthorizer_OpenBill_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID)) *UserProductAuthorizer_OpenBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductAuthorizer_OpenBill_Call) Return(_a0 *model.Bill, _a1 error) *UserProductAuthorizer_OpenBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductAuthorizer_OpenBill_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*model.Bill, error)) *UserProductAuthorizer_OpenBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserProduct provides a mock function with given fields: ctx, userID, billID, userProductID
+func (_m *UserProductAuthorizer) UserProduct(ctx context.Context, userID uuid.UUID, billID uuid.UUID, userProductID uuid.UUID) (*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID, billID, userProductID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserProduct")
+	}
+
+	var r0 *model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*model.UserProduct, error)); ok {
+		return rf(ctx, userID, billID, userProductID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) *model.UserProduct); ok {
+		r0 = rf(ctx, userID, billID, userProductID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, billID, userProductID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductAuthorizer_UserProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserProduct'
+type UserProductAuthorizer_UserProduct_Call struct {
+	*mock.Call
+}
+
+// UserProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - billID uuid.UUID
+//   - userProductID uuid.UUID
+func (_e *UserProductAuthorizer_Expecter) UserProduct(ctx interface{}, userID interface{}, billID interface{}, userProductID interface{}) *UserProductAuthorizer_UserProduct_Call {
+	return &UserProductAuthorizer_UserProduct_Call{Call: _e.mock.On("UserProduct", ctx, userID, billID, userProductID)}
+}
+
+func (_c *UserProductAuthorizer_UserProduct_Call) Run(run func(ctx context.Context, userID uuid.UUID, billID uuid.UUID, userProductID uuid.UUID)) *UserProductAuthorizer_UserProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductAuthorizer_UserProduct_Call) Return(_a0 *model.UserProduct, _a1 error) *UserProductAuthorizer_UserProduct_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductAuthorizer_UserProduct_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*model.UserProduct, error)) *UserProductAuthorizer_UserProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserProductAuthorizer creates a new instance of UserProductAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserProductAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserProductAuthorizer {
+	mock := &UserProductAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// UserProductStorer is an autogenerated mock type for the UserProductStorer type
+type UserProductStorer struct {
+	mock.Mock
+}
+
+type UserProductStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserProductStorer) EXPECT() *UserProductStorer_Expecter {
+	return &UserProductStorer_Expecter{mock: &_m.Mock}
+}
+
+// DeleteUserProduct provides a mock function with given fields: ctx, billID, userProductID
+func (_m *UserProductStorer) DeleteUserProduct(ctx context.Context, billID uuid.UUID, userProductID uuid.UUID) error {
+	ret := _m.Called(ctx, billID, userProductID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserProduct")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, billID, userProductID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserProductStorer_DeleteUserProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserProduct'
+type UserProductStorer_DeleteUserProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteUserProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+//   - userProductID uuid.UUID
+func (_e *UserProductStorer_Expecter) DeleteUserProduct(ctx interface{}, billID interface{}, userProductID interface{}) *UserProductStorer_DeleteUserProduct_Call {
+	return &UserProductStorer_DeleteUserProduct_Call{Call: _e.mock.On("DeleteUserProduct", ctx, billID, userProductID)}
+}
+
+func (_c *UserProductStorer_DeleteUserProduct_Call) Run(run func(ctx context.Context, billID uuid.UUID, userProductID uuid.UUID)) *UserProductStorer_DeleteUserProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_DeleteUserProduct_Call) Return(_a0 error) *UserProductStorer_DeleteUserProduct_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserProductStorer_DeleteUserProduct_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *UserProductStorer_DeleteUserProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, userProduct, userID
+func (_m *UserProductStorer) Insert(ctx context.Context, userProduct *model.UserProduct, userID uuid.UUID) error {
+	ret := _m.Called(ctx, userProduct, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserProduct, uuid.UUID) error); ok {
+		r0 = rf(ctx, userProduct, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserProductStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type UserProductStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userProduct *model.UserProduct
+//   - userID uuid.UUID
+func (_e *UserProductStorer_Expecter) Insert(ctx interface{}, userProduct interface{}, userID interface{}) *UserProductStorer_Insert_Call {
+	return &UserProductStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, userProduct, userID)}
+}
+
+func (_c *UserProductStorer_Insert_Call) Run(run func(ctx context.Context, userProduct *model.UserProduct, userID uuid.UUID)) *UserProductStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserProduct), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_Insert_Call) Return(_a0 error) *UserProductStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserProductStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.UserProduct, uuid.UUID) error) *UserProductStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectMostRecentUserProductByStoreID provides a mock function with given fields: ctx, storeID
+func (_m *UserProductStorer) SelectMostRecentUserProductByStoreID(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, storeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectMostRecentUserProductByStoreID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, storeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, storeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, storeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductStorer_SelectMostRecentUserProductByStoreID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectMostRecentUserProductByStoreID'
+type UserProductStorer_SelectMostRecentUserProductByStoreID_Call struct {
+	*mock.Call
+}
+
+// SelectMostRecentUserProductByStoreID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - storeID uuid.UUID
+func (_e *UserProductStorer_Expecter) SelectMostRecentUserProductByStoreID(ctx interface{}, storeID interface{}) *UserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	return &UserProductStorer_SelectMostRecentUserProductByStoreID_Call{Call: _e.mock.On("SelectMostRecentUserProductByStoreID", ctx, storeID)}
+}
+
+func (_c *UserProductStorer_SelectMostRecentUserProductByStoreID_Call) Run(run func(ctx context.Context, storeID uuid.UUID)) *UserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_SelectMostRecentUserProductByStoreID_Call) Return(_a0 []*model.UserProduct, _a1 error) *UserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductStorer_SelectMostRecentUserProductByStoreID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *UserProductStorer_SelectMostRecentUserProductByStoreID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectProductByID provides a mock function with given fields: ctx, id
+func (_m *UserProductStorer) SelectProductByID(ctx context.Context, id uuid.UUID) (*model.UserProduct, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductByID")
+	}
+
+	var r0 *model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.UserProduct, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.UserProduct); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductStorer_SelectProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductByID'
+type UserProductStorer_SelectProductByID_Call struct {
+	*mock.Call
+}
+
+// SelectProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserProductStorer_Expecter) SelectProductByID(ctx interface{}, id interface{}) *UserProductStorer_SelectProductByID_Call {
+	return &UserProductStorer_SelectProductByID_Call{Call: _e.mock.On("SelectProductByID", ctx, id)}
+}
+
+func (_c *UserProductStorer_SelectProductByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserProductStorer_SelectProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductByID_Call) Return(_a0 *model.UserProduct, _a1 error) *UserProductStorer_SelectProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*model.UserProduct, error)) *UserProductStorer_SelectProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectProductsByBillID provides a mock function with given fields: ctx, billID
+func (_m *UserProductStorer) SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, billID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByBillID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, billID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, billID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, billID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductStorer_SelectProductsByBillID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByBillID'
+type UserProductStorer_SelectProductsByBillID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByBillID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - billID uuid.UUID
+func (_e *UserProductStorer_Expecter) SelectProductsByBillID(ctx interface{}, billID interface{}) *UserProductStorer_SelectProductsByBillID_Call {
+	return &UserProductStorer_SelectProductsByBillID_Call{Call: _e.mock.On("SelectProductsByBillID", ctx, billID)}
+}
+
+func (_c *UserProductStorer_SelectProductsByBillID_Call) Run(run func(ctx context.Context, billID uuid.UUID)) *UserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductsByBillID_Call) Return(_a0 []*model.UserProduct, _a1 error) *UserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductsByBillID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *UserProductStorer_SelectProductsByBillID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectProductsByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserProductStorer) SelectProductsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProductsByUserID")
+	}
+
+	var r0 []*model.UserProduct
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserProduct)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserProductStorer_SelectProductsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByUserID'
+type UserProductStorer_SelectProductsByUserID_Call struct {
+	*mock.Call
+}
+
+// SelectProductsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserProductStorer_Expecter) SelectProductsByUserID(ctx interface{}, userID interface{}) *UserProductStorer_SelectProductsByUserID_Call {
+	return &UserProductStorer_SelectProductsByUserID_Call{Call: _e.mock.On("SelectProductsByUserID", ctx, userID)}
+}
+
+func (_c *UserProductStorer_SelectProductsByUserID_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductsByUserID_Call) Return(_a0 []*model.UserProduct, _a1 error) *UserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserProductStorer_SelectProductsByUserID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*model.UserProduct, error)) *UserProductStorer_SelectProductsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectProductsByUserIDAndStoreID provides a mock function with given fields: ctx, userID, storeID
+func (_m *UserProductStorer) SelectProductsByUserIDAndStoreID(ctx context.Context, userID uuid.UUID, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ret := _m.Called(ctx, userID, storeID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByLogin")
+		panic("no return value specified for SelectProductsByUserIDAndStoreID")
 	}
 
-	var r0 *model.User
+	var r0 []*model.UserProduct
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
-		return rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)); ok {
+		return rf(ctx, userID, storeID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*model.UserProduct); ok {
+		r0 = rf(ctx, userID, storeID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.User)
+			r0 = ret.Get(0).([]*model.UserProduct)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, userID, storeID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -174,75 +8950,47 @@ func (_m *AuthUserStorer) GetUserByLogin(_a0 context.Context, _a1 string) (*mode
 	return r0, r1
 }
 
-// AuthUserStorer_GetUserByLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByLogin'
-type AuthUserStorer_GetUserByLogin_Call struct {
+// UserProductStorer_SelectProductsByUserIDAndStoreID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProductsByUserIDAndStoreID'
+type UserProductStorer_SelectProductsByUserIDAndStoreID_Call struct {
 	*mock.Call
 }
 
-// GetUserByLogin is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 string
-func (_e *AuthUserStorer_Expecter) GetUserByLogin(_a0 interface{}, _a1 interface{}) *AuthUserStorer_GetUserByLogin_Call {
-	return &AuthUserStorer_GetUserByLogin_Call{Call: _e.mock.On("GetUserByLogin", _a0, _a1)}
+// SelectProductsByUserIDAndStoreID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - storeID uuid.UUID
+func (_e *UserProductStorer_Expecter) SelectProductsByUserIDAndStoreID(ctx interface{}, userID interface{}, storeID interface{}) *UserProductStorer_SelectProductsByUserIDAndStoreID_Call {
+	return &UserProductStorer_SelectProductsByUserIDAndStoreID_Call{Call: _e.mock.On("SelectProductsByUserIDAndStoreID", ctx, userID, storeID)}
 }
 
-func (_c *AuthUserStorer_GetUserByLogin_Call) Run(run func(_a0 context.Context, _a1 string)) *AuthUserStorer_GetUserByLogin_Call {
+func (_c *UserProductStorer_SelectProductsByUserIDAndStoreID_Call) Run(run func(ctx context.Context, userID uuid.UUID, storeID uuid.UUID)) *UserProductStorer_SelectProductsByUserIDAndStoreID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *AuthUserStorer_GetUserByLogin_Call) Return(_a0 *model.User, _a1 error) *AuthUserStorer_GetUserByLogin_Call {
+func (_c *UserProductStorer_SelectProductsByUserIDAndStoreID_Call) Return(_a0 []*model.UserProduct, _a1 error) *UserProductStorer_SelectProductsByUserIDAndStoreID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AuthUserStorer_GetUserByLogin_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *AuthUserStorer_GetUserByLogin_Call {
+func (_c *UserProductStorer_SelectProductsByUserIDAndStoreID_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]*model.UserProduct, error)) *UserProductStorer_SelectProductsByUserIDAndStoreID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewAuthUserStorer creates a new instance of AuthUserStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewAuthUserStorer(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *AuthUserStorer {
-	mock := &AuthUserStorer{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// Code generated by mockery v2.42.2. DO NOT EDIT.
-
-// CompanyStorer is an autogenerated mock type for the CompanyStorer type
-type CompanyStorer struct {
-	mock.Mock
-}
-
-type CompanyStorer_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *CompanyStorer) EXPECT() *CompanyStorer_Expecter {
-	return &CompanyStorer_Expecter{mock: &_m.Mock}
-}
-
-// Insert provides a mock function with given fields: ctx, company
-func (_m *CompanyStorer) Insert(ctx context.Context, company *model.Company) error {
-	ret := _m.Called(ctx, company)
+// UpdateQuantity provides a mock function with given fields: ctx, quantity, productType, productSize, sizeFormat, billID, userProductID
+func (_m *UserProductStorer) UpdateQuantity(ctx context.Context, quantity int64, productType string, productSize string, sizeFormat string, billID uuid.UUID, userProductID uuid.UUID) error {
+	ret := _m.Called(ctx, quantity, productType, productSize, sizeFormat, billID, userProductID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Insert")
+		panic("no return value specified for UpdateQuantity")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *model.Company) error); ok {
-		r0 = rf(ctx, company)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string, string, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, quantity, productType, productSize, sizeFormat, billID, userProductID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -250,101 +8998,121 @@ func (_m *CompanyStorer) Insert(ctx context.Context, company *model.Company) err
 	return r0
 }
 
-// CompanyStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
-type CompanyStorer_Insert_Call struct {
+// UserProductStorer_UpdateQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuantity'
+type UserProductStorer_UpdateQuantity_Call struct {
 	*mock.Call
 }
 
-// Insert is a helper method to define mock.On call
+// UpdateQuantity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - company *model.Company
-func (_e *CompanyStorer_Expecter) Insert(ctx interface{}, company interface{}) *CompanyStorer_Insert_Call {
-	return &CompanyStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, company)}
+//   - quantity int64
+//   - productType string
+//   - productSize string
+//   - sizeFormat string
+//   - billID uuid.UUID
+//   - userProductID uuid.UUID
+func (_e *UserProductStorer_Expecter) UpdateQuantity(ctx interface{}, quantity interface{}, productType interface{}, productSize interface{}, sizeFormat interface{}, billID interface{}, userProductID interface{}) *UserProductStorer_UpdateQuantity_Call {
+	return &UserProductStorer_UpdateQuantity_Call{Call: _e.mock.On("UpdateQuantity", ctx, quantity, productType, productSize, sizeFormat, billID, userProductID)}
 }
 
-func (_c *CompanyStorer_Insert_Call) Run(run func(ctx context.Context, company *model.Company)) *CompanyStorer_Insert_Call {
+func (_c *UserProductStorer_UpdateQuantity_Call) Run(run func(ctx context.Context, quantity int64, productType string, productSize string, sizeFormat string, billID uuid.UUID, userProductID uuid.UUID)) *UserProductStorer_UpdateQuantity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*model.Company))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string), args[4].(string), args[5].(uuid.UUID), args[6].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *CompanyStorer_Insert_Call) Return(_a0 error) *CompanyStorer_Insert_Call {
+func (_c *UserProductStorer_UpdateQuantity_Call) Return(_a0 error) *UserProductStorer_UpdateQuantity_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *CompanyStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.Company) error) *CompanyStorer_Insert_Call {
+func (_c *UserProductStorer_UpdateQuantity_Call) RunAndReturn(run func(context.Context, int64, string, string, string, uuid.UUID, uuid.UUID) error) *UserProductStorer_UpdateQuantity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SelectCompanies provides a mock function with given fields: ctx, name
-func (_m *CompanyStorer) SelectCompanies(ctx context.Context, name string) ([]*model.Company, error) {
-	ret := _m.Called(ctx, name)
+// NewUserProductStorer creates a new instance of UserProductStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserProductStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserProductStorer {
+	mock := &UserProductStorer{}
+	mock.Mock.Test(t)
 
-	if len(ret) == 0 {
-		panic("no return value specified for SelectCompanies")
-	}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-	var r0 []*model.Company
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Company, error)); ok {
-		return rf(ctx, name)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Company); ok {
-		r0 = rf(ctx, name)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Company)
-		}
+	return mock
+}
+
+// UsersMailer is an autogenerated mock type for the UsersMailer type
+type UsersMailer struct {
+	mock.Mock
+}
+
+type UsersMailer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UsersMailer) EXPECT() *UsersMailer_Expecter {
+	return &UsersMailer_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, m
+func (_m *UsersMailer) Send(ctx context.Context, m *mailer.Message) error {
+	ret := _m.Called(ctx, m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *mailer.Message) error); ok {
+		r0 = rf(ctx, m)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// CompanyStorer_SelectCompanies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectCompanies'
-type CompanyStorer_SelectCompanies_Call struct {
+// UsersMailer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type UsersMailer_Send_Call struct {
 	*mock.Call
 }
 
-// SelectCompanies is a helper method to define mock.On call
+// Send is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *CompanyStorer_Expecter) SelectCompanies(ctx interface{}, name interface{}) *CompanyStorer_SelectCompanies_Call {
-	return &CompanyStorer_SelectCompanies_Call{Call: _e.mock.On("SelectCompanies", ctx, name)}
+//   - m *mailer.Message
+func (_e *UsersMailer_Expecter) Send(ctx interface{}, m interface{}) *UsersMailer_Send_Call {
+	return &UsersMailer_Send_Call{Call: _e.mock.On("Send", ctx, m)}
 }
 
-func (_c *CompanyStorer_SelectCompanies_Call) Run(run func(ctx context.Context, name string)) *CompanyStorer_SelectCompanies_Call {
+func (_c *UsersMailer_Send_Call) Run(run func(ctx context.Context, m *mailer.Message)) *UsersMailer_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(*mailer.Message))
 	})
 	return _c
 }
 
-func (_c *CompanyStorer_SelectCompanies_Call) Return(_a0 []*model.Company, _a1 error) *CompanyStorer_SelectCompanies_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *UsersMailer_Send_Call) Return(_a0 error) *UsersMailer_Send_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *CompanyStorer_SelectCompanies_Call) RunAndReturn(run func(context.Context, string) ([]*model.Company, error)) *CompanyStorer_SelectCompanies_Call {
+func (_c *UsersMailer_Send_Call) RunAndReturn(run func(context.Context, *mailer.Message) error) *UsersMailer_Send_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewCompanyStorer creates a new instance of CompanyStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewUsersMailer creates a new instance of UsersMailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewCompanyStorer(t interface {
+func NewUsersMailer(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *CompanyStorer {
-	mock := &CompanyStorer{}
+}) *UsersMailer {
+	mock := &UsersMailer{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -352,8 +9120,6 @@ func NewCompanyStorer(t interface {
 	return mock
 }
 
-// Code generated by mockery v2.42.2. DO NOT EDIT.
-
 // UsersStorer is an autogenerated mock type for the UsersStorer type
 type UsersStorer struct {
 	mock.Mock
@@ -544,17 +9310,17 @@ func (_c *UsersStorer_GetUserByLogin_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// UpdateEmail provides a mock function with given fields: ctx, id, email
-func (_m *UsersStorer) UpdateEmail(ctx context.Context, id uuid.UUID, email string) error {
-	ret := _m.Called(ctx, id, email)
+// ResetPassword provides a mock function with given fields: ctx, id, password
+func (_m *UsersStorer) ResetPassword(ctx context.Context, id uuid.UUID, password string) error {
+	ret := _m.Called(ctx, id, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateEmail")
+		panic("no return value specified for ResetPassword")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
-		r0 = rf(ctx, id, email)
+		r0 = rf(ctx, id, password)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -562,32 +9328,32 @@ func (_m *UsersStorer) UpdateEmail(ctx context.Context, id uuid.UUID, email stri
 	return r0
 }
 
-// UsersStorer_UpdateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmail'
-type UsersStorer_UpdateEmail_Call struct {
+// UsersStorer_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type UsersStorer_ResetPassword_Call struct {
 	*mock.Call
 }
 
-// UpdateEmail is a helper method to define mock.On call
+// ResetPassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id uuid.UUID
-//   - email string
-func (_e *UsersStorer_Expecter) UpdateEmail(ctx interface{}, id interface{}, email interface{}) *UsersStorer_UpdateEmail_Call {
-	return &UsersStorer_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, id, email)}
+//   - password string
+func (_e *UsersStorer_Expecter) ResetPassword(ctx interface{}, id interface{}, password interface{}) *UsersStorer_ResetPassword_Call {
+	return &UsersStorer_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, id, password)}
 }
 
-func (_c *UsersStorer_UpdateEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, email string)) *UsersStorer_UpdateEmail_Call {
+func (_c *UsersStorer_ResetPassword_Call) Run(run func(ctx context.Context, id uuid.UUID, password string)) *UsersStorer_ResetPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *UsersStorer_UpdateEmail_Call) Return(_a0 error) *UsersStorer_UpdateEmail_Call {
+func (_c *UsersStorer_ResetPassword_Call) Return(_a0 error) *UsersStorer_ResetPassword_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UsersStorer_UpdateEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *UsersStorer_UpdateEmail_Call {
+func (_c *UsersStorer_ResetPassword_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *UsersStorer_ResetPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -687,6 +9453,54 @@ func (_c *UsersStorer_Upsert_Call) RunAndReturn(run func(context.Context, *model
 	return _c
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, id, email
+func (_m *UsersStorer) VerifyEmail(ctx context.Context, id uuid.UUID, email string) error {
+	ret := _m.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsersStorer_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type UsersStorer_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - email string
+func (_e *UsersStorer_Expecter) VerifyEmail(ctx interface{}, id interface{}, email interface{}) *UsersStorer_VerifyEmail_Call {
+	return &UsersStorer_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", ctx, id, email)}
+}
+
+func (_c *UsersStorer_VerifyEmail_Call) Run(run func(ctx context.Context, id uuid.UUID, email string)) *UsersStorer_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UsersStorer_VerifyEmail_Call) Return(_a0 error) *UsersStorer_VerifyEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsersStorer_VerifyEmail_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *UsersStorer_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUsersStorer creates a new instance of UsersStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUsersStorer(t interface {
@@ -700,3 +9514,137 @@ func NewUsersStorer(t interface {
 
 	return mock
 }
+
+// UsersTokenStorer is an autogenerated mock type for the UsersTokenStorer type
+type UsersTokenStorer struct {
+	mock.Mock
+}
+
+type UsersTokenStorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UsersTokenStorer) EXPECT() *UsersTokenStorer_Expecter {
+	return &UsersTokenStorer_Expecter{mock: &_m.Mock}
+}
+
+// Insert provides a mock function with given fields: ctx, userToken
+func (_m *UsersTokenStorer) Insert(ctx context.Context, userToken *model.UserToken) error {
+	ret := _m.Called(ctx, userToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserToken) error); ok {
+		r0 = rf(ctx, userToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsersTokenStorer_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type UsersTokenStorer_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userToken *model.UserToken
+func (_e *UsersTokenStorer_Expecter) Insert(ctx interface{}, userToken interface{}) *UsersTokenStorer_Insert_Call {
+	return &UsersTokenStorer_Insert_Call{Call: _e.mock.On("Insert", ctx, userToken)}
+}
+
+func (_c *UsersTokenStorer_Insert_Call) Run(run func(ctx context.Context, userToken *model.UserToken)) *UsersTokenStorer_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.UserToken))
+	})
+	return _c
+}
+
+func (_c *UsersTokenStorer_Insert_Call) Return(_a0 error) *UsersTokenStorer_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsersTokenStorer_Insert_Call) RunAndReturn(run func(context.Context, *model.UserToken) error) *UsersTokenStorer_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Use provides a mock function with given fields: ctx, purpose, token
+func (_m *UsersTokenStorer) Use(ctx context.Context, purpose string, token string) (*model.UserToken, error) {
+	ret := _m.Called(ctx, purpose, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Use")
+	}
+
+	var r0 *model.UserToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.UserToken, error)); ok {
+		return rf(ctx, purpose, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.UserToken); ok {
+		r0 = rf(ctx, purpose, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, purpose, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersTokenStorer_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type UsersTokenStorer_Use_Call struct {
+	*mock.Call
+}
+
+// Use is a helper method to define mock.On call
+//   - ctx context.Context
+//   - purpose string
+//   - token string
+func (_e *UsersTokenStorer_Expecter) Use(ctx interface{}, purpose interface{}, token interface{}) *UsersTokenStorer_Use_Call {
+	return &UsersTokenStorer_Use_Call{Call: _e.mock.On("Use", ctx, purpose, token)}
+}
+
+func (_c *UsersTokenStorer_Use_Call) Run(run func(ctx context.Context, purpose string, token string)) *UsersTokenStorer_Use_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UsersTokenStorer_Use_Call) Return(_a0 *model.UserToken, _a1 error) *UsersTokenStorer_Use_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsersTokenStorer_Use_Call) RunAndReturn(run func(context.Context, string, string) (*model.UserToken, error)) *UsersTokenStorer_Use_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUsersTokenStorer creates a new instance of UsersTokenStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsersTokenStorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsersTokenStorer {
+	mock := &UsersTokenStorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"math/rand"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/oidc"
+	"shop-aggregator/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	oidcStateTTL = 10 * time.Minute
+	// oidcLoginTries is how many logins are tried for an account created by the provider.
+	oidcLoginTries = 5
+)
+
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.Claims, error)
+}
+
+type OIDCStorer interface {
+	InsertState(ctx context.Context, state *model.OIDCState) error
+	UseState(ctx context.Context, state string) (*model.OIDCState, error)
+	LoginIdentity(ctx context.Context, issuer, subject string) (*model.User, error)
+	InsertIdentity(ctx context.Context, identity *model.UserIdentity) error
+	InsertUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) error
+}
+
+type OIDCUserStorer interface {
+	GetUserByLogin(ctx context.Context, login string) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+}
+
+type OIDCSessionOpener interface {
+	OpenSession(ctx context.Context, user *model.User, session *model.Session) (*response.Login, error)
+	TwoFactorChallenge(ctx context.Context, user *model.User, session *model.Session) (*model.LoginChallenge, error)
+}
+
+// OIDC logs the users in through the OpenID Connect provider and opens the same sessions as the password
+// login. The provider only stands for the first factor, a user who enabled two-factor gets the challenge of
+// the password login. An identity is linked on its first login to the user of the same email when both the provider and
+// the user verified it, otherwise a user is created when AutoCreate is set.
+type OIDC struct {
+	OIDCProvider      OIDCProvider
+	OIDCStorer        OIDCStorer
+	OIDCUserStorer    OIDCUserStorer
+	OIDCSessionOpener OIDCSessionOpener
+	Enabled           bool
+	AutoCreate        bool
+}
+
+func NewOIDC(op OIDCProvider, ost OIDCStorer, ous OIDCUserStorer, oso OIDCSessionOpener, cfg *config.OIDCConfig) *OIDC {
+	return &OIDC{
+		OIDCProvider:      op,
+		OIDCStorer:        ost,
+		OIDCUserStorer:    ous,
+		OIDCSessionOpener: oso,
+		Enabled:           cfg.Issuer != "",
+		AutoCreate:        cfg.AutoCreate,
+	}
+}
+
+// AuthURL starts a login of the device and returns the URL of the provider the user is sent to.
+func (o *OIDC) AuthURL(ctx context.Context, device string) (string, error) {
+	if !o.Enabled {
+		return "", model.ErrOIDCDisabledError
+	}
+
+	state := &model.OIDCState{
+		Device:    device,
+		ExpiresAt: time.Now().UTC().Add(oidcStateTTL),
+	}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		token, err := utils.GenerateToken(64)
+		if err != nil {
+			log.Error().Caller().Err(err).Msg("AuthURL.GenerateToken")
+			return "", model.ErrOIDCError
+		}
+		*value = token
+	}
+
+	if err := o.OIDCStorer.InsertState(ctx, state); err != nil {
+		log.Error().Caller().Err(err).Msg("AuthURL.InsertState")
+		return "", model.ErrOIDCError
+	}
+
+	authURL, err := o.OIDCProvider.AuthCodeURL(ctx, state.State, state.Nonce, oidc.CodeChallenge(state.CodeVerifier))
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("AuthURL.AuthCodeURL")
+		return "", model.ErrOIDCError
+	}
+	return authURL, nil
+}
+
+// Callback exchanges the code the provider sent back with the state of AuthURL and opens a session of the
+// user of the identity, or returns the login challenge of a user who enabled two-factor.
+func (o *OIDC) Callback(ctx context.Context, state, code string, session *model.Session) (*response.Login, *model.LoginChallenge, error) {
+	if !o.Enabled {
+		return nil, nil, model.ErrOIDCDisabledError
+	}
+
+	pending, err := o.OIDCStorer.UseState(ctx, state)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Callback.UseState")
+		return nil, nil, model.ErrOIDCError
+	}
+	if pending == nil {
+		return nil, nil, model.ErrInvalidOIDCStateError
+	}
+	if session.Device == "" {
+		session.Device = pending.Device
+	}
+
+	claims, err := o.OIDCProvider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		log.Warn().Err(err).Msg("Callback.Exchange")
+		return nil, nil, model.ErrOIDCError
+	}
+
+	user, err := o.OIDCStorer.LoginIdentity(ctx, claims.Issuer, claims.Subject)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Callback.LoginIdentity")
+		return nil, nil, model.ErrOIDCError
+	}
+	if user == nil {
+		if user, err = o.link(ctx, claims); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if user.Disabled {
+		return nil, nil, model.ErrUserDisabledError
+	}
+
+	challenge, err := o.OIDCSessionOpener.TwoFactorChallenge(ctx, user, session)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
+	}
+
+	tokens, err := o.OIDCSessionOpener.OpenSession(ctx, user, session)
+	return tokens, nil, err
+}
+
+// link links a new identity to the user of its email or to a new user.
+func (o *OIDC) link(ctx context.Context, claims *oidc.Claims) (*model.User, error) {
+	if claims.Email == "" {
+		log.Warn().Str("subject", claims.Subject).Msg("oidc identity without email")
+		return nil, model.ErrOIDCAccountNotFoundError
+	}
+	identity := &model.UserIdentity{Issuer: claims.Issuer, Subject: claims.Subject, Email: claims.Email}
+
+	user, err := o.OIDCUserStorer.GetUserByEmail(ctx, claims.Email)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("link.GetUserByEmail")
+		return nil, model.ErrOIDCError
+	}
+	if user != nil {
+		// an unverified email proves nothing, on either side
+		if !user.EmailVerified || !claims.EmailVerified {
+			log.Warn().Str("subject", claims.Subject).Msg("oidc identity matches an unverified email")
+			return nil, model.ErrOIDCAccountNotFoundError
+		}
+		identity.UserID = user.ID
+		if err = o.OIDCStorer.InsertIdentity(ctx, identity); err != nil {
+			log.Error().Caller().Err(err).Msg("link.InsertIdentity")
+			return nil, model.ErrOIDCError
+		}
+		return user, nil
+	}
+
+	if !o.AutoCreate {
+		return nil, model.ErrOIDCAccountNotFoundError
+	}
+
+	login, err := o.freeLogin(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	user = &model.User{Login: login, Email: claims.Email, EmailVerified: claims.EmailVerified}
+	if err = o.OIDCStorer.InsertUserWithIdentity(ctx, user, identity); err != nil {
+		log.Error().Caller().Err(err).Msg("link.InsertUserWithIdentity")
+		return nil, model.ErrOIDCError
+	}
+	return user, nil
+}
+
+// freeLogin returns the preferred username of the identity, or the local part of its email, followed by a
+// number when it is taken.
+func (o *OIDC) freeLogin(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	login := base
+	for i := 0; i < oidcLoginTries; i++ {
+		user, err := o.OIDCUserStorer.GetUserByLogin(ctx, login)
+		if err != nil {
+			log.Error().Caller().Err(err).Msg("freeLogin.GetUserByLogin")
+			return "", model.ErrOIDCError
+		}
+		if user == nil {
+			return login, nil
+		}
+		login = fmt.Sprintf("%s-%04d", base, rand.Intn(10000))
+	}
+
+	log.Warn().Str("login", base).Msg("no free login for the oidc identity")
+	return "", model.ErrOIDCError
+}
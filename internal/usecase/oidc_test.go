@@ -0,0 +1,178 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/oidc"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestOIDC_AuthURL(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := NewOIDCProvider(t)
+	mockStorer := NewOIDCStorer(t)
+	o := usecase.NewOIDC(mockProvider, mockStorer, nil, nil, &config.OIDCConfig{Issuer: "http://issuer"})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := usecase.NewOIDC(nil, nil, nil, nil, &config.OIDCConfig{}).AuthURL(ctx, "phone")
+		assert.ErrorIs(t, err, model.ErrOIDCDisabledError)
+	})
+
+	t.Run("InsertState error", func(t *testing.T) {
+		mockStorer.EXPECT().InsertState(ctx, mock.Anything).Return(errors.New("random error")).Once()
+		_, err := o.AuthURL(ctx, "phone")
+		assert.ErrorIs(t, err, model.ErrOIDCError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		var state *model.OIDCState
+		mockStorer.EXPECT().InsertState(ctx, mock.Anything).Run(func(_ context.Context, s *model.OIDCState) {
+			state = s
+		}).Return(nil).Once()
+		mockProvider.EXPECT().AuthCodeURL(ctx, mock.Anything, mock.Anything, mock.Anything).Return("http://issuer/authorize", nil).Once()
+
+		authURL, err := o.AuthURL(ctx, "phone")
+		require.NoError(t, err)
+		assert.Equal(t, "http://issuer/authorize", authURL)
+		require.NotNil(t, state)
+		assert.Equal(t, "phone", state.Device)
+		assert.NotEmpty(t, state.Nonce)
+		assert.NotEqual(t, state.State, state.CodeVerifier)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), state.ExpiresAt, time.Minute)
+		mockProvider.AssertCalled(t, "AuthCodeURL", ctx, state.State, state.Nonce, oidc.CodeChallenge(state.CodeVerifier))
+	})
+}
+
+func TestOIDC_Callback(t *testing.T) {
+	ctx := context.Background()
+	mockProvider := NewOIDCProvider(t)
+	mockStorer := NewOIDCStorer(t)
+	mockUserStorer := NewOIDCUserStorer(t)
+	mockSessionOpener := NewOIDCSessionOpener(t)
+	o := usecase.NewOIDC(mockProvider, mockStorer, mockUserStorer, mockSessionOpener, &config.OIDCConfig{Issuer: "http://issuer"})
+
+	pending := &model.OIDCState{State: "state", Nonce: "nonce", CodeVerifier: "verifier", Device: "phone"}
+	claims := &oidc.Claims{Issuer: "http://issuer", Subject: "42", Email: "alice@test.com", EmailVerified: true, PreferredUsername: "alice"}
+	user := &model.User{ID: uuid.New(), Login: "alice", Email: "alice@test.com", EmailVerified: true}
+	tokens := &response.Login{Token: "token"}
+	expectExchange := func() {
+		mockStorer.EXPECT().UseState(ctx, "state").Return(pending, nil).Once()
+		mockProvider.EXPECT().Exchange(ctx, "code", "verifier", "nonce").Return(claims, nil).Once()
+	}
+
+	t.Run("unknown state", func(t *testing.T) {
+		mockStorer.EXPECT().UseState(ctx, "state").Return(nil, nil).Once()
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrInvalidOIDCStateError)
+	})
+
+	t.Run("Exchange error", func(t *testing.T) {
+		mockStorer.EXPECT().UseState(ctx, "state").Return(pending, nil).Once()
+		mockProvider.EXPECT().Exchange(ctx, "code", "verifier", "nonce").Return(nil, oidc.ErrInvalidIDToken).Once()
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrOIDCError)
+	})
+
+	t.Run("linked identity", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(user, nil).Once()
+		session := &model.Session{IP: "10.0.0.1"}
+		mockSessionOpener.EXPECT().TwoFactorChallenge(ctx, user, session).Return(nil, nil).Once()
+		mockSessionOpener.EXPECT().OpenSession(ctx, user, session).Return(tokens, nil).Once()
+
+		got, challenge, err := o.Callback(ctx, "state", "code", session)
+		require.NoError(t, err)
+		assert.Equal(t, tokens, got)
+		assert.Nil(t, challenge)
+		assert.Equal(t, "phone", session.Device)
+	})
+
+	t.Run("two-factor user gets a challenge", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(user, nil).Once()
+		expected := &model.LoginChallenge{UserID: user.ID, Challenge: "challenge"}
+		mockSessionOpener.EXPECT().TwoFactorChallenge(ctx, user, mock.Anything).Return(expected, nil).Once()
+
+		got, challenge, err := o.Callback(ctx, "state", "code", &model.Session{})
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.Equal(t, expected, challenge)
+	})
+
+	t.Run("TwoFactorChallenge error", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(user, nil).Once()
+		mockSessionOpener.EXPECT().TwoFactorChallenge(ctx, user, mock.Anything).Return(nil, model.ErrUserError).Once()
+
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrUserError)
+	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(&model.User{Disabled: true}, nil).Once()
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrUserDisabledError)
+	})
+
+	t.Run("link the user of the verified email", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, "alice@test.com").Return(user, nil).Once()
+		mockStorer.EXPECT().InsertIdentity(ctx, &model.UserIdentity{UserID: user.ID, Issuer: "http://issuer", Subject: "42", Email: "alice@test.com"}).Return(nil).Once()
+		mockSessionOpener.EXPECT().TwoFactorChallenge(ctx, user, mock.Anything).Return(nil, nil).Once()
+		mockSessionOpener.EXPECT().OpenSession(ctx, user, mock.Anything).Return(tokens, nil).Once()
+
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		require.NoError(t, err)
+	})
+
+	t.Run("unverified email is not linked", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, "alice@test.com").Return(&model.User{ID: uuid.New()}, nil).Once()
+
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrOIDCAccountNotFoundError)
+	})
+
+	t.Run("no account and no auto create", func(t *testing.T) {
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, "alice@test.com").Return(nil, nil).Once()
+
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		assert.ErrorIs(t, err, model.ErrOIDCAccountNotFoundError)
+	})
+
+	t.Run("auto create with a taken login", func(t *testing.T) {
+		o := usecase.NewOIDC(mockProvider, mockStorer, mockUserStorer, mockSessionOpener, &config.OIDCConfig{Issuer: "http://issuer", AutoCreate: true})
+		expectExchange()
+		mockStorer.EXPECT().LoginIdentity(ctx, "http://issuer", "42").Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, "alice@test.com").Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, "alice").Return(&model.User{}, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, mock.MatchedBy(func(login string) bool {
+			return len(login) == len("alice-0000") && login[:6] == "alice-"
+		})).Return(nil, nil).Once()
+		mockStorer.EXPECT().InsertUserWithIdentity(ctx, mock.Anything, mock.Anything).Run(func(_ context.Context, u *model.User, identity *model.UserIdentity) {
+			assert.Equal(t, "alice@test.com", u.Email)
+			assert.True(t, u.EmailVerified)
+			assert.Equal(t, "42", identity.Subject)
+			u.ID = uuid.New()
+		}).Return(nil).Once()
+		mockSessionOpener.EXPECT().TwoFactorChallenge(ctx, mock.Anything, mock.Anything).Return(nil, nil).Once()
+		mockSessionOpener.EXPECT().OpenSession(ctx, mock.Anything, mock.Anything).Return(tokens, nil).Once()
+
+		_, _, err := o.Callback(ctx, "state", "code", &model.Session{})
+		require.NoError(t, err)
+	})
+}
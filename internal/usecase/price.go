@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+	"sort"
+)
+
+type PriceStorer interface {
+	SelectMostRecentPricesByProductID(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error)
+}
+
+type PriceUserProductStorer interface {
+	SelectMostRecentUserProductByStoreID(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error)
+	SelectProductsByUserIDAndStoreID(ctx context.Context, userID, storeID uuid.UUID) ([]*model.UserProduct, error)
+}
+
+type PriceProductStorer interface {
+	GetProductByEAN(ctx context.Context, ean string) (*model.Product, error)
+}
+
+type Price struct {
+	PriceStorer            PriceStorer
+	PriceUserProductStorer PriceUserProductStorer
+	PriceProductStorer     PriceProductStorer
+}
+
+func NewPrice(ps PriceStorer, pups PriceUserProductStorer, pps PriceProductStorer) *Price {
+	return &Price{
+		PriceStorer:            ps,
+		PriceUserProductStorer: pups,
+		PriceProductStorer:     pps,
+	}
+}
+
+func (p *Price) CompareByProductID(ctx context.Context, productID uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	prices, err := p.PriceStorer.SelectMostRecentPricesByProductID(ctx, productID, filter)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("CompareByProductID.SelectMostRecentPricesByProductID")
+		return nil, model.ErrPriceError
+	}
+
+	sortStorePrices(prices, filter.Sort)
+
+	return prices, nil
+}
+
+func (p *Price) CompareByEAN(ctx context.Context, ean string, filter *model.PriceFilter) ([]*model.StorePrice, error) {
+	product, err := p.PriceProductStorer.GetProductByEAN(ctx, ean)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("CompareByEAN.GetProductByEAN")
+		return nil, model.ErrProductError
+	}
+	if product == nil {
+		return nil, model.ErrNotExistsError
+	}
+
+	return p.CompareByProductID(ctx, product.ProductID, filter)
+}
+
+// SelectStorePrices returns the last price of each product in the store, read on the closed bills of all
+// the users.
+func (p *Price) SelectStorePrices(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ups, err := p.PriceUserProductStorer.SelectMostRecentUserProductByStoreID(ctx, storeID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("SelectStorePrices.SelectMostRecentUserProductByStoreID")
+		return nil, model.ErrPriceError
+	}
+
+	return ups, nil
+}
+
+func (p *Price) SelectUserStorePrices(ctx context.Context, userID, storeID uuid.UUID) ([]*model.UserProduct, error) {
+	ups, err := p.PriceUserProductStorer.SelectProductsByUserIDAndStoreID(ctx, userID, storeID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("SelectUserStorePrices.SelectProductsByUserIDAndStoreID")
+		return nil, model.ErrPriceError
+	}
+
+	return ups, nil
+}
+
+// sortStorePrices orders the cheapest first, unit prices that can't be computed are kept at the end.
+func sortStorePrices(prices []*model.StorePrice, sortBy string) {
+	value := func(sp *model.StorePrice) (model.Money, bool) {
+		if sortBy == model.PriceSortUnitPrice {
+			return model.UnitPrice(sp.Price, sp.ProductSize, sp.SizeFormat)
+		}
+		return sp.Price, true
+	}
+
+	sort.SliceStable(prices, func(i, j int) bool {
+		vi, oki := value(prices[i])
+		vj, okj := value(prices[j])
+		if oki != okj {
+			return oki
+		}
+		return vi.Cmp(vj) < 0
+	})
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+)
+
+type PriceHistoryStorer interface {
+	SelectPriceHistory(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter) ([]*model.PricePoint, error)
+}
+
+type PriceHistory struct {
+	PriceHistoryStorer PriceHistoryStorer
+}
+
+func NewPriceHistory(phs PriceHistoryStorer) *PriceHistory {
+	return &PriceHistory{
+		PriceHistoryStorer: phs,
+	}
+}
+
+func (ph *PriceHistory) SelectPriceHistory(ctx context.Context, productID uuid.UUID, filter *model.PriceHistoryFilter) ([]*model.PricePoint, error) {
+	if filter.Interval == "" {
+		filter.Interval = model.PriceHistoryIntervalDay
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, model.ErrPriceHistoryRangeError
+	}
+
+	points, err := ph.PriceHistoryStorer.SelectPriceHistory(ctx, productID, filter)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("SelectPriceHistory.SelectPriceHistory")
+		return nil, model.ErrPriceHistoryError
+	}
+
+	return points, nil
+}
@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestPriceHistory_SelectPriceHistory(t *testing.T) {
+	ctx := context.Background()
+	mockPriceHistoryStorer := NewPriceHistoryStorer(t)
+	ph := usecase.NewPriceHistory(mockPriceHistoryStorer)
+	productID := uuid.New()
+
+	t.Run("invalid range", func(t *testing.T) {
+		filter := &model.PriceHistoryFilter{
+			From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		points, err := ph.SelectPriceHistory(ctx, productID, filter)
+		assert.ErrorIs(t, err, model.ErrPriceHistoryRangeError)
+		assert.Nil(t, points)
+	})
+
+	t.Run("SelectPriceHistory error", func(t *testing.T) {
+		filter := &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalWeek}
+		mockPriceHistoryStorer.EXPECT().SelectPriceHistory(ctx, productID, filter).Return(nil, errors.New("random error")).Once()
+		points, err := ph.SelectPriceHistory(ctx, productID, filter)
+		assert.ErrorIs(t, err, model.ErrPriceHistoryError)
+		assert.Nil(t, points)
+	})
+
+	t.Run("default interval", func(t *testing.T) {
+		expected := []*model.PricePoint{{MinPrice: money("1"), MaxPrice: money("2"), AvgPrice: money("1.5"), MedianPrice: money("1.5"), Count: 2}}
+		mockPriceHistoryStorer.EXPECT().SelectPriceHistory(ctx, productID, &model.PriceHistoryFilter{Interval: model.PriceHistoryIntervalDay}).Return(expected, nil).Once()
+		points, err := ph.SelectPriceHistory(ctx, productID, &model.PriceHistoryFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, points)
+	})
+}
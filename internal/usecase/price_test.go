@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+)
+
+func TestPrice_CompareByProductID(t *testing.T) {
+	ctx := context.Background()
+	mockPriceStorer := NewPriceStorer(t)
+	p := usecase.NewPrice(mockPriceStorer, nil, nil)
+
+	productID := uuid.New()
+	expectedError := errors.New("random error")
+
+	t.Run("SelectMostRecentPricesByProductID error", func(t *testing.T) {
+		filter := &model.PriceFilter{}
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductID(ctx, productID, filter).Return(nil, expectedError).Once()
+		prices, err := p.CompareByProductID(ctx, productID, filter)
+		assert.ErrorIs(t, err, model.ErrPriceError)
+		assert.Nil(t, prices)
+	})
+
+	t.Run("sort by price", func(t *testing.T) {
+		filter := &model.PriceFilter{Sort: model.PriceSortPrice}
+		expensive := &model.StorePrice{Price: money("5.19")}
+		cheap := &model.StorePrice{Price: money("4,79")}
+		free := &model.StorePrice{Price: money("0")}
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductID(ctx, productID, filter).Return([]*model.StorePrice{expensive, free, cheap}, nil).Once()
+		prices, err := p.CompareByProductID(ctx, productID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.StorePrice{free, cheap, expensive}, prices)
+	})
+
+	t.Run("sort by unit price", func(t *testing.T) {
+		filter := &model.PriceFilter{Sort: model.PriceSortUnitPrice}
+		small := &model.StorePrice{Price: money("2"), ProductSize: "500", SizeFormat: model.SizeFormatWeightGr}
+		big := &model.StorePrice{Price: money("3"), ProductSize: "1", SizeFormat: model.SizeFormatWeightKg}
+		unknownSize := &model.StorePrice{Price: money("1")}
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductID(ctx, productID, filter).Return([]*model.StorePrice{unknownSize, small, big}, nil).Once()
+		prices, err := p.CompareByProductID(ctx, productID, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.StorePrice{big, small, unknownSize}, prices)
+	})
+}
+
+func TestPrice_CompareByEAN(t *testing.T) {
+	ctx := context.Background()
+	mockPriceStorer := NewPriceStorer(t)
+	mockProductStorer := NewPriceProductStorer(t)
+	p := usecase.NewPrice(mockPriceStorer, nil, mockProductStorer)
+
+	filter := &model.PriceFilter{}
+	product := &model.Product{ProductID: uuid.New(), EAN: "3017620422003"}
+	expectedError := errors.New("random error")
+
+	t.Run("GetProductByEAN error", func(t *testing.T) {
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, product.EAN).Return(nil, expectedError).Once()
+		_, err := p.CompareByEAN(ctx, product.EAN, filter)
+		assert.ErrorIs(t, err, model.ErrProductError)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, product.EAN).Return(nil, nil).Once()
+		_, err := p.CompareByEAN(ctx, product.EAN, filter)
+		assert.ErrorIs(t, err, model.ErrNotExistsError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		expected := []*model.StorePrice{{ProductID: product.ProductID, Price: money("4.99")}}
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, product.EAN).Return(product, nil).Once()
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductID(ctx, product.ProductID, filter).Return(expected, nil).Once()
+		prices, err := p.CompareByEAN(ctx, product.EAN, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, prices)
+	})
+}
+
+func TestPrice_SelectStorePrices(t *testing.T) {
+	ctx := context.Background()
+	mockUserProductStorer := NewPriceUserProductStorer(t)
+	p := usecase.NewPrice(nil, mockUserProductStorer, nil)
+
+	storeID := uuid.New()
+	userID := uuid.New()
+	expected := []*model.UserProduct{{StoreID: storeID}}
+
+	t.Run("SelectMostRecentUserProductByStoreID error", func(t *testing.T) {
+		mockUserProductStorer.EXPECT().SelectMostRecentUserProductByStoreID(ctx, storeID).Return(nil, errors.New("random error")).Once()
+		_, err := p.SelectStorePrices(ctx, storeID)
+		assert.ErrorIs(t, err, model.ErrPriceError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserProductStorer.EXPECT().SelectMostRecentUserProductByStoreID(ctx, storeID).Return(expected, nil).Once()
+		ups, err := p.SelectStorePrices(ctx, storeID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ups)
+	})
+
+	t.Run("user store prices", func(t *testing.T) {
+		mockUserProductStorer.EXPECT().SelectProductsByUserIDAndStoreID(ctx, userID, storeID).Return(expected, nil).Once()
+		ups, err := p.SelectUserStorePrices(ctx, userID, storeID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ups)
+	})
+}
+
+func money(amount string) model.Money {
+	m, err := model.ParseMoney(amount)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
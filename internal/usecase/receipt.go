@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"io"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/receipt"
+)
+
+type ReceiptRecognizer interface {
+	Recognize(ctx context.Context, image io.Reader) (string, error)
+}
+
+type ReceiptAuthorizer interface {
+	OpenBill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+}
+
+type ReceiptStoreStorer interface {
+	SelectStoreByID(ctx context.Context, storeID uuid.UUID) (*model.Store, error)
+}
+
+type ReceiptUserProductStorer interface {
+	SelectMostRecentProductsByUserIDAndCompanyID(ctx context.Context, userID, companyID uuid.UUID) ([]*model.UserProduct, error)
+}
+
+type Receipt struct {
+	ReceiptRecognizer        ReceiptRecognizer
+	ReceiptAuthorizer        ReceiptAuthorizer
+	ReceiptStoreStorer       ReceiptStoreStorer
+	ReceiptUserProductStorer ReceiptUserProductStorer
+}
+
+func NewReceipt(rr ReceiptRecognizer, ra ReceiptAuthorizer, rss ReceiptStoreStorer, rups ReceiptUserProductStorer) *Receipt {
+	return &Receipt{
+		ReceiptRecognizer:        rr,
+		ReceiptAuthorizer:        ra,
+		ReceiptStoreStorer:       rss,
+		ReceiptUserProductStorer: rups,
+	}
+}
+
+// Scan reads the receipt photo of an open bill and returns draft lines, nothing is stored until the user
+// confirms them as user products.
+func (r *Receipt) Scan(ctx context.Context, userID, billID uuid.UUID, image io.Reader) ([]*model.ReceiptDraft, error) {
+	bill, err := r.ReceiptAuthorizer.OpenBill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := r.ReceiptStoreStorer.SelectStoreByID(ctx, bill.StoreID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Scan.SelectStoreByID")
+		return nil, model.ErrStoreError
+	}
+
+	text, err := r.ReceiptRecognizer.Recognize(ctx, image)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Scan.Recognize")
+		return nil, model.ErrReceiptError
+	}
+
+	known, err := r.ReceiptUserProductStorer.SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, store.CompanyID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Scan.SelectMostRecentProductsByUserIDAndCompanyID")
+		return nil, model.ErrReceiptError
+	}
+
+	drafts := []*model.ReceiptDraft{}
+	for _, line := range receipt.ParseLines(text, bill.Amount.Currency) {
+		match, score := receipt.Match(line, known)
+		drafts = append(drafts, &model.ReceiptDraft{
+			BillID: bill.BillID,
+			Line:   line,
+			Match:  match,
+			Score:  score,
+		})
+	}
+
+	return drafts, nil
+}
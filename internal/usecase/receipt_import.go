@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/receipt"
+	"time"
+)
+
+type ReceiptImportParser interface {
+	ParseReceipt(company string, raw []byte, currency string) (*model.ParsedReceipt, error)
+}
+
+type ReceiptImportCompanyStorer interface {
+	SelectCompanyByID(ctx context.Context, companyID uuid.UUID) (*model.Company, error)
+}
+
+type ReceiptImportBillUseCase interface {
+	StartDatedBill(ctx context.Context, userID, storeID uuid.UUID, currency string, date time.Time) (*response.Bill, error)
+	CloseBill(ctx context.Context, userID, billID uuid.UUID, amount model.Money) (*response.Bill, error)
+	CancelBill(ctx context.Context, userID, billID uuid.UUID) error
+	GetOpenBill(ctx context.Context, userID, billID uuid.UUID) (*response.Bill, error)
+}
+
+type ReceiptImportUserProductUseCase interface {
+	Create(ctx context.Context, um *model.UserProduct, userID uuid.UUID) (*model.UserProduct, error)
+}
+
+type ReceiptImport struct {
+	ReceiptImportParser             ReceiptImportParser
+	ReceiptStoreStorer              ReceiptStoreStorer
+	ReceiptImportCompanyStorer      ReceiptImportCompanyStorer
+	ReceiptUserProductStorer        ReceiptUserProductStorer
+	ReceiptImportBillUseCase        ReceiptImportBillUseCase
+	ReceiptImportUserProductUseCase ReceiptImportUserProductUseCase
+}
+
+func NewReceiptImport(
+	rip ReceiptImportParser,
+	rss ReceiptStoreStorer,
+	rics ReceiptImportCompanyStorer,
+	rups ReceiptUserProductStorer,
+	ribu ReceiptImportBillUseCase,
+	riupu ReceiptImportUserProductUseCase,
+) *ReceiptImport {
+	return &ReceiptImport{
+		ReceiptImportParser:             rip,
+		ReceiptStoreStorer:              rss,
+		ReceiptImportCompanyStorer:      rics,
+		ReceiptUserProductStorer:        rups,
+		ReceiptImportBillUseCase:        ribu,
+		ReceiptImportUserProductUseCase: riupu,
+	}
+}
+
+// Import parses a text, HTML or e-mail receipt of the store with the parser of its company and fills a new
+// bill in the currency through the bill and user product flows. The receipt must name the company of the
+// store and print its date, the bill and its lines are dated at it. The lines matching a product already
+// bought by the user become user products. The bill is closed when every line matched, otherwise it stays
+// open and the other lines are returned for the user to complete. The discounts are not lines, so the bill is
+// closed with the total before them to match the sum of its lines. A bill that could not be filled is
+// canceled.
+func (ri *ReceiptImport) Import(ctx context.Context, userID, storeID uuid.UUID, currency string, raw []byte) (*response.ReceiptImport, error) {
+	store, err := ri.ReceiptStoreStorer.SelectStoreByID(ctx, storeID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Import.SelectStoreByID")
+		return nil, model.ErrStoreError
+	}
+	if store == nil {
+		return nil, model.ErrCatalogNotFoundError
+	}
+
+	company, err := ri.ReceiptImportCompanyStorer.SelectCompanyByID(ctx, store.CompanyID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Import.SelectCompanyByID")
+		return nil, model.ErrStoreError
+	}
+	if company == nil {
+		return nil, model.ErrCatalogNotFoundError
+	}
+
+	parsed, err := ri.ReceiptImportParser.ParseReceipt(company.CompanyName, raw, currency)
+	if err != nil {
+		// the file is not a receipt the parser knows, the user is told so
+		log.Warn().Caller().Err(err).Msg("Import.ParseReceipt")
+		return nil, model.ErrInvalidReceiptError
+	}
+	if !receipt.IsOfCompany(parsed.StoreName, company.CompanyName) {
+		return nil, fmt.Errorf("%w: the receipt is not one of %s", model.ErrInvalidReceiptError, company.CompanyName)
+	}
+	// the date of the purchase goes to the price history, the import time would be wrong for an old receipt
+	if parsed.Date.IsZero() || parsed.Date.After(time.Now()) {
+		return nil, fmt.Errorf("%w: the receipt has no valid date", model.ErrInvalidReceiptError)
+	}
+
+	// the discounts are negative
+	total := parsed.Total
+	for _, discount := range parsed.Discounts {
+		if total, err = total.Sub(discount.Total); err != nil {
+			return nil, model.ErrInvalidReceiptError
+		}
+	}
+
+	known, err := ri.ReceiptUserProductStorer.SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, company.CompanyID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Import.SelectMostRecentProductsByUserIDAndCompanyID")
+		return nil, model.ErrReceiptError
+	}
+
+	bill, err := ri.ReceiptImportBillUseCase.StartDatedBill(ctx, userID, storeID, currency, parsed.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmatched []*model.ReceiptDraft
+	for _, line := range parsed.Lines {
+		match, score := receipt.Match(line, known)
+		if match == nil {
+			unmatched = append(unmatched, &model.ReceiptDraft{BillID: bill.BillID, Line: line, Score: score})
+			continue
+		}
+		_, err = ri.ReceiptImportUserProductUseCase.Create(ctx, &model.UserProduct{
+			ProductID:   match.ProductID,
+			BillID:      bill.BillID,
+			Price:       line.UnitPrice,
+			Quantity:    line.Quantity,
+			ProductType: match.ProductType,
+			ProductSize: match.ProductSize,
+			SizeFormat:  match.SizeFormat,
+			CreatedAt:   parsed.Date,
+		}, userID)
+		if err != nil {
+			ri.cancel(ctx, userID, bill.BillID)
+			return nil, err
+		}
+	}
+
+	if len(unmatched) > 0 {
+		bill, err = ri.ReceiptImportBillUseCase.GetOpenBill(ctx, userID, bill.BillID)
+		if err != nil {
+			return nil, err
+		}
+		return response.NewReceiptImportFromModel(parsed, bill, unmatched), nil
+	}
+
+	closed, err := ri.ReceiptImportBillUseCase.CloseBill(ctx, userID, bill.BillID, total)
+	if err != nil {
+		ri.cancel(ctx, userID, bill.BillID)
+		return nil, err
+	}
+
+	return response.NewReceiptImportFromModel(parsed, closed, unmatched), nil
+}
+
+// cancel cancels the bill left half-filled by a failed import, the error of the import is the one returned.
+func (ri *ReceiptImport) cancel(ctx context.Context, userID, billID uuid.UUID) {
+	if err := ri.ReceiptImportBillUseCase.CancelBill(ctx, userID, billID); err != nil {
+		log.Error().Caller().Err(err).Msg("Import.CancelBill")
+	}
+}
@@ -0,0 +1,148 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/model/response"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestReceiptImport_Import(t *testing.T) {
+	ctx := context.Background()
+	mockParser := NewReceiptImportParser(t)
+	mockStoreStorer := NewReceiptStoreStorer(t)
+	mockCompanyStorer := NewReceiptImportCompanyStorer(t)
+	mockUserProductStorer := NewReceiptUserProductStorer(t)
+	mockBillUseCase := NewReceiptImportBillUseCase(t)
+	mockUserProductUseCase := NewReceiptImportUserProductUseCase(t)
+	ri := usecase.NewReceiptImport(mockParser, mockStoreStorer, mockCompanyStorer, mockUserProductStorer, mockBillUseCase, mockUserProductUseCase)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	company := &model.Company{CompanyID: store.CompanyID, CompanyName: "Lidl"}
+	raw := []byte("Lidl Laon\nNutella 400g 3,49 A\nA payer 3,49\n")
+	nutella := &model.UserProduct{ProductID: uuid.New(), ProductName: "Nutella 400g", Price: money("3.49"), ProductType: "épicerie"}
+	nutellaLine := &model.ReceiptLine{Name: "Nutella 400g", Quantity: 1, UnitPrice: money("3.49"), Total: money("3.49")}
+	bagLine := &model.ReceiptLine{Name: "Sac cabas", Quantity: 1, UnitPrice: money("0.10"), Total: money("0.10")}
+	newBill := func() *response.Bill {
+		return &response.Bill{BillID: uuid.New(), State: model.BillStateCreate, Store: response.NewBillStore(store, company)}
+	}
+	date := time.Date(2024, 3, 18, 9, 15, 0, 0, time.UTC)
+	// parsed returns a dated receipt of the company with the lines and a total of their sum
+	parsed := func(lines ...*model.ReceiptLine) *model.ParsedReceipt {
+		pr := &model.ParsedReceipt{StoreName: "Lidl Laon", Date: date, Lines: lines, Total: model.ZeroMoney("EUR")}
+		for _, line := range lines {
+			pr.Total, _ = pr.Total.Add(line.Total)
+		}
+		return pr
+	}
+	expectedError := errors.New("random error")
+
+	t.Run("SelectStoreByID error", func(t *testing.T) {
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(nil, expectedError).Once()
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		assert.ErrorIs(t, err, model.ErrStoreError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ParseReceipt error", func(t *testing.T) {
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(nil, expectedError).Once()
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		assert.ErrorIs(t, err, model.ErrInvalidReceiptError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("receipt of an other company", func(t *testing.T) {
+		other := parsed(nutellaLine)
+		other.StoreName = "Carrefour Market Laon"
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(other, nil).Once()
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		assert.ErrorIs(t, err, model.ErrInvalidReceiptError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("receipt without date", func(t *testing.T) {
+		undated := parsed(nutellaLine)
+		undated.Date = time.Time{}
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(undated, nil).Once()
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		assert.ErrorIs(t, err, model.ErrInvalidReceiptError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("all lines matched", func(t *testing.T) {
+		open := newBill()
+		closed := &response.Bill{BillID: open.BillID, State: model.BillStateCompleted, Amount: money("3.29")}
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(&model.ParsedReceipt{
+			StoreName: "Lidl Laon",
+			Date:      date,
+			Lines:     []*model.ReceiptLine{nutellaLine},
+			Discounts: []*model.ReceiptLine{{Name: "Lidl Plus", Quantity: 1, UnitPrice: money("-0.20"), Total: money("-0.20")}},
+			Total:     money("3.29"),
+		}, nil).Once()
+		mockUserProductStorer.EXPECT().SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, company.CompanyID).Return([]*model.UserProduct{nutella}, nil).Once()
+		mockBillUseCase.EXPECT().StartDatedBill(ctx, userID, store.StoreID, "EUR", date).Return(open, nil).Once()
+		mockUserProductUseCase.EXPECT().Create(ctx, mock.MatchedBy(func(up *model.UserProduct) bool {
+			return up.ProductID == nutella.ProductID && up.BillID == open.BillID && up.Quantity == 1 && up.ProductType == "épicerie" &&
+				up.CreatedAt.Equal(date)
+		}), userID).Return(&model.UserProduct{}, nil).Once()
+		// the discount is not a line, the bill is closed with the total before it
+		mockBillUseCase.EXPECT().CloseBill(ctx, userID, open.BillID, money("3.49")).Return(closed, nil).Once()
+
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		require.NoError(t, err)
+		assert.Equal(t, closed, result.Bill)
+		assert.Equal(t, "Lidl Laon", result.StoreName)
+		assert.Equal(t, date, *result.Date)
+		assert.Empty(t, result.Unmatched)
+		require.Len(t, result.Discounts, 1)
+		assert.Equal(t, "-0.20 EUR", result.Discounts[0].Total.String())
+	})
+
+	t.Run("Create error cancels the bill", func(t *testing.T) {
+		open := newBill()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(parsed(nutellaLine), nil).Once()
+		mockUserProductStorer.EXPECT().SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, company.CompanyID).Return([]*model.UserProduct{nutella}, nil).Once()
+		mockBillUseCase.EXPECT().StartDatedBill(ctx, userID, store.StoreID, "EUR", date).Return(open, nil).Once()
+		mockUserProductUseCase.EXPECT().Create(ctx, mock.Anything, userID).Return(nil, model.ErrUserProductError).Once()
+		mockBillUseCase.EXPECT().CancelBill(ctx, userID, open.BillID).Return(nil).Once()
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		assert.ErrorIs(t, err, model.ErrUserProductError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("unmatched lines keep the bill open", func(t *testing.T) {
+		open := newBill()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockCompanyStorer.EXPECT().SelectCompanyByID(ctx, store.CompanyID).Return(company, nil).Once()
+		mockParser.EXPECT().ParseReceipt(company.CompanyName, raw, "EUR").Return(parsed(nutellaLine, bagLine), nil).Once()
+		mockUserProductStorer.EXPECT().SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, company.CompanyID).Return([]*model.UserProduct{nutella}, nil).Once()
+		mockBillUseCase.EXPECT().StartDatedBill(ctx, userID, store.StoreID, "EUR", date).Return(open, nil).Once()
+		mockUserProductUseCase.EXPECT().Create(ctx, mock.Anything, userID).Return(&model.UserProduct{}, nil).Once()
+		mockBillUseCase.EXPECT().GetOpenBill(ctx, userID, open.BillID).Return(open, nil).Once()
+
+		result, err := ri.Import(ctx, userID, store.StoreID, "EUR", raw)
+		require.NoError(t, err)
+		assert.Equal(t, open, result.Bill)
+		require.Len(t, result.Unmatched, 1)
+		assert.Equal(t, "Sac cabas", result.Unmatched[0].ReceiptName)
+		assert.Equal(t, open.BillID, result.Unmatched[0].BillID)
+	})
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"strings"
+	"testing"
+)
+
+func TestReceipt_Scan(t *testing.T) {
+	ctx := context.Background()
+	mockRecognizer := NewReceiptRecognizer(t)
+	mockAuthorizer := NewReceiptAuthorizer(t)
+	mockStoreStorer := NewReceiptStoreStorer(t)
+	mockUserProductStorer := NewReceiptUserProductStorer(t)
+	r := usecase.NewReceipt(mockRecognizer, mockAuthorizer, mockStoreStorer, mockUserProductStorer)
+
+	userID := uuid.New()
+	store := &model.Store{StoreID: uuid.New(), CompanyID: uuid.New()}
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, StoreID: store.StoreID, Amount: model.ZeroMoney(model.DefaultCurrency), State: model.BillStateCreate}
+	nutella := &model.UserProduct{ProductID: uuid.New(), ProductName: "Pâte à tartiner 400g", BrandName: "Nutella", Price: money("3.49")}
+	expectedError := errors.New("random error")
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, billID).Return(nil, model.ErrBillNotFoundError).Once()
+		drafts, err := r.Scan(ctx, userID, billID, strings.NewReader("image"))
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, drafts)
+	})
+
+	t.Run("bill not open", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, billID).Return(nil, model.ErrBillNotOpenError).Once()
+		drafts, err := r.Scan(ctx, userID, billID, strings.NewReader("image"))
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, drafts)
+	})
+
+	t.Run("Recognize error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockRecognizer.EXPECT().Recognize(ctx, mock.Anything).Return("", expectedError).Once()
+		drafts, err := r.Scan(ctx, userID, bill.BillID, strings.NewReader("image"))
+		assert.ErrorIs(t, err, model.ErrReceiptError)
+		assert.Nil(t, drafts)
+	})
+
+	t.Run("SelectMostRecentProductsByUserIDAndCompanyID error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockRecognizer.EXPECT().Recognize(ctx, mock.Anything).Return("NUTELLA 400G 3,49", nil).Once()
+		mockUserProductStorer.EXPECT().SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, store.CompanyID).Return(nil, expectedError).Once()
+		drafts, err := r.Scan(ctx, userID, bill.BillID, strings.NewReader("image"))
+		assert.ErrorIs(t, err, model.ErrReceiptError)
+		assert.Nil(t, drafts)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockStoreStorer.EXPECT().SelectStoreByID(ctx, store.StoreID).Return(store, nil).Once()
+		mockRecognizer.EXPECT().Recognize(ctx, mock.Anything).Return("NUTELLA PATE A TARTINER 3,49 €\nSAC CABAS 0,10\nTOTAL 3,59", nil).Once()
+		mockUserProductStorer.EXPECT().SelectMostRecentProductsByUserIDAndCompanyID(ctx, userID, store.CompanyID).Return([]*model.UserProduct{nutella}, nil).Once()
+		drafts, err := r.Scan(ctx, userID, bill.BillID, strings.NewReader("image"))
+		require.NoError(t, err)
+		require.Len(t, drafts, 2)
+		assert.Equal(t, bill.BillID, drafts[0].BillID)
+		assert.Equal(t, "NUTELLA PATE A TARTINER", drafts[0].Line.Name)
+		assert.Equal(t, nutella, drafts[0].Match)
+		assert.Greater(t, drafts[0].Score, 0.0)
+		assert.Equal(t, "SAC CABAS", drafts[1].Line.Name)
+		assert.Nil(t, drafts[1].Match)
+	})
+}
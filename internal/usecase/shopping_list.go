@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/model"
+	"sort"
+)
+
+// shoppingListMaxCandidates bounds the stores considered when splitting a list, the number of
+// combinations grows quickly with it.
+const shoppingListMaxCandidates = 20
+
+type ShoppingListPriceStorer interface {
+	SelectMostRecentPricesByProductIDs(ctx context.Context, productIDs []uuid.UUID, filter *model.PriceFilter) ([]*model.StorePrice, error)
+}
+
+type ShoppingListProductStorer interface {
+	GetProductByEAN(ctx context.Context, ean string) (*model.Product, error)
+}
+
+type ShoppingList struct {
+	ShoppingListPriceStorer   ShoppingListPriceStorer
+	ShoppingListProductStorer ShoppingListProductStorer
+}
+
+func NewShoppingList(slps ShoppingListPriceStorer, slpds ShoppingListProductStorer) *ShoppingList {
+	return &ShoppingList{
+		ShoppingListPriceStorer:   slps,
+		ShoppingListProductStorer: slpds,
+	}
+}
+
+type storeOffer struct {
+	store  *model.StorePrice
+	prices map[uuid.UUID]*model.StorePrice
+}
+
+// Optimize prices the items in currency, model.DefaultCurrency when empty, the prices in other
+// currencies are left out of the plans.
+func (sl *ShoppingList) Optimize(ctx context.Context, items []*model.ShoppingListItem, maxStores int, currency string, filter *model.PriceFilter) (*model.ShoppingListOptimization, error) {
+	if len(items) > model.ShoppingListMaxItems {
+		return nil, fmt.Errorf("%w: at most %d items", model.ErrShoppingListTooLongError, model.ShoppingListMaxItems)
+	}
+	if maxStores <= 0 {
+		maxStores = 1
+	}
+	if maxStores > model.ShoppingListMaxStores {
+		maxStores = model.ShoppingListMaxStores
+	}
+
+	var productIDs []uuid.UUID
+	for _, item := range items {
+		if item.ProductID == uuid.Nil && item.Ean != "" {
+			product, err := sl.ShoppingListProductStorer.GetProductByEAN(ctx, item.Ean)
+			if err != nil {
+				log.Error().Caller().Err(err).Msg("Optimize.GetProductByEAN")
+				return nil, model.ErrProductError
+			}
+			if product != nil {
+				item.ProductID = product.ProductID
+			}
+		}
+		if item.ProductID != uuid.Nil {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	var prices []*model.StorePrice
+	if len(productIDs) > 0 {
+		var err error
+		prices, err = sl.ShoppingListPriceStorer.SelectMostRecentPricesByProductIDs(ctx, productIDs, filter)
+		if err != nil {
+			log.Error().Caller().Err(err).Msg("Optimize.SelectMostRecentPricesByProductIDs")
+			return nil, model.ErrShoppingListError
+		}
+	}
+
+	offers := newStoreOffers(prices)
+
+	return &model.ShoppingListOptimization{
+		SingleStore: cheapestPlan(items, offers, 1, currency),
+		Split:       cheapestPlan(items, offers, maxStores, currency),
+	}, nil
+}
+
+// newStoreOffers groups the prices by store, the stores covering the most products come first.
+func newStoreOffers(prices []*model.StorePrice) []*storeOffer {
+	byStore := map[uuid.UUID]*storeOffer{}
+	var offers []*storeOffer
+	for _, price := range prices {
+		offer, ok := byStore[price.StoreID]
+		if !ok {
+			offer = &storeOffer{
+				store:  price,
+				prices: map[uuid.UUID]*model.StorePrice{},
+			}
+			byStore[price.StoreID] = offer
+			offers = append(offers, offer)
+		}
+		offer.prices[price.ProductID] = price
+	}
+
+	sort.SliceStable(offers, func(i, j int) bool {
+		if len(offers[i].prices) != len(offers[j].prices) {
+			return len(offers[i].prices) > len(offers[j].prices)
+		}
+		return offers[i].store.StoreID.String() < offers[j].store.StoreID.String()
+	})
+
+	return offers
+}
+
+// cheapestPlan tries every combination of at most maxStores stores and keeps the plan pricing
+// the most items, then the cheapest one.
+func cheapestPlan(items []*model.ShoppingListItem, offers []*storeOffer, maxStores int, currency string) *model.ShoppingPlan {
+	if len(offers) > shoppingListMaxCandidates {
+		offers = offers[:shoppingListMaxCandidates]
+	}
+
+	best := buildPlan(items, nil, currency)
+	for size := 1; size <= maxStores && size <= len(offers); size++ {
+		combinations(len(offers), size, func(indexes []int) {
+			combination := make([]*storeOffer, len(indexes))
+			for i, index := range indexes {
+				combination[i] = offers[index]
+			}
+			if plan := buildPlan(items, combination, currency); betterPlan(plan, best) {
+				best = plan
+			}
+		})
+	}
+
+	return best
+}
+
+func buildPlan(items []*model.ShoppingListItem, offers []*storeOffer, currency string) *model.ShoppingPlan {
+	plan := &model.ShoppingPlan{
+		Stores:   []*model.ShoppingPlanStore{},
+		Unpriced: []*model.ShoppingListItem{},
+		Total:    model.ZeroMoney(currency),
+	}
+	stores := map[uuid.UUID]*model.ShoppingPlanStore{}
+
+	for _, item := range items {
+		var cheapest *storeOffer
+		for _, offer := range offers {
+			price, ok := offer.prices[item.ProductID]
+			// the plan adds up prices of its currency only
+			if ok && price.Price.Currency == plan.Total.Currency && (cheapest == nil || price.Price.Cmp(cheapest.prices[item.ProductID].Price) < 0) {
+				cheapest = offer
+			}
+		}
+		if cheapest == nil {
+			plan.Unpriced = append(plan.Unpriced, item)
+			continue
+		}
+
+		price := cheapest.prices[item.ProductID]
+		line := &model.ShoppingPlanLine{
+			UserProductID: price.UserProductID,
+			ProductID:     price.ProductID,
+			ProductName:   price.ProductName,
+			Ean:           price.Ean,
+			Quantity:      item.Quantity,
+			Price:         price.Price,
+			LineTotal:     price.Price.Mul(item.Quantity),
+			PricedAt:      price.PricedAt,
+		}
+
+		store, ok := stores[price.StoreID]
+		if !ok {
+			store = &model.ShoppingPlanStore{
+				StoreID:     price.StoreID,
+				StoreName:   price.StoreName,
+				ZipCode:     price.ZipCode,
+				City:        price.City,
+				CompanyID:   price.CompanyID,
+				CompanyName: price.CompanyName,
+				Subtotal:    model.ZeroMoney(price.Price.Currency),
+			}
+			stores[price.StoreID] = store
+			plan.Stores = append(plan.Stores, store)
+		}
+		store.Lines = append(store.Lines, line)
+		store.Subtotal.Amount = store.Subtotal.Amount.Add(line.LineTotal.Amount)
+		plan.Total.Amount = plan.Total.Amount.Add(line.LineTotal.Amount)
+		if plan.OldestPriceAt.IsZero() || line.PricedAt.Before(plan.OldestPriceAt) {
+			plan.OldestPriceAt = line.PricedAt
+		}
+	}
+
+	return plan
+}
+
+func betterPlan(plan, best *model.ShoppingPlan) bool {
+	if len(plan.Unpriced) != len(best.Unpriced) {
+		return len(plan.Unpriced) < len(best.Unpriced)
+	}
+	if cmp := plan.Total.Cmp(best.Total); cmp != 0 {
+		return cmp < 0
+	}
+	return len(plan.Stores) < len(best.Stores)
+}
+
+// combinations calls fn with every sorted set of k indexes picked in [0, n).
+func combinations(n, k int, fn func([]int)) {
+	indexes := make([]int, k)
+	var pick func(position, start int)
+	pick = func(position, start int) {
+		if position == k {
+			fn(indexes)
+			return
+		}
+		for i := start; i <= n-(k-position); i++ {
+			indexes[position] = i
+			pick(position+1, i+1)
+		}
+	}
+	pick(0, 0)
+}
@@ -0,0 +1,121 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestShoppingList_Optimize(t *testing.T) {
+	ctx := context.Background()
+	mockPriceStorer := NewShoppingListPriceStorer(t)
+	mockProductStorer := NewShoppingListProductStorer(t)
+	sl := usecase.NewShoppingList(mockPriceStorer, mockProductStorer)
+
+	filter := &model.PriceFilter{}
+	milk := uuid.New()
+	bread := uuid.New()
+	coffee := uuid.New()
+	storeA := uuid.New()
+	storeB := uuid.New()
+	now := time.Now()
+	expectedError := errors.New("random error")
+
+	newPrice := func(storeID, productID uuid.UUID, price string, pricedAt time.Time) *model.StorePrice {
+		return &model.StorePrice{StoreID: storeID, ProductID: productID, Price: money(price), PricedAt: pricedAt}
+	}
+	prices := []*model.StorePrice{
+		newPrice(storeA, milk, "1.00", now),
+		newPrice(storeA, bread, "2.00", now),
+		newPrice(storeB, milk, "0.80", now.Add(-time.Hour)),
+		newPrice(storeB, bread, "2,50", now),
+	}
+
+	t.Run("too many items", func(t *testing.T) {
+		items := make([]*model.ShoppingListItem, model.ShoppingListMaxItems+1)
+		for i := range items {
+			items[i] = &model.ShoppingListItem{ProductID: uuid.New(), Quantity: 1}
+		}
+		optimization, err := sl.Optimize(ctx, items, 2, "", filter)
+		assert.ErrorIs(t, err, model.ErrShoppingListTooLongError)
+		assert.Nil(t, optimization)
+	})
+
+	t.Run("GetProductByEAN error", func(t *testing.T) {
+		items := []*model.ShoppingListItem{{Ean: "3017620422003", Quantity: 1}}
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, "3017620422003").Return(nil, expectedError).Once()
+		optimization, err := sl.Optimize(ctx, items, 2, "", filter)
+		assert.ErrorIs(t, err, model.ErrProductError)
+		assert.Nil(t, optimization)
+	})
+
+	t.Run("SelectMostRecentPricesByProductIDs error", func(t *testing.T) {
+		items := []*model.ShoppingListItem{{ProductID: milk, Quantity: 1}}
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{milk}, filter).Return(nil, expectedError).Once()
+		optimization, err := sl.Optimize(ctx, items, 2, "", filter)
+		assert.ErrorIs(t, err, model.ErrShoppingListError)
+		assert.Nil(t, optimization)
+	})
+
+	t.Run("single store and split", func(t *testing.T) {
+		items := []*model.ShoppingListItem{
+			{ProductID: milk, Quantity: 2},
+			{Ean: "3017620422003", Quantity: 1},
+			{ProductID: coffee, Quantity: 1},
+		}
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, "3017620422003").Return(&model.Product{ProductID: bread}, nil).Once()
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{milk, bread, coffee}, filter).Return(prices, nil).Once()
+		optimization, err := sl.Optimize(ctx, items, 2, "", filter)
+		require.NoError(t, err)
+
+		single := optimization.SingleStore
+		require.Len(t, single.Stores, 1)
+		assert.Equal(t, storeA, single.Stores[0].StoreID)
+		assert.Equal(t, money("4.00").String(), single.Total.String())
+		require.Len(t, single.Unpriced, 1)
+		assert.Equal(t, coffee, single.Unpriced[0].ProductID)
+
+		split := optimization.Split
+		require.Len(t, split.Stores, 2)
+		assert.Equal(t, money("3.60").String(), split.Total.String())
+		assert.Equal(t, now.Add(-time.Hour), split.OldestPriceAt)
+		require.Len(t, split.Unpriced, 1)
+	})
+
+	t.Run("max stores clamped to one", func(t *testing.T) {
+		items := []*model.ShoppingListItem{{ProductID: milk, Quantity: 1}, {ProductID: bread, Quantity: 1}}
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{milk, bread}, filter).Return(prices, nil).Once()
+		optimization, err := sl.Optimize(ctx, items, 0, "", filter)
+		require.NoError(t, err)
+		assert.Equal(t, optimization.SingleStore, optimization.Split)
+	})
+
+	t.Run("currency", func(t *testing.T) {
+		items := []*model.ShoppingListItem{{ProductID: milk, Quantity: 1}, {ProductID: bread, Quantity: 1}}
+		usdPrices := append([]*model.StorePrice{}, prices...)
+		usdPrices = append(usdPrices, &model.StorePrice{StoreID: uuid.New(), ProductID: milk, Price: money("0.50 USD"), PricedAt: now})
+		mockPriceStorer.EXPECT().SelectMostRecentPricesByProductIDs(ctx, []uuid.UUID{milk, bread}, filter).Return(usdPrices, nil).Once()
+		optimization, err := sl.Optimize(ctx, items, 2, "USD", filter)
+		require.NoError(t, err)
+
+		split := optimization.Split
+		assert.Equal(t, money("0.50 USD").String(), split.Total.String())
+		require.Len(t, split.Unpriced, 1)
+		assert.Equal(t, bread, split.Unpriced[0].ProductID)
+	})
+
+	t.Run("nothing priced", func(t *testing.T) {
+		items := []*model.ShoppingListItem{{Ean: "0000000000000", Quantity: 1}}
+		mockProductStorer.EXPECT().GetProductByEAN(ctx, "0000000000000").Return(nil, nil).Once()
+		optimization, err := sl.Optimize(ctx, items, 3, "", filter)
+		require.NoError(t, err)
+		assert.Empty(t, optimization.Split.Stores)
+		assert.Equal(t, items, optimization.Split.Unpriced)
+	})
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/totp"
+	"shop-aggregator/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTOTPIssuer  = "shop-aggregator"
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+type TwoFactorStorer interface {
+	SelectTOTP(ctx context.Context, userID uuid.UUID) (*model.TOTP, error)
+	UpsertTOTP(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodes []string) error
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+type TwoFactorUserStorer interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+}
+
+// TwoFactor enrols the authenticator apps of the users. The 2FA is on once a first code confirms the
+// enrolment, the user then gets the recovery codes that replace a lost app, each of them once.
+type TwoFactor struct {
+	TwoFactorStorer     TwoFactorStorer
+	TwoFactorUserStorer TwoFactorUserStorer
+	PasswordHasher      utils.PasswordHasher
+	Issuer              string
+}
+
+func NewTwoFactor(tfs TwoFactorStorer, tfu TwoFactorUserStorer, ph utils.PasswordHasher, cfg *config.AuthConfig) *TwoFactor {
+	tf := &TwoFactor{
+		TwoFactorStorer:     tfs,
+		TwoFactorUserStorer: tfu,
+		PasswordHasher:      ph,
+		Issuer:              cfg.TOTPIssuer,
+	}
+	if tf.Issuer == "" {
+		tf.Issuer = defaultTOTPIssuer
+	}
+	return tf
+}
+
+// Enroll returns a new secret and its provisioning URI, a previous enrolment that was not confirmed is
+// replaced.
+func (tf *TwoFactor) Enroll(ctx context.Context, userID uuid.UUID) (*model.TOTPEnrolment, error) {
+	user, err := tf.TwoFactorUserStorer.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Enroll.GetUserByID")
+		return nil, model.ErrTwoFactorError
+	}
+	if user == nil {
+		return nil, model.ErrUserNotFound
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Enroll.GenerateSecret")
+		return nil, model.ErrTwoFactorError
+	}
+
+	err = tf.TwoFactorStorer.UpsertTOTP(ctx, userID, secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrTwoFactorEnabledError
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Enroll.UpsertTOTP")
+		return nil, model.ErrTwoFactorError
+	}
+
+	return &model.TOTPEnrolment{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(tf.Issuer, user.Login, secret),
+	}, nil
+}
+
+// Confirm enables the 2FA once the code of the app matches the enrolment and returns the recovery codes,
+// they are not shown again.
+func (tf *TwoFactor) Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	t, err := tf.TwoFactorStorer.SelectTOTP(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Confirm.SelectTOTP")
+		return nil, model.ErrTwoFactorError
+	}
+	if t == nil {
+		return nil, model.ErrTwoFactorNotEnrolledError
+	}
+	if t.IsEnabled() {
+		return nil, model.ErrTwoFactorEnabledError
+	}
+
+	step, ok := totp.Validate(t.Secret, code, time.Now().UTC())
+	if !ok {
+		return nil, model.ErrInvalidTwoFactorCodeError
+	}
+
+	recoveryCodes, err := generateRecoveryCodes()
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Confirm.generateRecoveryCodes")
+		return nil, model.ErrTwoFactorError
+	}
+	normalized := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		normalized = append(normalized, normalizeRecoveryCode(recoveryCode))
+	}
+
+	err = tf.TwoFactorStorer.EnableTOTP(ctx, userID, step, normalized)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// an other request confirmed it first
+		return nil, model.ErrTwoFactorEnabledError
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Confirm.EnableTOTP")
+		return nil, model.ErrTwoFactorError
+	}
+	return recoveryCodes, nil
+}
+
+// Disable turns the 2FA off, the user proves it is them again with their password and a code of the app or
+// a recovery code. A user without a password, linked through OpenID Connect only, proves it with the code
+// alone, it is valid once.
+func (tf *TwoFactor) Disable(ctx context.Context, userID uuid.UUID, password, code string) error {
+	user, err := tf.TwoFactorUserStorer.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Disable.GetUserByID")
+		return model.ErrTwoFactorError
+	}
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+	if user.HasPassword() {
+		if ok, _ := tf.PasswordHasher.Verify(password, user.HashPassword); !ok {
+			return model.ErrInvalidCredentialsError
+		}
+	}
+
+	t, err := tf.TwoFactorStorer.SelectTOTP(ctx, userID)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Disable.SelectTOTP")
+		return model.ErrTwoFactorError
+	}
+	if t == nil || !t.IsEnabled() {
+		return model.ErrTwoFactorNotEnrolledError
+	}
+
+	ok, err := checkTwoFactorCode(ctx, tf.TwoFactorStorer, t, code)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("Disable.checkTwoFactorCode")
+		return model.ErrTwoFactorError
+	}
+	if !ok {
+		return model.ErrInvalidTwoFactorCodeError
+	}
+
+	if err = tf.TwoFactorStorer.DeleteTOTP(ctx, userID); err != nil {
+		log.Error().Caller().Err(err).Msg("Disable.DeleteTOTP")
+		return model.ErrTwoFactorError
+	}
+	return nil
+}
+
+type twoFactorCodeStorer interface {
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+// checkTwoFactorCode tells if code is a code of the app or a recovery code of the user and uses it up, a code
+// of the app is valid once as well.
+func checkTwoFactorCode(ctx context.Context, tfc twoFactorCodeStorer, t *model.TOTP, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(t.Secret, code, time.Now().UTC())
+		if !ok || step <= t.LastStep {
+			return false, nil
+		}
+		err := tfc.UseStep(ctx, t.UserID, step)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	err := tfc.UseRecoveryCode(ctx, t.UserID, normalizeRecoveryCode(code))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// generateRecoveryCodes returns codes such as "k3j9x-p2mqa", easy to copy by hand.
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		random := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(random))[:recoveryCodeLength]
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode ignores the case and the separators the user typed.
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+}
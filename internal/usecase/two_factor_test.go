@@ -0,0 +1,151 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/totp"
+	"shop-aggregator/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTwoFactor_Enroll(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewTwoFactorStorer(t)
+	mockUserStorer := NewTwoFactorUserStorer(t)
+	tf := usecase.NewTwoFactor(mockStorer, mockUserStorer, passwordHasher, &config.AuthConfig{})
+	user := &model.User{ID: uuid.New(), Login: "alice"}
+
+	t.Run("already enabled", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		mockStorer.EXPECT().UpsertTOTP(ctx, user.ID, mock.Anything).Return(pgx.ErrNoRows).Once()
+		_, err := tf.Enroll(ctx, user.ID)
+		assert.ErrorIs(t, err, model.ErrTwoFactorEnabledError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		var stored string
+		mockStorer.EXPECT().UpsertTOTP(ctx, user.ID, mock.Anything).Run(func(_ context.Context, _ uuid.UUID, secret string) {
+			stored = secret
+		}).Return(nil).Once()
+
+		enrolment, err := tf.Enroll(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stored, enrolment.Secret)
+		uri, err := url.Parse(enrolment.URI)
+		require.NoError(t, err)
+		assert.Equal(t, "/shop-aggregator:alice", uri.Path)
+		assert.Equal(t, stored, uri.Query().Get("secret"))
+	})
+}
+
+func TestTwoFactor_Confirm(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewTwoFactorStorer(t)
+	tf := usecase.NewTwoFactor(mockStorer, nil, passwordHasher, &config.AuthConfig{})
+	userID := uuid.New()
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	pending := &model.TOTP{UserID: userID, Secret: secret}
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mockStorer.EXPECT().SelectTOTP(ctx, userID).Return(nil, nil).Once()
+		_, err := tf.Confirm(ctx, userID, "123456")
+		assert.ErrorIs(t, err, model.ErrTwoFactorNotEnrolledError)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockStorer.EXPECT().SelectTOTP(ctx, userID).Return(pending, nil).Once()
+		_, err := tf.Confirm(ctx, userID, "abcdef")
+		assert.ErrorIs(t, err, model.ErrInvalidTwoFactorCodeError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		step := totp.Step(time.Now())
+		code, err := totp.Code(secret, step)
+		require.NoError(t, err)
+		mockStorer.EXPECT().SelectTOTP(ctx, userID).Return(pending, nil).Once()
+		var stored []string
+		mockStorer.EXPECT().EnableTOTP(ctx, userID, mock.Anything, mock.Anything).Run(func(_ context.Context, _ uuid.UUID, _ int64, codes []string) {
+			stored = codes
+		}).Return(nil).Once()
+
+		recoveryCodes, err := tf.Confirm(ctx, userID, code)
+		require.NoError(t, err)
+		require.Len(t, recoveryCodes, 10)
+		require.Len(t, stored, 10)
+		assert.Equal(t, strings.ReplaceAll(recoveryCodes[0], "-", ""), stored[0])
+		assert.NotEqual(t, recoveryCodes[0], recoveryCodes[1])
+	})
+}
+
+func TestTwoFactor_Disable(t *testing.T) {
+	ctx := context.Background()
+	mockStorer := NewTwoFactorStorer(t)
+	mockUserStorer := NewTwoFactorUserStorer(t)
+	tf := usecase.NewTwoFactor(mockStorer, mockUserStorer, passwordHasher, &config.AuthConfig{})
+	hash, err := passwordHasher.Hash("password")
+	require.NoError(t, err)
+	user := &model.User{ID: uuid.New(), HashPassword: hash}
+	enabledAt := time.Now()
+	enabled := &model.TOTP{UserID: user.ID, Secret: "SECRET", EnabledAt: &enabledAt}
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		err := tf.Disable(ctx, user.ID, "wrong", "abcde-fghij")
+		assert.ErrorIs(t, err, model.ErrInvalidCredentialsError)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		mockStorer.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+		mockStorer.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(pgx.ErrNoRows).Once()
+		err := tf.Disable(ctx, user.ID, "password", "abcde-fghij")
+		assert.ErrorIs(t, err, model.ErrInvalidTwoFactorCodeError)
+	})
+
+	t.Run("DeleteTOTP error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		mockStorer.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+		mockStorer.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(nil).Once()
+		mockStorer.EXPECT().DeleteTOTP(ctx, user.ID).Return(errors.New("random error")).Once()
+		err := tf.Disable(ctx, user.ID, "password", "abcde-fghij")
+		assert.ErrorIs(t, err, model.ErrTwoFactorError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(user, nil).Once()
+		mockStorer.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+		mockStorer.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(nil).Once()
+		mockStorer.EXPECT().DeleteTOTP(ctx, user.ID).Return(nil).Once()
+		assert.NoError(t, tf.Disable(ctx, user.ID, "password", "abcde-fghij"))
+	})
+
+	t.Run("no password", func(t *testing.T) {
+		// a user linked through OpenID Connect only proves it with the code
+		oidcUser := &model.User{ID: user.ID}
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(oidcUser, nil).Once()
+		mockStorer.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+		mockStorer.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(nil).Once()
+		mockStorer.EXPECT().DeleteTOTP(ctx, user.ID).Return(nil).Once()
+		assert.NoError(t, tf.Disable(ctx, user.ID, "", "abcde-fghij"))
+	})
+
+	t.Run("no password wrong code", func(t *testing.T) {
+		oidcUser := &model.User{ID: user.ID}
+		mockUserStorer.EXPECT().GetUserByID(ctx, user.ID).Return(oidcUser, nil).Once()
+		mockStorer.EXPECT().SelectTOTP(ctx, user.ID).Return(enabled, nil).Once()
+		mockStorer.EXPECT().UseRecoveryCode(ctx, user.ID, "abcdefghij").Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, tf.Disable(ctx, user.ID, "", "abcde-fghij"), model.ErrInvalidTwoFactorCodeError)
+	})
+}
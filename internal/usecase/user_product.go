@@ -15,22 +15,45 @@ type UserProductStorer interface {
 	SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error)
 	SelectMostRecentUserProductByStoreID(ctx context.Context, storeID uuid.UUID) ([]*model.UserProduct, error)
 	SelectProductByID(ctx context.Context, id uuid.UUID) (*model.UserProduct, error)
-	UpdateQuantity(ctx context.Context, quantity int64, productType, productSize, sizeFormat string, userProductID uuid.UUID) error
-	DeleteUserProduct(ctx context.Context, userProductID uuid.UUID) (uuid.UUID, error)
+	UpdateQuantity(ctx context.Context, quantity int64, productType, productSize, sizeFormat string, billID, userProductID uuid.UUID) error
+	DeleteUserProduct(ctx context.Context, billID, userProductID uuid.UUID) error
+}
+
+type UserProductAuthorizer interface {
+	Bill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+	OpenBill(ctx context.Context, userID, billID uuid.UUID) (*model.Bill, error)
+	UserProduct(ctx context.Context, userID, billID, userProductID uuid.UUID) (*model.UserProduct, error)
 }
 
 type UserProduct struct {
-	UserProductStorer UserProductStorer
+	UserProductStorer     UserProductStorer
+	UserProductAuthorizer UserProductAuthorizer
 }
 
-func NewUserProduct(ups UserProductStorer) *UserProduct {
+func NewUserProduct(ups UserProductStorer, upa UserProductAuthorizer) *UserProduct {
 	return &UserProduct{
-		UserProductStorer: ups,
+		UserProductStorer:     ups,
+		UserProductAuthorizer: upa,
 	}
 }
 
+// Create adds the line to an open bill of the user, never to an other one. Only the amount of the price is
+// stored, it must be in the currency of the bill.
 func (up *UserProduct) Create(ctx context.Context, um *model.UserProduct, userID uuid.UUID) (*model.UserProduct, error) {
-	if err := up.UserProductStorer.Insert(ctx, um, userID); err != nil {
+	bill, err := up.UserProductAuthorizer.OpenBill(ctx, userID, um.BillID)
+	if err != nil {
+		return nil, err
+	}
+	if um.Quantity <= 0 {
+		return nil, model.ErrInvalidQuantityError
+	}
+	if um.Price.IsNegative() {
+		return nil, model.ErrInvalidPriceError
+	}
+	if um.Price.Currency != bill.Amount.Currency {
+		return nil, model.ErrCurrencyMismatchError
+	}
+	if err = up.UserProductStorer.Insert(ctx, um, userID); err != nil {
 		log.Error().Caller().Err(err).Msg("Create.Insert")
 		return nil, model.ErrUserProductError
 	}
@@ -49,7 +72,11 @@ func (up *UserProduct) Create(ctx context.Context, um *model.UserProduct, userID
 	return returnUp, nil
 }
 
-func (up *UserProduct) SelectProductsByBillID(ctx context.Context, billID uuid.UUID) ([]*model.UserProduct, error) {
+// SelectProductsByBillID returns the lines of a bill of the user, whatever its state.
+func (up *UserProduct) SelectProductsByBillID(ctx context.Context, userID, billID uuid.UUID) ([]*model.UserProduct, error) {
+	if _, err := up.UserProductAuthorizer.Bill(ctx, userID, billID); err != nil {
+		return nil, err
+	}
 	ups, err := up.UserProductStorer.SelectProductsByBillID(ctx, billID)
 	if err != nil {
 		log.Error().Caller().Err(err).Msg("SelectProductsByBillID.SelectProductsByBillID")
@@ -59,8 +86,14 @@ func (up *UserProduct) SelectProductsByBillID(ctx context.Context, billID uuid.U
 	return ups, nil
 }
 
-func (up *UserProduct) UpdateQuantity(ctx context.Context, billID, userProductID uuid.UUID, productType, productSize, sizeFormat string, quantity int64) ([]*model.UserProduct, error) {
-	if err := up.UserProductStorer.UpdateQuantity(ctx, quantity, productType, productSize, sizeFormat, userProductID); err != nil {
+func (up *UserProduct) UpdateQuantity(ctx context.Context, userID, billID, userProductID uuid.UUID, productType, productSize, sizeFormat string, quantity int64) ([]*model.UserProduct, error) {
+	if _, err := up.UserProductAuthorizer.UserProduct(ctx, userID, billID, userProductID); err != nil {
+		return nil, err
+	}
+	if quantity <= 0 {
+		return nil, model.ErrInvalidQuantityError
+	}
+	if err := up.UserProductStorer.UpdateQuantity(ctx, quantity, productType, productSize, sizeFormat, billID, userProductID); err != nil {
 		log.Error().Caller().Err(err).Msg("UpdateQuantity.UpdateQuantity")
 		return nil, model.ErrUserProductError
 	}
@@ -73,9 +106,11 @@ func (up *UserProduct) UpdateQuantity(ctx context.Context, billID, userProductID
 	return ups, nil
 }
 
-func (up *UserProduct) DeleteUserProduct(ctx context.Context, userProductID uuid.UUID) ([]*model.UserProduct, error) {
-	billID, err := up.UserProductStorer.DeleteUserProduct(ctx, userProductID)
-	if err != nil {
+func (up *UserProduct) DeleteUserProduct(ctx context.Context, userID, billID, userProductID uuid.UUID) ([]*model.UserProduct, error) {
+	if _, err := up.UserProductAuthorizer.UserProduct(ctx, userID, billID, userProductID); err != nil {
+		return nil, err
+	}
+	if err := up.UserProductStorer.DeleteUserProduct(ctx, billID, userProductID); err != nil {
 		log.Error().Caller().Err(err).Msg("DeleteUserProduct.DeleteUserProduct")
 		return nil, model.ErrUserProductError
 	}
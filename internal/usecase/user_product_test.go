@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/model"
+	"shop-aggregator/internal/usecase"
+	"testing"
+)
+
+func TestUserProduct_Create(t *testing.T) {
+	ctx := context.Background()
+	mockUserProductStorer := NewUserProductStorer(t)
+	mockAuthorizer := NewUserProductAuthorizer(t)
+	up := usecase.NewUserProduct(mockUserProductStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, Amount: model.ZeroMoney("EUR"), State: model.BillStateCreate}
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		um := &model.UserProduct{BillID: uuid.New()}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, um.BillID).Return(nil, model.ErrBillNotFoundError).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("bill not open", func(t *testing.T) {
+		um := &model.UserProduct{BillID: uuid.New()}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, um.BillID).Return(nil, model.ErrBillNotOpenError).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("zero quantity", func(t *testing.T) {
+		um := &model.UserProduct{BillID: bill.BillID, Price: money("1.25"), Quantity: 0}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrInvalidQuantityError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("negative quantity", func(t *testing.T) {
+		um := &model.UserProduct{BillID: bill.BillID, Price: money("1.25"), Quantity: -1}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrInvalidQuantityError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("negative price", func(t *testing.T) {
+		um := &model.UserProduct{BillID: bill.BillID, Price: money("-1.25"), Quantity: 2}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrInvalidPriceError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("price in an other currency", func(t *testing.T) {
+		um := &model.UserProduct{BillID: bill.BillID, Price: model.NewMoney(decimal.RequireFromString("1.25"), "USD"), Quantity: 2}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		result, err := up.Create(ctx, um, userID)
+		assert.ErrorIs(t, err, model.ErrCurrencyMismatchError)
+		assert.Nil(t, result)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		um := &model.UserProduct{UserProductID: uuid.New(), BillID: bill.BillID, Price: money("1.25"), Quantity: 2}
+		mockAuthorizer.EXPECT().OpenBill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockUserProductStorer.EXPECT().Insert(ctx, um, userID).Return(nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductByID(ctx, um.UserProductID).Return(um, nil).Once()
+		result, err := up.Create(ctx, um, userID)
+		require.NoError(t, err)
+		assert.Equal(t, um, result)
+	})
+}
+
+func TestUserProduct_SelectProductsByBillID(t *testing.T) {
+	ctx := context.Background()
+	mockUserProductStorer := NewUserProductStorer(t)
+	mockAuthorizer := NewUserProductAuthorizer(t)
+	up := usecase.NewUserProduct(mockUserProductStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCompleted}
+
+	t.Run("bill of an other user", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().Bill(ctx, userID, billID).Return(nil, model.ErrBillNotFoundError).Once()
+		ups, err := up.SelectProductsByBillID(ctx, userID, billID)
+		assert.ErrorIs(t, err, model.ErrBillNotFoundError)
+		assert.Nil(t, ups)
+	})
+
+	t.Run("closed bill", func(t *testing.T) {
+		lines := []*model.UserProduct{{UserProductID: uuid.New(), BillID: bill.BillID}}
+		mockAuthorizer.EXPECT().Bill(ctx, userID, bill.BillID).Return(bill, nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByBillID(ctx, bill.BillID).Return(lines, nil).Once()
+		ups, err := up.SelectProductsByBillID(ctx, userID, bill.BillID)
+		require.NoError(t, err)
+		assert.Equal(t, lines, ups)
+	})
+}
+
+func TestUserProduct_UpdateQuantity(t *testing.T) {
+	ctx := context.Background()
+	mockUserProductStorer := NewUserProductStorer(t)
+	mockAuthorizer := NewUserProductAuthorizer(t)
+	up := usecase.NewUserProduct(mockUserProductStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	userProductID := uuid.New()
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCreate}
+	line := &model.UserProduct{UserProductID: userProductID, BillID: bill.BillID}
+
+	t.Run("bill not open", func(t *testing.T) {
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, userProductID).Return(nil, model.ErrBillNotOpenError).Once()
+		ups, err := up.UpdateQuantity(ctx, userID, bill.BillID, userProductID, "", "", "", 2)
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, ups)
+	})
+
+	for _, quantity := range []int64{0, -3} {
+		t.Run(fmt.Sprintf("quantity %d", quantity), func(t *testing.T) {
+			mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, userProductID).Return(line, nil).Once()
+			ups, err := up.UpdateQuantity(ctx, userID, bill.BillID, userProductID, "", "", "", quantity)
+			assert.ErrorIs(t, err, model.ErrInvalidQuantityError)
+			assert.Nil(t, ups)
+		})
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		lines := []*model.UserProduct{line}
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, userProductID).Return(line, nil).Once()
+		mockUserProductStorer.EXPECT().UpdateQuantity(ctx, int64(2), "", "", "", bill.BillID, userProductID).Return(nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByBillID(ctx, bill.BillID).Return(lines, nil).Once()
+		ups, err := up.UpdateQuantity(ctx, userID, bill.BillID, userProductID, "", "", "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, lines, ups)
+	})
+}
+
+func TestUserProduct_DeleteUserProduct(t *testing.T) {
+	ctx := context.Background()
+	mockUserProductStorer := NewUserProductStorer(t)
+	mockAuthorizer := NewUserProductAuthorizer(t)
+	up := usecase.NewUserProduct(mockUserProductStorer, mockAuthorizer)
+
+	userID := uuid.New()
+	userProductID := uuid.New()
+	bill := &model.Bill{BillID: uuid.New(), UserID: userID, State: model.BillStateCreate}
+	line := &model.UserProduct{UserProductID: userProductID, BillID: bill.BillID}
+
+	t.Run("bill not open", func(t *testing.T) {
+		billID := uuid.New()
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, billID, userProductID).Return(nil, model.ErrBillNotOpenError).Once()
+		ups, err := up.DeleteUserProduct(ctx, userID, billID, userProductID)
+		assert.ErrorIs(t, err, model.ErrBillNotOpenError)
+		assert.Nil(t, ups)
+	})
+
+	t.Run("not a line of the bill", func(t *testing.T) {
+		otherID := uuid.New()
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, otherID).Return(nil, model.ErrUserProductNotFoundError).Once()
+		ups, err := up.DeleteUserProduct(ctx, userID, bill.BillID, otherID)
+		assert.ErrorIs(t, err, model.ErrUserProductNotFoundError)
+		assert.Nil(t, ups)
+	})
+
+	t.Run("DeleteUserProduct error", func(t *testing.T) {
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, userProductID).Return(line, nil).Once()
+		mockUserProductStorer.EXPECT().DeleteUserProduct(ctx, bill.BillID, userProductID).Return(errors.New("random error")).Once()
+		ups, err := up.DeleteUserProduct(ctx, userID, bill.BillID, userProductID)
+		assert.ErrorIs(t, err, model.ErrUserProductError)
+		assert.Nil(t, ups)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		remaining := []*model.UserProduct{{UserProductID: uuid.New(), BillID: bill.BillID}}
+		mockAuthorizer.EXPECT().UserProduct(ctx, userID, bill.BillID, userProductID).Return(line, nil).Once()
+		mockUserProductStorer.EXPECT().DeleteUserProduct(ctx, bill.BillID, userProductID).Return(nil).Once()
+		mockUserProductStorer.EXPECT().SelectProductsByBillID(ctx, bill.BillID).Return(remaining, nil).Once()
+		ups, err := up.DeleteUserProduct(ctx, userID, bill.BillID, userProductID)
+		require.NoError(t, err)
+		assert.Equal(t, remaining, ups)
+	})
+}
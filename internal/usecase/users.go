@@ -2,11 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/rs/zerolog/log"
+	"net/url"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/mailer"
 	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	defaultResetTokenTTL        = time.Hour
+	defaultVerificationTokenTTL = 48 * time.Hour
 )
 
 type UsersStorer interface {
@@ -15,17 +27,49 @@ type UsersStorer interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
 	UpdatePassword(context.Context, uuid.UUID, string) error
 	GetUserByLogin(ctx context.Context, login string) (*model.User, error)
-	UpdateEmail(ctx context.Context, id uuid.UUID, email string) error
+	ResetPassword(ctx context.Context, id uuid.UUID, password string) error
+	VerifyEmail(ctx context.Context, id uuid.UUID, email string) error
+}
+
+type UsersTokenStorer interface {
+	Insert(ctx context.Context, userToken *model.UserToken) error
+	Use(ctx context.Context, purpose, token string) (*model.UserToken, error)
 }
 
+type UsersMailer interface {
+	Send(ctx context.Context, m *mailer.Message) error
+}
+
+// Users sends the password reset and email verification links, their tokens are used once before
+// ResetTokenTTL or VerificationTokenTTL.
 type Users struct {
-	UsersStorer UsersStorer
+	UsersStorer          UsersStorer
+	UsersTokenStorer     UsersTokenStorer
+	UsersMailer          UsersMailer
+	PasswordHasher       utils.PasswordHasher
+	ResetTokenTTL        time.Duration
+	VerificationTokenTTL time.Duration
+	LinkBaseURL          string
 }
 
-func NewUsers(us UsersStorer) *Users {
-	return &Users{
-		UsersStorer: us,
+func NewUsers(us UsersStorer, uts UsersTokenStorer, um UsersMailer, ph utils.PasswordHasher, cfg *config.AuthConfig) *Users {
+	u := &Users{
+		UsersStorer:          us,
+		UsersTokenStorer:     uts,
+		UsersMailer:          um,
+		PasswordHasher:       ph,
+		ResetTokenTTL:        time.Duration(cfg.ResetTokenTTLMinutes) * time.Minute,
+		VerificationTokenTTL: time.Duration(cfg.VerificationTokenTTLHours) * time.Hour,
+		LinkBaseURL:          strings.TrimSuffix(cfg.LinkBaseURL, "/"),
+	}
+	if u.ResetTokenTTL <= 0 {
+		u.ResetTokenTTL = defaultResetTokenTTL
 	}
+	if u.VerificationTokenTTL <= 0 {
+		u.VerificationTokenTTL = defaultVerificationTokenTTL
+	}
+
+	return u
 }
 
 func (u *Users) CreateOrUpdateUser(ctx context.Context, m *model.User) error {
@@ -36,7 +80,7 @@ func (u *Users) CreateOrUpdateUser(ctx context.Context, m *model.User) error {
 	}
 
 	if checkUserEmailExist != nil {
-		return fmt.Errorf("user exist for email %s", m.Email)
+		return model.ErrEmailExistError
 	}
 
 	checkUserLoginExist, err := u.UsersStorer.GetUserByLogin(ctx, m.Login)
@@ -46,13 +90,13 @@ func (u *Users) CreateOrUpdateUser(ctx context.Context, m *model.User) error {
 	}
 
 	if checkUserLoginExist != nil {
-		return fmt.Errorf("user exist for login %s", m.Login)
+		return model.ErrLoginExistError
 	}
 
-	m.HashPassword, err = utils.HashPassword(m.Password)
+	m.HashPassword, err = u.PasswordHasher.Hash(m.Password)
 	if err != nil {
-		log.Error().Caller().Err(err)
-		return err
+		log.Error().Caller().Err(err).Msg("CreateOrUpdateUser.Hash")
+		return model.ErrUserError
 	}
 
 	if err = u.UsersStorer.Upsert(ctx, m); err != nil {
@@ -60,7 +104,12 @@ func (u *Users) CreateOrUpdateUser(ctx context.Context, m *model.User) error {
 		return model.ErrUserError
 	}
 
-	return err
+	// the user is created anyway, an other verification link can be asked for
+	if err = u.sendVerification(ctx, m.ID, m.Email); err != nil {
+		log.Error().Caller().Err(err).Msg("CreateOrUpdateUser.sendVerification")
+	}
+
+	return nil
 }
 
 func (u *Users) UpdatePassword(ctx context.Context, up *model.UpdatePassword) error {
@@ -74,11 +123,11 @@ func (u *Users) UpdatePassword(ctx context.Context, up *model.UpdatePassword) er
 		return model.ErrUserNotFound
 	}
 
-	if !utils.CheckPasswordHash(up.OldPassword, user.HashPassword) {
+	if ok, _ := u.PasswordHasher.Verify(up.OldPassword, user.HashPassword); !ok {
 		return model.ErrOldPasswordError
 	}
 
-	hashedPassword, err := utils.HashPassword(up.Password)
+	hashedPassword, err := u.PasswordHasher.Hash(up.Password)
 	if err != nil {
 		log.Error().Caller().Err(err)
 		return model.ErrUserError
@@ -93,6 +142,8 @@ func (u *Users) UpdatePassword(ctx context.Context, up *model.UpdatePassword) er
 	return nil
 }
 
+// UpdateEmail sends a verification link to the new email, the email of the user is changed once the link
+// is followed.
 func (u *Users) UpdateEmail(ctx context.Context, ue *model.UpdateEmail) error {
 	user, err := u.UsersStorer.GetUserByID(ctx, ue.ID)
 	if err != nil {
@@ -104,15 +155,188 @@ func (u *Users) UpdateEmail(ctx context.Context, ue *model.UpdateEmail) error {
 		return model.ErrUserNotFound
 	}
 
-	err = u.UsersStorer.UpdateEmail(ctx, ue.ID, ue.Email)
+	if user.Email == ue.Email && user.EmailVerified {
+		return nil
+	}
+
+	if err = u.checkEmailAvailable(ctx, user.ID, ue.Email); err != nil {
+		return err
+	}
+
+	if err = u.sendVerification(ctx, user.ID, ue.Email); err != nil {
+		log.Error().Caller().Err(err).Msg("UpdateEmail.sendVerification")
+		return model.ErrMailError
+	}
+
+	return nil
+}
+
+// ResendVerification sends an other verification link for the current email, nothing is sent once it is
+// verified.
+func (u *Users) ResendVerification(ctx context.Context, id uuid.UUID) error {
+	user, err := u.UsersStorer.GetUserByID(ctx, id)
 	if err != nil {
-		log.Error().Caller().Err(err)
+		log.Error().Caller().Err(err).Msg("ResendVerification.GetUserByID")
+		return model.ErrUserError
+	}
+
+	if user == nil {
+		return model.ErrUserNotFound
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err = u.sendVerification(ctx, user.ID, user.Email); err != nil {
+		log.Error().Caller().Err(err).Msg("ResendVerification.sendVerification")
+		return model.ErrMailError
+	}
+
+	return nil
+}
+
+// VerifyEmail sets the email carried by the verification token as the verified email of its user.
+func (u *Users) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := u.UsersTokenStorer.Use(ctx, model.UserTokenEmailVerification, token)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("VerifyEmail.Use")
+		return model.ErrUserError
+	}
+
+	if userToken == nil {
+		return model.ErrInvalidUserTokenError
+	}
+
+	// an other user may have taken the email since the link was sent
+	if err = u.checkEmailAvailable(ctx, userToken.UserID, userToken.Email); err != nil {
+		return err
+	}
+
+	err = u.UsersStorer.VerifyEmail(ctx, userToken.UserID, userToken.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrUserNotFound
+	}
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("VerifyEmail.VerifyEmail")
 		return model.ErrUserError
 	}
 
 	return nil
 }
 
+// ForgotPassword sends a password reset link when a user has the email. Whether the email is known is
+// not told to the caller.
+func (u *Users) ForgotPassword(ctx context.Context, email string) error {
+	user, err := u.UsersStorer.GetUserByEmail(ctx, email)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("ForgotPassword.GetUserByEmail")
+		return model.ErrUserError
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	token, err := u.issueToken(ctx, user.ID, model.UserTokenPasswordReset, "", u.ResetTokenTTL)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("ForgotPassword.issueToken")
+		return model.ErrUserError
+	}
+
+	err = u.UsersMailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nfollow this link to choose a new password:\n%s\n\nThe link expires in %s. "+
+			"If you did not ask for it, ignore this mail.", user.Login, u.link("reset-password", token), u.ResetTokenTTL),
+	})
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("ForgotPassword.Send")
+		return model.ErrMailError
+	}
+
+	return nil
+}
+
+// ResetPassword sets the password of the user of the reset token, the sessions of the user are revoked.
+func (u *Users) ResetPassword(ctx context.Context, token, password string) error {
+	userToken, err := u.UsersTokenStorer.Use(ctx, model.UserTokenPasswordReset, token)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("ResetPassword.Use")
+		return model.ErrUserError
+	}
+
+	if userToken == nil {
+		return model.ErrInvalidUserTokenError
+	}
+
+	hashedPassword, err := u.PasswordHasher.Hash(password)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("ResetPassword.HashPassword")
+		return model.ErrUserError
+	}
+
+	if err = u.UsersStorer.ResetPassword(ctx, userToken.UserID, hashedPassword); err != nil {
+		log.Error().Caller().Err(err).Msg("ResetPassword.ResetPassword")
+		return model.ErrUserError
+	}
+
+	return nil
+}
+
+func (u *Users) checkEmailAvailable(ctx context.Context, id uuid.UUID, email string) error {
+	owner, err := u.UsersStorer.GetUserByEmail(ctx, email)
+	if err != nil {
+		log.Error().Caller().Err(err).Msg("checkEmailAvailable.GetUserByEmail")
+		return model.ErrUserError
+	}
+
+	if owner != nil && owner.ID != id {
+		return model.ErrEmailExistError
+	}
+
+	return nil
+}
+
+func (u *Users) sendVerification(ctx context.Context, id uuid.UUID, email string) error {
+	token, err := u.issueToken(ctx, id, model.UserTokenEmailVerification, email, u.VerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.UsersMailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hello,\n\nfollow this link to verify your email:\n%s\n\nThe link expires in %s.",
+			u.link("verify-email", token), u.VerificationTokenTTL),
+	})
+}
+
+// issueToken stores a new token of the purpose and returns it, the previous one stops working.
+func (u *Users) issueToken(ctx context.Context, id uuid.UUID, purpose, email string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateToken(64)
+	if err != nil {
+		return "", err
+	}
+
+	err = u.UsersTokenStorer.Insert(ctx, &model.UserToken{
+		UserID:    id,
+		Purpose:   purpose,
+		Token:     token,
+		Email:     email,
+		ExpiresAt: time.Now().UTC().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (u *Users) link(path, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", u.LinkBaseURL, path, url.QueryEscape(token))
+}
+
 func (u *Users) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	user, err := u.UsersStorer.GetUserByID(ctx, id)
 	if err != nil {
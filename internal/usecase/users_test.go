@@ -3,22 +3,37 @@ package usecase_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
+	"shop-aggregator/internal/mailer"
 	"shop-aggregator/internal/model"
 	"shop-aggregator/internal/usecase"
 	"shop-aggregator/internal/utils"
+	"strings"
 	"testing"
+	"time"
 )
 
+// passwordHasher is cheap, the usecases do not test the cost of the hashes.
+var passwordHasher = utils.NewArgon2idHasher(64, 1, 1)
+
+func checkPassword(password, hash string) bool {
+	ok, _ := passwordHasher.Verify(password, hash)
+	return ok
+}
+
 func TestUsers_CreateOrUpdateUser(t *testing.T) {
 	ctx := context.Background()
 	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+	mockUsersMailer := NewUsersMailer(t)
 
 	expectedUser := &model.User{
+		ID:       uuid.New(),
 		Login:    "login",
 		Password: "password",
 		Email:    "email",
@@ -26,7 +41,7 @@ func TestUsers_CreateOrUpdateUser(t *testing.T) {
 
 	expectedError := errors.New("random error")
 
-	u := usecase.NewUsers(mockUserStorer)
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, mockUsersMailer, passwordHasher, &config.AuthConfig{LinkBaseURL: "http://localhost/"})
 
 	t.Run("GetUserByEmail error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, expectedError).Once()
@@ -35,7 +50,7 @@ func TestUsers_CreateOrUpdateUser(t *testing.T) {
 
 	t.Run("user exists", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(&model.User{}, nil).Once()
-		assert.Equal(t, fmt.Errorf("user exist for email %s", expectedUser.Email), u.CreateOrUpdateUser(ctx, expectedUser))
+		assert.ErrorIs(t, u.CreateOrUpdateUser(ctx, expectedUser), model.ErrEmailExistError)
 	})
 
 	t.Run("GetUserByLogin error", func(t *testing.T) {
@@ -47,13 +62,13 @@ func TestUsers_CreateOrUpdateUser(t *testing.T) {
 	t.Run("GetUserByLogin user exists error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, nil).Once()
 		mockUserStorer.EXPECT().GetUserByLogin(ctx, expectedUser.Login).Return(&model.User{}, nil).Once()
-		assert.Equal(t, fmt.Errorf("user exist for login %s", expectedUser.Login), u.CreateOrUpdateUser(ctx, expectedUser))
+		assert.ErrorIs(t, u.CreateOrUpdateUser(ctx, expectedUser), model.ErrLoginExistError)
 	})
 
 	t.Run("GetUserByLogin user exists error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, nil).Once()
 		mockUserStorer.EXPECT().GetUserByLogin(ctx, expectedUser.Login).Return(&model.User{}, nil).Once()
-		assert.Equal(t, fmt.Errorf("user exist for login %s", expectedUser.Login), u.CreateOrUpdateUser(ctx, expectedUser))
+		assert.ErrorIs(t, u.CreateOrUpdateUser(ctx, expectedUser), model.ErrLoginExistError)
 	})
 
 	t.Run("Upsert error", func(t *testing.T) {
@@ -67,10 +82,33 @@ func TestUsers_CreateOrUpdateUser(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, nil).Once()
 		mockUserStorer.EXPECT().GetUserByLogin(ctx, expectedUser.Login).Return(nil, nil).Once()
 		mockUserStorer.EXPECT().Upsert(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *model.User) {
-			require.True(t, utils.CheckPasswordHash(expectedUser.Password, _a1.HashPassword))
+			require.True(t, checkPassword(expectedUser.Password, _a1.HashPassword))
+		}).Return(nil).Once()
+		var token string
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *model.UserToken) {
+			assert.Equal(t, expectedUser.ID, _a1.UserID)
+			assert.Equal(t, model.UserTokenEmailVerification, _a1.Purpose)
+			assert.Equal(t, expectedUser.Email, _a1.Email)
+			assert.WithinDuration(t, time.Now().UTC().Add(48*time.Hour), _a1.ExpiresAt, time.Minute)
+			token = _a1.Token
+		}).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *mailer.Message) {
+			assert.Equal(t, expectedUser.Email, _a1.To)
+			assert.Contains(t, _a1.Body, "http://localhost/verify-email?token=")
+			assert.True(t, strings.Contains(_a1.Body, token))
 		}).Return(nil).Once()
 		assert.NoError(t, u.CreateOrUpdateUser(ctx, expectedUser))
 	})
+
+	t.Run("verification mail error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, nil).Once()
+		mockUserStorer.EXPECT().GetUserByLogin(ctx, expectedUser.Login).Return(nil, nil).Once()
+		mockUserStorer.EXPECT().Upsert(ctx, mock.Anything).Return(nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Return(expectedError).Once()
+		// the user is created, the link is sent again on demand
+		assert.NoError(t, u.CreateOrUpdateUser(ctx, expectedUser))
+	})
 }
 
 func TestUsers_UpdatePassword(t *testing.T) {
@@ -83,7 +121,7 @@ func TestUsers_UpdatePassword(t *testing.T) {
 		Password:    "new-password",
 	}
 
-	expectedOldHash, err := utils.HashPassword("password")
+	expectedOldHash, err := passwordHasher.Hash("password")
 	require.NoError(t, err)
 
 	expectedUser := &model.User{
@@ -92,7 +130,7 @@ func TestUsers_UpdatePassword(t *testing.T) {
 
 	expectedError := errors.New("random error")
 
-	u := usecase.NewUsers(mockUserStorer)
+	u := usecase.NewUsers(mockUserStorer, NewUsersTokenStorer(t), NewUsersMailer(t), passwordHasher, &config.AuthConfig{})
 
 	t.Run("GetUserByID error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdatePassword.ID).Return(nil, expectedError).Once()
@@ -105,17 +143,18 @@ func TestUsers_UpdatePassword(t *testing.T) {
 	})
 
 	t.Run("CheckPasswordHash invalid old password", func(t *testing.T) {
-		eup := expectedUpdatePassword
-		eup.OldPassword = ""
-		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdatePassword.ID).Return(expectedUser, nil).Once()
-		assert.ErrorIs(t, model.ErrOldPasswordError, u.UpdatePassword(ctx, expectedUpdatePassword))
+		// a copy, the other cases keep the right old password
+		eup := *expectedUpdatePassword
+		eup.OldPassword = "wrong-password"
+		mockUserStorer.EXPECT().GetUserByID(ctx, eup.ID).Return(expectedUser, nil).Once()
+		assert.ErrorIs(t, u.UpdatePassword(ctx, &eup), model.ErrOldPasswordError)
 	})
 
-	t.Run("UpdatePassword error", func(t *testing.T) {
-		eup := expectedUpdatePassword
+	t.Run("CheckPasswordHash empty old password", func(t *testing.T) {
+		eup := *expectedUpdatePassword
 		eup.OldPassword = ""
 		mockUserStorer.EXPECT().GetUserByID(ctx, eup.ID).Return(expectedUser, nil).Once()
-		assert.ErrorIs(t, model.ErrOldPasswordError, u.UpdatePassword(ctx, eup))
+		assert.ErrorIs(t, u.UpdatePassword(ctx, &eup), model.ErrOldPasswordError)
 	})
 
 	t.Run("UpdatePassword error", func(t *testing.T) {
@@ -127,7 +166,7 @@ func TestUsers_UpdatePassword(t *testing.T) {
 	t.Run("no error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdatePassword.ID).Return(expectedUser, nil).Once()
 		mockUserStorer.EXPECT().UpdatePassword(ctx, expectedUpdatePassword.ID, mock.Anything).Run(func(_a0 context.Context, _a1 uuid.UUID, _a2 string) {
-			require.True(t, utils.CheckPasswordHash(expectedUpdatePassword.Password, _a2))
+			require.True(t, checkPassword(expectedUpdatePassword.Password, _a2))
 		}).Return(nil).Once()
 		assert.Nil(t, u.UpdatePassword(ctx, expectedUpdatePassword))
 	})
@@ -136,6 +175,8 @@ func TestUsers_UpdatePassword(t *testing.T) {
 func TestUsers_UpdateEmail(t *testing.T) {
 	ctx := context.Background()
 	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+	mockUsersMailer := NewUsersMailer(t)
 
 	expectedUpdateEmail := &model.UpdateEmail{
 		ID:    uuid.New(),
@@ -143,12 +184,13 @@ func TestUsers_UpdateEmail(t *testing.T) {
 	}
 
 	expectedUser := &model.User{
+		ID:    expectedUpdateEmail.ID,
 		Email: "test@test.com",
 	}
 
 	expectedError := errors.New("random error")
 
-	u := usecase.NewUsers(mockUserStorer)
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, mockUsersMailer, passwordHasher, &config.AuthConfig{})
 
 	t.Run("GetUserByID error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdateEmail.ID).Return(nil, expectedError).Once()
@@ -160,19 +202,228 @@ func TestUsers_UpdateEmail(t *testing.T) {
 		assert.ErrorIs(t, model.ErrUserNotFound, u.UpdateEmail(ctx, expectedUpdateEmail))
 	})
 
-	t.Run("UpdateEmail, error", func(t *testing.T) {
+	t.Run("same verified email", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdateEmail.ID).Return(&model.User{ID: expectedUser.ID, Email: expectedUpdateEmail.Email, EmailVerified: true}, nil).Once()
+		assert.NoError(t, u.UpdateEmail(ctx, expectedUpdateEmail))
+	})
+
+	t.Run("email used by an other user", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdateEmail.ID).Return(expectedUser, nil).Once()
-		mockUserStorer.EXPECT().UpdateEmail(ctx, expectedUpdateEmail.ID, expectedUpdateEmail.Email).Return(expectedError).Once()
-		assert.ErrorIs(t, model.ErrUserError, u.UpdateEmail(ctx, expectedUpdateEmail))
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUpdateEmail.Email).Return(&model.User{ID: uuid.New()}, nil).Once()
+		assert.ErrorIs(t, model.ErrEmailExistError, u.UpdateEmail(ctx, expectedUpdateEmail))
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdateEmail.ID).Return(expectedUser, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUpdateEmail.Email).Return(nil, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Return(expectedError).Once()
+		assert.ErrorIs(t, model.ErrMailError, u.UpdateEmail(ctx, expectedUpdateEmail))
 	})
 
 	t.Run("no error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUpdateEmail.ID).Return(expectedUser, nil).Once()
-		mockUserStorer.EXPECT().UpdateEmail(ctx, expectedUpdateEmail.ID, expectedUpdateEmail.Email).Return(nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUpdateEmail.Email).Return(nil, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *model.UserToken) {
+			assert.Equal(t, model.UserTokenEmailVerification, _a1.Purpose)
+			assert.Equal(t, expectedUpdateEmail.Email, _a1.Email)
+		}).Return(nil).Once()
+		// the link goes to the new email, the email is not changed yet
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *mailer.Message) {
+			assert.Equal(t, expectedUpdateEmail.Email, _a1.To)
+		}).Return(nil).Once()
 		assert.NoError(t, u.UpdateEmail(ctx, expectedUpdateEmail))
 	})
 }
 
+func TestUsers_ResendVerification(t *testing.T) {
+	ctx := context.Background()
+	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+	mockUsersMailer := NewUsersMailer(t)
+
+	expectedUser := &model.User{
+		ID:    uuid.New(),
+		Email: "test@test.com",
+	}
+
+	expectedError := errors.New("random error")
+
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, mockUsersMailer, passwordHasher, &config.AuthConfig{})
+
+	t.Run("GetUserByID error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUser.ID).Return(nil, expectedError).Once()
+		assert.ErrorIs(t, u.ResendVerification(ctx, expectedUser.ID), model.ErrUserError)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUser.ID).Return(&model.User{ID: expectedUser.ID, EmailVerified: true}, nil).Once()
+		assert.NoError(t, u.ResendVerification(ctx, expectedUser.ID))
+	})
+
+	t.Run("Insert error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUser.ID).Return(expectedUser, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(expectedError).Once()
+		assert.ErrorIs(t, u.ResendVerification(ctx, expectedUser.ID), model.ErrMailError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUser.ID).Return(expectedUser, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Return(nil).Once()
+		assert.NoError(t, u.ResendVerification(ctx, expectedUser.ID))
+	})
+}
+
+func TestUsers_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+
+	expectedUserToken := &model.UserToken{
+		UserID:  uuid.New(),
+		Purpose: model.UserTokenEmailVerification,
+		Email:   "new@email.com",
+	}
+
+	expectedError := errors.New("random error")
+
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, NewUsersMailer(t), passwordHasher, &config.AuthConfig{})
+
+	t.Run("Use error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(nil, expectedError).Once()
+		assert.ErrorIs(t, u.VerifyEmail(ctx, "token"), model.ErrUserError)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(nil, nil).Once()
+		assert.ErrorIs(t, u.VerifyEmail(ctx, "token"), model.ErrInvalidUserTokenError)
+	})
+
+	t.Run("email taken since the link was sent", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUserToken.Email).Return(&model.User{ID: uuid.New()}, nil).Once()
+		assert.ErrorIs(t, u.VerifyEmail(ctx, "token"), model.ErrEmailExistError)
+	})
+
+	t.Run("user deleted", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUserToken.Email).Return(nil, nil).Once()
+		mockUserStorer.EXPECT().VerifyEmail(ctx, expectedUserToken.UserID, expectedUserToken.Email).Return(pgx.ErrNoRows).Once()
+		assert.ErrorIs(t, u.VerifyEmail(ctx, "token"), model.ErrUserNotFound)
+	})
+
+	t.Run("VerifyEmail error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUserToken.Email).Return(nil, nil).Once()
+		mockUserStorer.EXPECT().VerifyEmail(ctx, expectedUserToken.UserID, expectedUserToken.Email).Return(expectedError).Once()
+		assert.ErrorIs(t, u.VerifyEmail(ctx, "token"), model.ErrUserError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenEmailVerification, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUserToken.Email).Return(&model.User{ID: expectedUserToken.UserID}, nil).Once()
+		mockUserStorer.EXPECT().VerifyEmail(ctx, expectedUserToken.UserID, expectedUserToken.Email).Return(nil).Once()
+		assert.NoError(t, u.VerifyEmail(ctx, "token"))
+	})
+}
+
+func TestUsers_ForgotPassword(t *testing.T) {
+	ctx := context.Background()
+	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+	mockUsersMailer := NewUsersMailer(t)
+
+	expectedUser := &model.User{
+		ID:    uuid.New(),
+		Login: "login",
+		Email: "test@test.com",
+	}
+
+	expectedError := errors.New("random error")
+
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, mockUsersMailer, passwordHasher, &config.AuthConfig{ResetTokenTTLMinutes: 30, LinkBaseURL: "http://localhost"})
+
+	t.Run("GetUserByEmail error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, expectedError).Once()
+		assert.ErrorIs(t, u.ForgotPassword(ctx, expectedUser.Email), model.ErrUserError)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(nil, nil).Once()
+		assert.NoError(t, u.ForgotPassword(ctx, expectedUser.Email))
+	})
+
+	t.Run("Insert error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(expectedUser, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(expectedError).Once()
+		assert.ErrorIs(t, u.ForgotPassword(ctx, expectedUser.Email), model.ErrUserError)
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(expectedUser, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Return(expectedError).Once()
+		assert.ErrorIs(t, u.ForgotPassword(ctx, expectedUser.Email), model.ErrMailError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		var token string
+		mockUserStorer.EXPECT().GetUserByEmail(ctx, expectedUser.Email).Return(expectedUser, nil).Once()
+		mockUsersTokenStorer.EXPECT().Insert(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *model.UserToken) {
+			assert.Equal(t, expectedUser.ID, _a1.UserID)
+			assert.Equal(t, model.UserTokenPasswordReset, _a1.Purpose)
+			assert.WithinDuration(t, time.Now().UTC().Add(30*time.Minute), _a1.ExpiresAt, time.Minute)
+			token = _a1.Token
+		}).Return(nil).Once()
+		mockUsersMailer.EXPECT().Send(ctx, mock.Anything).Run(func(_a0 context.Context, _a1 *mailer.Message) {
+			assert.Equal(t, expectedUser.Email, _a1.To)
+			assert.Contains(t, _a1.Body, "http://localhost/reset-password?token=")
+			assert.True(t, strings.Contains(_a1.Body, token))
+		}).Return(nil).Once()
+		assert.NoError(t, u.ForgotPassword(ctx, expectedUser.Email))
+	})
+}
+
+func TestUsers_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	mockUserStorer := NewUsersStorer(t)
+	mockUsersTokenStorer := NewUsersTokenStorer(t)
+
+	expectedUserToken := &model.UserToken{
+		UserID:  uuid.New(),
+		Purpose: model.UserTokenPasswordReset,
+	}
+
+	expectedError := errors.New("random error")
+
+	u := usecase.NewUsers(mockUserStorer, mockUsersTokenStorer, NewUsersMailer(t), passwordHasher, &config.AuthConfig{})
+
+	t.Run("Use error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenPasswordReset, "token").Return(nil, expectedError).Once()
+		assert.ErrorIs(t, u.ResetPassword(ctx, "token", "new-password"), model.ErrUserError)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenPasswordReset, "token").Return(nil, nil).Once()
+		assert.ErrorIs(t, u.ResetPassword(ctx, "token", "new-password"), model.ErrInvalidUserTokenError)
+	})
+
+	t.Run("ResetPassword error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenPasswordReset, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().ResetPassword(ctx, expectedUserToken.UserID, mock.Anything).Return(expectedError).Once()
+		assert.ErrorIs(t, u.ResetPassword(ctx, "token", "new-password"), model.ErrUserError)
+	})
+
+	t.Run("no error", func(t *testing.T) {
+		mockUsersTokenStorer.EXPECT().Use(ctx, model.UserTokenPasswordReset, "token").Return(expectedUserToken, nil).Once()
+		mockUserStorer.EXPECT().ResetPassword(ctx, expectedUserToken.UserID, mock.Anything).Run(func(_a0 context.Context, _a1 uuid.UUID, _a2 string) {
+			require.True(t, checkPassword("new-password", _a2))
+		}).Return(nil).Once()
+		assert.NoError(t, u.ResetPassword(ctx, "token", "new-password"))
+	})
+}
+
 func TestUsers_GetUserByID(t *testing.T) {
 	ctx := context.Background()
 	mockUserStorer := NewUsersStorer(t)
@@ -184,7 +435,7 @@ func TestUsers_GetUserByID(t *testing.T) {
 
 	expectedError := errors.New("random error")
 
-	u := usecase.NewUsers(mockUserStorer)
+	u := usecase.NewUsers(mockUserStorer, NewUsersTokenStorer(t), NewUsersMailer(t), passwordHasher, &config.AuthConfig{})
 
 	t.Run("GetUserByID error", func(t *testing.T) {
 		mockUserStorer.EXPECT().GetUserByID(ctx, expectedUser.ID).Return(nil, expectedError).Once()
@@ -1,13 +1,170 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"shop-aggregator/internal/config"
+	"strings"
+)
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+
+	defaultArgon2idMemoryKiB   = 19 * 1024
+	defaultArgon2idIterations  = 2
+	defaultArgon2idParallelism = 1
+	defaultBcryptCost          = 12
+	argon2idSaltLength         = 16
+	argon2idKeyLength          = 32
+)
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+// PasswordHasher hashes the new passwords with the algorithm of the config. The hashes of the other
+// algorithms still verify, they are reported to be hashed again.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify tells whether password matches hash, and if so whether hash uses an outdated algorithm or cost.
+	Verify(password, hash string) (ok, rehash bool)
+}
+
+// NewPasswordHasher returns the hasher of the algorithm of the config, argon2id by default.
+func NewPasswordHasher(cfg *config.PasswordHashConfig) (PasswordHasher, error) {
+	switch cfg.Algorithm {
+	case "", PasswordHashArgon2id:
+		return NewArgon2idHasher(cfg.MemoryKiB, cfg.Iterations, cfg.Parallelism), nil
+	case PasswordHashBcrypt:
+		return NewBcryptHasher(cfg.BcryptCost), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %s", cfg.Algorithm)
+	}
+}
+
+// Argon2idHasher writes the hashes in the PHC string format, $argon2id$v=19$m=<KiB>,t=<iterations>,p=<lanes>$
+// followed by the base64 salt and key.
+type Argon2idHasher struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func NewArgon2idHasher(memoryKiB, iterations, parallelism int) *Argon2idHasher {
+	h := &Argon2idHasher{
+		memory:      uint32(memoryKiB),
+		iterations:  uint32(iterations),
+		parallelism: uint8(parallelism),
+	}
+	if memoryKiB <= 0 {
+		h.memory = defaultArgon2idMemoryKiB
+	}
+	if iterations <= 0 {
+		h.iterations = defaultArgon2idIterations
+	}
+	if parallelism <= 0 || parallelism > 255 {
+		h.parallelism = defaultArgon2idParallelism
+	}
+	return h
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.iterations, h.memory, h.parallelism, argon2idKeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2idHasher) Verify(password, hash string) (bool, bool) {
+	if !checkPassword(password, hash) {
+		return false, false
+	}
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return true, true
+	}
+	return true, p.memory != h.memory || p.iterations != h.iterations || p.parallelism != h.parallelism
+}
+
+// BcryptHasher is kept for the deployments that can not afford the memory of argon2id.
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	h := &BcryptHasher{
+		cost: cost,
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		h.cost = defaultBcryptCost
+	}
+	return h
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	return string(bytes), err
 }
 
-func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (h *BcryptHasher) Verify(password, hash string) (bool, bool) {
+	if !checkPassword(password, hash) {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || cost != h.cost
+}
+
+// checkPassword verifies password against a hash of any of the algorithms, whatever the one of the config.
+func checkPassword(password, hash string) bool {
+	if !strings.HasPrefix(hash, "$argon2id$") {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return false
+	}
+	key := argon2.IDKey([]byte(password), p.salt, p.iterations, p.memory, p.parallelism, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1
+}
+
+type argon2idParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func parseArgon2id(hash string) (*argon2idParams, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != PasswordHashArgon2id {
+		return nil, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrInvalidPasswordHash
+	}
+	p := &argon2idParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return nil, ErrInvalidPasswordHash
+	}
+	if p.memory == 0 || p.iterations == 0 || p.parallelism == 0 {
+		return nil, ErrInvalidPasswordHash
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidPasswordHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, ErrInvalidPasswordHash
+	}
+	return p, nil
 }
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"shop-aggregator/internal/config"
+	"strings"
+	"testing"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	h := NewArgon2idHasher(64, 1, 1)
+	hash, err := h.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	other, err := h.Hash("password")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	ok, rehash := h.Verify("password", hash)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _ = h.Verify("wrong", hash)
+	assert.False(t, ok)
+
+	// the hashes of other parameters still verify, they are hashed again
+	ok, rehash = NewArgon2idHasher(64, 2, 1).Verify("password", hash)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	ok, _ = h.Verify("password", "$argon2id$v=19$m=64,t=1,p=1$bad")
+	assert.False(t, ok)
+}
+
+func TestBcryptHasher(t *testing.T) {
+	h := NewBcryptHasher(4)
+	hash, err := h.Hash("password")
+	require.NoError(t, err)
+
+	ok, rehash := h.Verify("password", hash)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, rehash = NewBcryptHasher(5).Verify("password", hash)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	ok, rehash = NewArgon2idHasher(64, 1, 1).Verify("password", hash)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	ok, _ = NewArgon2idHasher(64, 1, 1).Verify("wrong", hash)
+	assert.False(t, ok)
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	h, err := NewPasswordHasher(&config.PasswordHashConfig{})
+	require.NoError(t, err)
+	assert.IsType(t, &Argon2idHasher{}, h)
+
+	h, err = NewPasswordHasher(&config.PasswordHashConfig{Algorithm: PasswordHashBcrypt})
+	require.NoError(t, err)
+	assert.IsType(t, &BcryptHasher{}, h)
+
+	_, err = NewPasswordHasher(&config.PasswordHashConfig{Algorithm: "md5"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// SecretCipher encrypts the secrets the server has to read back, such as the TOTP secrets, with AES-GCM and
+// a key derived from a secret of its own.
+type SecretCipher struct {
+	aead cipher.AEAD
+}
+
+func NewSecretCipher(secret string) *SecretCipher {
+	// the key differs from the one of the TokenHasher for the same secret
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("secret cipher"))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		// a 32 bytes key is always valid
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return &SecretCipher{
+		aead: aead,
+	}
+}
+
+// Encrypt returns the base64 encoded nonce and ciphertext of plaintext.
+func (sc *SecretCipher) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, sc.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sc.aead.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+func (sc *SecretCipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < sc.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	nonce, sealed := sealed[:sc.aead.NonceSize()], sealed[sc.aead.NonceSize():]
+	plaintext, err := sc.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSecretCipher(t *testing.T) {
+	sc := NewSecretCipher("secret")
+
+	ciphertext, err := sc.Encrypt("totp secret")
+	require.NoError(t, err)
+	assert.NotContains(t, ciphertext, "totp secret")
+
+	other, err := sc.Encrypt("totp secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, other)
+
+	plaintext, err := sc.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "totp secret", plaintext)
+
+	_, err = NewSecretCipher("other secret").Decrypt(ciphertext)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	_, err = sc.Decrypt("not base64!")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	_, err = sc.Decrypt("")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
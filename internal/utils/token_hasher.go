@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// TokenHasher keys the hash of the bearer tokens with a server secret, a database dump alone does not
+// give the tokens back.
+type TokenHasher struct {
+	secret []byte
+}
+
+func NewTokenHasher(secret string) *TokenHasher {
+	return &TokenHasher{
+		secret: []byte(secret),
+	}
+}
+
+// Hash returns the hex encoded HMAC-SHA256 of the token.
+func (h *TokenHasher) Hash(token string) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
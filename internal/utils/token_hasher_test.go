@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTokenHasher_Hash(t *testing.T) {
+	hasher := NewTokenHasher("secret")
+
+	hash := hasher.Hash("token")
+	assert.Len(t, hash, 64)
+	assert.NotEqual(t, "token", hash)
+	assert.Equal(t, hash, hasher.Hash("token"))
+	assert.NotEqual(t, hash, hasher.Hash("other token"))
+	assert.NotEqual(t, hash, NewTokenHasher("other secret").Hash("token"))
+}
@@ -0,0 +1,21 @@
+CREATE MATERIALIZED VIEW IF NOT EXISTS "price_history_daily" AS
+SELECT
+    up.product_id,
+    b.store_id,
+    s.company_id,
+    CAST(date_trunc('day', up.created_at) AS DATE)                        AS price_day,
+    MIN(CAST(REPLACE(TRIM(up.price), ',', '.') AS NUMERIC))               AS min_price,
+    MAX(CAST(REPLACE(TRIM(up.price), ',', '.') AS NUMERIC))               AS max_price,
+    AVG(CAST(REPLACE(TRIM(up.price), ',', '.') AS NUMERIC))               AS avg_price,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY CAST(REPLACE(TRIM(up.price), ',', '.') AS NUMERIC)) AS median_price,
+    ARRAY_AGG(CAST(REPLACE(TRIM(up.price), ',', '.') AS NUMERIC))         AS prices,
+    COUNT(*)                                                              AS sample_count
+FROM user_product up
+JOIN bill b ON up.bill_id = b.bill_id
+JOIN store s ON b.store_id = s.store_id
+WHERE b.bill_state = 'complete'
+AND TRIM(up.price) ~ '^[0-9]+([.,][0-9]+)?$'
+GROUP BY up.product_id, b.store_id, s.company_id, CAST(date_trunc('day', up.created_at) AS DATE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_history_daily_key ON "price_history_daily" (product_id, store_id, price_day);
+CREATE INDEX IF NOT EXISTS idx_price_history_daily_company_id ON "price_history_daily" (company_id);
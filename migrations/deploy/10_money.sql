@@ -0,0 +1,73 @@
+CREATE OR REPLACE FUNCTION parse_money(raw TEXT) RETURNS NUMERIC AS $$
+DECLARE
+    value TEXT := regexp_replace(COALESCE(raw, ''), '[[:space:]€£$'']|EUR|USD|GBP', '', 'gi');
+BEGIN
+    -- 2.49 or 2,49
+    IF value ~ '^-?[0-9]+([.,][0-9]+)?$' THEN
+        RETURN CAST(REPLACE(value, ',', '.') AS NUMERIC);
+    END IF;
+    -- 1.234,56
+    IF value ~ '^-?[0-9]{1,3}(\.[0-9]{3})+(,[0-9]+)?$' THEN
+        RETURN CAST(REPLACE(REPLACE(value, '.', ''), ',', '.') AS NUMERIC);
+    END IF;
+    -- 1,234.56
+    IF value ~ '^-?[0-9]{1,3}(,[0-9]{3})+(\.[0-9]+)?$' THEN
+        RETURN CAST(REPLACE(value, ',', '') AS NUMERIC);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS "money_migration_report"
+(
+    table_name   TEXT         NOT NULL,
+    row_id       UUID         NOT NULL,
+    raw_value    TEXT         NOT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO money_migration_report (table_name, row_id, raw_value)
+SELECT 'bill', bill_id, amount FROM bill WHERE parse_money(amount) IS NULL;
+
+INSERT INTO money_migration_report (table_name, row_id, raw_value)
+SELECT 'user_product', user_product_id, price FROM user_product WHERE parse_money(price) IS NULL;
+
+DO $$
+DECLARE
+    unparsable BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO unparsable FROM money_migration_report;
+    IF unparsable > 0 THEN
+        RAISE WARNING '% amount(s) could not be parsed and were set to 0, see money_migration_report', unparsable;
+    END IF;
+END;
+$$;
+
+-- the view reads user_product.price, it is rebuilt on the NUMERIC column below
+DROP MATERIALIZED VIEW IF EXISTS "price_history_daily";
+
+ALTER TABLE "bill" ALTER COLUMN amount TYPE NUMERIC USING COALESCE(parse_money(amount), 0);
+ALTER TABLE "bill" ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'EUR';
+ALTER TABLE "user_product" ALTER COLUMN price TYPE NUMERIC USING COALESCE(parse_money(price), 0);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS "price_history_daily" AS
+SELECT
+    up.product_id,
+    b.store_id,
+    s.company_id,
+    b.currency,
+    CAST(date_trunc('day', up.created_at) AS DATE)      AS price_day,
+    MIN(up.price)                                       AS min_price,
+    MAX(up.price)                                       AS max_price,
+    AVG(up.price)                                       AS avg_price,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY up.price) AS median_price,
+    ARRAY_AGG(up.price)                                 AS prices,
+    COUNT(*)                                            AS sample_count
+FROM user_product up
+JOIN bill b ON up.bill_id = b.bill_id
+JOIN store s ON b.store_id = s.store_id
+WHERE b.bill_state = 'complete'
+GROUP BY up.product_id, b.store_id, s.company_id, b.currency, CAST(date_trunc('day', up.created_at) AS DATE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_history_daily_key ON "price_history_daily" (product_id, store_id, currency, price_day);
+CREATE INDEX IF NOT EXISTS idx_price_history_daily_company_id ON "price_history_daily" (company_id);
@@ -0,0 +1,15 @@
+ALTER TABLE "bill" ADD COLUMN IF NOT EXISTS computed_amount NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE "bill" ADD COLUMN IF NOT EXISTS discrepancy NUMERIC GENERATED ALWAYS AS (ROUND(amount - computed_amount, 2)) STORED;
+
+-- bills closed before the reconciliation
+UPDATE "bill" b
+SET computed_amount = lines.total
+FROM (
+    SELECT bill_id, SUM(price * quantity) AS total
+    FROM user_product
+    GROUP BY bill_id
+) lines
+WHERE b.bill_id = lines.bill_id
+AND b.bill_state = 'complete';
+
+CREATE INDEX IF NOT EXISTS idx_bill_discrepancy ON "bill" (user_id) WHERE bill_state = 'complete' AND discrepancy <> 0;
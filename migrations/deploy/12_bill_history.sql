@@ -0,0 +1,3 @@
+-- keyset pagination of the bill history, see SelectBillsByUserIDQuery
+CREATE INDEX IF NOT EXISTS idx_bill_user_created_at ON "bill" (user_id, created_at, bill_id);
+CREATE INDEX IF NOT EXISTS idx_bill_user_amount ON "bill" (user_id, amount, bill_id);
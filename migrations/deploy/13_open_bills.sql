@@ -0,0 +1,2 @@
+-- a user may keep several bills open, one per store at most through StartBill
+CREATE INDEX IF NOT EXISTS idx_bill_open ON "bill" (user_id, store_id) WHERE bill_state = 'create';
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS "bill_event"
+(
+    bill_event_id   UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bill_id         UUID         NOT NULL,
+    actor_id        UUID         NOT NULL,
+    event           TEXT         NOT NULL,
+    from_state      TEXT         NOT NULL DEFAULT '',
+    to_state        TEXT         NOT NULL,
+    previous_amount NUMERIC      NOT NULL DEFAULT 0,
+    amount          NUMERIC      NOT NULL DEFAULT 0,
+    currency        TEXT         NOT NULL DEFAULT 'EUR',
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_event_bill_id ON "bill_event" (bill_id, created_at);
+
+-- the bills made before the history get their start event and, when closed or canceled, the last transition
+INSERT INTO "bill_event" (bill_id, actor_id, event, to_state, currency, created_at)
+SELECT bill_id, user_id, 'start', 'create', currency, created_at
+FROM "bill";
+
+INSERT INTO "bill_event" (bill_id, actor_id, event, from_state, to_state, amount, currency, created_at)
+SELECT bill_id, user_id, CASE bill_state WHEN 'complete' THEN 'close' ELSE 'cancel' END, 'create', bill_state, amount, currency, COALESCE(updated_at, created_at)
+FROM "bill"
+WHERE bill_state IN ('complete', 'cancel');
+
+-- archived bills keep their prices in the history
+DROP MATERIALIZED VIEW IF EXISTS "price_history_daily";
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS "price_history_daily" AS
+SELECT
+    up.product_id,
+    b.store_id,
+    s.company_id,
+    b.currency,
+    CAST(date_trunc('day', up.created_at) AS DATE)      AS price_day,
+    MIN(up.price)                                       AS min_price,
+    MAX(up.price)                                       AS max_price,
+    AVG(up.price)                                       AS avg_price,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY up.price) AS median_price,
+    ARRAY_AGG(up.price)                                 AS prices,
+    COUNT(*)                                            AS sample_count
+FROM user_product up
+JOIN bill b ON up.bill_id = b.bill_id
+JOIN store s ON b.store_id = s.store_id
+WHERE b.bill_state IN ('complete', 'archive')
+GROUP BY up.product_id, b.store_id, s.company_id, b.currency, CAST(date_trunc('day', up.created_at) AS DATE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_history_daily_key ON "price_history_daily" (product_id, store_id, currency, price_day);
+CREATE INDEX IF NOT EXISTS idx_price_history_daily_company_id ON "price_history_daily" (company_id);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "session"
+(
+    session_id   UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id      UUID         NOT NULL,
+    token        TEXT         NOT NULL,
+    device       TEXT         NOT NULL DEFAULT '',
+    ip           TEXT         NOT NULL DEFAULT '',
+    user_agent   TEXT         NOT NULL DEFAULT '',
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_session_token ON "session" (token);
+CREATE INDEX IF NOT EXISTS idx_session_user_id ON "session" (user_id) WHERE revoked_at IS NULL;
+
+-- the logged in users keep their token as a first session
+INSERT INTO "session" (user_id, token, created_at, last_seen_at)
+SELECT user_id, token, created_at, COALESCE(updated_at, created_at)
+FROM "auth"
+WHERE is_active;
+
+DROP TABLE IF EXISTS "auth";
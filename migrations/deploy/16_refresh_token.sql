@@ -0,0 +1,16 @@
+-- the sessions opened before the expiry have no refresh token, their users log in again
+ALTER TABLE "session" ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- every refresh token of a session is kept, a used one presented again revokes the session
+CREATE TABLE IF NOT EXISTS "refresh_token"
+(
+    refresh_token_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    session_id       UUID         NOT NULL,
+    token            TEXT         NOT NULL,
+    expires_at       TIMESTAMP    NOT NULL,
+    used_at          TIMESTAMP,
+    created_at       TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_token_token ON "refresh_token" (token);
+CREATE INDEX IF NOT EXISTS idx_refresh_token_session_id ON "refresh_token" (session_id);
@@ -0,0 +1,9 @@
+-- the tokens are stored as keyed hashes from now on, the plaintext ones cannot be converted without the
+-- server secret: every session is revoked and the stored tokens are overwritten, the users log in again
+UPDATE "session"
+SET revoked_at = COALESCE(revoked_at, NOW()),
+    token      = session_id::TEXT;
+
+UPDATE "refresh_token"
+SET used_at = COALESCE(used_at, NOW()),
+    token   = refresh_token_id::TEXT;
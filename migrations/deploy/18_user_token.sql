@@ -0,0 +1,18 @@
+-- the users created before the verification keep an unverified email until they ask for a new link
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- single-use tokens sent by mail, an email verification token carries the address it verifies
+CREATE TABLE IF NOT EXISTS "user_token"
+(
+    user_token_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id       UUID         NOT NULL,
+    purpose       TEXT         NOT NULL,
+    token         TEXT         NOT NULL,
+    email         TEXT         NOT NULL DEFAULT '',
+    expires_at    TIMESTAMP    NOT NULL,
+    used_at       TIMESTAMP,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_token_token ON "user_token" (token);
+CREATE INDEX IF NOT EXISTS idx_user_token_user_id ON "user_token" (user_id, purpose) WHERE used_at IS NULL;
@@ -0,0 +1,6 @@
+-- the first admins are granted by auth.admin_logins in the config, the others through the admin API
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'user';
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP;
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE "users" ADD CONSTRAINT users_role_check CHECK (role IN ('user', 'moderator', 'admin'));
@@ -0,0 +1,25 @@
+-- every login attempt, kept for the admins
+CREATE TABLE IF NOT EXISTS "login_attempt"
+(
+    login_attempt_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    login            TEXT         NOT NULL,
+    ip               TEXT         NOT NULL DEFAULT '',
+    user_id          UUID,
+    success          BOOLEAN      NOT NULL,
+    reason           TEXT         NOT NULL DEFAULT '',
+    created_at       TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_attempt_login ON "login_attempt" (login, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempt_ip ON "login_attempt" (ip, created_at);
+
+-- the failed attempts counted per login and per ip, a key is locked until locked_until
+CREATE TABLE IF NOT EXISTS "login_throttle"
+(
+    scope        TEXT         NOT NULL,
+    key          TEXT         NOT NULL,
+    failures     INT          NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    updated_at   TIMESTAMP    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, key)
+);
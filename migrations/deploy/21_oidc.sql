@@ -0,0 +1,30 @@
+-- the identities of the OpenID Connect provider linked to the users, an account created on the first login
+-- through the provider has no password until it is reset
+CREATE TABLE IF NOT EXISTS "user_identity"
+(
+    user_identity_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id          UUID         NOT NULL,
+    issuer           TEXT         NOT NULL,
+    subject          TEXT         NOT NULL,
+    email            TEXT         NOT NULL DEFAULT '',
+    created_at       TIMESTAMP    NOT NULL DEFAULT NOW(),
+    last_login_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identity_subject ON "user_identity" (issuer, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identity_user_id ON "user_identity" (user_id);
+
+-- the pending authorizations, the state is stored as a keyed hash
+CREATE TABLE IF NOT EXISTS "oidc_state"
+(
+    oidc_state_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    state         TEXT         NOT NULL,
+    nonce         TEXT         NOT NULL,
+    code_verifier TEXT         NOT NULL,
+    device        TEXT         NOT NULL DEFAULT '',
+    expires_at    TIMESTAMP    NOT NULL,
+    used_at       TIMESTAMP,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oidc_state_state ON "oidc_state" (state);
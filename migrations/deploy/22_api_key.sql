@@ -0,0 +1,17 @@
+-- the API keys of the users, the key is stored as a keyed hash and prefix is shown to recognize it
+CREATE TABLE IF NOT EXISTS "api_key"
+(
+    api_key_id   UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id      UUID         NOT NULL,
+    name         TEXT         NOT NULL,
+    prefix       TEXT         NOT NULL,
+    key          TEXT         NOT NULL,
+    scopes       TEXT[]       NOT NULL,
+    expires_at   TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_key_key ON "api_key" (key);
+CREATE INDEX IF NOT EXISTS idx_api_key_user_id ON "api_key" (user_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,38 @@
+-- the TOTP secrets of the users, encrypted by the server, the 2FA is on once enabled_at is set. last_step is
+-- the last period a code was used for, a code is valid once
+CREATE TABLE IF NOT EXISTS "user_totp"
+(
+    user_id    UUID PRIMARY KEY,
+    secret     TEXT         NOT NULL,
+    enabled_at TIMESTAMP,
+    last_step  BIGINT       NOT NULL DEFAULT 0,
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+-- the one-time recovery codes, stored as keyed hashes
+CREATE TABLE IF NOT EXISTS "recovery_code"
+(
+    recovery_code_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id          UUID         NOT NULL,
+    code             TEXT         NOT NULL,
+    used_at          TIMESTAMP,
+    created_at       TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recovery_code_code ON "recovery_code" (user_id, code);
+
+-- the logins waiting for their second factor, the challenge is stored as a keyed hash
+CREATE TABLE IF NOT EXISTS "login_challenge"
+(
+    login_challenge_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id            UUID         NOT NULL,
+    login              TEXT         NOT NULL,
+    challenge          TEXT         NOT NULL,
+    device             TEXT         NOT NULL DEFAULT '',
+    failures           INT          NOT NULL DEFAULT 0,
+    expires_at         TIMESTAMP    NOT NULL,
+    used_at            TIMESTAMP,
+    created_at         TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_login_challenge_challenge ON "login_challenge" (challenge);
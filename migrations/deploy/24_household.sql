@@ -0,0 +1,39 @@
+-- households share their bills between their members, a bill started for a household keeps the member who
+-- started it in user_id
+CREATE TABLE IF NOT EXISTS "household"
+(
+    household_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name         TEXT         NOT NULL,
+    created_by   UUID         NOT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS "household_member"
+(
+    household_id UUID         NOT NULL,
+    user_id      UUID         NOT NULL,
+    role         TEXT         NOT NULL CHECK (role IN ('owner', 'member')),
+    joined_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_household_member_user_id ON "household_member" (user_id);
+
+-- an invitation is accepted or declined by the invited user, it is deleted either way
+CREATE TABLE IF NOT EXISTS "household_invitation"
+(
+    household_invitation_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    household_id            UUID         NOT NULL,
+    user_id                 UUID         NOT NULL,
+    invited_by              UUID         NOT NULL,
+    role                    TEXT         NOT NULL CHECK (role IN ('owner', 'member')),
+    expires_at              TIMESTAMP    NOT NULL,
+    created_at              TIMESTAMP    NOT NULL DEFAULT NOW(),
+    UNIQUE (household_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_household_invitation_user_id ON "household_invitation" (user_id);
+
+ALTER TABLE "bill" ADD COLUMN IF NOT EXISTS household_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_bill_household_id ON "bill" (household_id) WHERE household_id IS NOT NULL;
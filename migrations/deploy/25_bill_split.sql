@@ -0,0 +1,49 @@
+-- the split of a closed bill between users: bill_split_line keeps the allocations of the lines as given,
+-- bill_split_share the total owed by each user, the payer included
+CREATE TABLE IF NOT EXISTS "bill_split"
+(
+    bill_id    UUID PRIMARY KEY,
+    payer_id   UUID         NOT NULL,
+    created_by UUID         NOT NULL,
+    currency   TEXT         NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS "bill_split_line"
+(
+    bill_id          UUID         NOT NULL,
+    position         INT          NOT NULL,
+    user_product_id  UUID         NOT NULL,
+    participant_type TEXT         NOT NULL CHECK (participant_type IN ('user', 'household')),
+    participant_id   UUID         NOT NULL,
+    method           TEXT         NOT NULL CHECK (method IN ('share', 'weight', 'fixed')),
+    value            NUMERIC      NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_split_line_bill_id ON "bill_split_line" (bill_id);
+
+CREATE TABLE IF NOT EXISTS "bill_split_share"
+(
+    bill_id  UUID         NOT NULL,
+    position INT          NOT NULL,
+    user_id  UUID         NOT NULL,
+    amount   NUMERIC      NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_split_share_bill_id ON "bill_split_share" (bill_id);
+CREATE INDEX IF NOT EXISTS idx_bill_split_share_user_id ON "bill_split_share" (user_id);
+
+-- a settlement is a payment from a user to an other one, it counts against what the payer owes
+CREATE TABLE IF NOT EXISTS "settlement"
+(
+    settlement_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_user_id  UUID         NOT NULL,
+    to_user_id    UUID         NOT NULL,
+    amount        NUMERIC      NOT NULL CHECK (amount > 0),
+    currency      TEXT         NOT NULL,
+    created_at    TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_settlement_from_user_id ON "settlement" (from_user_id);
+CREATE INDEX IF NOT EXISTS idx_settlement_to_user_id ON "settlement" (to_user_id);
@@ -0,0 +1,5 @@
+-- an account is deleted once deletion_scheduled_at is past, its closed bills and their lines stay for the
+-- price history under a pseudonym
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON "users" (deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;